@@ -81,8 +81,40 @@ type CustomAuthorizerParameters struct {
 	// to set the APIID.
 	// +optional
 	APIIDSelector *runtimev1alpha1.Selector `json:"apiIdSelector,omitempty"`
+
+	// ImmutableFieldUpdatePolicy determines what happens when a field of the
+	// authorizer that AWS does not allow to be updated, i.e. its
+	// authorizerType, is changed. The authorizer is deleted and created
+	// again if the policy is Recreate, unless Routes still reference it. The
+	// default policy, Ignore, leaves the immutable fields of the existing
+	// authorizer untouched and reports the change in its
+	// ImmutableFieldsSynced condition.
+	// +optional
+	// +kubebuilder:validation:Enum=Ignore;Recreate
+	ImmutableFieldUpdatePolicy *ImmutableFieldUpdatePolicy `json:"immutableFieldUpdatePolicy,omitempty"`
+}
+
+// CustomAuthorizerObservation includes the custom status fields.
+type CustomAuthorizerObservation struct {
+	// ConfigHash is a hash of the observed configuration of the authorizer.
+	// It changes whenever the configuration of the authorizer in AWS does.
+	ConfigHash *string `json:"configHash,omitempty"`
 }
 
+// ImmutableFieldUpdatePolicy determines how changes to the fields of a
+// resource that cannot be updated are handled.
+type ImmutableFieldUpdatePolicy string
+
+const (
+	// ImmutableFieldUpdatePolicyIgnore leaves the existing resource untouched
+	// when its immutable fields are changed.
+	ImmutableFieldUpdatePolicyIgnore ImmutableFieldUpdatePolicy = "Ignore"
+
+	// ImmutableFieldUpdatePolicyRecreate deletes the existing resource and
+	// creates a new one when its immutable fields are changed.
+	ImmutableFieldUpdatePolicyRecreate ImmutableFieldUpdatePolicy = "Recreate"
+)
+
 // CustomDeploymentParameters includes the custom fields.
 type CustomDeploymentParameters struct {
 	// APIID is the ID for the API.
@@ -63,6 +63,8 @@ type AuthorizerObservation struct {
 	AuthorizerID *string `json:"authorizerID,omitempty"`
 
 	Name *string `json:"name,omitempty"`
+
+	CustomAuthorizerObservation `json:",inline"`
 }
 
 // AuthorizerStatus defines the observed state of Authorizer.
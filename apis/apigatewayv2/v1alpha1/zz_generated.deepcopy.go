@@ -657,6 +657,7 @@ func (in *AuthorizerObservation) DeepCopyInto(out *AuthorizerObservation) {
 		*out = new(string)
 		**out = **in
 	}
+	in.CustomAuthorizerObservation.DeepCopyInto(&out.CustomAuthorizerObservation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizerObservation.
@@ -990,6 +991,26 @@ func (in *CustomAPIParameters) DeepCopy() *CustomAPIParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomAuthorizerObservation) DeepCopyInto(out *CustomAuthorizerObservation) {
+	*out = *in
+	if in.ConfigHash != nil {
+		in, out := &in.ConfigHash, &out.ConfigHash
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAuthorizerObservation.
+func (in *CustomAuthorizerObservation) DeepCopy() *CustomAuthorizerObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomAuthorizerObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomAuthorizerParameters) DeepCopyInto(out *CustomAuthorizerParameters) {
 	*out = *in
@@ -1008,6 +1029,11 @@ func (in *CustomAuthorizerParameters) DeepCopyInto(out *CustomAuthorizerParamete
 		*out = new(corev1alpha1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImmutableFieldUpdatePolicy != nil {
+		in, out := &in.ImmutableFieldUpdatePolicy, &out.ImmutableFieldUpdatePolicy
+		*out = new(ImmutableFieldUpdatePolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAuthorizerParameters.
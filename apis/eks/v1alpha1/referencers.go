@@ -93,3 +93,52 @@ func (mg *NodeGroup) ResolveReferences(ctx context.Context, c client.Reader) err
 
 	return nil
 }
+
+// ResolveReferences of this FargateProfile
+func (mg *FargateProfile) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.clusterName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ClusterName,
+		Reference:    mg.Spec.ForProvider.ClusterNameRef,
+		Selector:     mg.Spec.ForProvider.ClusterNameSelector,
+		To:           reference.To{Managed: &eksv1beta1.Cluster{}, List: &eksv1beta1.ClusterList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.clusterName")
+	}
+	mg.Spec.ForProvider.ClusterName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ClusterNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.podExecutionRoleArn
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.PodExecutionRoleArn,
+		Reference:    mg.Spec.ForProvider.PodExecutionRoleArnRef,
+		Selector:     mg.Spec.ForProvider.PodExecutionRoleArnSelector,
+		To:           reference.To{Managed: &iamv1beta1.IAMRole{}, List: &iamv1beta1.IAMRoleList{}},
+		Extract:      iamv1beta1.IAMRoleARN(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.podExecutionRoleArn")
+	}
+	mg.Spec.ForProvider.PodExecutionRoleArn = rsp.ResolvedValue
+	mg.Spec.ForProvider.PodExecutionRoleArnRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.subnets
+	mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.Subnets,
+		References:    mg.Spec.ForProvider.SubnetRefs,
+		Selector:      mg.Spec.ForProvider.SubnetSelector,
+		To:            reference.To{Managed: &ec2v1beta1.Subnet{}, List: &ec2v1beta1.SubnetList{}},
+		Extract:       reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.subnets")
+	}
+	mg.Spec.ForProvider.Subnets = mrsp.ResolvedValues
+	mg.Spec.ForProvider.SubnetRefs = mrsp.ResolvedReferences
+
+	return nil
+}
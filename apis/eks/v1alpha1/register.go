@@ -45,6 +45,24 @@ var (
 	NodeGroupGroupVersionKind = SchemeGroupVersion.WithKind(NodeGroupKind)
 )
 
+// NodeGroupClass type metadata.
+var (
+	NodeGroupClassKind             = reflect.TypeOf(NodeGroupClass{}).Name()
+	NodeGroupClassGroupKind        = schema.GroupKind{Group: Group, Kind: NodeGroupClassKind}.String()
+	NodeGroupClassKindAPIVersion   = NodeGroupClassKind + "." + SchemeGroupVersion.String()
+	NodeGroupClassGroupVersionKind = SchemeGroupVersion.WithKind(NodeGroupClassKind)
+)
+
+// FargateProfile type metadata.
+var (
+	FargateProfileKind             = reflect.TypeOf(FargateProfile{}).Name()
+	FargateProfileGroupKind        = schema.GroupKind{Group: Group, Kind: FargateProfileKind}.String()
+	FargateProfileKindAPIVersion   = FargateProfileKind + "." + SchemeGroupVersion.String()
+	FargateProfileGroupVersionKind = SchemeGroupVersion.WithKind(FargateProfileKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&NodeGroup{}, &NodeGroupList{})
+	SchemeBuilder.Register(&NodeGroupClass{}, &NodeGroupClassList{})
+	SchemeBuilder.Register(&FargateProfile{}, &FargateProfileList{})
 }
@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
 )
 
 // NodeGroupStatusType is a type of NodeGroup status.
@@ -99,12 +101,12 @@ type NodeGroupParameters struct {
 	// +immutable
 	NodeRole string `json:"nodeRole,omitempty"`
 
-	// NodeRoleRef is a reference to a Cluster used to set the NodeRole.
+	// NodeRoleRef is a reference to an IAMRole used to set the NodeRole.
 	// +immutable
 	// +optional
 	NodeRoleRef *runtimev1alpha1.Reference `json:"nodeRoleRef,omitempty"`
 
-	// NodeRoleSelector selects references to a Cluster used
+	// NodeRoleSelector selects references to an IAMRole used
 	// to set the NodeRole.
 	// +optional
 	NodeRoleSelector *runtimev1alpha1.Selector `json:"nodeRoleSelector,omitempty"`
@@ -128,6 +130,13 @@ type NodeGroupParameters struct {
 	// +optional
 	ScalingConfig *NodeGroupScalingConfig `json:"scalingConfig,omitempty"`
 
+	// ScalingConfigFrom reads the minimum and maximum size of the node group
+	// from a ConfigMap, e.g. one that is maintained by a capacity planner.
+	// Sizes read from the ConfigMap take precedence over those of
+	// ScalingConfig. The desired size is never read from it.
+	// +optional
+	ScalingConfigFrom *ScalingConfigSource `json:"scalingConfigFrom,omitempty"`
+
 	// The subnets to use for the Auto Scaling group that is created for your node
 	// group. These subnets must have the tag key kubernetes.io/cluster/CLUSTER_NAME
 	// with a value of shared, where CLUSTER_NAME is replaced with the name of your
@@ -149,14 +158,57 @@ type NodeGroupParameters struct {
 	// The metadata to apply to the node group to assist with categorization and
 	// organization. Each tag consists of a key and an optional value, both of which
 	// you define. Node group tags do not propagate to any other resources associated
-	// with the node group, such as the Amazon EC2 instances or subnets.
+	// with the node group, such as the Amazon EC2 instances or subnets. Values
+	// prefixed with "template:" may contain the placeholders {cluster.name},
+	// {cluster.arn}, {cluster.version} and {cluster.platformVersion}, which are
+	// substituted with the observed attributes of the cluster.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
 
+	// AutoscalerDiscovery adds the tags that the Cluster Autoscaler uses to
+	// discover node groups, k8s.io/cluster-autoscaler/enabled and
+	// k8s.io/cluster-autoscaler/<cluster name>, to the node group unless they
+	// are already set. The tags of a node group do not propagate to its Auto
+	// Scaling groups, so they are also added to the Auto Scaling groups
+	// directly.
+	// +optional
+	AutoscalerDiscovery *bool `json:"autoscalerDiscovery,omitempty"`
+
 	// The Kubernetes version to use for your managed nodes. By default, the Kubernetes
 	// version of the cluster is used, and this is the only accepted specified value.
+	// If unset, the version of the cluster at creation is used and observed in
+	// status.atProvider.version. Later upgrades of the cluster are then not
+	// considered drift unless FollowClusterVersion is true.
 	// +optional
 	Version *string `json:"version,omitempty"`
+
+	// FollowClusterVersion causes a node group without a Version to be
+	// upgraded to the Kubernetes version of its cluster whenever the cluster
+	// is upgraded. It has no effect if Version is set.
+	// +optional
+	FollowClusterVersion *bool `json:"followClusterVersion,omitempty"`
+
+	// MaintenanceWindow is the weekly time range (in UTC) during which the
+	// Kubernetes version of the node group may be updated, which replaces its
+	// nodes. Its format is ddd:hh24:mi-ddd:hh24:mi, e.g. sun:02:00-sun:06:00.
+	// Version updates are deferred until the window starts, while other
+	// updates, e.g. of its scaling configuration, labels and tags, are made at
+	// any time. Version updates may be made at any time if it is not set.
+	// +kubebuilder:validation:Pattern=`^(mon|tue|wed|thu|fri|sat|sun):([01][0-9]|2[0-3]):[0-5][0-9]-(mon|tue|wed|thu|fri|sat|sun):([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +optional
+	MaintenanceWindow *string `json:"maintenanceWindow,omitempty"`
+
+	// MinReadyNodes is the number of nodes that must be running before an
+	// active node group is reported as available. Nodes are only counted if
+	// it is set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadyNodes *int64 `json:"minReadyNodes,omitempty"`
+
+	// ClassRef is a reference to a NodeGroupClass whose defaults are applied
+	// to the fields of the node group that are not set.
+	// +optional
+	ClassRef *runtimev1alpha1.Reference `json:"classRef,omitempty"`
 }
 
 // RemoteAccessConfig is the configuration for remotely accessing a node.
@@ -204,6 +256,26 @@ type NodeGroupScalingConfig struct {
 	MinSize *int64 `json:"minSize,omitempty"`
 }
 
+// A ScalingConfigSource is a ConfigMap that the minimum and maximum size of a
+// node group are read from.
+type ScalingConfigSource struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// MinSizeKey is the key of the minimum size in the ConfigMap. Defaults to
+	// minSize.
+	// +optional
+	MinSizeKey *string `json:"minSizeKey,omitempty"`
+
+	// MaxSizeKey is the key of the maximum size in the ConfigMap. Defaults to
+	// maxSize.
+	// +optional
+	MaxSizeKey *string `json:"maxSizeKey,omitempty"`
+}
+
 // NodeGroupObservation is the observed state of a NodeGroup.
 type NodeGroupObservation struct {
 	// The Unix epoch timestamp in seconds for when the managed node group was created.
@@ -224,10 +296,83 @@ type NodeGroupObservation struct {
 	// and security groups for remote access.
 	Resources NodeGroupResources `json:"resources,omitempty"`
 
+	// AMIID is the ID of the AMI that the instances of the node group run. It
+	// is only observed if the node group is annotated with
+	// eks.aws.crossplane.io/observe-ami-id: "true". If the instances run
+	// different AMIs, e.g. during an update, their IDs are separated by commas.
+	// +optional
+	AMIID string `json:"amiId,omitempty"`
+
+	// NodeRoleArn is the ARN of the IAM role that the nodes of the node group
+	// use, which must be mapped in the aws-auth ConfigMap of the cluster.
+	// +optional
+	NodeRoleArn string `json:"nodeRoleArn,omitempty"`
+
+	// InstanceProfileName is the name of the instance profile of the node
+	// role. It is only observed if the node group is annotated with
+	// eks.aws.crossplane.io/observe-instance-profile: "true". If the role has
+	// several instance profiles their names are separated by commas.
+	// +optional
+	InstanceProfileName string `json:"instanceProfileName,omitempty"`
+
+	// InstanceTypes are the instance types of the node group as observed in
+	// AWS. They are only observed if the node group is annotated with
+	// eks.aws.crossplane.io/observe-capacity: "true".
+	// +optional
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+
+	// MaxPodsPerNode is the number of pods each node of the node group can
+	// run, as limited by the network interfaces and IP addresses of its
+	// instance type when using the Amazon VPC CNI plugin. It is the lowest of
+	// its instance types. It is computed from a table of common instance types
+	// unless the node group is annotated with
+	// eks.aws.crossplane.io/lookup-max-pods: "true", in which case the limits
+	// of its instance types are looked up in EC2.
+	// +optional
+	MaxPodsPerNode *int64 `json:"maxPodsPerNode,omitempty"`
+
+	// ReadyNodes is the number of running instances of the node group. It is
+	// only observed if MinReadyNodes is set.
+	// +optional
+	ReadyNodes *int64 `json:"readyNodes,omitempty"`
+
+	// The Kubernetes version of the node group. For node groups created
+	// without a Version it is the version inherited from the cluster.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// The scaling configuration of the node group as observed in AWS. The
+	// desired size may differ from the spec, e.g. when it is changed by an
+	// autoscaler.
+	ScalingConfig *NodeGroupScalingConfig `json:"scalingConfig,omitempty"`
+
+	// NodeBootstrap reports whether the instances of the node group failed
+	// to launch, or launched but failed to join the cluster, as derived from
+	// its health issues. It is empty if the health of the node group reports
+	// neither.
+	// +optional
+	NodeBootstrap NodeBootstrapStatus `json:"nodeBootstrap,omitempty"`
+
 	// The current status of the managed node group.
 	Status NodeGroupStatusType `json:"status,omitempty"`
 }
 
+// NodeBootstrapStatus describes why the instances of a node group did not
+// become nodes of its cluster.
+type NodeBootstrapStatus string
+
+// Types of NodeBootstrapStatus
+const (
+	// NodeBootstrapLaunchFailed indicates that the instances of a node group
+	// failed to launch, e.g. because of instance limits or subnet capacity.
+	NodeBootstrapLaunchFailed NodeBootstrapStatus = "InstancesFailedToLaunch"
+
+	// NodeBootstrapJoinFailed indicates that the instances of a node group
+	// launched but failed to register with its cluster, e.g. because their
+	// kubelet could not authenticate or reach the cluster API server.
+	NodeBootstrapJoinFailed NodeBootstrapStatus = "NodesFailedToJoin"
+)
+
 // NodeGroupHealth describes the health of a node group.
 type NodeGroupHealth struct {
 	// Any issues that are associated with the node group.
@@ -324,6 +469,50 @@ type NodeGroupSpec struct {
 type NodeGroupStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
 	AtProvider                     NodeGroupObservation `json:"atProvider,omitempty"`
+
+	// Diff lists the fields of the node group that differ from the desired
+	// state and will be updated. It is empty when the node group is up to date.
+	// +optional
+	Diff []FieldDiff `json:"diff,omitempty"`
+
+	// History records the outcomes of the most recent reconciles, oldest
+	// first.
+	// +optional
+	History []apisv1beta1.ReconcileRecord `json:"history,omitempty"`
+
+	// CreateBlockedGeneration is the generation of the node group whose
+	// creation failed with an error that retrying cannot resolve, such as the
+	// node group limit of the account being reached. Creation is retried once
+	// the node group is changed.
+	// +optional
+	CreateBlockedGeneration *int64 `json:"createBlockedGeneration,omitempty"`
+
+	// LastUpdateID is the ID of the most recent version or configuration
+	// update of the node group that has not yet been observed to complete.
+	// +optional
+	LastUpdateID *string `json:"lastUpdateId,omitempty"`
+
+	// Recommendations lists actions that operators may take to resolve the
+	// issues detected with the node group, e.g. health issues reported by
+	// EKS, insufficient subnet capacity or a failed update. It is purely
+	// informational and empty when no issues are detected.
+	// +optional
+	Recommendations []string `json:"recommendations,omitempty"`
+}
+
+// A FieldDiff is a difference between the desired and the observed value of a
+// field of an external resource.
+type FieldDiff struct {
+	// Field is the path of the field within spec.forProvider.
+	Field string `json:"field"`
+
+	// Desired is the JSON encoded desired value of the field.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+
+	// Observed is the JSON encoded observed value of the field.
+	// +optional
+	Observed string `json:"observed,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -333,6 +522,7 @@ type NodeGroupStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.forProvider.clusterName"
+// +kubebuilder:printcolumn:name="INSTANCE-TYPES",type="string",JSONPath=".status.atProvider.instanceTypes",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,aws}
@@ -352,3 +542,77 @@ type NodeGroupList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []NodeGroup `json:"items"`
 }
+
+// NodeGroupDefaults are the defaults a NodeGroupClass applies to the
+// parameters of the node groups that reference it.
+type NodeGroupDefaults struct {
+	// AMIType is the default AMI type of node groups.
+	// +optional
+	AMIType *string `json:"amiType,omitempty"`
+
+	// DiskSize is the default root device disk size (in GiB) of node groups.
+	// +optional
+	DiskSize *int64 `json:"diskSize,omitempty"`
+
+	// InstanceTypes are the default instance types of node groups.
+	// +optional
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+
+	// Labels are added to the labels of node groups. Labels that are set on
+	// a node group take precedence.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// NodeRole is the default ARN of the IAM role of node groups.
+	// +optional
+	NodeRole *string `json:"nodeRole,omitempty"`
+
+	// ReleaseVersion is the default AMI version of node groups.
+	// +optional
+	ReleaseVersion *string `json:"releaseVersion,omitempty"`
+
+	// RemoteAccess is the default remote access configuration of node groups.
+	// +optional
+	RemoteAccess *RemoteAccessConfig `json:"remoteAccess,omitempty"`
+
+	// ScalingConfig is the default scaling configuration of node groups.
+	// Each size is only applied if the node group does not set it.
+	// +optional
+	ScalingConfig *NodeGroupScalingConfig `json:"scalingConfig,omitempty"`
+
+	// Subnets are the default subnets of node groups. They are not applied
+	// to node groups that set SubnetRefs or a SubnetSelector.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+}
+
+// A NodeGroupClassSpec defines the desired state of a NodeGroupClass.
+type NodeGroupClassSpec struct {
+	// Defaults are applied to the fields of the node groups that reference
+	// the class and do not set them. Fields that are set on a node group
+	// always take precedence.
+	Defaults NodeGroupDefaults `json:"defaults"`
+}
+
+// +kubebuilder:object:root=true
+
+// A NodeGroupClass holds defaults for the parameters of the node groups that
+// reference it, e.g. the subnets, disk size and AMI type shared by many
+// similar node groups.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,class,aws}
+type NodeGroupClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeGroupClassSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeGroupClassList contains a list of NodeGroupClass
+type NodeGroupClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeGroupClass `json:"items"`
+}
@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	corev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/provider-aws/apis/v1beta1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -40,6 +41,219 @@ func (in *AutoScalingGroup) DeepCopy() *AutoScalingGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfile) DeepCopyInto(out *FargateProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfile.
+func (in *FargateProfile) DeepCopy() *FargateProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FargateProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileList) DeepCopyInto(out *FargateProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FargateProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileList.
+func (in *FargateProfileList) DeepCopy() *FargateProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FargateProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileObservation) DeepCopyInto(out *FargateProfileObservation) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileObservation.
+func (in *FargateProfileObservation) DeepCopy() *FargateProfileObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileParameters) DeepCopyInto(out *FargateProfileParameters) {
+	*out = *in
+	if in.ClusterNameRef != nil {
+		in, out := &in.ClusterNameRef, &out.ClusterNameRef
+		*out = new(corev1alpha1.Reference)
+		**out = **in
+	}
+	if in.ClusterNameSelector != nil {
+		in, out := &in.ClusterNameSelector, &out.ClusterNameSelector
+		*out = new(corev1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodExecutionRoleArnRef != nil {
+		in, out := &in.PodExecutionRoleArnRef, &out.PodExecutionRoleArnRef
+		*out = new(corev1alpha1.Reference)
+		**out = **in
+	}
+	if in.PodExecutionRoleArnSelector != nil {
+		in, out := &in.PodExecutionRoleArnSelector, &out.PodExecutionRoleArnSelector
+		*out = new(corev1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]FargateProfileSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubnetRefs != nil {
+		in, out := &in.SubnetRefs, &out.SubnetRefs
+		*out = make([]corev1alpha1.Reference, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubnetSelector != nil {
+		in, out := &in.SubnetSelector, &out.SubnetSelector
+		*out = new(corev1alpha1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileParameters.
+func (in *FargateProfileParameters) DeepCopy() *FargateProfileParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileSelector) DeepCopyInto(out *FargateProfileSelector) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileSelector.
+func (in *FargateProfileSelector) DeepCopy() *FargateProfileSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileSpec) DeepCopyInto(out *FargateProfileSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileSpec.
+func (in *FargateProfileSpec) DeepCopy() *FargateProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileStatus) DeepCopyInto(out *FargateProfileStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileStatus.
+func (in *FargateProfileStatus) DeepCopy() *FargateProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDiff) DeepCopyInto(out *FieldDiff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDiff.
+func (in *FieldDiff) DeepCopy() *FieldDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Issue) DeepCopyInto(out *Issue) {
 	*out = *in
@@ -87,6 +301,142 @@ func (in *NodeGroup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupClass) DeepCopyInto(out *NodeGroupClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupClass.
+func (in *NodeGroupClass) DeepCopy() *NodeGroupClass {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeGroupClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupClassList) DeepCopyInto(out *NodeGroupClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeGroupClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupClassList.
+func (in *NodeGroupClassList) DeepCopy() *NodeGroupClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeGroupClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupClassSpec) DeepCopyInto(out *NodeGroupClassSpec) {
+	*out = *in
+	in.Defaults.DeepCopyInto(&out.Defaults)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupClassSpec.
+func (in *NodeGroupClassSpec) DeepCopy() *NodeGroupClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupDefaults) DeepCopyInto(out *NodeGroupDefaults) {
+	*out = *in
+	if in.AMIType != nil {
+		in, out := &in.AMIType, &out.AMIType
+		*out = new(string)
+		**out = **in
+	}
+	if in.DiskSize != nil {
+		in, out := &in.DiskSize, &out.DiskSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeRole != nil {
+		in, out := &in.NodeRole, &out.NodeRole
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReleaseVersion != nil {
+		in, out := &in.ReleaseVersion, &out.ReleaseVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.RemoteAccess != nil {
+		in, out := &in.RemoteAccess, &out.RemoteAccess
+		*out = new(RemoteAccessConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScalingConfig != nil {
+		in, out := &in.ScalingConfig, &out.ScalingConfig
+		*out = new(NodeGroupScalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupDefaults.
+func (in *NodeGroupDefaults) DeepCopy() *NodeGroupDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupHealth) DeepCopyInto(out *NodeGroupHealth) {
 	*out = *in
@@ -154,6 +504,26 @@ func (in *NodeGroupObservation) DeepCopyInto(out *NodeGroupObservation) {
 		*out = (*in).DeepCopy()
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxPodsPerNode != nil {
+		in, out := &in.MaxPodsPerNode, &out.MaxPodsPerNode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadyNodes != nil {
+		in, out := &in.ReadyNodes, &out.ReadyNodes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ScalingConfig != nil {
+		in, out := &in.ScalingConfig, &out.ScalingConfig
+		*out = new(NodeGroupScalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupObservation.
@@ -226,6 +596,11 @@ func (in *NodeGroupParameters) DeepCopyInto(out *NodeGroupParameters) {
 		*out = new(NodeGroupScalingConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScalingConfigFrom != nil {
+		in, out := &in.ScalingConfigFrom, &out.ScalingConfigFrom
+		*out = new(ScalingConfigSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
 		*out = make([]string, len(*in))
@@ -248,11 +623,36 @@ func (in *NodeGroupParameters) DeepCopyInto(out *NodeGroupParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.AutoscalerDiscovery != nil {
+		in, out := &in.AutoscalerDiscovery, &out.AutoscalerDiscovery
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Version != nil {
 		in, out := &in.Version, &out.Version
 		*out = new(string)
 		**out = **in
 	}
+	if in.FollowClusterVersion != nil {
+		in, out := &in.FollowClusterVersion, &out.FollowClusterVersion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinReadyNodes != nil {
+		in, out := &in.MinReadyNodes, &out.MinReadyNodes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ClassRef != nil {
+		in, out := &in.ClassRef, &out.ClassRef
+		*out = new(corev1alpha1.Reference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupParameters.
@@ -337,6 +737,33 @@ func (in *NodeGroupStatus) DeepCopyInto(out *NodeGroupStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]FieldDiff, len(*in))
+		copy(*out, *in)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]v1beta1.ReconcileRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreateBlockedGeneration != nil {
+		in, out := &in.CreateBlockedGeneration, &out.CreateBlockedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastUpdateID != nil {
+		in, out := &in.LastUpdateID, &out.LastUpdateID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupStatus.
@@ -383,3 +810,28 @@ func (in *RemoteAccessConfig) DeepCopy() *RemoteAccessConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingConfigSource) DeepCopyInto(out *ScalingConfigSource) {
+	*out = *in
+	if in.MinSizeKey != nil {
+		in, out := &in.MinSizeKey, &out.MinSizeKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxSizeKey != nil {
+		in, out := &in.MaxSizeKey, &out.MaxSizeKey
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingConfigSource.
+func (in *ScalingConfigSource) DeepCopy() *ScalingConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
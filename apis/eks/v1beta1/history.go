@@ -0,0 +1,29 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
+
+// GetReconcileHistory of this Cluster.
+func (mg *Cluster) GetReconcileHistory() []apisv1beta1.ReconcileRecord {
+	return mg.Status.History
+}
+
+// SetReconcileHistory of this Cluster.
+func (mg *Cluster) SetReconcileHistory(h []apisv1beta1.ReconcileRecord) {
+	mg.Status.History = h
+}
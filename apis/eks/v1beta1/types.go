@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
 )
 
 // ClusterStatusType is the status of an EKS cluster.
@@ -293,6 +295,11 @@ type ClusterSpec struct {
 type ClusterStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
 	AtProvider                     ClusterObservation `json:"atProvider,omitempty"`
+
+	// History records the outcomes of the most recent reconciles, oldest
+	// first.
+	// +optional
+	History []apisv1beta1.ReconcileRecord `json:"history,omitempty"`
 }
 
 // +kubebuilder:object:root=true
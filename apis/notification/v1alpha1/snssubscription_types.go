@@ -20,6 +20,8 @@ import (
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
 )
 
 // SNSSubscriptionParameters define the desired state of a AWS SNS Topic
@@ -59,6 +61,13 @@ type SNSSubscriptionParameters struct {
 	// +optional
 	FilterPolicy *string `json:"filterPolicy,omitempty"`
 
+	// FilterPolicyScope determines whether the filter policy applies to the
+	// attributes or the body of messages. AWS applies it to their attributes
+	// by default.
+	// +optional
+	// +kubebuilder:validation:Enum=MessageAttributes;MessageBody
+	FilterPolicyScope *string `json:"filterPolicyScope,omitempty"`
+
 	//  When set to true, enables raw message delivery
 	//  to Amazon SQS or HTTP/S endpoints. This eliminates the need for the endpoints
 	//  to process JSON formatting, which is otherwise created for Amazon SNS
@@ -74,8 +83,39 @@ type SNSSubscriptionParameters struct {
 	//  analysis or reprocessing.
 	// +optional
 	RedrivePolicy *string `json:"redrivePolicy,omitempty"`
+
+	// ReturnSubscriptionArn determines whether AWS returns the ARN of the
+	// subscription when it is created, even if the subscription still has to
+	// be confirmed. If false, subscriptions that require confirmation are
+	// only assigned an external name once they are confirmed. Defaults to
+	// true.
+	// +optional
+	ReturnSubscriptionArn *bool `json:"returnSubscriptionArn,omitempty"`
+
+	// EndpointUpdatePolicy determines what happens when the endpoint or
+	// protocol of the subscription is changed. AWS does not allow either of
+	// them to be updated, so the subscription is deleted and created again if
+	// the policy is Recreate. The default policy, Ignore, leaves the existing
+	// subscription untouched.
+	// +optional
+	// +kubebuilder:validation:Enum=Ignore;Recreate
+	EndpointUpdatePolicy *EndpointUpdatePolicy `json:"endpointUpdatePolicy,omitempty"`
 }
 
+// EndpointUpdatePolicy determines how changes to the endpoint or protocol of
+// an SNS Subscription are handled.
+type EndpointUpdatePolicy string
+
+const (
+	// EndpointUpdatePolicyIgnore leaves the existing subscription untouched
+	// when its endpoint or protocol is changed.
+	EndpointUpdatePolicyIgnore EndpointUpdatePolicy = "Ignore"
+
+	// EndpointUpdatePolicyRecreate deletes the existing subscription and
+	// creates a new one when its endpoint or protocol is changed.
+	EndpointUpdatePolicyRecreate EndpointUpdatePolicy = "Recreate"
+)
+
 // SNSSubscriptionSpec defined the desired state of a AWS SNS Topic
 type SNSSubscriptionSpec struct {
 	runtimev1alpha1.ResourceSpec `json:",inline"`
@@ -113,6 +153,11 @@ type SNSSubscriptionObservation struct {
 type SNSSubscriptionStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
 	AtProvider                     SNSSubscriptionObservation `json:"atProvider,omitempty"`
+
+	// History records the outcomes of the most recent reconciles, oldest
+	// first.
+	// +optional
+	History []apisv1beta1.ReconcileRecord `json:"history,omitempty"`
 }
 
 // +kubebuilder:object:root=true
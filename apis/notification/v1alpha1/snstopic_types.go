@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
 )
 
 // Tag represent a user-provided metadata that can be associated with a
@@ -76,6 +78,78 @@ type SNSTopicParameters struct {
 	// +optional
 	DeliveryPolicy *string `json:"deliveryPolicy,omitempty"`
 
+	// TracingConfig is the AWS X-Ray tracing mode of the topic. PassThrough
+	// only traces messages that are already traced upstream, while Active
+	// also traces messages that are not.
+	// +kubebuilder:validation:Enum=PassThrough;Active
+	// +optional
+	TracingConfig *string `json:"tracingConfig,omitempty"`
+
+	// SignatureVersion is the version of the signature SNS uses to sign the
+	// messages it delivers from the topic. Version 1 signs messages using
+	// SHA1, while version 2 uses SHA256.
+	// +kubebuilder:validation:Enum="1";"2"
+	// +optional
+	SignatureVersion *string `json:"signatureVersion,omitempty"`
+
+	// HTTPSuccessFeedbackRoleARN is the ARN of the IAM role that SNS assumes
+	// to log the successful deliveries of the topic to HTTP(S) endpoints in
+	// CloudWatch Logs. Only its format is checked before it is set; SNS
+	// reports a role that does not exist or cannot be assumed.
+	// +optional
+	HTTPSuccessFeedbackRoleARN *string `json:"httpSuccessFeedbackRoleArn,omitempty"`
+
+	// HTTPSuccessFeedbackSampleRate is the percentage of successful
+	// deliveries of the topic to HTTP(S) endpoints that are logged, between
+	// 0 and 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HTTPSuccessFeedbackSampleRate *int64 `json:"httpSuccessFeedbackSampleRate,omitempty"`
+
+	// HTTPFailureFeedbackRoleARN is the ARN of the IAM role that SNS assumes
+	// to log the failed deliveries of the topic to HTTP(S) endpoints in
+	// CloudWatch Logs. Only its format is checked before it is set; SNS
+	// reports a role that does not exist or cannot be assumed.
+	// +optional
+	HTTPFailureFeedbackRoleARN *string `json:"httpFailureFeedbackRoleArn,omitempty"`
+
+	// ArchivePolicy is the JSON serialization of the message archiving policy
+	// of the topic, e.g. {"MessageRetentionPeriod":"30"}. Archived messages
+	// can be replayed to subscriptions. It can only be set on FIFO topics,
+	// whose names end in .fifo.
+	// +optional
+	ArchivePolicy *string `json:"archivePolicy,omitempty"`
+
+	// FifoThroughputScope is the scope of the throughput quota of the topic.
+	// MessageGroup enables high throughput by applying the quota to each
+	// message group rather than to the whole topic. It can only be set on
+	// FIFO topics.
+	// +kubebuilder:validation:Enum=Topic;MessageGroup
+	// +optional
+	FifoThroughputScope *string `json:"fifoThroughputScope,omitempty"`
+
+	// ContentBasedDeduplication enables deduplication of the messages
+	// published to the topic using a hash of their body, rather than a
+	// deduplication ID supplied by the publisher. It can only be set on FIFO
+	// topics.
+	// +optional
+	ContentBasedDeduplication *bool `json:"contentBasedDeduplication,omitempty"`
+
+	// DataProtectionPolicy is the JSON serialization of the data protection
+	// policy of the topic, which audits, masks or redacts sensitive data in
+	// the messages published to it. The topic has no data protection policy
+	// if it is omitted.
+	// +optional
+	DataProtectionPolicy *string `json:"dataProtectionPolicy,omitempty"`
+
+	// SubscriptionDefaults are inherited by the SNSSubscriptions that
+	// reference this topic and do not set them themselves. They are copied
+	// to a subscription when its references are resolved, so changing them
+	// does not affect subscriptions that already inherited them.
+	// +optional
+	SubscriptionDefaults *SNSSubscriptionDefaults `json:"subscriptionDefaults,omitempty"`
+
 	// Tags represetnt a list of user-provided metadata that can be associated with a
 	// SNS Topic. For more information about tagging,
 	// see Tagging SNS Topics (https://docs.aws.amazon.com/sns/latest/dg/sns-tags.html)
@@ -85,6 +159,20 @@ type SNSTopicParameters struct {
 	Tags []Tag `json:"tags,omitempty"`
 }
 
+// SNSSubscriptionDefaults are the attributes that SNSSubscriptions inherit
+// from the SNSTopic they reference.
+type SNSSubscriptionDefaults struct {
+	// DeliveryPolicy defines how Amazon SNS retries failed deliveries to
+	// HTTP/S endpoints.
+	// +optional
+	DeliveryPolicy *string `json:"deliveryPolicy,omitempty"`
+
+	// FilterPolicy is the simple JSON object that lets a subscriber receive
+	// only a subset of the messages published to the topic.
+	// +optional
+	FilterPolicy *string `json:"filterPolicy,omitempty"`
+}
+
 // SNSTopicSpec defined the desired state of a AWS SNS Topic
 type SNSTopicSpec struct {
 	runtimev1alpha1.ResourceSpec `json:",inline"`
@@ -111,12 +199,24 @@ type SNSTopicObservation struct {
 
 	// ARN is the Amazon Resource Name (ARN) specifying the SNS Topic.
 	ARN string `json:"arn"`
+
+	// DataProtectionPolicyApplied is true if the topic was last observed to
+	// have a data protection policy. The policy of a topic is only observed
+	// while it is desired or applied, so that topics that do not use data
+	// protection policies do not require permission to get them.
+	// +optional
+	DataProtectionPolicyApplied bool `json:"dataProtectionPolicyApplied,omitempty"`
 }
 
 // SNSTopicStatus is the status of AWS SNS Topic
 type SNSTopicStatus struct {
 	runtimev1alpha1.ResourceStatus `json:",inline"`
 	AtProvider                     SNSTopicObservation `json:"atProvider"`
+
+	// History records the outcomes of the most recent reconciles, oldest
+	// first.
+	// +optional
+	History []apisv1beta1.ReconcileRecord `json:"history,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -126,6 +226,8 @@ type SNSTopicStatus struct {
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="TOPIC-NAME",type="string",JSONPath=".spec.forProvider.name"
 // +kubebuilder:printcolumn:name="DISPLAY-NAME",type="string",JSONPath=".spec.forProvider.displayName"
+// +kubebuilder:printcolumn:name="CONFIRMED",type="integer",JSONPath=".status.atProvider.confirmedSubscriptions"
+// +kubebuilder:printcolumn:name="PENDING",type="integer",JSONPath=".status.atProvider.pendingSubscriptions"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,aws}
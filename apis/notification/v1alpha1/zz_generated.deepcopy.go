@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	corev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/provider-aws/apis/v1beta1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +53,31 @@ func (in *SNSSubscription) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNSSubscriptionDefaults) DeepCopyInto(out *SNSSubscriptionDefaults) {
+	*out = *in
+	if in.DeliveryPolicy != nil {
+		in, out := &in.DeliveryPolicy, &out.DeliveryPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.FilterPolicy != nil {
+		in, out := &in.FilterPolicy, &out.FilterPolicy
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSSubscriptionDefaults.
+func (in *SNSSubscriptionDefaults) DeepCopy() *SNSSubscriptionDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(SNSSubscriptionDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SNSSubscriptionList) DeepCopyInto(out *SNSSubscriptionList) {
 	*out = *in
@@ -137,6 +163,11 @@ func (in *SNSSubscriptionParameters) DeepCopyInto(out *SNSSubscriptionParameters
 		*out = new(string)
 		**out = **in
 	}
+	if in.FilterPolicyScope != nil {
+		in, out := &in.FilterPolicyScope, &out.FilterPolicyScope
+		*out = new(string)
+		**out = **in
+	}
 	if in.RawMessageDelivery != nil {
 		in, out := &in.RawMessageDelivery, &out.RawMessageDelivery
 		*out = new(string)
@@ -147,6 +178,16 @@ func (in *SNSSubscriptionParameters) DeepCopyInto(out *SNSSubscriptionParameters
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReturnSubscriptionArn != nil {
+		in, out := &in.ReturnSubscriptionArn, &out.ReturnSubscriptionArn
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EndpointUpdatePolicy != nil {
+		in, out := &in.EndpointUpdatePolicy, &out.EndpointUpdatePolicy
+		*out = new(EndpointUpdatePolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSSubscriptionParameters.
@@ -181,6 +222,13 @@ func (in *SNSSubscriptionStatus) DeepCopyInto(out *SNSSubscriptionStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]v1beta1.ReconcileRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSSubscriptionStatus.
@@ -310,6 +358,56 @@ func (in *SNSTopicParameters) DeepCopyInto(out *SNSTopicParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TracingConfig != nil {
+		in, out := &in.TracingConfig, &out.TracingConfig
+		*out = new(string)
+		**out = **in
+	}
+	if in.SignatureVersion != nil {
+		in, out := &in.SignatureVersion, &out.SignatureVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSuccessFeedbackRoleARN != nil {
+		in, out := &in.HTTPSuccessFeedbackRoleARN, &out.HTTPSuccessFeedbackRoleARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSuccessFeedbackSampleRate != nil {
+		in, out := &in.HTTPSuccessFeedbackSampleRate, &out.HTTPSuccessFeedbackSampleRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HTTPFailureFeedbackRoleARN != nil {
+		in, out := &in.HTTPFailureFeedbackRoleARN, &out.HTTPFailureFeedbackRoleARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ArchivePolicy != nil {
+		in, out := &in.ArchivePolicy, &out.ArchivePolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.FifoThroughputScope != nil {
+		in, out := &in.FifoThroughputScope, &out.FifoThroughputScope
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContentBasedDeduplication != nil {
+		in, out := &in.ContentBasedDeduplication, &out.ContentBasedDeduplication
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DataProtectionPolicy != nil {
+		in, out := &in.DataProtectionPolicy, &out.DataProtectionPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubscriptionDefaults != nil {
+		in, out := &in.SubscriptionDefaults, &out.SubscriptionDefaults
+		*out = new(SNSSubscriptionDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]Tag, len(*in))
@@ -351,6 +449,13 @@ func (in *SNSTopicStatus) DeepCopyInto(out *SNSTopicStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]v1beta1.ReconcileRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSTopicStatus.
@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A ReconcileRecord records the outcome of an operation on the external
+// resource of a managed resource.
+type ReconcileRecord struct {
+	// Action is the operation that was performed, i.e. Observe, Create,
+	// Update or Delete.
+	Action string `json:"action"`
+
+	// Result is the outcome of the operation.
+	Result string `json:"result"`
+
+	// Time is when the operation first had this outcome. Repeats of the
+	// same outcome do not update it.
+	Time metav1.Time `json:"time"`
+}
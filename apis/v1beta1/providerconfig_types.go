@@ -25,6 +25,39 @@ import (
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
 	runtimev1alpha1.ProviderConfigSpec `json:",inline"`
+
+	// DefaultTags are added to the tags of the managed resources that use
+	// this ProviderConfig. They are only supported by the EKS Cluster,
+	// NodeGroup and FargateProfile, the SNSTopic, and the API Gateway v2 API,
+	// DomainName, Stage and VPCLink kinds; other kinds ignore them. Tags that
+	// are explicitly set on a managed resource take precedence. Keys with the
+	// AWS reserved "aws:" prefix are ignored.
+	// +optional
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+
+	// SessionNameTemplate is the name of the STS sessions in which the role of
+	// an InjectedIdentity is assumed. It appears in CloudTrail, so that
+	// events can be attributed to the managed resource they were caused by.
+	// The placeholders {kind} and {name} are substituted with the kind and
+	// name of the managed resource. Characters that STS does not accept are
+	// replaced with "-" and the name is truncated to 64 characters. The
+	// default session name is crossplane-provider-aws.
+	// +optional
+	SessionNameTemplate string `json:"sessionNameTemplate,omitempty"`
+
+	// Profile is the profile of the shared credentials file in the credentials
+	// secret whose credentials are used. The default profile is used if it is
+	// empty. It is ignored for credentials sources other than Secret.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// SigningRegion is the region that requests to AWS are signed for. It
+	// only needs to be set if it differs from the region of the endpoint,
+	// e.g. when requests are sent through a proxy or to a VPC or custom
+	// endpoint. Requests are signed for the resolved region of their endpoint
+	// if it is empty.
+	// +optional
+	SigningRegion string `json:"signingRegion,omitempty"`
 }
 
 // A ProviderConfigStatus represents the status of a ProviderConfig.
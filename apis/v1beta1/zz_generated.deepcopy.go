@@ -87,6 +87,13 @@ func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
 func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
 	*out = *in
 	in.ProviderConfigSpec.DeepCopyInto(&out.ProviderConfigSpec)
+	if in.DefaultTags != nil {
+		in, out := &in.DefaultTags, &out.DefaultTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
@@ -172,3 +179,19 @@ func (in *ProviderConfigUsageList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileRecord) DeepCopyInto(out *ReconcileRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileRecord.
+func (in *ReconcileRecord) DeepCopy() *ReconcileRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileRecord)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -28,7 +29,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane/provider-aws/apis"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/controller"
+	"github.com/crossplane/provider-aws/pkg/utils/health"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 func main() {
@@ -37,6 +41,18 @@ func main() {
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod     = app.Flag("sync", "Controller manager sync period duration such as 300ms, 1.5h or 2h45m").Short('s').Default("1h").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		pollInterval   = app.Flag("poll", "Poll interval controls how often an up to date managed resource is checked for drift of its external resource, such as 300ms, 1.5h or 2h45m. Changes to a managed resource are reconciled immediately.").Default("1m").Duration()
+		coalesceWindow = app.Flag("coalesce-window", "Window within which successive changes to the spec of a managed resource are reconciled at once, such as 5s. Zero reconciles each change immediately. Only applies to EKS clusters and node groups.").Default("0s").Duration()
+		referenceTTL   = app.Flag("reference-cache-ttl", "How long the referents of managed resources are cached while resolving references, unless they change, such as 30s. Zero disables caching. Only applies to EKS node groups and SNS subscriptions.").Default("0s").Duration()
+		startupJitter  = app.Flag("startup-jitter", "Window across which the first reconcile of each resource is spread after startup, such as 30s or 5m. Zero disables startup jitter.").Default("0s").Duration()
+		httpProxy      = app.Flag("aws-http-proxy", "URL of the proxy that requests to the AWS API are sent through. The proxy configured by the environment is used if unset.").String()
+		caBundle       = app.Flag("aws-ca-bundle", "Path to a file of PEM encoded certificates that are trusted for requests to the AWS API, in addition to those of the system.").ExistingFile()
+		maxIdleConns   = app.Flag("aws-max-idle-conns", "Maximum number of idle connections to the AWS API that are kept open. Zero uses the Go default.").Default("0").Int()
+		hookURL        = app.Flag("post-reconcile-webhook-url", "URL that is notified using a POST request when the Ready or Synced condition of a managed resource changes. No notifications are sent if unset.").String()
+		hookTemplate   = app.Flag("post-reconcile-webhook-template", "Go template of the body of the notifications sent to the post-reconcile webhook. It is executed with the API version, kind, name, external name and conditions of the managed resource.").Default(reconciler.DefaultPostReconcileTemplate).String()
+		shutdownGrace  = app.Flag("shutdown-grace-period", "How long in-flight reconciles are allowed to complete when the provider is shut down, such as 20s. It should be shorter than the termination grace period of the provider's pod.").Default("20s").Duration()
+		healthAddr     = app.Flag("health-probe-addr", "Address on which the health endpoint is served, such as :8081. The health endpoint reports whether this replica is the leader and how many controllers it runs. It is not served if unset.").String()
+		summaryPeriod  = app.Flag("resource-summary-interval", "How often the number of managed resources of each kind that are ready, creating, deleting, degraded or failing to reconcile is exported as the aws_managed_resources metric, such as 1m. Zero disables the summary.").Default("0s").Duration()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -49,7 +65,19 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
+	log.Debug("Starting", "sync-period", syncPeriod.String(), "poll-interval", pollInterval.String(), "startup-jitter", startupJitter.String(), "coalesce-window", coalesceWindow.String())
+
+	if *httpProxy != "" || *caBundle != "" || *maxIdleConns > 0 {
+		o := awsclients.HTTPClientOptions{ProxyURL: *httpProxy, MaxIdleConns: *maxIdleConns}
+		if *caBundle != "" {
+			ca, err := ioutil.ReadFile(*caBundle)
+			kingpin.FatalIfError(err, "Cannot read AWS CA bundle")
+			o.CABundle = ca
+		}
+		hc, err := awsclients.NewHTTPClient(o)
+		kingpin.FatalIfError(err, "Cannot create AWS HTTP client")
+		awsclients.SetHTTPClient(hc)
+	}
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
@@ -62,7 +90,43 @@ func main() {
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add AWS APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup AWS controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+
+	o := reconciler.Options{
+		PollInterval:      *pollInterval,
+		StartupJitter:     *startupJitter,
+		CoalesceWindow:    *coalesceWindow,
+		ReferenceCacheTTL: *referenceTTL,
+		Drainer:           reconciler.NewDrainer(),
+	}
+	if *hookURL != "" {
+		o.PostReconcile, err = reconciler.NewPostReconcileHook(*hookURL, *hookTemplate, reconciler.WithHookLogger(log))
+		kingpin.FatalIfError(err, "Cannot create post-reconcile webhook")
+		// The hook is added to the manager rather than the counting manager;
+		// it is not a controller.
+		kingpin.FatalIfError(mgr.Add(o.PostReconcile), "Cannot add post-reconcile webhook")
+	}
+
+	status := health.NewStatus()
+	kingpin.FatalIfError(controller.Setup(status.Counting(mgr), log, o), "Cannot setup AWS controllers")
+	if *summaryPeriod > 0 {
+		// The summarizer is added to the manager rather than the counting
+		// manager; it is not a controller.
+		kingpin.FatalIfError(mgr.Add(health.NewSummarizer(mgr.GetClient(), mgr.GetScheme(), *summaryPeriod, health.WithSummarizerLogger(log))), "Cannot add managed resource summary")
+	}
+	if *healthAddr != "" {
+		kingpin.FatalIfError(mgr.Add(status), "Cannot track leader election status")
+		kingpin.FatalIfError(mgr.Add(health.NewServer(*healthAddr, status)), "Cannot add health endpoint")
+	}
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	// The manager stops its controllers without waiting for the reconciles
+	// they are running, so we wait for them before exiting.
+	if n := o.Drainer.Active(); n > 0 {
+		log.Info("Waiting for in-flight reconciles to complete", "reconciles", n, "grace-period", shutdownGrace.String())
+	}
+	if !o.Drainer.Wait(*shutdownGrace) {
+		log.Info("Stopping with reconciles still in flight", "reconciles", o.Drainer.Active())
+	}
+	kingpin.FatalIfError(err, "Cannot start controller manager")
 
 }
@@ -0,0 +1,42 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+
+	clientset "github.com/crossplane/provider-aws/pkg/clients/autoscaling"
+)
+
+// this ensures that the mock implements the client interface
+var _ clientset.GroupClient = (*MockGroupClient)(nil)
+
+// MockGroupClient is a type that implements all the methods for GroupClient interface
+type MockGroupClient struct {
+	MockDescribeAutoScalingGroupsRequest func(*autoscaling.DescribeAutoScalingGroupsInput) autoscaling.DescribeAutoScalingGroupsRequest
+	MockCreateOrUpdateTagsRequest        func(*autoscaling.CreateOrUpdateTagsInput) autoscaling.CreateOrUpdateTagsRequest
+}
+
+// DescribeAutoScalingGroupsRequest mocks DescribeAutoScalingGroupsRequest method
+func (m *MockGroupClient) DescribeAutoScalingGroupsRequest(input *autoscaling.DescribeAutoScalingGroupsInput) autoscaling.DescribeAutoScalingGroupsRequest {
+	return m.MockDescribeAutoScalingGroupsRequest(input)
+}
+
+// CreateOrUpdateTagsRequest mocks CreateOrUpdateTagsRequest method
+func (m *MockGroupClient) CreateOrUpdateTagsRequest(input *autoscaling.CreateOrUpdateTagsInput) autoscaling.CreateOrUpdateTagsRequest {
+	return m.MockCreateOrUpdateTagsRequest(input)
+}
@@ -24,9 +24,9 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
-	"strconv"
+	"reflect"
+	"regexp"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 
@@ -35,6 +35,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/external"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/stsiface"
 	awsv1 "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	endpointsv1 "github.com/aws/aws-sdk-go/aws/endpoints"
@@ -99,8 +100,11 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 
 	switch s := pc.Spec.Credentials.Source; s { //nolint:exhaustive
 	case runtimev1alpha1.CredentialsSourceInjectedIdentity:
-		cfg, err := UsePodServiceAccount(ctx, []byte{}, DefaultSection, region)
-		return SetResolver(ctx, mg, cfg), err
+		cfg, err := UsePodServiceAccountSession(ctx, GetSessionName(pc, mg), region)
+		if err != nil {
+			return nil, err
+		}
+		return SetSigningRegion(SetResolver(ctx, mg, cfg), pc.Spec.SigningRegion), nil
 	case runtimev1alpha1.CredentialsSourceSecret:
 		csr := pc.Spec.Credentials.SecretRef
 		if csr == nil {
@@ -110,13 +114,60 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 		if err := c.Get(ctx, types.NamespacedName{Namespace: csr.Namespace, Name: csr.Name}, s); err != nil {
 			return nil, errors.Wrap(err, "cannot get credentials secret")
 		}
-		cfg, err := UseProviderSecret(ctx, s.Data[csr.Key], DefaultSection, region)
-		return SetResolver(ctx, mg, cfg), err
+		cfg, err := UseProviderSecret(ctx, s.Data[csr.Key], GetProfile(pc), region)
+		if err != nil {
+			return nil, err
+		}
+		return SetSigningRegion(SetResolver(ctx, mg, cfg), pc.Spec.SigningRegion), nil
 	default:
 		return nil, errors.Errorf("credentials source %s is not currently supported", s)
 	}
 }
 
+// GetProfile returns the profile of the shared credentials file that the
+// supplied ProviderConfig uses, which is the default profile unless another
+// one is configured.
+func GetProfile(pc *v1beta1.ProviderConfig) string {
+	if pc.Spec.Profile == "" {
+		return DefaultSection
+	}
+	return pc.Spec.Profile
+}
+
+// DefaultSessionName is the name of the STS sessions in which roles are
+// assumed unless a ProviderConfig configures a session name template.
+const DefaultSessionName = "crossplane-provider-aws"
+
+// The length limits and the characters STS accepts for session names.
+const (
+	minSessionNameLength = 2
+	maxSessionNameLength = 64
+)
+
+var invalidSessionNameChars = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// GetSessionName returns the name of the STS session in which the supplied
+// managed resource assumes a role, according to the session name template of
+// the supplied ProviderConfig.
+func GetSessionName(pc *v1beta1.ProviderConfig, mg resource.Managed) string {
+	if pc.Spec.SessionNameTemplate == "" {
+		return DefaultSessionName
+	}
+	kind := mg.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = reflect.Indirect(reflect.ValueOf(mg)).Type().Name()
+	}
+	n := strings.NewReplacer("{kind}", kind, "{name}", mg.GetName()).Replace(pc.Spec.SessionNameTemplate)
+	n = invalidSessionNameChars.ReplaceAllString(n, "-")
+	if len(n) > maxSessionNameLength {
+		n = n[:maxSessionNameLength]
+	}
+	if len(n) < minSessionNameLength {
+		return DefaultSessionName
+	}
+	return n
+}
+
 // SetResolver parses annotations from the managed resource
 // and returns a configuration accordingly.
 func SetResolver(ctx context.Context, mg resource.Managed, cfg *aws.Config) *aws.Config {
@@ -143,6 +194,25 @@ func SetResolver(ctx context.Context, mg resource.Managed, cfg *aws.Config) *aws
 	return cfg
 }
 
+// SetSigningRegion returns the supplied configuration with the endpoints it
+// resolves signed for the supplied region. Endpoints are signed for the region
+// they are resolved for if the supplied region is empty.
+func SetSigningRegion(cfg *aws.Config, region string) *aws.Config {
+	if region == "" {
+		return cfg
+	}
+	resolver := cfg.EndpointResolver
+	if resolver == nil {
+		resolver = endpoints.NewDefaultResolver()
+	}
+	cfg.EndpointResolver = aws.EndpointResolverFunc(func(service, r string) (aws.Endpoint, error) {
+		e, err := resolver.ResolveEndpoint(service, r)
+		e.SigningRegion = region
+		return e, err
+	})
+	return cfg
+}
+
 // UseProvider to produce a config that can be used to authenticate to AWS.
 // Deprecated: Use UseProviderConfig.
 func UseProvider(ctx context.Context, c client.Client, mg resource.Managed, region string) (*aws.Config, error) {
@@ -223,6 +293,7 @@ func UseProviderSecret(_ context.Context, data []byte, profile, region string) (
 	}
 
 	config, err := external.LoadDefaultAWSConfig(shared)
+	config = withHTTPClient(config)
 	return &config, err
 }
 
@@ -233,40 +304,54 @@ func UseProviderSecret(_ context.Context, data []byte, profile, region string) (
 // Identity Token Provider in the following PR after merge and subsequent
 // release of AWS SDK: https://github.com/aws/aws-sdk-go-v2/pull/488
 func UsePodServiceAccount(ctx context.Context, _ []byte, _, region string) (*aws.Config, error) {
+	return UsePodServiceAccountSession(ctx, DefaultSessionName, region)
+}
+
+// UsePodServiceAccountSession assumes an IAM role configured via a
+// ServiceAccount in an STS session with the supplied name.
+func UsePodServiceAccountSession(ctx context.Context, session, region string) (*aws.Config, error) {
 	cfg, err := external.LoadDefaultAWSConfig()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load default AWS config")
 	}
+	cfg = withHTTPClient(cfg)
 	cfg.Region = region
-	svc := sts.New(cfg)
+	creds, err := assumeRoleWithWebIdentity(ctx, sts.New(cfg), session)
+	if err != nil {
+		return nil, err
+	}
+	shared := external.SharedConfig{
+		Credentials: creds,
+		Region:      region,
+	}
+	config, err := external.LoadDefaultAWSConfig(shared)
+	config = withHTTPClient(config)
+	return &config, err
+}
 
+// assumeRoleWithWebIdentity assumes the role and uses the web identity token
+// that are configured for the pod in an STS session with the supplied name.
+func assumeRoleWithWebIdentity(ctx context.Context, svc stsiface.ClientAPI, session string) (aws.Credentials, error) {
 	b, err := ioutil.ReadFile(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"))
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to read web identity token file in pod")
+		return aws.Credentials{}, errors.Wrap(err, "unable to read web identity token file in pod")
 	}
 	token := string(b)
-	sess := strconv.FormatInt(time.Now().UnixNano(), 10)
 	role := os.Getenv("AWS_ROLE_ARN")
 	resp, err := svc.AssumeRoleWithWebIdentityRequest(
 		&sts.AssumeRoleWithWebIdentityInput{
-			RoleSessionName:  &sess,
+			RoleSessionName:  &session,
 			WebIdentityToken: &token,
 			RoleArn:          &role,
 		}).Send(ctx)
 	if err != nil {
-		return nil, err
+		return aws.Credentials{}, err
 	}
-	creds := aws.Credentials{
+	return aws.Credentials{
 		AccessKeyID:     aws.StringValue(resp.Credentials.AccessKeyId),
 		SecretAccessKey: aws.StringValue(resp.Credentials.SecretAccessKey),
 		SessionToken:    aws.StringValue(resp.Credentials.SessionToken),
-	}
-	shared := external.SharedConfig{
-		Credentials: creds,
-		Region:      region,
-	}
-	config, err := external.LoadDefaultAWSConfig(shared)
-	return &config, err
+	}, nil
 }
 
 // NOTE(muvaf): ACK-generated controllers use aws/aws-sdk-go instead of
@@ -289,11 +374,11 @@ func GetConfigV1(ctx context.Context, c client.Client, mg resource.Managed, regi
 	}
 	switch s := pc.Spec.Credentials.Source; s { //nolint:exhaustive
 	case runtimev1alpha1.CredentialsSourceInjectedIdentity:
-		cfg, err := UsePodServiceAccountV1(ctx, []byte{}, mg, DefaultSection, region)
+		cfg, err := UsePodServiceAccountSessionV1(ctx, mg, GetSessionName(pc, mg), region)
 		if err != nil {
 			return nil, errors.Wrap(err, "cannot use pod service account")
 		}
-		return session.NewSession(cfg)
+		return session.NewSession(SetSigningRegionV1(cfg, pc.Spec.SigningRegion))
 	case runtimev1alpha1.CredentialsSourceSecret:
 		csr := pc.Spec.Credentials.SecretRef
 		if csr == nil {
@@ -303,11 +388,11 @@ func GetConfigV1(ctx context.Context, c client.Client, mg resource.Managed, regi
 		if err := c.Get(ctx, types.NamespacedName{Namespace: csr.Namespace, Name: csr.Name}, s); err != nil {
 			return nil, errors.Wrap(err, "cannot get credentials secret")
 		}
-		cfg, err := UseProviderSecretV1(ctx, s.Data[csr.Key], mg, DefaultSection, region)
+		cfg, err := UseProviderSecretV1(ctx, s.Data[csr.Key], mg, GetProfile(pc), region)
 		if err != nil {
 			return nil, errors.Wrap(err, "cannot use secret")
 		}
-		return session.NewSession(cfg)
+		return session.NewSession(SetSigningRegionV1(cfg, pc.Spec.SigningRegion))
 	}
 	return nil, errors.Errorf("credentials source %s is not currently supported", pc.Spec.Credentials.Source)
 }
@@ -341,41 +426,30 @@ func UseProviderSecretV1(ctx context.Context, data []byte, mg resource.Managed,
 	}
 
 	creds := credentials.NewStaticCredentials(accessKeyID.Value(), secretAccessKey.Value(), sessionToken.Value())
-	return SetResolverV1(ctx, mg, awsv1.NewConfig().WithCredentials(creds).WithRegion(region)), nil
+	return SetResolverV1(ctx, mg, withHTTPClientV1(awsv1.NewConfig().WithCredentials(creds).WithRegion(region))), nil
 }
 
 // UsePodServiceAccountV1 assumes an IAM role configured via a ServiceAccount.
 // https://docs.aws.amazon.com/eks/latest/userguide/iam-roles-for-service-accounts.html
 func UsePodServiceAccountV1(ctx context.Context, _ []byte, mg resource.Managed, _, region string) (*awsv1.Config, error) {
+	return UsePodServiceAccountSessionV1(ctx, mg, DefaultSessionName, region)
+}
+
+// UsePodServiceAccountSessionV1 assumes an IAM role configured via a
+// ServiceAccount in an STS session with the supplied name.
+func UsePodServiceAccountSessionV1(ctx context.Context, mg resource.Managed, session, region string) (*awsv1.Config, error) {
 	cfg, err := external.LoadDefaultAWSConfig()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load default AWS config")
 	}
+	cfg = withHTTPClient(cfg)
 	cfg.Region = region
-	svc := sts.New(cfg)
-
-	b, err := ioutil.ReadFile(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"))
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to read web identity token file in pod")
-	}
-	token := string(b)
-	sess := strconv.FormatInt(time.Now().UnixNano(), 10)
-	role := os.Getenv("AWS_ROLE_ARN")
-	resp, err := svc.AssumeRoleWithWebIdentityRequest(
-		&sts.AssumeRoleWithWebIdentityInput{
-			RoleSessionName:  &sess,
-			WebIdentityToken: &token,
-			RoleArn:          &role,
-		}).Send(ctx)
+	c, err := assumeRoleWithWebIdentity(ctx, sts.New(cfg), session)
 	if err != nil {
 		return nil, err
 	}
-	creds := credentials.NewStaticCredentials(
-		aws.StringValue(resp.Credentials.AccessKeyId),
-		aws.StringValue(resp.Credentials.SecretAccessKey),
-		aws.StringValue(resp.Credentials.SessionToken))
-
-	return SetResolverV1(ctx, mg, awsv1.NewConfig().WithCredentials(creds).WithRegion(region)), nil
+	creds := credentials.NewStaticCredentials(c.AccessKeyID, c.SecretAccessKey, c.SessionToken)
+	return SetResolverV1(ctx, mg, withHTTPClientV1(awsv1.NewConfig().WithCredentials(creds).WithRegion(region))), nil
 }
 
 // SetResolverV1 parses annotations from the managed resource
@@ -403,6 +477,25 @@ func SetResolverV1(ctx context.Context, mg resource.Managed, cfg *awsv1.Config)
 	return cfg
 }
 
+// SetSigningRegionV1 returns the supplied V1 configuration with the endpoints
+// it resolves signed for the supplied region. Endpoints are signed for the
+// region they are resolved for if the supplied region is empty.
+func SetSigningRegionV1(cfg *awsv1.Config, region string) *awsv1.Config {
+	if region == "" {
+		return cfg
+	}
+	resolver := cfg.EndpointResolver
+	if resolver == nil {
+		resolver = endpointsv1.DefaultResolver()
+	}
+	cfg.EndpointResolver = endpointsv1.ResolverFunc(func(service, r string, optFns ...func(*endpointsv1.Options)) (endpointsv1.ResolvedEndpoint, error) {
+		e, err := resolver.EndpointFor(service, r, optFns...)
+		e.SigningRegion = region
+		return e, err
+	})
+	return cfg
+}
+
 // TODO(muvaf): All the types that use CreateJSONPatch are known during
 // development time. In order to avoid unnecessary panic checks, we can generate
 // the code that creates a patch between two objects that share the same type.
@@ -561,6 +654,62 @@ func CompactAndEscapeJSON(s string) (string, error) {
 	return url.QueryEscape(buffer.String()), nil
 }
 
+// GetDefaultTags returns the default tags of the ProviderConfig referenced by
+// the supplied managed resource, omitting any that use a reserved key.
+func GetDefaultTags(ctx context.Context, c client.Client, mg resource.Managed) (map[string]string, error) {
+	if mg.GetProviderConfigReference() == nil {
+		return nil, nil
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, "cannot get referenced ProviderConfig")
+	}
+	tags := make(map[string]string, len(pc.Spec.DefaultTags))
+	for k, v := range pc.Spec.DefaultTags {
+		if !IsReservedTagKey(k) {
+			tags[k] = v
+		}
+	}
+	return tags, nil
+}
+
+// IsReservedTagKey returns true if the supplied tag key uses the "aws:" prefix,
+// which is reserved for use by AWS.
+func IsReservedTagKey(k string) bool {
+	return strings.HasPrefix(strings.ToLower(k), "aws:")
+}
+
+// MergeTags returns the union of the supplied tags and default tags. Tags take
+// precedence over default tags with the same key.
+func MergeTags(tags, defaults map[string]string) map[string]string {
+	if len(tags) == 0 && len(defaults) == 0 {
+		return tags
+	}
+	merged := make(map[string]string, len(tags)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// MergeTagPtrs is MergeTags for tags whose values are pointers.
+func MergeTagPtrs(tags map[string]*string, defaults map[string]string) map[string]*string {
+	if len(tags) == 0 && len(defaults) == 0 {
+		return tags
+	}
+	merged := make(map[string]*string, len(tags)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = aws.String(v)
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
 // DiffTags returns tags that should be added or removed.
 func DiffTags(local, remote map[string]string) (add map[string]string, remove []string) {
 	add = make(map[string]string, len(local))
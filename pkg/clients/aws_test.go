@@ -19,13 +19,31 @@ package aws
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/stsiface"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/v1beta1"
 )
 
 const (
@@ -76,6 +94,237 @@ func TestUseProviderSecret(t *testing.T) {
 	g.Expect(config).NotTo(BeNil())
 }
 
+func TestUseProviderConfigProfile(t *testing.T) {
+	credentials := []byte(fmt.Sprintf(awsCredentialsFileFormat, "default", "defaultID", "defaultSecret") + "\n" +
+		fmt.Sprintf(awsCredentialsFileFormat, "cool", "coolID", "coolSecret"))
+
+	cases := map[string]struct {
+		profile string
+		want    string
+	}{
+		"DefaultProfile": {
+			want: "defaultID",
+		},
+		"SelectedProfile": {
+			profile: "cool",
+			want:    "coolID",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					switch o := obj.(type) {
+					case *v1beta1.ProviderConfig:
+						o.Spec.Profile = tc.profile
+						o.Spec.Credentials.Source = runtimev1alpha1.CredentialsSourceSecret
+						o.Spec.Credentials.SecretRef = &runtimev1alpha1.SecretKeySelector{Key: "credentials"}
+					case *corev1.Secret:
+						o.Data = map[string][]byte{"credentials": credentials}
+					}
+					return nil
+				},
+				MockCreate: test.NewMockCreateFn(nil),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			}
+			mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &runtimev1alpha1.Reference{Name: "default"}}}
+
+			cfg, err := UseProviderConfig(context.Background(), kube, mg, "us-east-1")
+			if err != nil {
+				t.Fatalf("UseProviderConfig(...): unexpected error: %s", err)
+			}
+			creds, err := cfg.Credentials.Retrieve(context.Background())
+			if err != nil {
+				t.Fatalf("Retrieve(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, creds.AccessKeyID); diff != "" {
+				t.Errorf("UseProviderConfig(...): -want, +got:\n%s", diff)
+			}
+
+			sess, err := GetConfigV1(context.Background(), kube, mg, "us-east-1")
+			if err != nil {
+				t.Fatalf("GetConfigV1(...): unexpected error: %s", err)
+			}
+			credsv1, err := sess.Config.Credentials.Get()
+			if err != nil {
+				t.Fatalf("Get(): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, credsv1.AccessKeyID); diff != "" {
+				t.Errorf("GetConfigV1(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUseProviderConfigSigningRegion(t *testing.T) {
+	credentials := []byte(fmt.Sprintf(awsCredentialsFileFormat, "default", "defaultID", "defaultSecret"))
+
+	cases := map[string]struct {
+		signingRegion string
+		want          string
+	}{
+		"DefaultSigningRegion": {
+			want: "us-east-1",
+		},
+		"SigningRegionOverridden": {
+			signingRegion: "eu-west-1",
+			want:          "eu-west-1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					switch o := obj.(type) {
+					case *v1beta1.ProviderConfig:
+						o.Spec.SigningRegion = tc.signingRegion
+						o.Spec.Credentials.Source = runtimev1alpha1.CredentialsSourceSecret
+						o.Spec.Credentials.SecretRef = &runtimev1alpha1.SecretKeySelector{Key: "credentials"}
+					case *corev1.Secret:
+						o.Data = map[string][]byte{"credentials": credentials}
+					}
+					return nil
+				},
+				MockCreate: test.NewMockCreateFn(nil),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			}
+			mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &runtimev1alpha1.Reference{Name: "default"}}}
+
+			cfg, err := UseProviderConfig(context.Background(), kube, mg, "us-east-1")
+			if err != nil {
+				t.Fatalf("UseProviderConfig(...): unexpected error: %s", err)
+			}
+			e, err := cfg.EndpointResolver.ResolveEndpoint("sqs", cfg.Region)
+			if err != nil {
+				t.Fatalf("ResolveEndpoint(...): unexpected error: %s", err)
+			}
+			if e.SigningRegion == "" {
+				e.SigningRegion = cfg.Region
+			}
+			if diff := cmp.Diff(tc.want, e.SigningRegion); diff != "" {
+				t.Errorf("UseProviderConfig(...): -want, +got:\n%s", diff)
+			}
+
+			sess, err := GetConfigV1(context.Background(), kube, mg, "us-east-1")
+			if err != nil {
+				t.Fatalf("GetConfigV1(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, sess.ClientConfig("sqs").SigningRegion); diff != "" {
+				t.Errorf("GetConfigV1(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetSessionName(t *testing.T) {
+	cases := map[string]struct {
+		template string
+		want     string
+	}{
+		"Default": {
+			want: DefaultSessionName,
+		},
+		"Template": {
+			template: "crossplane-{kind}-{name}",
+			want:     "crossplane-Managed-cool-resource",
+		},
+		"InvalidCharactersReplaced": {
+			template: "crossplane/{kind} {name}",
+			want:     "crossplane-Managed-cool-resource",
+		},
+		"Truncated": {
+			template: "{name}-" + strings.Repeat("a", 64),
+			want:     "cool-resource-" + strings.Repeat("a", 50),
+		},
+		"TooShort": {
+			template: "x",
+			want:     DefaultSessionName,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &v1beta1.ProviderConfig{Spec: v1beta1.ProviderConfigSpec{SessionNameTemplate: tc.template}}
+			mg := &fake.Managed{}
+			mg.SetName("cool-resource")
+			if diff := cmp.Diff(tc.want, GetSessionName(pc, mg)); diff != "" {
+				t.Errorf("GetSessionName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type mockSTSClient struct {
+	stsiface.ClientAPI
+
+	MockAssumeRoleWithWebIdentityRequest func(*sts.AssumeRoleWithWebIdentityInput) sts.AssumeRoleWithWebIdentityRequest
+}
+
+func (m *mockSTSClient) AssumeRoleWithWebIdentityRequest(in *sts.AssumeRoleWithWebIdentityInput) sts.AssumeRoleWithWebIdentityRequest {
+	return m.MockAssumeRoleWithWebIdentityRequest(in)
+}
+
+func TestAssumeRoleWithWebIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "web-identity")
+	if err != nil {
+		t.Fatalf("TempDir(...): unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir) // nolint:errcheck
+	token := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(token, []byte("cool-token"), 0600); err != nil {
+		t.Fatalf("WriteFile(...): unexpected error: %s", err)
+	}
+	for k, v := range map[string]string{"AWS_WEB_IDENTITY_TOKEN_FILE": token, "AWS_ROLE_ARN": "cool-role"} {
+		old, set := os.LookupEnv(k)
+		if err := os.Setenv(k, v); err != nil {
+			t.Fatalf("Setenv(...): unexpected error: %s", err)
+		}
+		defer func(k string) {
+			if set {
+				os.Setenv(k, old) // nolint:errcheck
+				return
+			}
+			os.Unsetenv(k) // nolint:errcheck
+		}(k)
+	}
+
+	pc := &v1beta1.ProviderConfig{Spec: v1beta1.ProviderConfigSpec{SessionNameTemplate: "crossplane-{kind}-{name}"}}
+	mg := &fake.Managed{}
+	mg.SetName("cool-resource")
+
+	var got *sts.AssumeRoleWithWebIdentityInput
+	svc := &mockSTSClient{MockAssumeRoleWithWebIdentityRequest: func(in *sts.AssumeRoleWithWebIdentityInput) sts.AssumeRoleWithWebIdentityRequest {
+		got = in
+		return sts.AssumeRoleWithWebIdentityRequest{Request: &aws.Request{
+			HTTPRequest: &http.Request{},
+			Retryer:     aws.NoOpRetryer{},
+			Data: &sts.AssumeRoleWithWebIdentityOutput{Credentials: &sts.Credentials{
+				AccessKeyId:     aws.String("cool-id"),
+				SecretAccessKey: aws.String("cool-secret"),
+				SessionToken:    aws.String("cool-session-token"),
+			}},
+		}}
+	}}
+
+	creds, err := assumeRoleWithWebIdentity(context.Background(), svc, GetSessionName(pc, mg))
+	if err != nil {
+		t.Fatalf("assumeRoleWithWebIdentity(...): unexpected error: %s", err)
+	}
+	want := &sts.AssumeRoleWithWebIdentityInput{
+		RoleArn:          aws.String("cool-role"),
+		RoleSessionName:  aws.String("crossplane-Managed-cool-resource"),
+		WebIdentityToken: aws.String("cool-token"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AssumeRoleWithWebIdentityInput: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff("cool-id", creds.AccessKeyID); diff != "" {
+		t.Errorf("AccessKeyID: -want, +got:\n%s", diff)
+	}
+}
+
 func TestDiffTags(t *testing.T) {
 	type args struct {
 		local  map[string]string
@@ -454,3 +703,103 @@ func TestDiffLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDefaultTags(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		kube client.Client
+		mg   resource.Managed
+	}
+
+	type want struct {
+		tags map[string]string
+		err  error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoProviderConfigReference": {
+			args: args{
+				mg: &fake.Managed{},
+			},
+		},
+		"ReservedKeysOmitted": {
+			args: args{
+				kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					pc := obj.(*v1beta1.ProviderConfig)
+					pc.Spec.DefaultTags = map[string]string{"team": "cool", "aws:cloudformation:stack-name": "a", "AWS:foo": "b"}
+					return nil
+				}},
+				mg: &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &runtimev1alpha1.Reference{Name: "default"}}},
+			},
+			want: want{
+				tags: map[string]string{"team": "cool"},
+			},
+		},
+		"GetFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				mg:   &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &runtimev1alpha1.Reference{Name: "default"}}},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tags, err := GetDefaultTags(context.Background(), tc.args.kube, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("err: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.tags, tags, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("tags: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	type args struct {
+		tags     map[string]string
+		defaults map[string]string
+	}
+
+	cases := map[string]struct {
+		args args
+		want map[string]string
+	}{
+		"NoDefaults": {
+			args: args{
+				tags: map[string]string{"key": "val"},
+			},
+			want: map[string]string{"key": "val"},
+		},
+		"OnlyDefaults": {
+			args: args{
+				defaults: map[string]string{"team": "cool"},
+			},
+			want: map[string]string{"team": "cool"},
+		},
+		"TagsTakePrecedence": {
+			args: args{
+				tags:     map[string]string{"key": "val", "team": "cooler"},
+				defaults: map[string]string{"team": "cool", "env": "prod"},
+			},
+			want: map[string]string{"key": "val", "team": "cooler", "env": "prod"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := MergeTags(tc.args.tags, tc.args.defaults)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	clientset "github.com/crossplane/provider-aws/pkg/clients/ec2"
+)
+
+// this ensures that the mock implements the client interface
+var _ clientset.InstanceClient = (*MockInstanceClient)(nil)
+
+// MockInstanceClient is a type that implements all the methods for InstanceClient interface
+type MockInstanceClient struct {
+	MockDescribe              func(*ec2.DescribeInstancesInput) ec2.DescribeInstancesRequest
+	MockDescribeInstanceTypes func(*ec2.DescribeInstanceTypesInput) ec2.DescribeInstanceTypesRequest
+	MockDescribeOfferings     func(*ec2.DescribeInstanceTypeOfferingsInput) ec2.DescribeInstanceTypeOfferingsRequest
+}
+
+// DescribeInstancesRequest mocks DescribeInstancesRequest method
+func (m *MockInstanceClient) DescribeInstancesRequest(input *ec2.DescribeInstancesInput) ec2.DescribeInstancesRequest {
+	return m.MockDescribe(input)
+}
+
+// DescribeInstanceTypesRequest mocks DescribeInstanceTypesRequest method
+func (m *MockInstanceClient) DescribeInstanceTypesRequest(input *ec2.DescribeInstanceTypesInput) ec2.DescribeInstanceTypesRequest {
+	return m.MockDescribeInstanceTypes(input)
+}
+
+// DescribeInstanceTypeOfferingsRequest mocks DescribeInstanceTypeOfferingsRequest method
+func (m *MockInstanceClient) DescribeInstanceTypeOfferingsRequest(input *ec2.DescribeInstanceTypeOfferingsInput) ec2.DescribeInstanceTypeOfferingsRequest {
+	return m.MockDescribeOfferings(input)
+}
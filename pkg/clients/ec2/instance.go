@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// InstanceClient is the external client used to look up EC2 instances and
+// their instance types.
+type InstanceClient interface {
+	DescribeInstancesRequest(input *ec2.DescribeInstancesInput) ec2.DescribeInstancesRequest
+	DescribeInstanceTypesRequest(input *ec2.DescribeInstanceTypesInput) ec2.DescribeInstanceTypesRequest
+	DescribeInstanceTypeOfferingsRequest(input *ec2.DescribeInstanceTypeOfferingsInput) ec2.DescribeInstanceTypeOfferingsRequest
+}
+
+// NewInstanceClient returns a new client using AWS credentials as JSON encoded data.
+func NewInstanceClient(cfg aws.Config) InstanceClient {
+	return ec2.New(cfg)
+}
@@ -19,9 +19,10 @@ package eks
 import (
 	"encoding/base64"
 	"encoding/json"
-
+	"hash/fnv"
 	"net"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -32,6 +33,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
@@ -87,6 +89,22 @@ func IsErrorInvalidRequest(err error) bool {
 	return strings.Contains(err.Error(), eks.ErrCodeInvalidRequestException)
 }
 
+// IsErrorResourceLimitExceeded helper function to test for
+// ErrCodeResourceLimitExceededException error.
+func IsErrorResourceLimitExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), eks.ErrCodeResourceLimitExceededException)
+}
+
+// IsErrorTerminal returns true if the supplied error will not be resolved by
+// retrying the request that caused it, e.g. because a service limit has been
+// reached.
+func IsErrorTerminal(err error) bool {
+	return IsErrorResourceLimitExceeded(err)
+}
+
 // GenerateCreateClusterInput from ClusterParameters.
 func GenerateCreateClusterInput(name string, p *v1beta1.ClusterParameters) *eks.CreateClusterInput {
 	c := &eks.CreateClusterInput{
@@ -319,6 +337,91 @@ func IsUpToDate(p *v1beta1.ClusterParameters, cluster *eks.Cluster) (bool, error
 	return res, nil
 }
 
+// UpToDateCacheTTL is how long an UpToDateCache remembers a comparison. It
+// bounds the cache when clusters are removed without being forgotten, e.g.
+// because their deletion policy is Orphan.
+const UpToDateCacheTTL = 1 * time.Hour
+
+// An UpToDateCache remembers whether clusters were up to date with their
+// external clusters, so that a cluster whose desired parameters and observed
+// state did not change since it was last compared need not be compared again.
+type UpToDateCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]upToDateEntry
+
+	isUpToDate func(p *v1beta1.ClusterParameters, cluster *eks.Cluster) (bool, error)
+	now        func() time.Time
+}
+
+type upToDateEntry struct {
+	desired  uint64
+	observed uint64
+	upToDate bool
+	compared time.Time
+}
+
+// NewUpToDateCache returns an empty UpToDateCache.
+func NewUpToDateCache() *UpToDateCache {
+	return &UpToDateCache{entries: map[types.UID]upToDateEntry{}, isUpToDate: IsUpToDate, now: time.Now}
+}
+
+// IsUpToDate returns whether the supplied cluster, with the supplied desired
+// parameters, is up to date with the supplied external cluster. The desired
+// parameters may differ from the spec of the cluster, e.g. because they
+// include the default tags of its ProviderConfig. The result of the last
+// comparison is returned if neither the desired parameters nor the external
+// cluster changed since it was made, and it was made less than
+// UpToDateCacheTTL ago.
+func (c *UpToDateCache) IsUpToDate(cr *v1beta1.Cluster, p *v1beta1.ClusterParameters, cluster *eks.Cluster) (bool, error) {
+	desired, err := hash(p)
+	if err != nil {
+		return c.isUpToDate(p, cluster)
+	}
+	observed, err := hash(cluster)
+	if err != nil {
+		return c.isUpToDate(p, cluster)
+	}
+	now := c.now()
+	c.mu.Lock()
+	e, ok := c.entries[cr.GetUID()]
+	c.mu.Unlock()
+	if ok && e.desired == desired && e.observed == observed && now.Sub(e.compared) < UpToDateCacheTTL {
+		return e.upToDate, nil
+	}
+	upToDate, err := c.isUpToDate(p, cluster)
+	if err != nil {
+		return false, err
+	}
+	c.mu.Lock()
+	// Comparisons are rare enough that expired entries can be swept whenever
+	// one is made.
+	for uid, e := range c.entries {
+		if now.Sub(e.compared) >= UpToDateCacheTTL {
+			delete(c.entries, uid)
+		}
+	}
+	c.entries[cr.GetUID()] = upToDateEntry{desired: desired, observed: observed, upToDate: upToDate, compared: now}
+	c.mu.Unlock()
+	return upToDate, nil
+}
+
+// Forget the result of the last comparison of the supplied cluster.
+func (c *UpToDateCache) Forget(cr *v1beta1.Cluster) {
+	c.mu.Lock()
+	delete(c.entries, cr.GetUID())
+	c.mu.Unlock()
+}
+
+func hash(v interface{}) (uint64, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	return h.Sum64(), nil
+}
+
 // GetConnectionDetails extracts managed.ConnectionDetails out of eks.Cluster.
 func GetConnectionDetails(cluster *eks.Cluster, stsClient STSClient) managed.ConnectionDetails {
 	if cluster == nil || cluster.Name == nil || cluster.Endpoint == nil || cluster.CertificateAuthority == nil || cluster.CertificateAuthority.Data == nil {
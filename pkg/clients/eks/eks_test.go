@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -123,6 +124,34 @@ func TestIsErrorInvalidRequest(t *testing.T) {
 	}
 }
 
+func TestIsErrorTerminal(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"ResourceLimitExceeded": {
+			err:  errors.New(eks.ErrCodeResourceLimitExceededException),
+			want: true,
+		},
+		"NotTerminal": {
+			err:  errors.New(eks.ErrCodeResourceInUseException),
+			want: false,
+		},
+		"Nil": {
+			err:  nil,
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsErrorTerminal(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGenerateCreateClusterInput(t *testing.T) {
 	type args struct {
 		name string
@@ -818,3 +847,160 @@ func TestIsUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpToDateCache(t *testing.T) {
+	cr := &v1beta1.Cluster{}
+	cr.SetUID("cool-uid")
+	cr.Spec.ForProvider.Version = &version
+	observed := &eks.Cluster{Name: &clusterName, Version: &version}
+
+	type want struct {
+		upToDate    bool
+		comparisons int
+	}
+
+	cases := map[string]struct {
+		desired  *v1beta1.ClusterParameters
+		observed *eks.Cluster
+		want     want
+	}{
+		"Unchanged": {
+			desired:  &cr.Spec.ForProvider,
+			observed: observed,
+			want:     want{upToDate: true, comparisons: 1},
+		},
+		"DesiredChanged": {
+			desired:  &v1beta1.ClusterParameters{Version: &version, Tags: map[string]string{"key": "val"}},
+			observed: observed,
+			want:     want{upToDate: false, comparisons: 2},
+		},
+		"ObservedChanged": {
+			desired:  &cr.Spec.ForProvider,
+			observed: &eks.Cluster{Name: &clusterName, Version: aws.String("1.15")},
+			want:     want{upToDate: false, comparisons: 2},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			comparisons := 0
+			c := NewUpToDateCache()
+			c.isUpToDate = func(*v1beta1.ClusterParameters, *eks.Cluster) (bool, error) {
+				comparisons++
+				// Only the first comparison reports the cluster as up to date,
+				// so a cached result can be told apart from a fresh one.
+				return comparisons == 1, nil
+			}
+
+			if _, err := c.IsUpToDate(cr, &cr.Spec.ForProvider, observed); err != nil {
+				t.Fatalf("IsUpToDate(...): unexpected error: %s", err)
+			}
+			got, err := c.IsUpToDate(cr, tc.desired, tc.observed)
+			if err != nil {
+				t.Fatalf("IsUpToDate(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, want{upToDate: got, comparisons: comparisons}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpToDateCacheForget(t *testing.T) {
+	cr := &v1beta1.Cluster{}
+	cr.SetUID("cool-uid")
+	observed := &eks.Cluster{Name: &clusterName}
+
+	comparisons := 0
+	c := NewUpToDateCache()
+	c.isUpToDate = func(*v1beta1.ClusterParameters, *eks.Cluster) (bool, error) {
+		comparisons++
+		return true, nil
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.IsUpToDate(cr, &cr.Spec.ForProvider, observed); err != nil {
+			t.Fatalf("IsUpToDate(...): unexpected error: %s", err)
+		}
+		c.Forget(cr)
+	}
+	if diff := cmp.Diff(2, comparisons); diff != "" {
+		t.Errorf("comparisons: -want, +got:\n%s", diff)
+	}
+}
+
+func TestUpToDateCacheTTL(t *testing.T) {
+	cr := &v1beta1.Cluster{}
+	cr.SetUID("cool-uid")
+	other := &v1beta1.Cluster{}
+	other.SetUID("other-uid")
+	observed := &eks.Cluster{Name: &clusterName}
+
+	now := time.Now()
+	comparisons := 0
+	c := NewUpToDateCache()
+	c.now = func() time.Time { return now }
+	c.isUpToDate = func(*v1beta1.ClusterParameters, *eks.Cluster) (bool, error) {
+		comparisons++
+		return true, nil
+	}
+	for _, cr := range []*v1beta1.Cluster{cr, cr, other} {
+		if _, err := c.IsUpToDate(cr, &cr.Spec.ForProvider, observed); err != nil {
+			t.Fatalf("IsUpToDate(...): unexpected error: %s", err)
+		}
+	}
+	now = now.Add(UpToDateCacheTTL)
+	if _, err := c.IsUpToDate(other, &other.Spec.ForProvider, observed); err != nil {
+		t.Fatalf("IsUpToDate(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(3, comparisons); diff != "" {
+		t.Errorf("comparisons: -want, +got:\n%s", diff)
+	}
+	if _, ok := c.entries[cr.GetUID()]; ok {
+		t.Errorf("entries: expired entry of %s was not evicted", cr.GetUID())
+	}
+}
+
+func benchmarkCluster() (*v1beta1.Cluster, *eks.Cluster) {
+	cr := &v1beta1.Cluster{}
+	cr.SetUID("cool-uid")
+	cr.Spec.ForProvider = v1beta1.ClusterParameters{
+		ResourcesVpcConfig: v1beta1.VpcConfigRequest{
+			EndpointPrivateAccess: &trueVal,
+			EndpointPublicAccess:  &trueVal,
+			PublicAccessCidrs:     []string{"0.0.0.0/0"},
+			SecurityGroupIDs:      []string{"cool-sg-1"},
+			SubnetIDs:             []string{"cool-subnet"},
+		},
+		RoleArn: roleArn,
+		Tags:    map[string]string{"key": "val"},
+		Version: &version,
+	}
+	return cr, &eks.Cluster{
+		Name: &clusterName,
+		ResourcesVpcConfig: &eks.VpcConfigResponse{
+			EndpointPrivateAccess: &trueVal,
+			EndpointPublicAccess:  &trueVal,
+			PublicAccessCidrs:     []string{"0.0.0.0/0"},
+			SecurityGroupIds:      []string{"cool-sg-1"},
+			SubnetIds:             []string{"cool-subnet"},
+		},
+		RoleArn: &roleArn,
+		Tags:    map[string]string{"key": "val"},
+		Version: &version,
+	}
+}
+
+func BenchmarkIsUpToDate(b *testing.B) {
+	cr, cluster := benchmarkCluster()
+	for i := 0; i < b.N; i++ {
+		_, _ = IsUpToDate(&cr.Spec.ForProvider, cluster)
+	}
+}
+
+func BenchmarkUpToDateCache(b *testing.B) {
+	cr, cluster := benchmarkCluster()
+	c := NewUpToDateCache()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.IsUpToDate(cr, &cr.Spec.ForProvider, cluster)
+	}
+}
@@ -40,6 +40,12 @@ type MockClient struct {
 	MockUpdateNodegroupVersionRequest func(*eks.UpdateNodegroupVersionInput) eks.UpdateNodegroupVersionRequest
 	MockUpdateNodegroupConfigRequest  func(*eks.UpdateNodegroupConfigInput) eks.UpdateNodegroupConfigRequest
 	MockDeleteNodegroupRequest        func(*eks.DeleteNodegroupInput) eks.DeleteNodegroupRequest
+
+	MockDescribeUpdateRequest func(*eks.DescribeUpdateInput) eks.DescribeUpdateRequest
+
+	MockCreateFargateProfileRequest   func(*eks.CreateFargateProfileInput) eks.CreateFargateProfileRequest
+	MockDescribeFargateProfileRequest func(*eks.DescribeFargateProfileInput) eks.DescribeFargateProfileRequest
+	MockDeleteFargateProfileRequest   func(*eks.DeleteFargateProfileInput) eks.DeleteFargateProfileRequest
 }
 
 // CreateClusterRequest calls the underlying MockCreateClusterRequest method.
@@ -109,3 +115,26 @@ func (c *MockClient) UpdateNodegroupConfigRequest(i *eks.UpdateNodegroupConfigIn
 func (c *MockClient) DeleteNodegroupRequest(i *eks.DeleteNodegroupInput) eks.DeleteNodegroupRequest {
 	return c.MockDeleteNodegroupRequest(i)
 }
+
+// DescribeUpdateRequest calls the underlying MockDescribeUpdateRequest method.
+func (c *MockClient) DescribeUpdateRequest(i *eks.DescribeUpdateInput) eks.DescribeUpdateRequest {
+	return c.MockDescribeUpdateRequest(i)
+}
+
+// CreateFargateProfileRequest calls the underlying
+// MockCreateFargateProfileRequest method.
+func (c *MockClient) CreateFargateProfileRequest(i *eks.CreateFargateProfileInput) eks.CreateFargateProfileRequest {
+	return c.MockCreateFargateProfileRequest(i)
+}
+
+// DescribeFargateProfileRequest calls the underlying
+// MockDescribeFargateProfileRequest method.
+func (c *MockClient) DescribeFargateProfileRequest(i *eks.DescribeFargateProfileInput) eks.DescribeFargateProfileRequest {
+	return c.MockDescribeFargateProfileRequest(i)
+}
+
+// DeleteFargateProfileRequest calls the underlying
+// MockDeleteFargateProfileRequest method.
+func (c *MockClient) DeleteFargateProfileRequest(i *eks.DeleteFargateProfileInput) eks.DeleteFargateProfileRequest {
+	return c.MockDeleteFargateProfileRequest(i)
+}
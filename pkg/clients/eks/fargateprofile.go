@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+// FargateProfileClient is the external client used for FargateProfile Custom
+// Resource
+type FargateProfileClient interface {
+	CreateFargateProfileRequest(*eks.CreateFargateProfileInput) eks.CreateFargateProfileRequest
+	DescribeFargateProfileRequest(*eks.DescribeFargateProfileInput) eks.DescribeFargateProfileRequest
+	DeleteFargateProfileRequest(*eks.DeleteFargateProfileInput) eks.DeleteFargateProfileRequest
+	TagResourceRequest(*eks.TagResourceInput) eks.TagResourceRequest
+	UntagResourceRequest(*eks.UntagResourceInput) eks.UntagResourceRequest
+}
+
+// NewFargateProfileClient returns a new client using AWS credentials as JSON
+// encoded data.
+func NewFargateProfileClient(cfg aws.Config) FargateProfileClient {
+	return eks.New(cfg)
+}
+
+// GenerateCreateFargateProfileInput from FargateProfileParameters.
+func GenerateCreateFargateProfileInput(name string, p *v1alpha1.FargateProfileParameters) *eks.CreateFargateProfileInput {
+	c := &eks.CreateFargateProfileInput{
+		FargateProfileName:  &name,
+		ClusterName:         &p.ClusterName,
+		PodExecutionRoleArn: &p.PodExecutionRoleArn,
+		Subnets:             p.Subnets,
+		Tags:                p.Tags,
+	}
+	if len(p.Selectors) > 0 {
+		c.Selectors = make([]eks.FargateProfileSelector, len(p.Selectors))
+		for i, s := range p.Selectors {
+			c.Selectors[i] = eks.FargateProfileSelector{
+				Namespace: awsclients.String(s.Namespace),
+				Labels:    s.Labels,
+			}
+		}
+	}
+	return c
+}
+
+// GenerateFargateProfileObservation is used to produce
+// v1alpha1.FargateProfileObservation from eks.FargateProfile.
+func GenerateFargateProfileObservation(fp *eks.FargateProfile) v1alpha1.FargateProfileObservation {
+	if fp == nil {
+		return v1alpha1.FargateProfileObservation{}
+	}
+	o := v1alpha1.FargateProfileObservation{
+		FargateProfileArn: awsclients.StringValue(fp.FargateProfileArn),
+		Status:            v1alpha1.FargateProfileStatusType(fp.Status),
+	}
+	if fp.CreatedAt != nil {
+		o.CreatedAt = &metav1.Time{Time: *fp.CreatedAt}
+	}
+	return o
+}
+
+// LateInitializeFargateProfile fills the empty fields in
+// *v1alpha1.FargateProfileParameters with the values seen in
+// eks.FargateProfile. EKS chooses the private subnets of the cluster if none
+// are specified.
+func LateInitializeFargateProfile(in *v1alpha1.FargateProfileParameters, fp *eks.FargateProfile) {
+	if fp == nil {
+		return
+	}
+	if len(in.Subnets) == 0 && len(fp.Subnets) > 0 {
+		in.Subnets = fp.Subnets
+	}
+}
+
+// IsFargateProfileUpToDate checks whether there is a change in any of the
+// modifiable fields of the Fargate profile. Only its tags may be changed once
+// it is created.
+func IsFargateProfileUpToDate(p *v1alpha1.FargateProfileParameters, fp *eks.FargateProfile) bool {
+	return cmp.Equal(p.Tags, FilterAWSManagedTags(fp.Tags), cmpopts.EquateEmpty())
+}
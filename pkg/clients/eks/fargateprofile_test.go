@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+var (
+	fpName     = "my-cool-fp"
+	fpArn      = "arn:aws:eks:us-east-1:123456789012:fargateprofile/my-cool-cluster/my-cool-fp/abc"
+	fpRoleArn  = "arn:aws:iam::123456789012:role/fargate"
+	fpCluster  = "my-cool-cluster"
+	fpSubnetID = "subnet-cool"
+)
+
+func TestGenerateCreateFargateProfileInput(t *testing.T) {
+	type args struct {
+		name string
+		p    *v1alpha1.FargateProfileParameters
+	}
+
+	cases := map[string]struct {
+		args args
+		want *eks.CreateFargateProfileInput
+	}{
+		"AllFields": {
+			args: args{
+				name: fpName,
+				p: &v1alpha1.FargateProfileParameters{
+					ClusterName:         fpCluster,
+					PodExecutionRoleArn: fpRoleArn,
+					Selectors: []v1alpha1.FargateProfileSelector{
+						{Namespace: "default", Labels: map[string]string{"cool": "label"}},
+						{Namespace: "kube-system"},
+					},
+					Subnets: []string{fpSubnetID},
+					Tags:    map[string]string{"cool": "tag"},
+				},
+			},
+			want: &eks.CreateFargateProfileInput{
+				FargateProfileName:  &fpName,
+				ClusterName:         &fpCluster,
+				PodExecutionRoleArn: &fpRoleArn,
+				Selectors: []eks.FargateProfileSelector{
+					{Namespace: aws.String("default"), Labels: map[string]string{"cool": "label"}},
+					{Namespace: aws.String("kube-system")},
+				},
+				Subnets: []string{fpSubnetID},
+				Tags:    map[string]string{"cool": "tag"},
+			},
+		},
+		"NoSelectors": {
+			args: args{
+				name: fpName,
+				p: &v1alpha1.FargateProfileParameters{
+					ClusterName:         fpCluster,
+					PodExecutionRoleArn: fpRoleArn,
+				},
+			},
+			want: &eks.CreateFargateProfileInput{
+				FargateProfileName:  &fpName,
+				ClusterName:         &fpCluster,
+				PodExecutionRoleArn: &fpRoleArn,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateCreateFargateProfileInput(tc.args.name, tc.args.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateFargateProfileObservation(t *testing.T) {
+	now := time.Now()
+
+	cases := map[string]struct {
+		fp   *eks.FargateProfile
+		want v1alpha1.FargateProfileObservation
+	}{
+		"AllFields": {
+			fp: &eks.FargateProfile{
+				CreatedAt:         &now,
+				FargateProfileArn: &fpArn,
+				Status:            eks.FargateProfileStatusActive,
+			},
+			want: v1alpha1.FargateProfileObservation{
+				CreatedAt:         &metav1.Time{Time: now},
+				FargateProfileArn: fpArn,
+				Status:            v1alpha1.FargateProfileStatusActive,
+			},
+		},
+		"Nil": {
+			want: v1alpha1.FargateProfileObservation{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateFargateProfileObservation(tc.fp)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeFargateProfile(t *testing.T) {
+	type args struct {
+		p  *v1alpha1.FargateProfileParameters
+		fp *eks.FargateProfile
+	}
+
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.FargateProfileParameters
+	}{
+		"SubnetsEmpty": {
+			args: args{
+				p:  &v1alpha1.FargateProfileParameters{},
+				fp: &eks.FargateProfile{Subnets: []string{fpSubnetID}},
+			},
+			want: &v1alpha1.FargateProfileParameters{Subnets: []string{fpSubnetID}},
+		},
+		"SubnetsSet": {
+			args: args{
+				p:  &v1alpha1.FargateProfileParameters{Subnets: []string{"subnet-other"}},
+				fp: &eks.FargateProfile{Subnets: []string{fpSubnetID}},
+			},
+			want: &v1alpha1.FargateProfileParameters{Subnets: []string{"subnet-other"}},
+		},
+		"NotObserved": {
+			args: args{
+				p: &v1alpha1.FargateProfileParameters{},
+			},
+			want: &v1alpha1.FargateProfileParameters{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeFargateProfile(tc.args.p, tc.args.fp)
+			if diff := cmp.Diff(tc.want, tc.args.p); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsFargateProfileUpToDate(t *testing.T) {
+	type args struct {
+		p  *v1alpha1.FargateProfileParameters
+		fp *eks.FargateProfile
+	}
+
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"UpToDate": {
+			args: args{
+				p:  &v1alpha1.FargateProfileParameters{Tags: map[string]string{"cool": "tag"}},
+				fp: &eks.FargateProfile{Tags: map[string]string{"cool": "tag"}},
+			},
+			want: true,
+		},
+		"IgnoresAWSManagedTags": {
+			args: args{
+				p:  &v1alpha1.FargateProfileParameters{},
+				fp: &eks.FargateProfile{Tags: map[string]string{"aws:cloudformation:stack-name": "cool"}},
+			},
+			want: true,
+		},
+		"IgnoresImmutableFields": {
+			args: args{
+				p:  &v1alpha1.FargateProfileParameters{Subnets: []string{"subnet-other"}},
+				fp: &eks.FargateProfile{Subnets: []string{fpSubnetID}},
+			},
+			want: true,
+		},
+		"TagsDiffer": {
+			args: args{
+				p:  &v1alpha1.FargateProfileParameters{Tags: map[string]string{"cool": "tag"}},
+				fp: &eks.FargateProfile{Tags: map[string]string{"cool": "other"}},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsFargateProfileUpToDate(tc.args.p, tc.args.fp)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// AnnotationKeyLookupMaxPods is the annotation that enables looking up the
+// network interface limits of the instance types of a node group in EC2 to
+// compute the max pods per node, rather than using DefaultENILimits. Doing so
+// requires describing its instance types on every observation.
+const AnnotationKeyLookupMaxPods = "eks.aws.crossplane.io/lookup-max-pods"
+
+// ENILimits are the network interface limits of an instance type that bound
+// how many pods its nodes can run when using the Amazon VPC CNI plugin.
+type ENILimits struct {
+	// ENIs is the maximum number of network interfaces.
+	ENIs int64
+
+	// IPv4AddressesPerENI is the maximum number of IPv4 addresses of each
+	// network interface.
+	IPv4AddressesPerENI int64
+}
+
+// MaxPods returns the number of pods a node with the supplied limits can run.
+// The primary address of each network interface is not available to pods,
+// while pods using host networking, e.g. kube-proxy and the CNI plugin, need
+// no address of their own.
+func (l ENILimits) MaxPods() int64 {
+	return l.ENIs*(l.IPv4AddressesPerENI-1) + 2
+}
+
+// DefaultENILimits are the network interface limits of common instance types.
+var DefaultENILimits = map[string]ENILimits{
+	"t3.micro":    {ENIs: 2, IPv4AddressesPerENI: 2},
+	"t3.small":    {ENIs: 3, IPv4AddressesPerENI: 4},
+	"t3.medium":   {ENIs: 3, IPv4AddressesPerENI: 6},
+	"t3.large":    {ENIs: 3, IPv4AddressesPerENI: 12},
+	"t3.xlarge":   {ENIs: 4, IPv4AddressesPerENI: 15},
+	"t3.2xlarge":  {ENIs: 4, IPv4AddressesPerENI: 15},
+	"t3a.micro":   {ENIs: 2, IPv4AddressesPerENI: 2},
+	"t3a.small":   {ENIs: 2, IPv4AddressesPerENI: 4},
+	"t3a.medium":  {ENIs: 3, IPv4AddressesPerENI: 6},
+	"t3a.large":   {ENIs: 3, IPv4AddressesPerENI: 12},
+	"t3a.xlarge":  {ENIs: 4, IPv4AddressesPerENI: 15},
+	"t3a.2xlarge": {ENIs: 4, IPv4AddressesPerENI: 15},
+	"m5.large":    {ENIs: 3, IPv4AddressesPerENI: 10},
+	"m5.xlarge":   {ENIs: 4, IPv4AddressesPerENI: 15},
+	"m5.2xlarge":  {ENIs: 4, IPv4AddressesPerENI: 15},
+	"m5.4xlarge":  {ENIs: 8, IPv4AddressesPerENI: 30},
+	"m5.8xlarge":  {ENIs: 8, IPv4AddressesPerENI: 30},
+	"m5.12xlarge": {ENIs: 8, IPv4AddressesPerENI: 30},
+	"m5.16xlarge": {ENIs: 15, IPv4AddressesPerENI: 50},
+	"m5.24xlarge": {ENIs: 15, IPv4AddressesPerENI: 50},
+	"c5.large":    {ENIs: 3, IPv4AddressesPerENI: 10},
+	"c5.xlarge":   {ENIs: 4, IPv4AddressesPerENI: 15},
+	"c5.2xlarge":  {ENIs: 4, IPv4AddressesPerENI: 15},
+	"c5.4xlarge":  {ENIs: 8, IPv4AddressesPerENI: 30},
+	"c5.9xlarge":  {ENIs: 8, IPv4AddressesPerENI: 30},
+	"c5.12xlarge": {ENIs: 8, IPv4AddressesPerENI: 30},
+	"c5.18xlarge": {ENIs: 15, IPv4AddressesPerENI: 50},
+	"c5.24xlarge": {ENIs: 15, IPv4AddressesPerENI: 50},
+	"r5.large":    {ENIs: 3, IPv4AddressesPerENI: 10},
+	"r5.xlarge":   {ENIs: 4, IPv4AddressesPerENI: 15},
+	"r5.2xlarge":  {ENIs: 4, IPv4AddressesPerENI: 15},
+	"r5.4xlarge":  {ENIs: 8, IPv4AddressesPerENI: 30},
+	"r5.8xlarge":  {ENIs: 8, IPv4AddressesPerENI: 30},
+	"r5.12xlarge": {ENIs: 8, IPv4AddressesPerENI: 30},
+	"r5.16xlarge": {ENIs: 15, IPv4AddressesPerENI: 50},
+	"r5.24xlarge": {ENIs: 15, IPv4AddressesPerENI: 50},
+}
+
+// GenerateDescribeInstanceTypesInput returns the input to describe the
+// supplied instance types.
+func GenerateDescribeInstanceTypesInput(instanceTypes []string) *ec2.DescribeInstanceTypesInput {
+	in := &ec2.DescribeInstanceTypesInput{InstanceTypes: make([]ec2.InstanceType, len(instanceTypes))}
+	for i, t := range instanceTypes {
+		in.InstanceTypes[i] = ec2.InstanceType(t)
+	}
+	return in
+}
+
+// GetENILimits returns the network interface limits of the supplied instance
+// types, keyed by instance type.
+func GetENILimits(types []ec2.InstanceTypeInfo) map[string]ENILimits {
+	limits := make(map[string]ENILimits, len(types))
+	for _, t := range types {
+		if t.NetworkInfo == nil {
+			continue
+		}
+		limits[string(t.InstanceType)] = ENILimits{
+			ENIs:                aws.Int64Value(t.NetworkInfo.MaximumNetworkInterfaces),
+			IPv4AddressesPerENI: aws.Int64Value(t.NetworkInfo.Ipv4AddressesPerInterface),
+		}
+	}
+	return limits
+}
+
+// GetMaxPodsPerNode returns the lowest number of pods a node of any of the
+// supplied instance types can run, given the supplied limits. It returns nil
+// if the limits of any of the instance types are not known.
+func GetMaxPodsPerNode(instanceTypes []string, limits map[string]ENILimits) *int64 {
+	var maxPods *int64
+	for _, t := range instanceTypes {
+		l, ok := limits[t]
+		if !ok {
+			return nil
+		}
+		if n := l.MaxPods(); maxPods == nil || n < *maxPods {
+			maxPods = aws.Int64(n)
+		}
+	}
+	return maxPods
+}
@@ -17,16 +17,196 @@ limitations under the License.
 package eks
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 
 	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 )
 
+const (
+	// AnnotationKeyDeletionGracePeriod is the annotation that overrides how
+	// long after its deletion a node group may be observed in DELETING state
+	// before it is considered stuck. The value is parsed as a Go duration.
+	AnnotationKeyDeletionGracePeriod = "eks.aws.crossplane.io/deletion-grace-period"
+
+	// AnnotationKeyCreateTime is the annotation that records when a node
+	// group was created, formatted as RFC 3339. It is set once the creation
+	// of the node group was accepted by EKS.
+	AnnotationKeyCreateTime = "eks.aws.crossplane.io/create-time"
+
+	// AnnotationKeyCreateGracePeriod is the annotation that overrides how
+	// long after its creation a node group that cannot be found is assumed to
+	// be still creating, rather than gone. The value is parsed as a Go
+	// duration.
+	AnnotationKeyCreateGracePeriod = "eks.aws.crossplane.io/create-grace-period"
+
+	// AnnotationKeyRemoveFinalizerAfterGracePeriod is the annotation that
+	// allows the finalizer of a node group that is stuck deleting to be removed
+	// once its deletion grace period has elapsed.
+	AnnotationKeyRemoveFinalizerAfterGracePeriod = "eks.aws.crossplane.io/remove-finalizer-after-grace-period"
+
+	// AnnotationKeyObserveAMIID is the annotation that enables observing the
+	// ID of the AMI that the instances of a node group run. Doing so requires
+	// describing the instances of the node group on every observation.
+	AnnotationKeyObserveAMIID = "eks.aws.crossplane.io/observe-ami-id"
+
+	// AnnotationKeyObserveInstanceProfile is the annotation that enables
+	// observing the name of the instance profile of the node role of a node
+	// group. Doing so requires an IAM API call on every observation.
+	AnnotationKeyObserveInstanceProfile = "eks.aws.crossplane.io/observe-instance-profile"
+
+	// AnnotationKeyObserveCapacity is the annotation that enables observing
+	// the instance types of a node group, e.g. so that its cost can be
+	// estimated. Its capacity type is not observed; the EKS API version this
+	// provider uses does not report it.
+	AnnotationKeyObserveCapacity = "eks.aws.crossplane.io/observe-capacity"
+
+	// AnnotationKeyCheckSubnetCapacity is the annotation that enables checking
+	// whether the subnets of a node group have enough free IP addresses for it
+	// to scale to its maximum size. Doing so requires describing the subnets
+	// of the node group on every observation.
+	AnnotationKeyCheckSubnetCapacity = "eks.aws.crossplane.io/check-subnet-capacity"
+
+	// AnnotationKeyCheckServiceCIDR is the annotation that enables checking
+	// whether the subnets of a node group overlap the service CIDR of its
+	// cluster. Its value is either "true", to check the service CIDR EKS
+	// picks by default for the VPC of the cluster, or the service CIDR of the
+	// cluster, e.g. if it was created with a custom one. Doing so requires
+	// describing the subnets of the node group on every observation.
+	AnnotationKeyCheckServiceCIDR = "eks.aws.crossplane.io/check-service-cidr"
+
+	// AnnotationKeyRequiredNodeLabels is the annotation that lists the node
+	// labels the workloads scheduled onto a node group select, so that users
+	// are warned if the labels of the node group do not match them. Its value
+	// is a comma separated list of key=value pairs, or keys that match any
+	// value, e.g. "team=data,gpu".
+	AnnotationKeyRequiredNodeLabels = "eks.aws.crossplane.io/required-node-labels"
+
+	// AnnotationKeyCheckInstanceTypeOfferings is the annotation that enables
+	// checking whether the instance types of a node group are offered in all
+	// availability zones of its subnets before it is created. EKS would
+	// otherwise fail to create a node group with an instance type that is
+	// retired or unavailable in the region without naming it.
+	AnnotationKeyCheckInstanceTypeOfferings = "eks.aws.crossplane.io/check-instance-type-offerings"
+
+	// AnnotationKeyObserveOnly is the annotation that puts a node group in
+	// observe-only mode. Its drift from the desired state is still reported
+	// in its status, but it is not updated until the annotation is removed.
+	// This allows previewing the changes that would be made to an adopted
+	// node group before managing it.
+	AnnotationKeyObserveOnly = "eks.aws.crossplane.io/observe-only"
+
+	// ConnectionSecretRemoteAccessSecurityGroupIDKey is the connection secret
+	// key under which the ID of the security group that is created for remote
+	// access to the nodes of a node group is published.
+	ConnectionSecretRemoteAccessSecurityGroupIDKey = "remoteAccessSecurityGroupId"
+
+	// DefaultCreateGracePeriod is how long after its creation a node group
+	// that cannot be found is assumed to be still creating. EKS may not
+	// return a node group it just created until its creation has propagated.
+	DefaultCreateGracePeriod = 1 * time.Minute
+
+	// DefaultDeletionGracePeriod is how long after its deletion a node group
+	// may be observed in DELETING state before it is considered stuck.
+	DefaultDeletionGracePeriod = 30 * time.Minute
+
+	// TagTemplatePrefix marks a node group tag value as a template. The
+	// placeholders {cluster.name}, {cluster.arn}, {cluster.version} and
+	// {cluster.platformVersion} in a template are substituted with the
+	// observed attributes of the cluster of the node group. Values without
+	// the prefix are used literally.
+	TagTemplatePrefix = "template:"
+)
+
+// awsManagedTagPrefixes are the prefixes of the tags that AWS adds to node
+// groups on its own, e.g. aws:eks:cluster-name.
+var awsManagedTagPrefixes = []string{"aws:", "eks:"}
+
+// IsAWSManagedTag returns true if the supplied tag key is reserved for tags
+// that AWS adds to node groups on its own.
+func IsAWSManagedTag(k string) bool {
+	for _, p := range awsManagedTagPrefixes {
+		if strings.HasPrefix(k, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterAWSManagedTags returns the supplied tags without those that AWS adds
+// to node groups on its own. Such tags can neither be set nor removed by
+// users, so they must not be considered when comparing tags.
+func FilterAWSManagedTags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if !IsAWSManagedTag(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// HasTagTemplates returns true if any of the supplied tag values is a template.
+func HasTagTemplates(tags map[string]string) bool {
+	for _, v := range tags {
+		if strings.HasPrefix(v, TagTemplatePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveTagTemplates returns a copy of the supplied tags in which every
+// template value is resolved using the attributes of the supplied cluster.
+func ResolveTagTemplates(tags map[string]string, c *eks.Cluster) map[string]string {
+	if !HasTagTemplates(tags) {
+		return tags
+	}
+	if c == nil {
+		c = &eks.Cluster{}
+	}
+	r := strings.NewReplacer(
+		"{cluster.name}", aws.StringValue(c.Name),
+		"{cluster.arn}", aws.StringValue(c.Arn),
+		"{cluster.version}", aws.StringValue(c.Version),
+		"{cluster.platformVersion}", aws.StringValue(c.PlatformVersion),
+	)
+	resolved := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if strings.HasPrefix(v, TagTemplatePrefix) {
+			v = r.Replace(strings.TrimPrefix(v, TagTemplatePrefix))
+		}
+		resolved[k] = v
+	}
+	return resolved
+}
+
 // GenerateCreateNodeGroupInput from NodeGroupParameters.
 func GenerateCreateNodeGroupInput(name string, p *v1alpha1.NodeGroupParameters) *eks.CreateNodegroupInput {
 	c := &eks.CreateNodegroupInput{
@@ -58,7 +238,9 @@ func GenerateCreateNodeGroupInput(name string, p *v1alpha1.NodeGroupParameters)
 	return c
 }
 
-// GenerateUpdateNodeGroupConfigInput from NodeGroupParameters.
+// GenerateUpdateNodeGroupConfigInput from NodeGroupParameters. Label and
+// scaling configuration changes are combined into a single input so that they
+// are applied atomically by one UpdateNodegroupConfig call.
 func GenerateUpdateNodeGroupConfigInput(name string, p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) *eks.UpdateNodegroupConfigInput {
 	u := &eks.UpdateNodegroupConfigInput{
 		NodegroupName: &name,
@@ -67,9 +249,11 @@ func GenerateUpdateNodeGroupConfigInput(name string, p *v1alpha1.NodeGroupParame
 
 	if len(p.Labels) > 0 {
 		addOrModify, remove := aws.DiffLabels(p.Labels, ng.Labels)
-		u.Labels = &eks.UpdateLabelsPayload{
-			AddOrUpdateLabels: addOrModify,
-			RemoveLabels:      remove,
+		if len(addOrModify) > 0 || len(remove) > 0 {
+			u.Labels = &eks.UpdateLabelsPayload{
+				AddOrUpdateLabels: addOrModify,
+				RemoveLabels:      remove,
+			}
 		}
 	}
 	if p.ScalingConfig != nil {
@@ -91,6 +275,8 @@ func GenerateNodeGroupObservation(ng *eks.Nodegroup) v1alpha1.NodeGroupObservati
 	o := v1alpha1.NodeGroupObservation{
 		NodeGroupArn: awsclients.StringValue(ng.NodegroupArn),
 		Status:       v1alpha1.NodeGroupStatusType(ng.Status),
+		Version:      awsclients.StringValue(ng.Version),
+		NodeRoleArn:  awsclients.StringValue(ng.NodeRole),
 	}
 	if ng.CreatedAt != nil {
 		o.CreatedAt = &metav1.Time{Time: *ng.CreatedAt}
@@ -106,10 +292,18 @@ func GenerateNodeGroupObservation(ng *eks.Nodegroup) v1alpha1.NodeGroupObservati
 				ResourceIDs: i.ResourceIds,
 			}
 		}
+		_, o.NodeBootstrap = GetBootstrapIssue(o.Health)
 	}
 	if ng.ModifiedAt != nil {
 		o.ModifiedAt = &metav1.Time{Time: *ng.ModifiedAt}
 	}
+	if ng.ScalingConfig != nil {
+		o.ScalingConfig = &v1alpha1.NodeGroupScalingConfig{
+			DesiredSize: ng.ScalingConfig.DesiredSize,
+			MinSize:     ng.ScalingConfig.MinSize,
+			MaxSize:     ng.ScalingConfig.MaxSize,
+		}
+	}
 	if ng.Resources != nil {
 		o.Resources = v1alpha1.NodeGroupResources{
 			RemoteAccessSecurityGroup: aws.StringValue(ng.Resources.RemoteAccessSecurityGroup),
@@ -125,6 +319,359 @@ func GenerateNodeGroupObservation(ng *eks.Nodegroup) v1alpha1.NodeGroupObservati
 	return o
 }
 
+// GetSubnetsOutsideVPC returns the IDs of the supplied subnets that are not in
+// the supplied VPC.
+func GetSubnetsOutsideVPC(vpcID string, subnets []ec2.Subnet) []string {
+	var outside []string
+	for _, s := range subnets {
+		if aws.StringValue(s.VpcId) != vpcID {
+			outside = append(outside, aws.StringValue(s.SubnetId))
+		}
+	}
+	return outside
+}
+
+// GetAvailabilityZones returns the sorted, distinct availability zones of the
+// supplied subnets.
+func GetAvailabilityZones(subnets []ec2.Subnet) []string {
+	seen := map[string]bool{}
+	var zones []string
+	for _, s := range subnets {
+		z := aws.StringValue(s.AvailabilityZone)
+		if z == "" || seen[z] {
+			continue
+		}
+		seen[z] = true
+		zones = append(zones, z)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// GenerateDescribeInstanceTypeOfferingsInput returns the input to describe
+// the offerings of the supplied instance types in the supplied availability
+// zones.
+func GenerateDescribeInstanceTypeOfferingsInput(instanceTypes, zones []string) *ec2.DescribeInstanceTypeOfferingsInput {
+	return &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: ec2.LocationTypeAvailabilityZone,
+		Filters: []ec2.Filter{
+			{Name: aws.String("instance-type"), Values: instanceTypes},
+			{Name: aws.String("location"), Values: zones},
+		},
+	}
+}
+
+// GetUnofferedInstanceTypes returns the supplied instance types that are not
+// offered in all of the supplied availability zones, in the order they were
+// supplied.
+func GetUnofferedInstanceTypes(instanceTypes, zones []string, offerings []ec2.InstanceTypeOffering) []string {
+	offered := map[string]map[string]bool{}
+	for _, o := range offerings {
+		t := string(o.InstanceType)
+		if offered[t] == nil {
+			offered[t] = map[string]bool{}
+		}
+		offered[t][aws.StringValue(o.Location)] = true
+	}
+	var unoffered []string
+	for _, t := range instanceTypes {
+		for _, z := range zones {
+			if !offered[t][z] {
+				unoffered = append(unoffered, t)
+				break
+			}
+		}
+	}
+	return unoffered
+}
+
+// The service CIDRs EKS picks for clusters that were not created with a custom
+// one. The second is used if the primary CIDR of the VPC of the cluster is
+// within 10.0.0.0/8, so that the service CIDR does not overlap it.
+const (
+	DefaultServiceCIDR    = "10.100.0.0/16"
+	AlternateServiceCIDR  = "172.20.0.0/16"
+	alternateServiceRange = "10.0.0.0/8"
+)
+
+// GetDefaultServiceCIDR returns the service CIDR EKS picks for a cluster in a
+// VPC with the supplied primary CIDR.
+func GetDefaultServiceCIDR(vpcCIDR string) (string, error) {
+	ip, _, err := net.ParseCIDR(vpcCIDR)
+	if err != nil {
+		return "", err
+	}
+	_, alternate, _ := net.ParseCIDR(alternateServiceRange)
+	if alternate.Contains(ip) {
+		return AlternateServiceCIDR, nil
+	}
+	return DefaultServiceCIDR, nil
+}
+
+const errInvalidNodeLabelSelector = "invalid node label selector %q: it must be a key=value pair or a key"
+
+// GetMissingNodeLabels returns the sorted node label selectors of the supplied
+// comma separated list that the supplied node labels do not match. A selector
+// is either a key=value pair or a key that matches any value.
+func GetMissingNodeLabels(required string, labels map[string]string) ([]string, error) {
+	var missing []string
+	for _, sel := range strings.Split(required, ",") {
+		sel = strings.TrimSpace(sel)
+		kv := strings.SplitN(sel, "=", 2)
+		k := strings.TrimSpace(kv[0])
+		if k == "" {
+			return nil, errors.Errorf(errInvalidNodeLabelSelector, sel)
+		}
+		v, ok := labels[k]
+		if !ok || (len(kv) == 2 && v != strings.TrimSpace(kv[1])) {
+			missing = append(missing, sel)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// GetSubnetsOverlappingCIDR returns the IDs of the supplied subnets whose CIDR
+// overlaps the supplied CIDR.
+func GetSubnetsOverlappingCIDR(cidr string, subnets []ec2.Subnet) ([]string, error) {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	var overlapping []string
+	for _, s := range subnets {
+		_, sn, err := net.ParseCIDR(aws.StringValue(s.CidrBlock))
+		if err != nil {
+			continue
+		}
+		// Two CIDRs overlap if and only if one contains the other.
+		if n.Contains(sn.IP) || sn.Contains(n.IP) {
+			overlapping = append(overlapping, aws.StringValue(s.SubnetId))
+		}
+	}
+	return overlapping, nil
+}
+
+// CountAvailableIPAddresses returns the number of free IP addresses across the
+// supplied subnets.
+func CountAvailableIPAddresses(subnets []ec2.Subnet) int64 {
+	var n int64
+	for _, s := range subnets {
+		n += aws.Int64Value(s.AvailableIpAddressCount)
+	}
+	return n
+}
+
+// GetAdditionalNodes returns how many nodes the supplied node group may add to
+// those it currently has, i.e. the difference between the larger of its
+// desired and maximum size and its observed desired size. Each of these nodes
+// needs at least one free IP address in the subnets of the node group.
+func GetAdditionalNodes(p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) int64 {
+	var target, current int64
+	if ng.ScalingConfig != nil {
+		target = aws.Int64Value(ng.ScalingConfig.MaxSize)
+		current = aws.Int64Value(ng.ScalingConfig.DesiredSize)
+	}
+	if sc := p.ScalingConfig; sc != nil {
+		if sc.MaxSize != nil {
+			target = *sc.MaxSize
+		}
+		if sc.DesiredSize != nil && *sc.DesiredSize > target {
+			target = *sc.DesiredSize
+		}
+	}
+	if target <= current {
+		return 0
+	}
+	return target - current
+}
+
+// GenerateDescribeNodeGroupInstancesInput returns the input to describe the
+// running instances of the supplied node group.
+func GenerateDescribeNodeGroupInstancesInput(clusterName, name string) *ec2.DescribeInstancesInput {
+	return &ec2.DescribeInstancesInput{
+		Filters: []ec2.Filter{
+			{Name: aws.String("tag:eks:cluster-name"), Values: []string{clusterName}},
+			{Name: aws.String("tag:eks:nodegroup-name"), Values: []string{name}},
+			{Name: aws.String("instance-state-name"), Values: []string{string(ec2.InstanceStateNamePending), string(ec2.InstanceStateNameRunning)}},
+		},
+	}
+}
+
+// GetAMIID returns the sorted, comma separated IDs of the AMIs that the
+// supplied instances run.
+func GetAMIID(reservations []ec2.Reservation) string {
+	ids := map[string]bool{}
+	for _, r := range reservations {
+		for _, i := range r.Instances {
+			if i.ImageId != nil {
+				ids[*i.ImageId] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// GenerateListInstanceProfilesForRoleInput returns the input to list the
+// instance profiles of the role with the supplied ARN.
+func GenerateListInstanceProfilesForRoleInput(roleArn string) *iam.ListInstanceProfilesForRoleInput {
+	return &iam.ListInstanceProfilesForRoleInput{RoleName: aws.String(roleName(roleArn))}
+}
+
+// GenerateGetRoleInput returns the input to get the role with the supplied
+// ARN.
+func GenerateGetRoleInput(roleArn string) *iam.GetRoleInput {
+	return &iam.GetRoleInput{RoleName: aws.String(roleName(roleArn))}
+}
+
+// roleName returns the name of the role with the supplied ARN.
+func roleName(roleArn string) string {
+	// The name of a role is the last element of its ARN, which may include a
+	// path, e.g. arn:aws:iam::123456789012:role/path/name.
+	return roleArn[strings.LastIndex(roleArn, "/")+1:]
+}
+
+// EC2ServicePrincipal is the service principal that the node role of a node
+// group must trust, so that its instances can assume it.
+const EC2ServicePrincipal = "ec2.amazonaws.com"
+
+// A stringList is a JSON value of an IAM policy that may be either a single
+// string or a list of strings.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*l = stringList{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*l = ss
+	return nil
+}
+
+type trustStatement struct {
+	Effect    string          `json:"Effect"`
+	Principal json.RawMessage `json:"Principal"`
+	Action    stringList      `json:"Action"`
+}
+
+// IsTrustedByService returns true if the supplied assume role policy document
+// allows the supplied service principal to assume the role. The document may
+// be URL encoded, as returned by the IAM API. Conditions and statements that
+// deny access are not considered.
+func IsTrustedByService(doc, service string) (bool, error) {
+	d, err := url.QueryUnescape(doc)
+	if err != nil {
+		return false, err
+	}
+	p := struct {
+		Statement json.RawMessage `json:"Statement"`
+	}{}
+	if err := json.Unmarshal([]byte(d), &p); err != nil {
+		return false, err
+	}
+	// The statement of a policy may be either a single statement or a list.
+	statements := []trustStatement{}
+	if err := json.Unmarshal(p.Statement, &statements); err != nil {
+		st := trustStatement{}
+		if err := json.Unmarshal(p.Statement, &st); err != nil {
+			return false, err
+		}
+		statements = append(statements, st)
+	}
+	for _, st := range statements {
+		if st.Effect == "Allow" && allowsAssumeRole(st.Action) && hasServicePrincipal(st.Principal, service) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func allowsAssumeRole(actions []string) bool {
+	for _, a := range actions {
+		switch a {
+		case "sts:AssumeRole", "sts:*", "*":
+			return true
+		}
+	}
+	return false
+}
+
+func hasServicePrincipal(raw json.RawMessage, service string) bool {
+	var wildcard string
+	if err := json.Unmarshal(raw, &wildcard); err == nil {
+		return wildcard == "*"
+	}
+	p := struct {
+		Service stringList `json:"Service"`
+	}{}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return false
+	}
+	for _, s := range p.Service {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInstanceProfileName returns the sorted, comma separated names of the
+// supplied instance profiles.
+func GetInstanceProfileName(profiles []iam.InstanceProfile) string {
+	names := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		if p.InstanceProfileName != nil {
+			names = append(names, *p.InstanceProfileName)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// ObserveCapacity sets the instance types of the supplied observation to
+// those of the supplied node group.
+func ObserveCapacity(o *v1alpha1.NodeGroupObservation, ng *eks.Nodegroup) {
+	o.InstanceTypes = nil
+	if len(ng.InstanceTypes) > 0 {
+		o.InstanceTypes = append([]string{}, ng.InstanceTypes...)
+	}
+}
+
+// CountRunningInstances returns the number of the supplied instances that are
+// running.
+func CountRunningInstances(reservations []ec2.Reservation) int64 {
+	n := int64(0)
+	for _, r := range reservations {
+		for _, i := range r.Instances {
+			if i.State != nil && i.State.Name == ec2.InstanceStateNameRunning {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// GetNodeGroupConnectionDetails extracts managed.ConnectionDetails out of
+// eks.Nodegroup. Details are only returned once the node group is active.
+func GetNodeGroupConnectionDetails(ng *eks.Nodegroup) managed.ConnectionDetails {
+	if ng == nil || ng.Status != eks.NodegroupStatusActive || ng.Resources == nil || ng.Resources.RemoteAccessSecurityGroup == nil {
+		return managed.ConnectionDetails{}
+	}
+	return managed.ConnectionDetails{
+		ConnectionSecretRemoteAccessSecurityGroupIDKey: []byte(*ng.Resources.RemoteAccessSecurityGroup),
+	}
+}
+
 // LateInitializeNodeGroup fills the empty fields in *v1alpha1.NodeGroupParameters with the
 // values seen in eks.Nodegroup.
 func LateInitializeNodeGroup(in *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) { // nolint:gocyclo
@@ -145,31 +692,441 @@ func LateInitializeNodeGroup(in *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup
 			SourceSecurityGroups: ng.RemoteAccess.SourceSecurityGroups,
 		}
 	}
-	if in.ScalingConfig == nil && ng.ScalingConfig != nil {
-		in.ScalingConfig = &v1alpha1.NodeGroupScalingConfig{
-			DesiredSize: ng.ScalingConfig.DesiredSize,
-			MinSize:     ng.ScalingConfig.MinSize,
-			MaxSize:     ng.ScalingConfig.MaxSize,
+	// NOTE: the desired size is deliberately not late initialized. It is
+	// frequently changed by autoscalers, and writing it back to the spec would
+	// cause the spec to drift from what is kept in source control. It is
+	// observed in the status instead.
+	if ng.ScalingConfig != nil {
+		if in.ScalingConfig == nil {
+			in.ScalingConfig = &v1alpha1.NodeGroupScalingConfig{}
 		}
+		in.ScalingConfig.MinSize = awsclients.LateInitializeInt64Ptr(in.ScalingConfig.MinSize, ng.ScalingConfig.MinSize)
+		in.ScalingConfig.MaxSize = awsclients.LateInitializeInt64Ptr(in.ScalingConfig.MaxSize, ng.ScalingConfig.MaxSize)
 	}
 	in.ReleaseVersion = awsclients.LateInitializeStringPtr(in.ReleaseVersion, ng.ReleaseVersion)
-	in.Version = awsclients.LateInitializeStringPtr(in.Version, ng.Version)
+	// A node group that follows the version of its cluster must not have the
+	// version it currently runs written back, as that would pin it.
+	if !IsClusterVersionFollowed(in) {
+		in.Version = awsclients.LateInitializeStringPtr(in.Version, ng.Version)
+	}
 	// NOTE(hasheddan): we always will set the default Crossplane tags in
 	// practice during initialization in the controller, but we check if no tags
 	// exist for consistency with expected late initialization behavior.
 	if len(in.Tags) == 0 {
-		in.Tags = ng.Tags
+		in.Tags = FilterAWSManagedTags(ng.Tags)
+	}
+}
+
+// ApplyNodeGroupDefaults sets the fields of the supplied parameters that are
+// not set to the supplied defaults. Fields that are set always take
+// precedence; default labels are added unless a label with the same key is
+// set. Default subnets and node roles are not applied if they are to be set
+// by a reference or selector.
+func ApplyNodeGroupDefaults(p *v1alpha1.NodeGroupParameters, d v1alpha1.NodeGroupDefaults) { // nolint:gocyclo
+	p.AMIType = awsclients.LateInitializeStringPtr(p.AMIType, d.AMIType)
+	p.DiskSize = awsclients.LateInitializeInt64Ptr(p.DiskSize, d.DiskSize)
+	if len(p.InstanceTypes) == 0 && len(d.InstanceTypes) > 0 {
+		p.InstanceTypes = append([]string{}, d.InstanceTypes...)
+	}
+	for k, v := range d.Labels {
+		if _, ok := p.Labels[k]; ok {
+			continue
+		}
+		if p.Labels == nil {
+			p.Labels = map[string]string{}
+		}
+		p.Labels[k] = v
+	}
+	if p.NodeRole == "" && p.NodeRoleRef == nil && p.NodeRoleSelector == nil {
+		p.NodeRole = aws.StringValue(d.NodeRole)
+	}
+	p.ReleaseVersion = awsclients.LateInitializeStringPtr(p.ReleaseVersion, d.ReleaseVersion)
+	if p.RemoteAccess == nil && d.RemoteAccess != nil {
+		p.RemoteAccess = d.RemoteAccess.DeepCopy()
+	}
+	if d.ScalingConfig != nil {
+		if p.ScalingConfig == nil {
+			p.ScalingConfig = &v1alpha1.NodeGroupScalingConfig{}
+		}
+		p.ScalingConfig.DesiredSize = awsclients.LateInitializeInt64Ptr(p.ScalingConfig.DesiredSize, d.ScalingConfig.DesiredSize)
+		p.ScalingConfig.MinSize = awsclients.LateInitializeInt64Ptr(p.ScalingConfig.MinSize, d.ScalingConfig.MinSize)
+		p.ScalingConfig.MaxSize = awsclients.LateInitializeInt64Ptr(p.ScalingConfig.MaxSize, d.ScalingConfig.MaxSize)
+	}
+	if len(p.Subnets) == 0 && len(p.SubnetRefs) == 0 && p.SubnetSelector == nil && len(d.Subnets) > 0 {
+		p.Subnets = append([]string{}, d.Subnets...)
+	}
+}
+
+// updateFailureGuidance maps the error codes of failed node group updates to
+// guidance on how to resolve the most common failures.
+var updateFailureGuidance = map[eks.ErrorCode]string{
+	eks.ErrorCodePodEvictionFailure:        "Pods could not be evicted from the nodes being replaced, most likely because of a PodDisruptionBudget. Check the PodDisruptionBudgets of the cluster, or retry the update with force enabled to ignore them.",
+	eks.ErrorCodeNodeCreationFailure:       "New nodes could not be launched or did not join the cluster. Check the node role, the subnets and the security groups of the node group.",
+	eks.ErrorCodeInsufficientFreeAddresses: "The subnets of the node group do not have enough free IP addresses for new nodes. Free up addresses or add subnets with more capacity.",
+	eks.ErrorCodeIpNotAvailable:            "The subnets of the node group do not have enough free IP addresses for new nodes. Free up addresses or add subnets with more capacity.",
+	eks.ErrorCodeEniLimitReached:           "The elastic network interface limit of the account has been reached. Request a limit increase or delete unused network interfaces.",
+	eks.ErrorCodeAccessDenied:              "The node group role lacks permissions required for the update. Check the policies attached to the node role.",
+	eks.ErrorCodeOperationNotPermitted:     "The node group role lacks permissions required for the update. Check the policies attached to the node role.",
+	eks.ErrorCodeSubnetNotFound:            "A subnet of the node group no longer exists. Update the subnets of the node group.",
+	eks.ErrorCodeSecurityGroupNotFound:     "A security group of the node group no longer exists. Update the remote access configuration of the node group.",
+}
+
+// healthIssueGuidance suggests how to resolve health issues of node groups.
+var healthIssueGuidance = map[eks.NodegroupIssueCode]string{
+	eks.NodegroupIssueCodeAutoScalingGroupNotFound:         "Recreate the Auto Scaling group of the node group with the same settings.",
+	eks.NodegroupIssueCodeEc2securityGroupNotFound:         "The cluster security group no longer exists. Recreate the cluster.",
+	eks.NodegroupIssueCodeEc2securityGroupDeletionFailure:  "Remove the dependencies of the remote access security group of the node group.",
+	eks.NodegroupIssueCodeEc2launchTemplateNotFound:        "Recreate the launch template of the node group with the same settings.",
+	eks.NodegroupIssueCodeEc2launchTemplateVersionMismatch: "Revert the launch template of the node group to the version that EKS created.",
+	eks.NodegroupIssueCodeIamInstanceProfileNotFound:       "Recreate the instance profile of the node group with the same settings.",
+	eks.NodegroupIssueCodeIamNodeRoleNotFound:              "Recreate the node role of the node group with the same settings.",
+	eks.NodegroupIssueCodeNodeCreationFailure:              "Check the permissions of the node role and the outbound internet access of the subnets of the node group.",
+	eks.NodegroupIssueCodeInstanceLimitExceeded:            "Request an EC2 instance limit increase for the instance types of the node group.",
+	eks.NodegroupIssueCodeInsufficientFreeAddresses:        "Free up IP addresses in the subnets of the node group or add subnets with more capacity.",
+	eks.NodegroupIssueCodeAccessDenied:                     "Check that the node role is mapped in the aws-auth ConfigMap of the cluster.",
+}
+
+// bootstrapFailures are the health issues of node groups that indicate their
+// instances failed to launch or to join the cluster.
+var bootstrapFailures = map[eks.NodegroupIssueCode]v1alpha1.NodeBootstrapStatus{
+	eks.NodegroupIssueCodeAsgInstanceLaunchFailures: v1alpha1.NodeBootstrapLaunchFailed,
+	eks.NodegroupIssueCodeInstanceLimitExceeded:     v1alpha1.NodeBootstrapLaunchFailed,
+	eks.NodegroupIssueCodeInsufficientFreeAddresses: v1alpha1.NodeBootstrapLaunchFailed,
+	eks.NodegroupIssueCodeNodeCreationFailure:       v1alpha1.NodeBootstrapJoinFailed,
+	eks.NodegroupIssueCodeAccessDenied:              v1alpha1.NodeBootstrapJoinFailed,
+}
+
+// GetBootstrapIssue returns the first health issue of a node group that
+// indicates its instances failed to launch or to join the cluster, and which
+// of the two it indicates. Launch failures take precedence, because instances
+// that did not launch cannot join the cluster either. It returns nil if there
+// is no such issue.
+func GetBootstrapIssue(h v1alpha1.NodeGroupHealth) (*v1alpha1.Issue, v1alpha1.NodeBootstrapStatus) {
+	var join *v1alpha1.Issue
+	for i := range h.Issues {
+		switch bootstrapFailures[eks.NodegroupIssueCode(h.Issues[i].Code)] {
+		case v1alpha1.NodeBootstrapLaunchFailed:
+			return &h.Issues[i], v1alpha1.NodeBootstrapLaunchFailed
+		case v1alpha1.NodeBootstrapJoinFailed:
+			if join == nil {
+				join = &h.Issues[i]
+			}
+		}
+	}
+	if join == nil {
+		return nil, ""
+	}
+	return join, v1alpha1.NodeBootstrapJoinFailed
+}
+
+// GetHealthIssueRecommendation returns an action that may resolve the supplied
+// health issue of a node group.
+func GetHealthIssueRecommendation(i v1alpha1.Issue) string {
+	action, ok := healthIssueGuidance[eks.NodegroupIssueCode(i.Code)]
+	if !ok {
+		action = i.Message
+	}
+	if len(i.ResourceIDs) == 0 {
+		return fmt.Sprintf("%s: %s", i.Code, action)
+	}
+	return fmt.Sprintf("%s (%s): %s", i.Code, strings.Join(i.ResourceIDs, ", "), action)
+}
+
+// GetUpdateFailureGuidance returns guidance on how to resolve a node group
+// update that failed with the supplied error code, or an empty string if there
+// is none.
+func GetUpdateFailureGuidance(c eks.ErrorCode) string {
+	return updateFailureGuidance[c]
+}
+
+// DescribeUpdateFailure returns the error code of the first error of the
+// supplied failed update, and a message that describes all of its errors along
+// with guidance on how to resolve them.
+func DescribeUpdateFailure(u *eks.Update) (eks.ErrorCode, string) {
+	code := eks.ErrorCodeUnknown
+	if len(u.Errors) > 0 && u.Errors[0].ErrorCode != "" {
+		code = u.Errors[0].ErrorCode
+	}
+	msgs := make([]string, 0, len(u.Errors))
+	for _, e := range u.Errors {
+		msg := string(e.ErrorCode) + ": " + aws.StringValue(e.ErrorMessage)
+		if g := GetUpdateFailureGuidance(e.ErrorCode); g != "" {
+			msg += " " + g
+		}
+		msgs = append(msgs, msg)
+	}
+	msg := "update " + aws.StringValue(u.Id) + " of EKS node group failed"
+	if len(msgs) > 0 {
+		msg += ": " + strings.Join(msgs, "; ")
+	}
+	return code, msg
+}
+
+// Default keys of the sizes in a scaling config source ConfigMap.
+const (
+	DefaultMinSizeKey = "minSize"
+	DefaultMaxSizeKey = "maxSize"
+)
+
+const errParseScalingConfigSize = "cannot parse %s of scaling config source as an integer"
+
+// ResolveScalingConfig sets the minimum and maximum size of the supplied
+// parameters to those found in the supplied data of their scaling config
+// source ConfigMap. Sizes that are not found are left untouched.
+func ResolveScalingConfig(p *v1alpha1.NodeGroupParameters, data map[string]string) error {
+	src := p.ScalingConfigFrom
+	if src == nil {
+		return nil
+	}
+	minKey, maxKey := DefaultMinSizeKey, DefaultMaxSizeKey
+	if src.MinSizeKey != nil {
+		minKey = *src.MinSizeKey
+	}
+	if src.MaxSizeKey != nil {
+		maxKey = *src.MaxSizeKey
+	}
+	minSize, err := parseSize(data, minKey)
+	if err != nil {
+		return err
+	}
+	maxSize, err := parseSize(data, maxKey)
+	if err != nil {
+		return err
+	}
+	if minSize == nil && maxSize == nil {
+		return nil
+	}
+	if p.ScalingConfig == nil {
+		p.ScalingConfig = &v1alpha1.NodeGroupScalingConfig{}
+	}
+	if minSize != nil {
+		p.ScalingConfig.MinSize = minSize
+	}
+	if maxSize != nil {
+		p.ScalingConfig.MaxSize = maxSize
+	}
+	return nil
+}
+
+func parseSize(data map[string]string, key string) (*int64, error) {
+	v, ok := data[key]
+	if !ok {
+		return nil, nil
+	}
+	i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, errParseScalingConfigSize, key)
+	}
+	return &i, nil
+}
+
+// ValidateNodeGroup returns an error describing every combination of the
+// supplied parameters that EKS would reject, or nil if there is none.
+func ValidateNodeGroup(p *v1alpha1.NodeGroupParameters) error {
+	path := field.NewPath("spec", "forProvider")
+	var errs field.ErrorList
+	if ra := p.RemoteAccess; ra != nil && aws.StringValue(ra.EC2SSHKey) == "" &&
+		(len(ra.SourceSecurityGroups) > 0 || len(ra.SourceSecurityGroupRefs) > 0 || ra.SourceSecurityGroupSelector != nil) {
+		errs = append(errs, field.Required(path.Child("remoteAccess", "ec2SSHKey"), "source security groups can only be set together with an EC2 SSH key"))
+	}
+	// The minimum and maximum size of node groups with a scaling config source
+	// are only known once the source is read.
+	if sc := p.ScalingConfig; sc != nil && p.ScalingConfigFrom == nil {
+		scPath := path.Child("scalingConfig")
+		if sc.MinSize != nil && sc.MaxSize != nil && *sc.MinSize > *sc.MaxSize {
+			errs = append(errs, field.Invalid(scPath.Child("minSize"), *sc.MinSize, "must not be greater than maxSize"))
+		}
+		if sc.DesiredSize != nil && sc.MinSize != nil && *sc.DesiredSize < *sc.MinSize {
+			errs = append(errs, field.Invalid(scPath.Child("desiredSize"), *sc.DesiredSize, "must not be less than minSize"))
+		}
+		if sc.DesiredSize != nil && sc.MaxSize != nil && *sc.DesiredSize > *sc.MaxSize {
+			errs = append(errs, field.Invalid(scPath.Child("desiredSize"), *sc.DesiredSize, "must not be greater than maxSize"))
+		}
+	}
+	return errs.ToAggregate()
+}
+
+// The limits AWS imposes on the tags of a resource. Key and value lengths are
+// in Unicode characters.
+const (
+	MaxTags           = 50
+	MaxTagKeyLength   = 128
+	MaxTagValueLength = 256
+)
+
+// ValidateTags returns an error describing every way in which the supplied
+// tags exceed the limits AWS imposes on tags, or nil if they do not. It should
+// be called with the tags that are about to be applied, i.e. after defaults
+// were merged and templates were resolved.
+func ValidateTags(tags map[string]string) error {
+	path := field.NewPath("spec", "forProvider", "tags")
+	var errs field.ErrorList
+	if len(tags) > MaxTags {
+		errs = append(errs, field.TooMany(path, len(tags), MaxTags))
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if n := utf8.RuneCountInString(k); n == 0 || n > MaxTagKeyLength {
+			errs = append(errs, field.Invalid(path, k, fmt.Sprintf("tag keys must have between 1 and %d characters", MaxTagKeyLength)))
+		}
+		if n := utf8.RuneCountInString(tags[k]); n > MaxTagValueLength {
+			errs = append(errs, field.Invalid(path.Key(k), n, fmt.Sprintf("tag values must have at most %d characters", MaxTagValueLength)))
+		}
+	}
+	return errs.ToAggregate()
+}
+
+// Tags that the Cluster Autoscaler uses to discover the Auto Scaling groups of
+// a cluster.
+const (
+	TagKeyAutoscalerEnabled       = "k8s.io/cluster-autoscaler/enabled"
+	TagKeyAutoscalerClusterPrefix = "k8s.io/cluster-autoscaler/"
+)
+
+// IsAutoscalerDiscoveryEnabled returns true if a node group with the supplied
+// parameters should be tagged for discovery by the Cluster Autoscaler.
+func IsAutoscalerDiscoveryEnabled(p *v1alpha1.NodeGroupParameters) bool {
+	return p.AutoscalerDiscovery != nil && *p.AutoscalerDiscovery
+}
+
+// GetAutoscalerDiscoveryTags returns the tags that the Cluster Autoscaler uses
+// to discover the Auto Scaling groups of the supplied cluster.
+func GetAutoscalerDiscoveryTags(cluster string) map[string]string {
+	return map[string]string{
+		TagKeyAutoscalerEnabled:                 "true",
+		TagKeyAutoscalerClusterPrefix + cluster: "owned",
+	}
+}
+
+// GetAutoScalingGroupNames returns the names of the Auto Scaling groups of the
+// supplied node group.
+func GetAutoScalingGroupNames(ng *eks.Nodegroup) []string {
+	if ng.Resources == nil {
+		return nil
+	}
+	names := make([]string, 0, len(ng.Resources.AutoScalingGroups))
+	for _, g := range ng.Resources.AutoScalingGroups {
+		if g.Name != nil {
+			names = append(names, *g.Name)
+		}
+	}
+	return names
+}
+
+// GetUntaggedAutoScalingGroups returns the names of the supplied Auto Scaling
+// groups that lack any of the supplied tags.
+func GetUntaggedAutoScalingGroups(groups []autoscaling.AutoScalingGroup, tags map[string]string) []string {
+	var untagged []string
+	for _, g := range groups {
+		have := make(map[string]string, len(g.Tags))
+		for _, t := range g.Tags {
+			have[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+		for k, v := range tags {
+			if cur, ok := have[k]; !ok || cur != v {
+				untagged = append(untagged, aws.StringValue(g.AutoScalingGroupName))
+				break
+			}
+		}
 	}
+	return untagged
+}
+
+// GenerateCreateOrUpdateAutoScalingGroupTagsInput returns the input to add the
+// supplied tags to the supplied Auto Scaling groups. The tags are not
+// propagated to the instances the groups launch.
+func GenerateCreateOrUpdateAutoScalingGroupTagsInput(groups []string, tags map[string]string) *autoscaling.CreateOrUpdateTagsInput {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	in := &autoscaling.CreateOrUpdateTagsInput{}
+	for _, g := range groups {
+		for _, k := range keys {
+			in.Tags = append(in.Tags, autoscaling.Tag{
+				ResourceId:        aws.String(g),
+				ResourceType:      aws.String("auto-scaling-group"),
+				Key:               aws.String(k),
+				Value:             aws.String(tags[k]),
+				PropagateAtLaunch: aws.Bool(false, aws.FieldRequired),
+			})
+		}
+	}
+	return in
+}
+
+// IsClusterVersionFollowed returns true if the Kubernetes version of a node
+// group with the supplied parameters follows the version of its cluster.
+func IsClusterVersionFollowed(p *v1alpha1.NodeGroupParameters) bool {
+	return p.Version == nil && p.FollowClusterVersion != nil && *p.FollowClusterVersion
 }
 
 // IsNodeGroupUpToDate checks whether there is a change in any of the modifiable fields.
-func IsNodeGroupUpToDate(p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) bool { // nolint:gocyclo
-	if !cmp.Equal(p.Tags, ng.Tags, cmpopts.EquateEmpty()) {
+func IsNodeGroupUpToDate(p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) bool {
+	if !cmp.Equal(p.Tags, FilterAWSManagedTags(ng.Tags), cmpopts.EquateEmpty()) {
 		return false
 	}
 	if !cmp.Equal(p.Version, ng.Version) {
 		return false
 	}
+	return IsNodeGroupConfigUpToDate(p, ng)
+}
+
+// DiffNodeGroup returns the modifiable fields of the supplied node group that
+// differ from the supplied parameters, i.e. those that IsNodeGroupUpToDate
+// considers out of date. The desired size is only compared if it is set.
+func DiffNodeGroup(p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) []v1alpha1.FieldDiff { // nolint:gocyclo
+	var d []v1alpha1.FieldDiff
+	add := func(field string, desired, observed interface{}) {
+		d = append(d, v1alpha1.FieldDiff{Field: field, Desired: toJSON(desired), Observed: toJSON(observed)})
+	}
+	if tags := FilterAWSManagedTags(ng.Tags); !cmp.Equal(p.Tags, tags, cmpopts.EquateEmpty()) {
+		add("tags", p.Tags, tags)
+	}
+	if !cmp.Equal(p.Version, ng.Version) {
+		add("version", p.Version, ng.Version)
+	}
+	if !cmp.Equal(p.Labels, ng.Labels, cmpopts.EquateEmpty()) {
+		add("labels", p.Labels, ng.Labels)
+	}
+	switch {
+	case p.ScalingConfig == nil && ng.ScalingConfig == nil:
+	case p.ScalingConfig == nil || ng.ScalingConfig == nil:
+		add("scalingConfig", p.ScalingConfig, GenerateNodeGroupObservation(ng).ScalingConfig)
+	default:
+		if p.ScalingConfig.DesiredSize != nil && !cmp.Equal(p.ScalingConfig.DesiredSize, ng.ScalingConfig.DesiredSize) {
+			add("scalingConfig.desiredSize", p.ScalingConfig.DesiredSize, ng.ScalingConfig.DesiredSize)
+		}
+		if !cmp.Equal(p.ScalingConfig.MaxSize, ng.ScalingConfig.MaxSize) {
+			add("scalingConfig.maxSize", p.ScalingConfig.MaxSize, ng.ScalingConfig.MaxSize)
+		}
+		if !cmp.Equal(p.ScalingConfig.MinSize, ng.ScalingConfig.MinSize) {
+			add("scalingConfig.minSize", p.ScalingConfig.MinSize, ng.ScalingConfig.MinSize)
+		}
+	}
+	return d
+}
+
+// toJSON returns the JSON encoding of v, or an empty string if v is nil.
+func toJSON(v interface{}) string {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || ((rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map) && rv.IsNil()) {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// IsNodeGroupConfigUpToDate checks whether the fields that are updated through
+// UpdateNodegroupConfig, i.e. labels and scaling configuration, are up to date.
+func IsNodeGroupConfigUpToDate(p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) bool { // nolint:gocyclo
 	if !cmp.Equal(p.Labels, ng.Labels, cmpopts.EquateEmpty()) {
 		return false
 	}
@@ -177,7 +1134,9 @@ func IsNodeGroupUpToDate(p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) boo
 		return true
 	}
 	if p.ScalingConfig != nil && ng.ScalingConfig != nil {
-		if !cmp.Equal(p.ScalingConfig.DesiredSize, ng.ScalingConfig.DesiredSize) {
+		// An unset desired size means that whatever size is observed is the
+		// desired one.
+		if p.ScalingConfig.DesiredSize != nil && !cmp.Equal(p.ScalingConfig.DesiredSize, ng.ScalingConfig.DesiredSize) {
 			return false
 		}
 		if !cmp.Equal(p.ScalingConfig.MaxSize, ng.ScalingConfig.MaxSize) {
@@ -190,3 +1149,110 @@ func IsNodeGroupUpToDate(p *v1alpha1.NodeGroupParameters, ng *eks.Nodegroup) boo
 	}
 	return false
 }
+
+// GetDeletionGracePeriod returns the deletion grace period of the supplied
+// object, falling back to DefaultDeletionGracePeriod if it is not annotated
+// with a valid duration.
+func GetDeletionGracePeriod(o metav1.Object) time.Duration {
+	d, err := time.ParseDuration(o.GetAnnotations()[AnnotationKeyDeletionGracePeriod])
+	if err != nil || d <= 0 {
+		return DefaultDeletionGracePeriod
+	}
+	return d
+}
+
+// GetCreateGracePeriod returns the create grace period of the supplied
+// object, falling back to DefaultCreateGracePeriod if it is not annotated with
+// a valid duration.
+func GetCreateGracePeriod(o metav1.Object) time.Duration {
+	d, err := time.ParseDuration(o.GetAnnotations()[AnnotationKeyCreateGracePeriod])
+	if err != nil || d <= 0 {
+		return DefaultCreateGracePeriod
+	}
+	return d
+}
+
+// SetCreateTime annotates the supplied object with its create time.
+func SetCreateTime(o metav1.Object, t time.Time) {
+	meta.AddAnnotations(o, map[string]string{AnnotationKeyCreateTime: t.UTC().Format(time.RFC3339)})
+}
+
+const errInvalidMaintenanceWindow = "invalid maintenance window %q: it must be formatted as ddd:hh24:mi-ddd:hh24:mi"
+
+// weekdays maps the abbreviated days of maintenance windows to their days of
+// the week.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// IsWithinMaintenanceWindow returns true if the supplied time is within the
+// supplied weekly maintenance window, formatted as ddd:hh24:mi-ddd:hh24:mi in
+// UTC. A window may wrap around the end of the week, e.g. sat:22:00-sun:02:00.
+func IsWithinMaintenanceWindow(window string, now time.Time) (bool, error) {
+	bounds := strings.Split(window, "-")
+	if len(bounds) != 2 {
+		return false, errors.Errorf(errInvalidMaintenanceWindow, window)
+	}
+	start, err := minuteOfWeek(bounds[0])
+	if err != nil {
+		return false, errors.Errorf(errInvalidMaintenanceWindow, window)
+	}
+	end, err := minuteOfWeek(bounds[1])
+	if err != nil {
+		return false, errors.Errorf(errInvalidMaintenanceWindow, window)
+	}
+	now = now.UTC()
+	m := int(now.Weekday())*24*60 + now.Hour()*60 + now.Minute()
+	if start <= end {
+		return m >= start && m < end, nil
+	}
+	return m >= start || m < end, nil
+}
+
+// minuteOfWeek returns the minute of the week, starting on Sunday, of the
+// supplied ddd:hh24:mi time.
+func minuteOfWeek(s string) (int, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, errors.New(s)
+	}
+	d, ok := weekdays[strings.ToLower(parts[0])]
+	if !ok {
+		return 0, errors.New(s)
+	}
+	t, err := time.Parse("15:04", parts[1]+":"+parts[2])
+	if err != nil {
+		return 0, err
+	}
+	return int(d)*24*60 + t.Hour()*60 + t.Minute(), nil
+}
+
+// IsWithinCreateGracePeriod returns true if the supplied object was created
+// less than its create grace period before the supplied time. Objects without
+// a valid create time are never within their grace period.
+func IsWithinCreateGracePeriod(o metav1.Object, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, o.GetAnnotations()[AnnotationKeyCreateTime])
+	if err != nil {
+		return false
+	}
+	return now.Sub(t) < GetCreateGracePeriod(o)
+}
+
+// GetDeletionStartTime returns the time at which the deletion of the supplied
+// node group started, which is the deletion timestamp of the supplied object
+// that represents it. The node group is deleted in the first reconcile after
+// that. We don't use the time at which the node group was last modified,
+// because any later change to its status moves it. It returns false if the
+// node group is not being deleted.
+func GetDeletionStartTime(o metav1.Object, ng *eks.Nodegroup) (time.Time, bool) {
+	if ng == nil || ng.Status != eks.NodegroupStatusDeleting || o.GetDeletionTimestamp() == nil {
+		return time.Time{}, false
+	}
+	return o.GetDeletionTimestamp().Time, true
+}
@@ -17,14 +17,23 @@ limitations under the License.
 package eks
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 
 	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
 )
 
 var (
@@ -235,6 +244,28 @@ func TestGenerateUpdateNodeGroupInput(t *testing.T) {
 				},
 			},
 		},
+		"LabelsUnchanged": {
+			args: args{
+				name: ngName,
+				p: &v1alpha1.NodeGroupParameters{
+					ClusterName: clusterName,
+					Labels:      map[string]string{"cool": "label"},
+					ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
+						DesiredSize: &size,
+					},
+				},
+				n: &eks.Nodegroup{
+					Labels: map[string]string{"cool": "label"},
+				},
+			},
+			want: &eks.UpdateNodegroupConfigInput{
+				ClusterName:   &clusterName,
+				NodegroupName: &ngName,
+				ScalingConfig: &eks.NodegroupScalingConfig{
+					DesiredSize: &size,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -266,6 +297,7 @@ func TestGenerateUpdateNodeObservation(t *testing.T) {
 			args: args{
 				n: &eks.Nodegroup{
 					NodegroupArn: &ngArn,
+					NodeRole:     &nodeRole,
 					Status:       eks.NodegroupStatusActive,
 					CreatedAt:    &now,
 					Health: &eks.NodegroupHealth{
@@ -278,6 +310,11 @@ func TestGenerateUpdateNodeObservation(t *testing.T) {
 						},
 					},
 					ModifiedAt: &now,
+					ScalingConfig: &eks.NodegroupScalingConfig{
+						DesiredSize: &size,
+						MaxSize:     &size,
+						MinSize:     &size,
+					},
 					Resources: &eks.NodegroupResources{
 						RemoteAccessSecurityGroup: &rasg,
 						AutoScalingGroups: []eks.AutoScalingGroup{
@@ -290,6 +327,7 @@ func TestGenerateUpdateNodeObservation(t *testing.T) {
 			},
 			want: v1alpha1.NodeGroupObservation{
 				NodeGroupArn: ngArn,
+				NodeRoleArn:  nodeRole,
 				Status:       v1alpha1.NodeGroupStatusActive,
 				CreatedAt:    &v1.Time{Time: now},
 				Health: v1alpha1.NodeGroupHealth{
@@ -301,7 +339,13 @@ func TestGenerateUpdateNodeObservation(t *testing.T) {
 						},
 					},
 				},
-				ModifiedAt: &v1.Time{Time: now},
+				NodeBootstrap: v1alpha1.NodeBootstrapJoinFailed,
+				ModifiedAt:    &v1.Time{Time: now},
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
+					DesiredSize: &size,
+					MaxSize:     &size,
+					MinSize:     &size,
+				},
 				Resources: v1alpha1.NodeGroupResources{
 					RemoteAccessSecurityGroup: rasg,
 					AutoScalingGroups: []v1alpha1.AutoScalingGroup{
@@ -326,6 +370,8 @@ func TestGenerateUpdateNodeObservation(t *testing.T) {
 
 func TestLateInitializeNodeGroup(t *testing.T) {
 	ami := "AL2_x86_64"
+	otherSize := int64(100)
+	follow := true
 	type args struct {
 		p *v1alpha1.NodeGroupParameters
 		n *eks.Nodegroup
@@ -368,14 +414,59 @@ func TestLateInitializeNodeGroup(t *testing.T) {
 					SourceSecurityGroups: []string{"cool-group"},
 				},
 				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
-					DesiredSize: &size,
-					MaxSize:     &size,
-					MinSize:     &size,
+					MaxSize: &size,
+					MinSize: &size,
 				},
 				Tags:    map[string]string{"cool": "tag"},
 				Version: &version,
 			},
 		},
+		"DesiredSizeNotLateInitialized": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{
+					ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
+						MaxSize: &otherSize,
+					},
+				},
+				n: &eks.Nodegroup{
+					ScalingConfig: &eks.NodegroupScalingConfig{
+						DesiredSize: &size,
+						MaxSize:     &size,
+						MinSize:     &size,
+					},
+				},
+			},
+			want: &v1alpha1.NodeGroupParameters{
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
+					MaxSize: &otherSize,
+					MinSize: &size,
+				},
+			},
+		},
+		"AWSManagedTagsNotLateInitialized": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{},
+				n: &eks.Nodegroup{
+					Tags: map[string]string{"cool": "tag", "aws:eks:cluster-name": "cool-cluster"},
+				},
+			},
+			want: &v1alpha1.NodeGroupParameters{
+				Tags: map[string]string{"cool": "tag"},
+			},
+		},
+		"FollowedVersionNotLateInitialized": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{
+					FollowClusterVersion: &follow,
+				},
+				n: &eks.Nodegroup{
+					Version: &version,
+				},
+			},
+			want: &v1alpha1.NodeGroupParameters{
+				FollowClusterVersion: &follow,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -426,6 +517,30 @@ func TestIsNodeGroupUpToDate(t *testing.T) {
 			},
 			want: true,
 		},
+		"DesiredSizeUnset": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{
+					Tags:    map[string]string{"cool": "tag"},
+					Version: &version,
+					Labels:  map[string]string{"cool": "label"},
+					ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
+						MaxSize: &otherSize,
+						MinSize: &size,
+					},
+				},
+				n: &eks.Nodegroup{
+					Labels: map[string]string{"cool": "label"},
+					ScalingConfig: &eks.NodegroupScalingConfig{
+						DesiredSize: &size,
+						MaxSize:     &otherSize,
+						MinSize:     &size,
+					},
+					Version: &version,
+					Tags:    map[string]string{"cool": "tag"},
+				},
+			},
+			want: true,
+		},
 		"UpdateTags": {
 			args: args{
 				p: &v1alpha1.NodeGroupParameters{
@@ -503,6 +618,19 @@ func TestIsNodeGroupUpToDate(t *testing.T) {
 			},
 			want: false,
 		},
+		"AWSManagedTagsIgnored": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{
+					Tags:    map[string]string{"cool": "tag"},
+					Version: &version,
+				},
+				n: &eks.Nodegroup{
+					Version: &version,
+					Tags:    map[string]string{"cool": "tag", "aws:eks:cluster-name": "cool-cluster", "eks:nodegroup-name": "cool-group"},
+				},
+			},
+			want: true,
+		},
 	}
 
 	for name, tc := range cases {
@@ -514,3 +642,1155 @@ func TestIsNodeGroupUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDefaultServiceCIDR(t *testing.T) {
+	cases := map[string]struct {
+		vpcCIDR string
+		want    string
+	}{
+		"VPCOutsideTen": {
+			vpcCIDR: "192.168.0.0/16",
+			want:    DefaultServiceCIDR,
+		},
+		"VPCWithinTen": {
+			vpcCIDR: "10.0.0.0/16",
+			want:    AlternateServiceCIDR,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetDefaultServiceCIDR(tc.vpcCIDR)
+			if err != nil {
+				t.Fatalf("GetDefaultServiceCIDR(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetSubnetsOverlappingCIDR(t *testing.T) {
+	subnet := "subnet-cool"
+	otherSubnet := "subnet-other"
+	inside := "10.100.8.0/24"
+	outside := "10.0.0.0/24"
+	containing := "10.0.0.0/8"
+
+	cases := map[string]struct {
+		subnets []ec2.Subnet
+		want    []string
+	}{
+		"NoOverlap": {
+			subnets: []ec2.Subnet{{SubnetId: &subnet, CidrBlock: &outside}},
+		},
+		"SubnetWithinCIDR": {
+			subnets: []ec2.Subnet{
+				{SubnetId: &subnet, CidrBlock: &inside},
+				{SubnetId: &otherSubnet, CidrBlock: &outside},
+			},
+			want: []string{subnet},
+		},
+		"SubnetContainsCIDR": {
+			subnets: []ec2.Subnet{{SubnetId: &otherSubnet, CidrBlock: &containing}},
+			want:    []string{otherSubnet},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetSubnetsOverlappingCIDR(DefaultServiceCIDR, tc.subnets)
+			if err != nil {
+				t.Fatalf("GetSubnetsOverlappingCIDR(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetMaxPodsPerNode(t *testing.T) {
+	cases := map[string]struct {
+		instanceTypes []string
+		limits        map[string]ENILimits
+		want          *int64
+	}{
+		"T3Medium": {
+			instanceTypes: []string{"t3.medium"},
+			limits:        DefaultENILimits,
+			want:          aws.Int64(17),
+		},
+		"M5Large": {
+			instanceTypes: []string{"m5.large"},
+			limits:        DefaultENILimits,
+			want:          aws.Int64(29),
+		},
+		"C54XLarge": {
+			instanceTypes: []string{"c5.4xlarge"},
+			limits:        DefaultENILimits,
+			want:          aws.Int64(234),
+		},
+		"R524XLarge": {
+			instanceTypes: []string{"r5.24xlarge"},
+			limits:        DefaultENILimits,
+			want:          aws.Int64(737),
+		},
+		"LowestOfInstanceTypes": {
+			instanceTypes: []string{"m5.xlarge", "t3.large", "c5.2xlarge"},
+			limits:        DefaultENILimits,
+			want:          aws.Int64(35),
+		},
+		"UnknownInstanceType": {
+			instanceTypes: []string{"t3.medium", "x1e.32xlarge"},
+			limits:        DefaultENILimits,
+		},
+		"LookedUp": {
+			instanceTypes: []string{"x1e.32xlarge"},
+			limits: GetENILimits([]ec2.InstanceTypeInfo{{
+				InstanceType: "x1e.32xlarge",
+				NetworkInfo:  &ec2.NetworkInfo{MaximumNetworkInterfaces: aws.Int64(8), Ipv4AddressesPerInterface: aws.Int64(30)},
+			}}),
+			want: aws.Int64(234),
+		},
+		"NoInstanceTypes": {
+			limits: DefaultENILimits,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetMaxPodsPerNode(tc.instanceTypes, tc.limits)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetMissingNodeLabels(t *testing.T) {
+	labels := map[string]string{"team": "data", "gpu": "nvidia"}
+
+	type want struct {
+		missing []string
+		err     bool
+	}
+
+	cases := map[string]struct {
+		required string
+		want     want
+	}{
+		"AllMatch": {
+			required: "team=data, gpu",
+		},
+		"ValueMismatch": {
+			required: "team=web,gpu=nvidia",
+			want:     want{missing: []string{"team=web"}},
+		},
+		"KeyMissing": {
+			required: "zone,team",
+			want:     want{missing: []string{"zone"}},
+		},
+		"InvalidSelector": {
+			required: "team,=data",
+			want:     want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetMissingNodeLabels(tc.required, labels)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("err: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.missing, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetAvailabilityZones(t *testing.T) {
+	a, b := "us-east-1a", "us-east-1b"
+
+	cases := map[string]struct {
+		subnets []ec2.Subnet
+		want    []string
+	}{
+		"Distinct": {
+			subnets: []ec2.Subnet{{AvailabilityZone: &b}, {AvailabilityZone: &a}, {AvailabilityZone: &b}},
+			want:    []string{a, b},
+		},
+		"NoZones": {
+			subnets: []ec2.Subnet{{}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetAvailabilityZones(tc.subnets)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetUnofferedInstanceTypes(t *testing.T) {
+	a, b := "us-east-1a", "us-east-1b"
+	offering := func(t, zone string) ec2.InstanceTypeOffering {
+		return ec2.InstanceTypeOffering{InstanceType: ec2.InstanceType(t), Location: &zone}
+	}
+
+	type args struct {
+		instanceTypes []string
+		zones         []string
+		offerings     []ec2.InstanceTypeOffering
+	}
+
+	cases := map[string]struct {
+		args args
+		want []string
+	}{
+		"AllOffered": {
+			args: args{
+				instanceTypes: []string{"m5.large", "c5.large"},
+				zones:         []string{a, b},
+				offerings:     []ec2.InstanceTypeOffering{offering("m5.large", a), offering("m5.large", b), offering("c5.large", a), offering("c5.large", b)},
+			},
+		},
+		"NotOfferedInSomeZone": {
+			args: args{
+				instanceTypes: []string{"m5.large", "c5.large"},
+				zones:         []string{a, b},
+				offerings:     []ec2.InstanceTypeOffering{offering("m5.large", a), offering("m5.large", b), offering("c5.large", a)},
+			},
+			want: []string{"c5.large"},
+		},
+		"Retired": {
+			args: args{
+				instanceTypes: []string{"m1.small", "m5.large"},
+				zones:         []string{a},
+				offerings:     []ec2.InstanceTypeOffering{offering("m5.large", a)},
+			},
+			want: []string{"m1.small"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetUnofferedInstanceTypes(tc.args.instanceTypes, tc.args.zones, tc.args.offerings)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetSubnetsOutsideVPC(t *testing.T) {
+	vpc := "vpc-cool"
+	otherVPC := "vpc-other"
+	subnet := "subnet-cool"
+	otherSubnet := "subnet-other"
+
+	type args struct {
+		vpcID   string
+		subnets []ec2.Subnet
+	}
+
+	cases := map[string]struct {
+		args args
+		want []string
+	}{
+		"AllInVPC": {
+			args: args{
+				vpcID:   vpc,
+				subnets: []ec2.Subnet{{SubnetId: &subnet, VpcId: &vpc}},
+			},
+		},
+		"SomeOutsideVPC": {
+			args: args{
+				vpcID: vpc,
+				subnets: []ec2.Subnet{
+					{SubnetId: &subnet, VpcId: &vpc},
+					{SubnetId: &otherSubnet, VpcId: &otherVPC},
+				},
+			},
+			want: []string{otherSubnet},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetSubnetsOutsideVPC(tc.args.vpcID, tc.args.subnets)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffNodeGroup(t *testing.T) {
+	otherVersion := "1.17"
+	otherSize := int64(100)
+
+	type args struct {
+		p *v1alpha1.NodeGroupParameters
+		n *eks.Nodegroup
+	}
+
+	cases := map[string]struct {
+		args args
+		want []v1alpha1.FieldDiff
+	}{
+		"UpToDate": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{
+					Tags:    map[string]string{"cool": "tag"},
+					Version: &version,
+					ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
+						MaxSize: &size,
+						MinSize: &size,
+					},
+				},
+				n: &eks.Nodegroup{
+					Tags:    map[string]string{"cool": "tag"},
+					Version: &version,
+					ScalingConfig: &eks.NodegroupScalingConfig{
+						DesiredSize: &otherSize,
+						MaxSize:     &size,
+						MinSize:     &size,
+					},
+				},
+			},
+		},
+		"Drifted": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{
+					Tags:    map[string]string{"cool": "tag"},
+					Version: &otherVersion,
+					Labels:  map[string]string{"cool": "label"},
+					ScalingConfig: &v1alpha1.NodeGroupScalingConfig{
+						DesiredSize: &size,
+						MaxSize:     &otherSize,
+						MinSize:     &size,
+					},
+				},
+				n: &eks.Nodegroup{
+					Tags:    map[string]string{"cool": "tag", "another": "tag"},
+					Version: &version,
+					ScalingConfig: &eks.NodegroupScalingConfig{
+						DesiredSize: &otherSize,
+						MaxSize:     &size,
+						MinSize:     &size,
+					},
+				},
+			},
+			want: []v1alpha1.FieldDiff{
+				{Field: "tags", Desired: `{"cool":"tag"}`, Observed: `{"another":"tag","cool":"tag"}`},
+				{Field: "version", Desired: `"1.17"`, Observed: `"1.16"`},
+				{Field: "labels", Desired: `{"cool":"label"}`},
+				{Field: "scalingConfig.desiredSize", Desired: "2", Observed: "100"},
+				{Field: "scalingConfig.maxSize", Desired: "100", Observed: "2"},
+			},
+		},
+		"ScalingConfigMissing": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{},
+				n: &eks.Nodegroup{
+					ScalingConfig: &eks.NodegroupScalingConfig{
+						DesiredSize: &size,
+					},
+				},
+			},
+			want: []v1alpha1.FieldDiff{
+				{Field: "scalingConfig", Observed: `{"desiredSize":2}`},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffNodeGroup(tc.args.p, tc.args.n)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(len(tc.want) == 0, IsNodeGroupUpToDate(tc.args.p, tc.args.n)); diff != "" {
+				t.Errorf("IsNodeGroupUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveTagTemplates(t *testing.T) {
+	arn := "cool:arn"
+	platformVersion := "eks.3"
+	cluster := &eks.Cluster{
+		Name:            &clusterName,
+		Arn:             &arn,
+		Version:         &version,
+		PlatformVersion: &platformVersion,
+	}
+
+	cases := map[string]struct {
+		tags map[string]string
+		want map[string]string
+	}{
+		"Substituted": {
+			tags: map[string]string{
+				"version":  TagTemplatePrefix + "{cluster.version}-{cluster.platformVersion}",
+				"cluster":  TagTemplatePrefix + "{cluster.name} ({cluster.arn})",
+				"unknown":  TagTemplatePrefix + "{cluster.unknown}",
+				"constant": "cool",
+			},
+			want: map[string]string{
+				"version":  "1.16-eks.3",
+				"cluster":  clusterName + " (cool:arn)",
+				"unknown":  "{cluster.unknown}",
+				"constant": "cool",
+			},
+		},
+		"LiteralPreserved": {
+			tags: map[string]string{"literal": "{cluster.version}"},
+			want: map[string]string{"literal": "{cluster.version}"},
+		},
+		"NoTags": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ResolveTagTemplates(tc.tags, cluster)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveScalingConfig(t *testing.T) {
+	minKey, maxKey := "min", "max"
+	two, ten, five := int64(2), int64(10), int64(5)
+
+	type want struct {
+		cfg *v1alpha1.NodeGroupScalingConfig
+		err bool
+	}
+
+	cases := map[string]struct {
+		p    *v1alpha1.NodeGroupParameters
+		data map[string]string
+		want want
+	}{
+		"DefaultKeys": {
+			p: &v1alpha1.NodeGroupParameters{
+				ScalingConfig:     &v1alpha1.NodeGroupScalingConfig{DesiredSize: &size},
+				ScalingConfigFrom: &v1alpha1.ScalingConfigSource{Name: "cool"},
+			},
+			data: map[string]string{DefaultMinSizeKey: "2", DefaultMaxSizeKey: " 10 "},
+			want: want{cfg: &v1alpha1.NodeGroupScalingConfig{DesiredSize: &size, MinSize: &two, MaxSize: &ten}},
+		},
+		"CustomKeys": {
+			p: &v1alpha1.NodeGroupParameters{
+				ScalingConfigFrom: &v1alpha1.ScalingConfigSource{Name: "cool", MinSizeKey: &minKey, MaxSizeKey: &maxKey},
+			},
+			data: map[string]string{"min": "2", "max": "10", DefaultMinSizeKey: "5"},
+			want: want{cfg: &v1alpha1.NodeGroupScalingConfig{MinSize: &two, MaxSize: &ten}},
+		},
+		"MissingKeysUntouched": {
+			p: &v1alpha1.NodeGroupParameters{
+				ScalingConfig:     &v1alpha1.NodeGroupScalingConfig{MinSize: &five, MaxSize: &five},
+				ScalingConfigFrom: &v1alpha1.ScalingConfigSource{Name: "cool"},
+			},
+			data: map[string]string{DefaultMaxSizeKey: "10"},
+			want: want{cfg: &v1alpha1.NodeGroupScalingConfig{MinSize: &five, MaxSize: &ten}},
+		},
+		"NoSource": {
+			p:    &v1alpha1.NodeGroupParameters{},
+			data: map[string]string{DefaultMinSizeKey: "2"},
+			want: want{},
+		},
+		"InvalidSize": {
+			p: &v1alpha1.NodeGroupParameters{
+				ScalingConfigFrom: &v1alpha1.ScalingConfigSource{Name: "cool"},
+			},
+			data: map[string]string{DefaultMinSizeKey: "two"},
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ResolveScalingConfig(tc.p, tc.data)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("r: -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.cfg, tc.p.ScalingConfig); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateNodeGroup(t *testing.T) {
+	key := "cool-key"
+	one, two, three := int64(1), int64(2), int64(3)
+
+	cases := map[string]struct {
+		p    *v1alpha1.NodeGroupParameters
+		want []string
+	}{
+		"Valid": {
+			p: &v1alpha1.NodeGroupParameters{
+				RemoteAccess:  &v1alpha1.RemoteAccessConfig{EC2SSHKey: &key, SourceSecurityGroups: []string{"sg-cool"}},
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &one, DesiredSize: &two, MaxSize: &three},
+			},
+		},
+		"Empty": {
+			p: &v1alpha1.NodeGroupParameters{},
+		},
+		"SourceSecurityGroupsWithoutKey": {
+			p: &v1alpha1.NodeGroupParameters{
+				RemoteAccess: &v1alpha1.RemoteAccessConfig{SourceSecurityGroups: []string{"sg-cool"}},
+			},
+			want: []string{"spec.forProvider.remoteAccess.ec2SSHKey"},
+		},
+		"SourceSecurityGroupSelectorWithoutKey": {
+			p: &v1alpha1.NodeGroupParameters{
+				RemoteAccess: &v1alpha1.RemoteAccessConfig{SourceSecurityGroupSelector: &runtimev1alpha1.Selector{}},
+			},
+			want: []string{"spec.forProvider.remoteAccess.ec2SSHKey"},
+		},
+		"MinGreaterThanMax": {
+			p: &v1alpha1.NodeGroupParameters{
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &three, MaxSize: &two},
+			},
+			want: []string{"spec.forProvider.scalingConfig.minSize"},
+		},
+		"DesiredOutOfRange": {
+			p: &v1alpha1.NodeGroupParameters{
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &two, DesiredSize: &one, MaxSize: &three},
+			},
+			want: []string{"spec.forProvider.scalingConfig.desiredSize"},
+		},
+		"AllInvalid": {
+			p: &v1alpha1.NodeGroupParameters{
+				RemoteAccess:  &v1alpha1.RemoteAccessConfig{SourceSecurityGroups: []string{"sg-cool"}},
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &three, DesiredSize: &two, MaxSize: &one},
+			},
+			want: []string{
+				"spec.forProvider.remoteAccess.ec2SSHKey",
+				"spec.forProvider.scalingConfig.minSize",
+				"spec.forProvider.scalingConfig.desiredSize",
+				"spec.forProvider.scalingConfig.desiredSize",
+			},
+		},
+		"SizesFromSource": {
+			p: &v1alpha1.NodeGroupParameters{
+				ScalingConfig:     &v1alpha1.NodeGroupScalingConfig{MinSize: &three, MaxSize: &two},
+				ScalingConfigFrom: &v1alpha1.ScalingConfigSource{Name: "cool"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateNodeGroup(tc.p)
+			var got []string
+			if agg, ok := err.(utilerrors.Aggregate); ok {
+				for _, e := range agg.Errors() {
+					got = append(got, e.(*field.Error).Field)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= MaxTags; i++ {
+		tooMany[fmt.Sprintf("key-%d", i)] = "value"
+	}
+
+	cases := map[string]struct {
+		tags map[string]string
+		want []string
+	}{
+		"Valid": {
+			tags: map[string]string{"cool": "tag", strings.Repeat("k", MaxTagKeyLength): strings.Repeat("v", MaxTagValueLength)},
+		},
+		"NoTags": {},
+		"TooMany": {
+			tags: tooMany,
+			want: []string{"spec.forProvider.tags"},
+		},
+		"KeyTooLong": {
+			tags: map[string]string{strings.Repeat("k", MaxTagKeyLength+1): "value"},
+			want: []string{"spec.forProvider.tags"},
+		},
+		"EmptyKey": {
+			tags: map[string]string{"": "value"},
+			want: []string{"spec.forProvider.tags"},
+		},
+		"ValueTooLong": {
+			tags: map[string]string{"cool": strings.Repeat("v", MaxTagValueLength+1)},
+			want: []string{"spec.forProvider.tags[cool]"},
+		},
+		"MultibyteCharacters": {
+			tags: map[string]string{"cool": strings.Repeat("ü", MaxTagValueLength)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateTags(tc.tags)
+			var got []string
+			if agg, ok := err.(utilerrors.Aggregate); ok {
+				for _, e := range agg.Errors() {
+					got = append(got, e.(*field.Error).Field)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetUpdateFailureGuidance(t *testing.T) {
+	cases := map[eks.ErrorCode]string{
+		eks.ErrorCodePodEvictionFailure:        "PodDisruptionBudget",
+		eks.ErrorCodeNodeCreationFailure:       "did not join the cluster",
+		eks.ErrorCodeInsufficientFreeAddresses: "free IP addresses",
+		eks.ErrorCodeIpNotAvailable:            "free IP addresses",
+		eks.ErrorCodeEniLimitReached:           "network interface limit",
+		eks.ErrorCodeAccessDenied:              "node role",
+		eks.ErrorCodeOperationNotPermitted:     "node role",
+		eks.ErrorCodeSubnetNotFound:            "subnet",
+		eks.ErrorCodeSecurityGroupNotFound:     "security group",
+		eks.ErrorCodeUnknown:                   "",
+	}
+
+	for code, want := range cases {
+		t.Run(string(code), func(t *testing.T) {
+			got := GetUpdateFailureGuidance(code)
+			if want == "" && got != "" {
+				t.Errorf("GetUpdateFailureGuidance(%s): want no guidance, got %q", code, got)
+			}
+			if !strings.Contains(got, want) {
+				t.Errorf("GetUpdateFailureGuidance(%s): want guidance containing %q, got %q", code, want, got)
+			}
+		})
+	}
+}
+
+func TestGetHealthIssueRecommendation(t *testing.T) {
+	cases := map[string]struct {
+		issue v1alpha1.Issue
+		want  string
+	}{
+		"KnownIssue": {
+			issue: v1alpha1.Issue{Code: string(eks.NodegroupIssueCodeIamNodeRoleNotFound), Message: "role not found"},
+			want:  "IamNodeRoleNotFound: " + healthIssueGuidance[eks.NodegroupIssueCodeIamNodeRoleNotFound],
+		},
+		"KnownIssueWithResources": {
+			issue: v1alpha1.Issue{Code: string(eks.NodegroupIssueCodeEc2subnetNotFound), ResourceIDs: []string{"subnet-1", "subnet-2"}},
+			want:  "Ec2SubnetNotFound (subnet-1, subnet-2): " + healthIssueGuidance[eks.NodegroupIssueCodeEc2subnetNotFound],
+		},
+		"UnknownIssue": {
+			issue: v1alpha1.Issue{Code: "InternalFailure", Message: "something went wrong"},
+			want:  "InternalFailure: something went wrong",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetHealthIssueRecommendation(tc.issue)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetBootstrapIssue(t *testing.T) {
+	launch := v1alpha1.Issue{Code: string(eks.NodegroupIssueCodeAsgInstanceLaunchFailures), Message: "could not launch"}
+	limit := v1alpha1.Issue{Code: string(eks.NodegroupIssueCodeInstanceLimitExceeded), Message: "limit exceeded"}
+	join := v1alpha1.Issue{Code: string(eks.NodegroupIssueCodeNodeCreationFailure), Message: "could not join"}
+	denied := v1alpha1.Issue{Code: string(eks.NodegroupIssueCodeAccessDenied), Message: "access denied"}
+	other := v1alpha1.Issue{Code: string(eks.NodegroupIssueCodeIamNodeRoleNotFound), Message: "role not found"}
+
+	type want struct {
+		issue  *v1alpha1.Issue
+		status v1alpha1.NodeBootstrapStatus
+	}
+
+	cases := map[string]struct {
+		h    v1alpha1.NodeGroupHealth
+		want want
+	}{
+		"NoIssues": {
+			h:    v1alpha1.NodeGroupHealth{},
+			want: want{},
+		},
+		"OtherIssue": {
+			h:    v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{other}},
+			want: want{},
+		},
+		"LaunchFailed": {
+			h:    v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{other, limit}},
+			want: want{issue: &limit, status: v1alpha1.NodeBootstrapLaunchFailed},
+		},
+		"JoinFailed": {
+			h:    v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{join, denied}},
+			want: want{issue: &join, status: v1alpha1.NodeBootstrapJoinFailed},
+		},
+		"AccessDenied": {
+			h:    v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{denied}},
+			want: want{issue: &denied, status: v1alpha1.NodeBootstrapJoinFailed},
+		},
+		"LaunchFailurePrecedes": {
+			h:    v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{join, launch}},
+			want: want{issue: &launch, status: v1alpha1.NodeBootstrapLaunchFailed},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			issue, status := GetBootstrapIssue(tc.h)
+			if diff := cmp.Diff(tc.want.issue, issue); diff != "" {
+				t.Errorf("issue: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("status: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDescribeUpdateFailure(t *testing.T) {
+	id := "cool-update"
+
+	type want struct {
+		code eks.ErrorCode
+		msg  string
+	}
+
+	cases := map[string]struct {
+		u    *eks.Update
+		want want
+	}{
+		"KnownError": {
+			u: &eks.Update{Id: &id, Errors: []eks.ErrorDetail{
+				{ErrorCode: eks.ErrorCodePodEvictionFailure, ErrorMessage: aws.String("Reached max retries while trying to evict pods")},
+			}},
+			want: want{
+				code: eks.ErrorCodePodEvictionFailure,
+				msg:  "update cool-update of EKS node group failed: PodEvictionFailure: Reached max retries while trying to evict pods " + GetUpdateFailureGuidance(eks.ErrorCodePodEvictionFailure),
+			},
+		},
+		"UnknownError": {
+			u: &eks.Update{Id: &id, Errors: []eks.ErrorDetail{
+				{ErrorCode: "CoolFailure", ErrorMessage: aws.String("something went wrong")},
+			}},
+			want: want{
+				code: "CoolFailure",
+				msg:  "update cool-update of EKS node group failed: CoolFailure: something went wrong",
+			},
+		},
+		"MultipleErrors": {
+			u: &eks.Update{Id: &id, Errors: []eks.ErrorDetail{
+				{ErrorCode: "CoolFailure", ErrorMessage: aws.String("something went wrong")},
+				{ErrorCode: "OtherFailure", ErrorMessage: aws.String("something else went wrong")},
+			}},
+			want: want{
+				code: "CoolFailure",
+				msg:  "update cool-update of EKS node group failed: CoolFailure: something went wrong; OtherFailure: something else went wrong",
+			},
+		},
+		"NoErrors": {
+			u: &eks.Update{Id: &id},
+			want: want{
+				code: eks.ErrorCodeUnknown,
+				msg:  "update cool-update of EKS node group failed",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			code, msg := DescribeUpdateFailure(tc.u)
+			if diff := cmp.Diff(tc.want, want{code: code, msg: msg}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCountAvailableIPAddresses(t *testing.T) {
+	free := int64(10)
+	otherFree := int64(5)
+	got := CountAvailableIPAddresses([]ec2.Subnet{{AvailableIpAddressCount: &free}, {AvailableIpAddressCount: &otherFree}, {}})
+	if diff := cmp.Diff(int64(15), got); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+}
+
+func TestGetAdditionalNodes(t *testing.T) {
+	one, three, five, eight := int64(1), int64(3), int64(5), int64(8)
+
+	type args struct {
+		p *v1alpha1.NodeGroupParameters
+		n *eks.Nodegroup
+	}
+
+	cases := map[string]struct {
+		args args
+		want int64
+	}{
+		"UpToMaxSize": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &one, DesiredSize: &three, MaxSize: &five}},
+				n: &eks.Nodegroup{ScalingConfig: &eks.NodegroupScalingConfig{MinSize: &one, DesiredSize: &three, MaxSize: &five}},
+			},
+			want: 2,
+		},
+		"MaxSizeRaised": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MaxSize: &eight}},
+				n: &eks.Nodegroup{ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: &three, MaxSize: &five}},
+			},
+			want: 5,
+		},
+		"DesiredSizeAboveMaxSize": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{ScalingConfig: &v1alpha1.NodeGroupScalingConfig{DesiredSize: &eight, MaxSize: &five}},
+				n: &eks.Nodegroup{ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: &three}},
+			},
+			want: 5,
+		},
+		"MaxSizeFromObservation": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{},
+				n: &eks.Nodegroup{ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: &one, MaxSize: &three}},
+			},
+			want: 2,
+		},
+		"ScalingDown": {
+			args: args{
+				p: &v1alpha1.NodeGroupParameters{ScalingConfig: &v1alpha1.NodeGroupScalingConfig{DesiredSize: &one, MaxSize: &one}},
+				n: &eks.Nodegroup{ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: &three, MaxSize: &five}},
+			},
+			want: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetAdditionalNodes(tc.args.p, tc.args.n)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsWithinCreateGracePeriod(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	created := func(ago time.Duration) string { return now.Add(-ago).Format(time.RFC3339) }
+
+	cases := map[string]struct {
+		annotations map[string]string
+		want        bool
+	}{
+		"WithinDefault": {
+			annotations: map[string]string{AnnotationKeyCreateTime: created(30 * time.Second)},
+			want:        true,
+		},
+		"AfterDefault": {
+			annotations: map[string]string{AnnotationKeyCreateTime: created(2 * time.Minute)},
+		},
+		"WithinAnnotated": {
+			annotations: map[string]string{
+				AnnotationKeyCreateTime:        created(2 * time.Minute),
+				AnnotationKeyCreateGracePeriod: "5m",
+			},
+			want: true,
+		},
+		"InvalidGracePeriod": {
+			annotations: map[string]string{
+				AnnotationKeyCreateTime:        created(2 * time.Minute),
+				AnnotationKeyCreateGracePeriod: "soon",
+			},
+		},
+		"InvalidCreateTime": {
+			annotations: map[string]string{AnnotationKeyCreateTime: "yesterday"},
+		},
+		"NotCreated": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &v1.ObjectMeta{Annotations: tc.annotations}
+			if diff := cmp.Diff(tc.want, IsWithinCreateGracePeriod(o, now)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyNodeGroupDefaults(t *testing.T) {
+	ami := "AL2_x86_64"
+	otherAMI := "AL2_ARM_64"
+	otherSize := int64(100)
+	defaults := v1alpha1.NodeGroupDefaults{
+		AMIType:       &ami,
+		DiskSize:      &diskSize,
+		InstanceTypes: []string{"cool-type"},
+		Labels:        map[string]string{"cool": "label", "team": "platform"},
+		NodeRole:      &nodeRole,
+		RemoteAccess:  &v1alpha1.RemoteAccessConfig{EC2SSHKey: &keyArn},
+		ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &size, MaxSize: &size},
+		Subnets:       []string{"cool-subnet"},
+	}
+
+	cases := map[string]struct {
+		p    *v1alpha1.NodeGroupParameters
+		want *v1alpha1.NodeGroupParameters
+	}{
+		"UnsetFieldsDefaulted": {
+			p: &v1alpha1.NodeGroupParameters{},
+			want: &v1alpha1.NodeGroupParameters{
+				AMIType:       &ami,
+				DiskSize:      &diskSize,
+				InstanceTypes: []string{"cool-type"},
+				Labels:        map[string]string{"cool": "label", "team": "platform"},
+				NodeRole:      nodeRole,
+				RemoteAccess:  &v1alpha1.RemoteAccessConfig{EC2SSHKey: &keyArn},
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &size, MaxSize: &size},
+				Subnets:       []string{"cool-subnet"},
+			},
+		},
+		"SetFieldsNotOverridden": {
+			p: &v1alpha1.NodeGroupParameters{
+				AMIType:       &otherAMI,
+				DiskSize:      &otherSize,
+				InstanceTypes: []string{"other-type"},
+				Labels:        map[string]string{"team": "data"},
+				NodeRole:      "other-role",
+				RemoteAccess:  &v1alpha1.RemoteAccessConfig{},
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MaxSize: &otherSize},
+				Subnets:       []string{"other-subnet"},
+			},
+			want: &v1alpha1.NodeGroupParameters{
+				AMIType:       &otherAMI,
+				DiskSize:      &otherSize,
+				InstanceTypes: []string{"other-type"},
+				Labels:        map[string]string{"cool": "label", "team": "data"},
+				NodeRole:      "other-role",
+				RemoteAccess:  &v1alpha1.RemoteAccessConfig{},
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &size, MaxSize: &otherSize},
+				Subnets:       []string{"other-subnet"},
+			},
+		},
+		"ReferencedFieldsNotDefaulted": {
+			p: &v1alpha1.NodeGroupParameters{
+				NodeRoleRef: &runtimev1alpha1.Reference{Name: "cool-role"},
+				SubnetRefs:  []runtimev1alpha1.Reference{{Name: "cool-subnet"}},
+			},
+			want: &v1alpha1.NodeGroupParameters{
+				AMIType:       &ami,
+				DiskSize:      &diskSize,
+				InstanceTypes: []string{"cool-type"},
+				Labels:        map[string]string{"cool": "label", "team": "platform"},
+				NodeRoleRef:   &runtimev1alpha1.Reference{Name: "cool-role"},
+				RemoteAccess:  &v1alpha1.RemoteAccessConfig{EC2SSHKey: &keyArn},
+				ScalingConfig: &v1alpha1.NodeGroupScalingConfig{MinSize: &size, MaxSize: &size},
+				SubnetRefs:    []runtimev1alpha1.Reference{{Name: "cool-subnet"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ApplyNodeGroupDefaults(tc.p, defaults)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsTrustedByService(t *testing.T) {
+	type want struct {
+		trusted bool
+		err     bool
+	}
+
+	cases := map[string]struct {
+		doc  string
+		want want
+	}{
+		"Trusted": {
+			doc:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			want: want{trusted: true},
+		},
+		"TrustedURLEncoded": {
+			doc:  `%7B%22Statement%22%3A%5B%7B%22Effect%22%3A%22Allow%22%2C%22Principal%22%3A%7B%22Service%22%3A%22ec2.amazonaws.com%22%7D%2C%22Action%22%3A%22sts%3AAssumeRole%22%7D%5D%7D`,
+			want: want{trusted: true},
+		},
+		"TrustedSingleStatement": {
+			doc:  `{"Statement":{"Effect":"Allow","Principal":{"Service":["eks.amazonaws.com","ec2.amazonaws.com"]},"Action":["sts:AssumeRole"]}}`,
+			want: want{trusted: true},
+		},
+		"TrustedWildcardPrincipal": {
+			doc:  `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"sts:*"}]}`,
+			want: want{trusted: true},
+		},
+		"OtherService": {
+			doc:  `{"Statement":[{"Effect":"Allow","Principal":{"Service":"eks.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			want: want{trusted: false},
+		},
+		"OtherAction": {
+			doc:  `{"Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:TagSession"}]}`,
+			want: want{trusted: false},
+		},
+		"Denied": {
+			doc:  `{"Statement":[{"Effect":"Deny","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			want: want{trusted: false},
+		},
+		"Malformed": {
+			doc:  `{"Statement":`,
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			trusted, err := IsTrustedByService(tc.doc, EC2ServicePrincipal)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("err: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.trusted, trusted); diff != "" {
+				t.Errorf("trusted: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetUntaggedAutoScalingGroups(t *testing.T) {
+	tags := GetAutoscalerDiscoveryTags("cool-cluster")
+	group := func(name string, tags map[string]string) autoscaling.AutoScalingGroup {
+		g := autoscaling.AutoScalingGroup{AutoScalingGroupName: aws.String(name)}
+		for k, v := range tags {
+			g.Tags = append(g.Tags, autoscaling.TagDescription{Key: aws.String(k), Value: aws.String(v)})
+		}
+		return g
+	}
+
+	cases := map[string]struct {
+		groups []autoscaling.AutoScalingGroup
+		want   []string
+	}{
+		"Tagged": {
+			groups: []autoscaling.AutoScalingGroup{group("asg", map[string]string{
+				TagKeyAutoscalerEnabled:                        "true",
+				TagKeyAutoscalerClusterPrefix + "cool-cluster": "owned",
+				"other": "tag",
+			})},
+		},
+		"TagMissing": {
+			groups: []autoscaling.AutoScalingGroup{group("asg", map[string]string{TagKeyAutoscalerEnabled: "true"})},
+			want:   []string{"asg"},
+		},
+		"TagValueDiffers": {
+			groups: []autoscaling.AutoScalingGroup{group("asg", map[string]string{
+				TagKeyAutoscalerEnabled:                        "false",
+				TagKeyAutoscalerClusterPrefix + "cool-cluster": "owned",
+			})},
+			want: []string{"asg"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetUntaggedAutoScalingGroups(tc.groups, tags)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetUntaggedAutoScalingGroups(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsWithinMaintenanceWindow(t *testing.T) {
+	// The first of November 2020 was a Sunday.
+	sunday := func(hour, min int) time.Time { return time.Date(2020, time.November, 1, hour, min, 0, 0, time.UTC) }
+	saturday := func(hour, min int) time.Time { return sunday(hour, min).AddDate(0, 0, -1) }
+
+	type want struct {
+		within bool
+		err    bool
+	}
+
+	cases := map[string]struct {
+		window string
+		now    time.Time
+		want   want
+	}{
+		"WithinWindow": {
+			window: "sun:02:00-sun:06:00",
+			now:    sunday(3, 30),
+			want:   want{within: true},
+		},
+		"AtStartOfWindow": {
+			window: "sun:02:00-sun:06:00",
+			now:    sunday(2, 0),
+			want:   want{within: true},
+		},
+		"AtEndOfWindow": {
+			window: "sun:02:00-sun:06:00",
+			now:    sunday(6, 0),
+		},
+		"OtherDay": {
+			window: "sun:02:00-sun:06:00",
+			now:    saturday(3, 30),
+		},
+		"OtherTimeZone": {
+			window: "sun:02:00-sun:06:00",
+			now:    sunday(3, 30).In(time.FixedZone("UTC+8", 8*60*60)),
+			want:   want{within: true},
+		},
+		"WrapsAroundWeek": {
+			window: "sat:22:00-sun:02:00",
+			now:    sunday(1, 0),
+			want:   want{within: true},
+		},
+		"OutsideWrappingWindow": {
+			window: "sat:22:00-sun:02:00",
+			now:    saturday(21, 0),
+		},
+		"InvalidWindow": {
+			window: "sunday:02:00",
+			now:    sunday(3, 30),
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			within, err := IsWithinMaintenanceWindow(tc.window, tc.now)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("err: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.within, within); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
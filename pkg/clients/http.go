@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+)
+
+const (
+	errParseProxyURL = "cannot parse HTTP proxy URL"
+	errAppendCA      = "cannot append CA bundle: no PEM encoded certificates found"
+)
+
+// HTTPClientOptions configure the HTTP client that is used to call the AWS
+// API, e.g. for environments that may only reach it through a proxy.
+type HTTPClientOptions struct {
+	// ProxyURL is the URL of the proxy that all requests are sent through. The
+	// proxy configured by the environment is used if it is empty.
+	ProxyURL string
+
+	// CABundle contains PEM encoded certificates that are trusted in addition
+	// to those of the system.
+	CABundle []byte
+
+	// MaxIdleConns is the maximum number of idle connections kept open across
+	// all hosts. The default of the Go standard library is used if it is zero.
+	MaxIdleConns int
+}
+
+// httpClient is used by the AWS configs produced by this package. The default
+// HTTP client of the AWS SDK is used if it is nil.
+var httpClient *http.Client
+
+// NewHTTPClient returns an HTTP client that is configured with the supplied
+// options. Like the default HTTP client of the AWS SDK it does not follow
+// redirects.
+func NewHTTPClient(o HTTPClientOptions) (*http.Client, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if o.ProxyURL != "" {
+		u, err := url.Parse(o.ProxyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, errParseProxyURL)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+	if len(o.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(o.CABundle) {
+			return nil, errors.New(errAppendCA)
+		}
+		tr.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	if o.MaxIdleConns > 0 {
+		tr.MaxIdleConns = o.MaxIdleConns
+	}
+	return &http.Client{
+		Transport: tr,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}, nil
+}
+
+// SetHTTPClient sets the HTTP client that is used by all AWS configs produced
+// by this package. It is intended to be called once, before any controllers
+// are started.
+func SetHTTPClient(c *http.Client) {
+	httpClient = c
+}
+
+// withHTTPClient returns the supplied config, using the configured HTTP client
+// if there is one.
+func withHTTPClient(cfg aws.Config) aws.Config {
+	if httpClient != nil {
+		cfg.HTTPClient = httpClient
+	}
+	return cfg
+}
+
+// withHTTPClientV1 returns the supplied config, using the configured HTTP
+// client if there is one.
+func withHTTPClientV1(cfg *awsv1.Config) *awsv1.Config {
+	if httpClient != nil {
+		return cfg.WithHTTPClient(httpClient)
+	}
+	return cfg
+}
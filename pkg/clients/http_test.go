@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	_, parseErr := url.Parse("http://proxy\x7f")
+
+	type want struct {
+		proxy   string
+		trusted bool
+		idle    int
+		err     error
+	}
+
+	cases := map[string]struct {
+		o    HTTPClientOptions
+		want want
+	}{
+		"Proxy": {
+			o:    HTTPClientOptions{ProxyURL: "http://proxy.example.org:3128"},
+			want: want{proxy: "http://proxy.example.org:3128", idle: 100},
+		},
+		"CABundle": {
+			o:    HTTPClientOptions{CABundle: ca},
+			want: want{trusted: true, idle: 100},
+		},
+		"MaxIdleConns": {
+			o:    HTTPClientOptions{MaxIdleConns: 10},
+			want: want{idle: 10},
+		},
+		"InvalidProxyURL": {
+			o:    HTTPClientOptions{ProxyURL: "http://proxy\x7f"},
+			want: want{err: errors.Wrap(parseErr, errParseProxyURL)},
+		},
+		"InvalidCABundle": {
+			o:    HTTPClientOptions{CABundle: []byte("not a certificate")},
+			want: want{err: errors.New(errAppendCA)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewHTTPClient(tc.o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("NewHTTPClient(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			tr := c.Transport.(*http.Transport)
+			proxy := ""
+			if u, _ := tr.Proxy(httptest.NewRequest(http.MethodGet, "https://ec2.amazonaws.com", nil)); u != nil && tc.o.ProxyURL != "" {
+				proxy = u.String()
+			}
+			if diff := cmp.Diff(tc.want.proxy, proxy); diff != "" {
+				t.Errorf("proxy: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.idle, tr.MaxIdleConns); diff != "" {
+				t.Errorf("MaxIdleConns: -want, +got:\n%s", diff)
+			}
+			rsp, err := c.Get(server.URL)
+			if err == nil {
+				rsp.Body.Close() // nolint:errcheck
+			}
+			if diff := cmp.Diff(tc.want.trusted, err == nil); diff != "" {
+				t.Errorf("trusted: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHTTPClientIsUsed(t *testing.T) {
+	c := &http.Client{}
+	SetHTTPClient(c)
+	defer SetHTTPClient(nil)
+
+	credentials := []byte(fmt.Sprintf(awsCredentialsFileFormat, "default", "id", "secret"))
+
+	cfg, err := UseProviderSecret(context.TODO(), credentials, "default", "us-east-1")
+	if err != nil {
+		t.Fatalf("UseProviderSecret(...): unexpected error: %s", err)
+	}
+	if cfg.HTTPClient != c {
+		t.Errorf("UseProviderSecret(...): configured HTTP client is not used")
+	}
+
+	cfgv1, err := UseProviderSecretV1(context.TODO(), credentials, &fake.Managed{}, "default", "us-east-1")
+	if err != nil {
+		t.Fatalf("UseProviderSecretV1(...): unexpected error: %s", err)
+	}
+	if cfgv1.HTTPClient != c {
+		t.Errorf("UseProviderSecretV1(...): configured HTTP client is not used")
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sns
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+
+	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
+)
+
+// The version of the AWS SDK used by this provider predates the data
+// protection policies of SNS topics, so their operations are defined here in
+// the same shape as those the SDK generates.
+
+const (
+	opGetDataProtectionPolicy = "GetDataProtectionPolicy"
+	opPutDataProtectionPolicy = "PutDataProtectionPolicy"
+)
+
+// GetDataProtectionPolicyInput is the input of GetDataProtectionPolicy.
+type GetDataProtectionPolicyInput struct {
+	_ struct{} `type:"structure"`
+
+	// The ARN of the topic whose data protection policy is returned.
+	ResourceArn *string `type:"string" required:"true"`
+}
+
+// GetDataProtectionPolicyOutput is the output of GetDataProtectionPolicy.
+type GetDataProtectionPolicyOutput struct {
+	_ struct{} `type:"structure"`
+
+	// The JSON serialization of the data protection policy of the topic. It
+	// is empty if the topic has none.
+	DataProtectionPolicy *string `type:"string"`
+}
+
+// GetDataProtectionPolicyRequest is the request type of
+// GetDataProtectionPolicy.
+type GetDataProtectionPolicyRequest struct {
+	*aws.Request
+	Input *GetDataProtectionPolicyInput
+}
+
+// Send marshals and sends the GetDataProtectionPolicy API request.
+func (r GetDataProtectionPolicyRequest) Send(ctx context.Context) (*GetDataProtectionPolicyOutput, error) {
+	r.Request.SetContext(ctx)
+	if err := r.Request.Send(); err != nil {
+		return nil, err
+	}
+	return r.Request.Data.(*GetDataProtectionPolicyOutput), nil
+}
+
+// PutDataProtectionPolicyInput is the input of PutDataProtectionPolicy.
+type PutDataProtectionPolicyInput struct {
+	_ struct{} `type:"structure"`
+
+	// The ARN of the topic whose data protection policy is set.
+	ResourceArn *string `type:"string" required:"true"`
+
+	// The JSON serialization of the data protection policy. An empty policy
+	// removes the data protection policy of the topic.
+	DataProtectionPolicy *string `type:"string" required:"true"`
+}
+
+// PutDataProtectionPolicyOutput is the output of PutDataProtectionPolicy.
+type PutDataProtectionPolicyOutput struct {
+	_ struct{} `type:"structure"`
+}
+
+// PutDataProtectionPolicyRequest is the request type of
+// PutDataProtectionPolicy.
+type PutDataProtectionPolicyRequest struct {
+	*aws.Request
+	Input *PutDataProtectionPolicyInput
+}
+
+// Send marshals and sends the PutDataProtectionPolicy API request.
+func (r PutDataProtectionPolicyRequest) Send(ctx context.Context) (*PutDataProtectionPolicyOutput, error) {
+	r.Request.SetContext(ctx)
+	if err := r.Request.Send(); err != nil {
+		return nil, err
+	}
+	return r.Request.Data.(*PutDataProtectionPolicyOutput), nil
+}
+
+// topicClient adds the data protection policy operations to the SNS client.
+type topicClient struct {
+	*sns.Client
+}
+
+// GetDataProtectionPolicyRequest returns a request to get the data protection
+// policy of a topic.
+func (c *topicClient) GetDataProtectionPolicyRequest(input *GetDataProtectionPolicyInput) GetDataProtectionPolicyRequest {
+	op := &aws.Operation{
+		Name:       opGetDataProtectionPolicy,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	if input == nil {
+		input = &GetDataProtectionPolicyInput{}
+	}
+	return GetDataProtectionPolicyRequest{Request: c.NewRequest(op, input, &GetDataProtectionPolicyOutput{}), Input: input}
+}
+
+// PutDataProtectionPolicyRequest returns a request to set the data protection
+// policy of a topic.
+func (c *topicClient) PutDataProtectionPolicyRequest(input *PutDataProtectionPolicyInput) PutDataProtectionPolicyRequest {
+	op := &aws.Operation{
+		Name:       opPutDataProtectionPolicy,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	if input == nil {
+		input = &PutDataProtectionPolicyInput{}
+	}
+	return PutDataProtectionPolicyRequest{Request: c.NewRequest(op, input, &PutDataProtectionPolicyOutput{}), Input: input}
+}
+
+// IsDataProtectionPolicyUpToDate returns true if the supplied observed data
+// protection policy is the same JSON document as the desired one. A topic
+// without a desired policy is up to date if it has none.
+func IsDataProtectionPolicyUpToDate(p v1alpha1.SNSTopicParameters, observed *string) bool {
+	return isJSONEqual(aws.StringValue(p.DataProtectionPolicy), aws.StringValue(observed))
+}
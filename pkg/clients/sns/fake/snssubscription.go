@@ -1,6 +1,9 @@
 package fake
 
-import "github.com/aws/aws-sdk-go-v2/service/sns"
+import (
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
 
 // MockSubscriptionClient is a type that implements all the methods for SubscriptionClient interface
 type MockSubscriptionClient struct {
@@ -8,6 +11,8 @@ type MockSubscriptionClient struct {
 	MockUnsubscribeRequest               func(*sns.UnsubscribeInput) sns.UnsubscribeRequest
 	MockGetSubscriptionAttributesRequest func(*sns.GetSubscriptionAttributesInput) sns.GetSubscriptionAttributesRequest
 	MockSetSubscriptionAttributesRequest func(*sns.SetSubscriptionAttributesInput) sns.SetSubscriptionAttributesRequest
+	MockGetTopicAttributesRequest        func(*sns.GetTopicAttributesInput) sns.GetTopicAttributesRequest
+	MockListSubscriptionsByTopicRequest  func(*sns.ListSubscriptionsByTopicInput) sns.ListSubscriptionsByTopicRequest
 }
 
 // SubscribeRequest mocks SubscribeRequest method
@@ -29,3 +34,29 @@ func (m *MockSubscriptionClient) GetSubscriptionAttributesRequest(input *sns.Get
 func (m *MockSubscriptionClient) SetSubscriptionAttributesRequest(input *sns.SetSubscriptionAttributesInput) sns.SetSubscriptionAttributesRequest {
 	return m.MockSetSubscriptionAttributesRequest(input)
 }
+
+// GetTopicAttributesRequest mocks GetTopicAttributesRequest method
+func (m *MockSubscriptionClient) GetTopicAttributesRequest(input *sns.GetTopicAttributesInput) sns.GetTopicAttributesRequest {
+	return m.MockGetTopicAttributesRequest(input)
+}
+
+// ListSubscriptionsByTopicRequest mocks ListSubscriptionsByTopicRequest method
+func (m *MockSubscriptionClient) ListSubscriptionsByTopicRequest(input *sns.ListSubscriptionsByTopicInput) sns.ListSubscriptionsByTopicRequest {
+	return m.MockListSubscriptionsByTopicRequest(input)
+}
+
+// MockKeyClient is a type that implements all the methods for KeyClient interface
+type MockKeyClient struct {
+	MockDescribeKeyRequest  func(*kms.DescribeKeyInput) kms.DescribeKeyRequest
+	MockGetKeyPolicyRequest func(*kms.GetKeyPolicyInput) kms.GetKeyPolicyRequest
+}
+
+// DescribeKeyRequest mocks DescribeKeyRequest method
+func (m *MockKeyClient) DescribeKeyRequest(input *kms.DescribeKeyInput) kms.DescribeKeyRequest {
+	return m.MockDescribeKeyRequest(input)
+}
+
+// GetKeyPolicyRequest mocks GetKeyPolicyRequest method
+func (m *MockKeyClient) GetKeyPolicyRequest(input *kms.GetKeyPolicyInput) kms.GetKeyPolicyRequest {
+	return m.MockGetKeyPolicyRequest(input)
+}
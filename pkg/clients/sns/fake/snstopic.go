@@ -2,6 +2,8 @@ package fake
 
 import (
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+
+	clientset "github.com/crossplane/provider-aws/pkg/clients/sns"
 )
 
 // MockTopicClient is a type that implements all the methods for TopicClient interface
@@ -10,6 +12,9 @@ type MockTopicClient struct {
 	MockDeleteTopicRequest        func(*sns.DeleteTopicInput) sns.DeleteTopicRequest
 	MockGetTopicAttributesRequest func(*sns.GetTopicAttributesInput) sns.GetTopicAttributesRequest
 	MockSetTopicAttributesRequest func(*sns.SetTopicAttributesInput) sns.SetTopicAttributesRequest
+
+	MockGetDataProtectionPolicyRequest func(*clientset.GetDataProtectionPolicyInput) clientset.GetDataProtectionPolicyRequest
+	MockPutDataProtectionPolicyRequest func(*clientset.PutDataProtectionPolicyInput) clientset.PutDataProtectionPolicyRequest
 }
 
 // CreateTopicRequest mocks CreateTopicRequest method
@@ -31,3 +36,13 @@ func (m *MockTopicClient) GetTopicAttributesRequest(input *sns.GetTopicAttribute
 func (m *MockTopicClient) SetTopicAttributesRequest(input *sns.SetTopicAttributesInput) sns.SetTopicAttributesRequest {
 	return m.MockSetTopicAttributesRequest(input)
 }
+
+// GetDataProtectionPolicyRequest mocks GetDataProtectionPolicyRequest method
+func (m *MockTopicClient) GetDataProtectionPolicyRequest(input *clientset.GetDataProtectionPolicyInput) clientset.GetDataProtectionPolicyRequest {
+	return m.MockGetDataProtectionPolicyRequest(input)
+}
+
+// PutDataProtectionPolicyRequest mocks PutDataProtectionPolicyRequest method
+func (m *MockTopicClient) PutDataProtectionPolicyRequest(input *clientset.PutDataProtectionPolicyInput) clientset.PutDataProtectionPolicyRequest {
+	return m.MockPutDataProtectionPolicyRequest(input)
+}
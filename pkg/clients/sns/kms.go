@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sns
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// ServicePrincipal is the service principal that SNS uses to deliver messages
+// to the endpoints of subscriptions.
+const ServicePrincipal = "sns.amazonaws.com"
+
+// DefaultKeyPolicyName is the name of the only policy a KMS key may have.
+const DefaultKeyPolicyName = "default"
+
+// KeyClient is the external client used to check whether SNS may use the KMS
+// key that encrypts the endpoint of a subscription.
+type KeyClient interface {
+	DescribeKeyRequest(*kms.DescribeKeyInput) kms.DescribeKeyRequest
+	GetKeyPolicyRequest(*kms.GetKeyPolicyInput) kms.GetKeyPolicyRequest
+}
+
+// NewKeyClient returns a new KMS client using the supplied AWS config.
+func NewKeyClient(cfg aws.Config) KeyClient {
+	return kms.New(cfg)
+}
+
+// keyActions are the actions SNS needs to be allowed to encrypt messages it
+// delivers to an encrypted SQS queue.
+var keyActions = []string{"kms:GenerateDataKey", "kms:Decrypt"}
+
+// A stringList is a JSON value of a key policy that may be either a single
+// string or a list of strings.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*l = stringList{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*l = ss
+	return nil
+}
+
+type keyStatement struct {
+	Effect    string          `json:"Effect"`
+	Principal json.RawMessage `json:"Principal"`
+	Action    stringList      `json:"Action"`
+}
+
+// IsKeyUsableByService returns true if the supplied key policy allows the
+// supplied service principal to generate data keys with and decrypt using the
+// key. The allowed actions may be spread across statements. Conditions and
+// statements that deny access are not considered.
+func IsKeyUsableByService(policy, service string) (bool, error) {
+	p := struct {
+		Statement json.RawMessage `json:"Statement"`
+	}{}
+	if err := json.Unmarshal([]byte(policy), &p); err != nil {
+		return false, err
+	}
+	// The statement of a policy may be either a single statement or a list.
+	statements := []keyStatement{}
+	if err := json.Unmarshal(p.Statement, &statements); err != nil {
+		st := keyStatement{}
+		if err := json.Unmarshal(p.Statement, &st); err != nil {
+			return false, err
+		}
+		statements = append(statements, st)
+	}
+	allowed := map[string]bool{}
+	for _, st := range statements {
+		if st.Effect != "Allow" || !hasServicePrincipal(st.Principal, service) {
+			continue
+		}
+		for _, want := range keyActions {
+			for _, a := range st.Action {
+				if allowsKeyAction(a, want) {
+					allowed[want] = true
+				}
+			}
+		}
+	}
+	return len(allowed) == len(keyActions), nil
+}
+
+// allowsKeyAction returns true if the supplied policy action, which may end
+// with a wildcard, allows the wanted action.
+func allowsKeyAction(action, want string) bool {
+	if strings.HasSuffix(action, "*") {
+		return strings.HasPrefix(want, strings.TrimSuffix(action, "*"))
+	}
+	return action == want
+}
+
+func hasServicePrincipal(raw json.RawMessage, service string) bool {
+	var wildcard string
+	if err := json.Unmarshal(raw, &wildcard); err == nil {
+		return wildcard == "*"
+	}
+	p := struct {
+		Service stringList `json:"Service"`
+	}{}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return false
+	}
+	for _, s := range p.Service {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
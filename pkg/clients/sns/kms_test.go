@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsKeyUsableByService(t *testing.T) {
+	type want struct {
+		usable bool
+		err    bool
+	}
+
+	cases := map[string]struct {
+		policy string
+		want   want
+	}{
+		"Granted": {
+			policy: `{"Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":["kms:GenerateDataKey*","kms:Decrypt"],"Resource":"*"}]}`,
+			want:   want{usable: true},
+		},
+		"GrantedAcrossStatements": {
+			policy: `{"Statement":[{"Effect":"Allow","Principal":{"Service":["sns.amazonaws.com"]},"Action":"kms:GenerateDataKey"},{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"kms:Decrypt"}]}`,
+			want:   want{usable: true},
+		},
+		"GrantedToAnyone": {
+			policy: `{"Statement":{"Effect":"Allow","Principal":"*","Action":"kms:*"}}`,
+			want:   want{usable: true},
+		},
+		"DecryptOnly": {
+			policy: `{"Statement":{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"kms:Decrypt"}}`,
+		},
+		"OtherPrincipal": {
+			policy: `{"Statement":{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"kms:*"}}`,
+		},
+		"Denied": {
+			policy: `{"Statement":{"Effect":"Deny","Principal":{"Service":"sns.amazonaws.com"},"Action":"kms:*"}}`,
+		},
+		"Malformed": {
+			policy: `{"Statement":`,
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			usable, err := IsKeyUsableByService(tc.policy, ServicePrincipal)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("err: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.usable, usable); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package sns
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
@@ -36,10 +39,16 @@ const (
 	SubscriptionDeliveryPolicy = "DeliveryPolicy"
 	// SubscriptionFilterPolicy is FilterPolicy of SNS Subscription
 	SubscriptionFilterPolicy = "FilterPolicy"
+	// SubscriptionFilterPolicyScope is FilterPolicyScope of SNS Subscription
+	SubscriptionFilterPolicyScope = "FilterPolicyScope"
 	// SubscriptionRawMessageDelivery is RawMessageDelivery of SNS Subscription
 	SubscriptionRawMessageDelivery = "RawMessageDelivery"
 	// SubscriptionRedrivePolicy is RedrivePolicy of SNS Subscription
 	SubscriptionRedrivePolicy = "RedrivePolicy"
+	// SubscriptionEndpoint is Endpoint of SNS Subscription
+	SubscriptionEndpoint = "Endpoint"
+	// SubscriptionProtocol is Protocol of SNS Subscription
+	SubscriptionProtocol = "Protocol"
 	// SubscriptionOwner is Owner of SNS Subscription
 	SubscriptionOwner = "Owner"
 	// SubscriptionPendingConfirmation is Confirmation Status of SNS Subscription
@@ -48,12 +57,22 @@ const (
 	SubscriptionConfirmationWasAuthenticated = "ConfirmationWasAuthenticated"
 )
 
+// The scopes a filter policy of a subscription may apply to.
+const (
+	FilterPolicyScopeMessageAttributes = "MessageAttributes"
+	FilterPolicyScopeMessageBody       = "MessageBody"
+)
+
+const errInvalidFilterPolicyScope = "filterPolicyScope must be " + FilterPolicyScopeMessageAttributes + " or " + FilterPolicyScopeMessageBody + ", not %q"
+
 // SubscriptionClient is the external client used for AWS SNSSubscription
 type SubscriptionClient interface {
 	SubscribeRequest(*sns.SubscribeInput) sns.SubscribeRequest
 	UnsubscribeRequest(*sns.UnsubscribeInput) sns.UnsubscribeRequest
 	GetSubscriptionAttributesRequest(*sns.GetSubscriptionAttributesInput) sns.GetSubscriptionAttributesRequest
 	SetSubscriptionAttributesRequest(*sns.SetSubscriptionAttributesInput) sns.SetSubscriptionAttributesRequest
+	GetTopicAttributesRequest(*sns.GetTopicAttributesInput) sns.GetTopicAttributesRequest
+	ListSubscriptionsByTopicRequest(*sns.ListSubscriptionsByTopicInput) sns.ListSubscriptionsByTopicRequest
 }
 
 // NewSubscriptionClient returns a new client using AWS credentials as JSON encoded
@@ -62,16 +81,50 @@ func NewSubscriptionClient(cfg aws.Config) SubscriptionClient {
 	return sns.New(cfg)
 }
 
-// GenerateSubscribeInput prepares input for SubscribeRequest
-func GenerateSubscribeInput(p *v1alpha1.SNSSubscriptionParameters) *sns.SubscribeInput {
+// GenerateSubscribeInput prepares input for SubscribeRequest. It returns an
+// error if the filter policy scope is not one that SNS accepts.
+func GenerateSubscribeInput(p *v1alpha1.SNSSubscriptionParameters) (*sns.SubscribeInput, error) {
 	input := &sns.SubscribeInput{
 		Endpoint:              aws.String(p.Endpoint),
 		Protocol:              aws.String(p.Protocol),
 		TopicArn:              aws.String(p.TopicARN),
-		ReturnSubscriptionArn: aws.Bool(true),
+		ReturnSubscriptionArn: aws.Bool(IsSubscriptionArnReturned(*p)),
+	}
+	if p.FilterPolicyScope != nil {
+		switch s := *p.FilterPolicyScope; s {
+		case FilterPolicyScopeMessageAttributes, FilterPolicyScopeMessageBody:
+			input.Attributes = map[string]string{SubscriptionFilterPolicyScope: s}
+		default:
+			return nil, errors.Errorf(errInvalidFilterPolicyScope, s)
+		}
 	}
 
-	return input
+	return input, nil
+}
+
+// IsSubscriptionArnReturned returns true if AWS returns the ARN of the
+// subscription when it is created, even if it has yet to be confirmed.
+func IsSubscriptionArnReturned(p v1alpha1.SNSSubscriptionParameters) bool {
+	return p.ReturnSubscriptionArn == nil || *p.ReturnSubscriptionArn
+}
+
+// IsSubscriptionArn returns true if the supplied string is the ARN of a
+// subscription. AWS returns a placeholder such as "pending confirmation"
+// instead of the ARN of subscriptions that have yet to be confirmed unless
+// their ARN is explicitly requested.
+func IsSubscriptionArn(s string) bool {
+	return strings.HasPrefix(s, "arn:")
+}
+
+// FindSubscription returns the subscription with the protocol and endpoint of
+// the supplied parameters, or nil if there is none.
+func FindSubscription(p v1alpha1.SNSSubscriptionParameters, subs []sns.Subscription) *sns.Subscription {
+	for i := range subs {
+		if aws.StringValue(subs[i].Protocol) == p.Protocol && aws.StringValue(subs[i].Endpoint) == p.Endpoint {
+			return &subs[i]
+		}
+	}
+	return nil
 }
 
 // GenerateSubscriptionObservation is used to produce SNSSubscriptionObservation
@@ -97,21 +150,51 @@ func GenerateSubscriptionObservation(attr map[string]string) v1alpha1.SNSSubscri
 	return o
 }
 
+// IsSubscriptionConfirmed returns true if the supplied subscription attributes
+// report that the endpoint owner confirmed the subscription. A subscription
+// whose confirmation status can not be determined is not considered confirmed.
+func IsSubscriptionConfirmed(attr map[string]string) bool {
+	pending, err := strconv.ParseBool(attr[SubscriptionPendingConfirmation])
+	return err == nil && !pending
+}
+
 // LateInitializeSubscription fills the empty fields in
 // *v1alpha1.SNSSubscriptionParameters with the values seen in
 // sns.Subscription
 func LateInitializeSubscription(in *v1alpha1.SNSSubscriptionParameters, subAttributes map[string]string) {
 	in.DeliveryPolicy = awsclients.LateInitializeStringPtr(in.DeliveryPolicy, awsclients.String(subAttributes[SubscriptionDeliveryPolicy]))
 	in.FilterPolicy = awsclients.LateInitializeStringPtr(in.FilterPolicy, awsclients.String(subAttributes[SubscriptionFilterPolicy]))
+	in.FilterPolicyScope = awsclients.LateInitializeStringPtr(in.FilterPolicyScope, awsclients.String(subAttributes[SubscriptionFilterPolicyScope]))
 	in.RawMessageDelivery = awsclients.LateInitializeStringPtr(in.RawMessageDelivery, awsclients.String(subAttributes[SubscriptionRawMessageDelivery]))
 	in.RedrivePolicy = awsclients.LateInitializeStringPtr(in.RedrivePolicy, awsclients.String(subAttributes[SubscriptionRedrivePolicy]))
 }
 
+// InheritSubscriptionDefaults fills the unset attributes of the supplied
+// subscription parameters with the supplied subscription defaults of its
+// topic. Attributes set on the subscription take precedence. It returns true
+// if any attribute was inherited.
+func InheritSubscriptionDefaults(in *v1alpha1.SNSSubscriptionParameters, d *v1alpha1.SNSSubscriptionDefaults) bool {
+	if d == nil {
+		return false
+	}
+	inherited := false
+	if in.DeliveryPolicy == nil && d.DeliveryPolicy != nil {
+		in.DeliveryPolicy = aws.String(*d.DeliveryPolicy)
+		inherited = true
+	}
+	if in.FilterPolicy == nil && d.FilterPolicy != nil {
+		in.FilterPolicy = aws.String(*d.FilterPolicy)
+		inherited = true
+	}
+	return inherited
+}
+
 // getSubAttributes returns map of SNS Sunscription Attributes
 func getSubAttributes(p v1alpha1.SNSSubscriptionParameters) map[string]string {
 	return map[string]string{
 		SubscriptionDeliveryPolicy:     aws.StringValue(p.DeliveryPolicy),
 		SubscriptionFilterPolicy:       aws.StringValue(p.FilterPolicy),
+		SubscriptionFilterPolicyScope:  aws.StringValue(p.FilterPolicyScope),
 		SubscriptionRawMessageDelivery: aws.StringValue(p.RawMessageDelivery),
 		SubscriptionRedrivePolicy:      aws.StringValue(p.RedrivePolicy),
 	}
@@ -123,6 +206,12 @@ func GetChangedSubAttributes(p v1alpha1.SNSSubscriptionParameters, attrs map[str
 	subAttrs := getSubAttributes(p)
 	changedAttrs := make(map[string]string)
 	for k, v := range subAttrs {
+		if k == SubscriptionDeliveryPolicy && isDeliveryPolicyUpToDate(v, attrs[k]) {
+			continue
+		}
+		if k == SubscriptionRawMessageDelivery && isRawMessageDeliveryUpToDate(p.RawMessageDelivery, attrs[k]) {
+			continue
+		}
 		if v != attrs[k] {
 			changedAttrs[k] = v
 		}
@@ -133,12 +222,87 @@ func GetChangedSubAttributes(p v1alpha1.SNSSubscriptionParameters, attrs map[str
 
 // IsSNSSubscriptionAttributesUpToDate checks if attributes are up to date
 func IsSNSSubscriptionAttributesUpToDate(p v1alpha1.SNSSubscriptionParameters, subAttributes map[string]string) bool {
-	return aws.StringValue(p.DeliveryPolicy) == subAttributes[SubscriptionDeliveryPolicy] &&
+	return isDeliveryPolicyUpToDate(aws.StringValue(p.DeliveryPolicy), subAttributes[SubscriptionDeliveryPolicy]) &&
 		aws.StringValue(p.FilterPolicy) == subAttributes[SubscriptionFilterPolicy] &&
-		aws.StringValue(p.RawMessageDelivery) == subAttributes[SubscriptionRawMessageDelivery] &&
+		aws.StringValue(p.FilterPolicyScope) == subAttributes[SubscriptionFilterPolicyScope] &&
+		isRawMessageDeliveryUpToDate(p.RawMessageDelivery, subAttributes[SubscriptionRawMessageDelivery]) &&
 		aws.StringValue(p.RedrivePolicy) == subAttributes[SubscriptionRedrivePolicy]
 }
 
+// rawMessageDeliveryDefault is the raw message delivery setting AWS reports
+// for subscriptions that did not set it.
+const rawMessageDeliveryDefault = "false"
+
+// isRawMessageDeliveryUpToDate returns true if the observed raw message
+// delivery setting of a subscription matches the desired one. An unset desired
+// setting matches the default AWS reports for it.
+func isRawMessageDeliveryUpToDate(desired *string, observed string) bool {
+	if desired == nil {
+		return observed == "" || observed == rawMessageDeliveryDefault
+	}
+	return *desired == observed
+}
+
+// isDeliveryPolicyUpToDate returns true if the observed delivery policy of a
+// subscription contains the desired one. AWS merges the delivery policy of a
+// subscription with the effective delivery policy of its topic and returns the
+// result, so the observed policy contains defaults that were never specified.
+func isDeliveryPolicyUpToDate(desired, observed string) bool {
+	if desired == "" || desired == observed {
+		return desired == observed
+	}
+	var d, o interface{}
+	if json.Unmarshal([]byte(desired), &d) != nil || json.Unmarshal([]byte(observed), &o) != nil {
+		return false
+	}
+	return isJSONSubset(d, o)
+}
+
+// isJSONSubset returns true if every value in the decoded JSON document a is
+// also present in b. Objects may have additional keys in b, but arrays must
+// have the same length.
+func isJSONSubset(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range av {
+			if _, ok := bv[k]; !ok || !isJSONSubset(v, bv[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !isJSONSubset(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// IsSNSSubscriptionEndpointUpToDate checks if the endpoint and protocol of a
+// subscription are up to date
+func IsSNSSubscriptionEndpointUpToDate(p v1alpha1.SNSSubscriptionParameters, subAttributes map[string]string) bool {
+	return p.Endpoint == subAttributes[SubscriptionEndpoint] &&
+		p.Protocol == subAttributes[SubscriptionProtocol]
+}
+
+// IsSubscriptionRecreatedOnEndpointUpdate returns true if the subscription
+// should be recreated when its endpoint or protocol is changed
+func IsSubscriptionRecreatedOnEndpointUpdate(p v1alpha1.SNSSubscriptionParameters) bool {
+	return p.EndpointUpdatePolicy != nil && *p.EndpointUpdatePolicy == v1alpha1.EndpointUpdatePolicyRecreate
+}
+
 // IsSubscriptionNotFound returns true if the error code indicates that the item was not found
 func IsSubscriptionNotFound(err error) bool {
 	if subErr, ok := err.(awserr.Error); ok && subErr.Code() == sns.ErrCodeNotFoundException {
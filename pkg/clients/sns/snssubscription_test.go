@@ -21,6 +21,9 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
@@ -39,6 +42,7 @@ var (
 	subStringFalse         = "false"
 	subStringTrue          = "true"
 	subBoolTrue            = true
+	subBoolFalse           = false
 )
 
 // Subscription Attribute Modifier
@@ -91,6 +95,13 @@ func withSubConfirmationWasAuthenticated(s *string) subAttrModifier {
 	}
 }
 
+func withSubEndpoint(protocol, endpoint string) subAttrModifier {
+	return func(attr *map[string]string) {
+		(*attr)[SubscriptionProtocol] = protocol
+		(*attr)[SubscriptionEndpoint] = endpoint
+	}
+}
+
 func withSubOwner(s *string) subAttrModifier {
 	return func(attr *map[string]string) {
 		(*attr)[string(SubscriptionOwner)] = *s
@@ -147,10 +158,67 @@ func withSubObservationConfirmationWasAuthenticated(s bool) subObservationModifi
 	}
 }
 
+func TestInheritSubscriptionDefaults(t *testing.T) {
+	topicFilterPolicy := `{"event":["created"]}`
+	topicDeliveryPolicy := `{"healthyRetryPolicy":{"numRetries":5}}`
+
+	type want struct {
+		p         *v1alpha1.SNSSubscriptionParameters
+		inherited bool
+	}
+
+	cases := map[string]struct {
+		p    *v1alpha1.SNSSubscriptionParameters
+		d    *v1alpha1.SNSSubscriptionDefaults
+		want want
+	}{
+		"NoDefaults": {
+			p:    &v1alpha1.SNSSubscriptionParameters{},
+			want: want{p: &v1alpha1.SNSSubscriptionParameters{}},
+		},
+		"Inherited": {
+			p: &v1alpha1.SNSSubscriptionParameters{},
+			d: &v1alpha1.SNSSubscriptionDefaults{FilterPolicy: &topicFilterPolicy, DeliveryPolicy: &topicDeliveryPolicy},
+			want: want{
+				p:         &v1alpha1.SNSSubscriptionParameters{FilterPolicy: &topicFilterPolicy, DeliveryPolicy: &topicDeliveryPolicy},
+				inherited: true,
+			},
+		},
+		"Overridden": {
+			p: &v1alpha1.SNSSubscriptionParameters{FilterPolicy: &subFilterPolicy, DeliveryPolicy: &subDeliveryPolicy},
+			d: &v1alpha1.SNSSubscriptionDefaults{FilterPolicy: &topicFilterPolicy, DeliveryPolicy: &topicDeliveryPolicy},
+			want: want{
+				p: &v1alpha1.SNSSubscriptionParameters{FilterPolicy: &subFilterPolicy, DeliveryPolicy: &subDeliveryPolicy},
+			},
+		},
+		"PartiallyOverridden": {
+			p: &v1alpha1.SNSSubscriptionParameters{FilterPolicy: &subFilterPolicy},
+			d: &v1alpha1.SNSSubscriptionDefaults{FilterPolicy: &topicFilterPolicy, DeliveryPolicy: &topicDeliveryPolicy},
+			want: want{
+				p:         &v1alpha1.SNSSubscriptionParameters{FilterPolicy: &subFilterPolicy, DeliveryPolicy: &topicDeliveryPolicy},
+				inherited: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			inherited := InheritSubscriptionDefaults(tc.p, tc.d)
+			if diff := cmp.Diff(tc.want.inherited, inherited); diff != "" {
+				t.Errorf("InheritSubscriptionDefaults(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.p, tc.p); diff != "" {
+				t.Errorf("InheritSubscriptionDefaults(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGenerateSubscribeInput(t *testing.T) {
 	cases := map[string]struct {
 		in  v1alpha1.SNSSubscriptionParameters
-		out sns.SubscribeInput
+		out *sns.SubscribeInput
+		err error
 	}{
 		"FilledInput": {
 			in: v1alpha1.SNSSubscriptionParameters{
@@ -158,19 +226,60 @@ func TestGenerateSubscribeInput(t *testing.T) {
 				Endpoint: subEmailEndpoint,
 				Protocol: subEmailProtocol,
 			},
-			out: sns.SubscribeInput{
+			out: &sns.SubscribeInput{
+				TopicArn:              aws.String(topicArn),
+				Endpoint:              &subEmailEndpoint,
+				Protocol:              &subEmailProtocol,
+				ReturnSubscriptionArn: &subBoolTrue,
+			},
+		},
+		"ReturnSubscriptionArnDisabled": {
+			in: v1alpha1.SNSSubscriptionParameters{
+				TopicARN:              topicArn,
+				Endpoint:              subEmailEndpoint,
+				Protocol:              subEmailProtocol,
+				ReturnSubscriptionArn: &subBoolFalse,
+			},
+			out: &sns.SubscribeInput{
+				TopicArn:              aws.String(topicArn),
+				Endpoint:              &subEmailEndpoint,
+				Protocol:              &subEmailProtocol,
+				ReturnSubscriptionArn: &subBoolFalse,
+			},
+		},
+		"FilterPolicyScope": {
+			in: v1alpha1.SNSSubscriptionParameters{
+				TopicARN:          topicArn,
+				Endpoint:          subEmailEndpoint,
+				Protocol:          subEmailProtocol,
+				FilterPolicyScope: aws.String(FilterPolicyScopeMessageBody),
+			},
+			out: &sns.SubscribeInput{
 				TopicArn:              aws.String(topicArn),
 				Endpoint:              &subEmailEndpoint,
 				Protocol:              &subEmailProtocol,
 				ReturnSubscriptionArn: &subBoolTrue,
+				Attributes:            map[string]string{SubscriptionFilterPolicyScope: FilterPolicyScopeMessageBody},
+			},
+		},
+		"InvalidFilterPolicyScope": {
+			in: v1alpha1.SNSSubscriptionParameters{
+				TopicARN:          topicArn,
+				Endpoint:          subEmailEndpoint,
+				Protocol:          subEmailProtocol,
+				FilterPolicyScope: aws.String("MessageHeaders"),
 			},
+			err: errors.Errorf(errInvalidFilterPolicyScope, "MessageHeaders"),
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			input := GenerateSubscribeInput(&tc.in)
-			if diff := cmp.Diff(input, &tc.out); diff != "" {
+			input, err := GenerateSubscribeInput(&tc.in)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GenerateSubscribeInput(...): -want error, +got error\n:%s", diff)
+			}
+			if diff := cmp.Diff(tc.out, input); diff != "" {
 				t.Errorf("GenerateSubscribeInput(...): -want, +got\n:%s", diff)
 			}
 		})
@@ -198,6 +307,23 @@ func TestLateInitializeSubscription(t *testing.T) {
 			},
 			want: subParams(),
 		},
+		"FilterPolicyScope": {
+			args: args{
+				spec: &v1alpha1.SNSSubscriptionParameters{RawMessageDelivery: &subStringTrue},
+				attr: map[string]string{SubscriptionFilterPolicyScope: FilterPolicyScopeMessageAttributes},
+			},
+			want: &v1alpha1.SNSSubscriptionParameters{
+				RawMessageDelivery: &subStringTrue,
+				FilterPolicyScope:  aws.String(FilterPolicyScopeMessageAttributes),
+			},
+		},
+		"RawMessageDeliveryDefault": {
+			args: args{
+				spec: &v1alpha1.SNSSubscriptionParameters{},
+				attr: map[string]string{SubscriptionRawMessageDelivery: "false"},
+			},
+			want: &v1alpha1.SNSSubscriptionParameters{RawMessageDelivery: aws.String("false")},
+		},
 		"PartialFilled": {
 			args: args{
 				spec: subParams(func(sub *v1alpha1.SNSSubscriptionParameters) {
@@ -268,6 +394,106 @@ func TestGetChangedSubAttributes(t *testing.T) {
 	}
 }
 
+func TestIsSNSSubscriptionRawMessageDeliveryUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		raw      *string
+		observed string
+		want     bool
+	}{
+		"UnsetMatchesDefault": {
+			observed: "false",
+			want:     true,
+		},
+		"UnsetNotObserved": {
+			want: true,
+		},
+		"UnsetEnabled": {
+			observed: "true",
+		},
+		"Identical": {
+			raw:      aws.String("true"),
+			observed: "true",
+			want:     true,
+		},
+		"Changed": {
+			raw:      aws.String("true"),
+			observed: "false",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := v1alpha1.SNSSubscriptionParameters{RawMessageDelivery: tc.raw}
+			attrs := map[string]string{SubscriptionRawMessageDelivery: tc.observed}
+			if diff := cmp.Diff(tc.want, IsSNSSubscriptionAttributesUpToDate(p, attrs)); diff != "" {
+				t.Errorf("IsSNSSubscriptionAttributesUpToDate(...): -want, +got:\n%s", diff)
+			}
+			_, changed := GetChangedSubAttributes(p, attrs)[SubscriptionRawMessageDelivery]
+			if diff := cmp.Diff(!tc.want, changed); diff != "" {
+				t.Errorf("GetChangedSubAttributes(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsSNSSubscriptionAttributesUpToDate(t *testing.T) {
+	desired := `{"healthyRetryPolicy":{"numRetries":5,"minDelayTarget":10}}`
+	// AWS merges the delivery policy of a subscription with the effective
+	// delivery policy of its topic.
+	merged := `{"healthyRetryPolicy":{"minDelayTarget":10,"maxDelayTarget":20,"numRetries":5,"numMaxDelayRetries":0,"numNoDelayRetries":0,"numMinDelayRetries":0,"backoffFunction":"linear"},"sicklyRetryPolicy":null,"throttlePolicy":null,"guaranteed":false}`
+
+	cases := map[string]struct {
+		policy   *string
+		observed string
+		want     bool
+	}{
+		"Identical": {
+			policy:   &desired,
+			observed: desired,
+			want:     true,
+		},
+		"AWSInjectedDefaults": {
+			policy:   &desired,
+			observed: merged,
+			want:     true,
+		},
+		"Reformatted": {
+			policy:   aws.String(`{ "healthyRetryPolicy": { "minDelayTarget": 10, "numRetries": 5 } }`),
+			observed: merged,
+			want:     true,
+		},
+		"ValueChanged": {
+			policy:   aws.String(`{"healthyRetryPolicy":{"numRetries":3,"minDelayTarget":10}}`),
+			observed: merged,
+		},
+		"KeyNotObserved": {
+			policy:   aws.String(`{"healthyRetryPolicy":{"numRetries":5},"throttlePolicy":{"maxReceivesPerSecond":1}}`),
+			observed: merged,
+		},
+		"NotSpecified": {
+			observed: merged,
+		},
+		"NotJSON": {
+			policy:   &subDeliveryPolicy,
+			observed: merged,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := v1alpha1.SNSSubscriptionParameters{DeliveryPolicy: tc.policy}
+			attrs := map[string]string{SubscriptionDeliveryPolicy: tc.observed}
+			if diff := cmp.Diff(tc.want, IsSNSSubscriptionAttributesUpToDate(p, attrs)); diff != "" {
+				t.Errorf("IsSNSSubscriptionAttributesUpToDate(...): -want, +got:\n%s", diff)
+			}
+			_, changed := GetChangedSubAttributes(p, attrs)[SubscriptionDeliveryPolicy]
+			if diff := cmp.Diff(!tc.want, changed); diff != "" {
+				t.Errorf("GetChangedSubAttributes(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGenerateSubscriptionObservation(t *testing.T) {
 	cases := map[string]struct {
 		in  *map[string]string
@@ -306,3 +532,65 @@ func TestGenerateSubscriptionObservation(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSubscriptionConfirmed(t *testing.T) {
+	cases := map[string]struct {
+		attr map[string]string
+		want bool
+	}{
+		"Confirmed": {
+			attr: map[string]string{SubscriptionPendingConfirmation: "false"},
+			want: true,
+		},
+		"Pending": {
+			attr: map[string]string{SubscriptionPendingConfirmation: "true"},
+			want: false,
+		},
+		"Unknown": {
+			attr: map[string]string{},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSubscriptionConfirmed(tc.attr)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsSubscriptionConfirmed(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsSNSSubscriptionEndpointUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		p    v1alpha1.SNSSubscriptionParameters
+		attr *map[string]string
+		want bool
+	}{
+		"UpToDate": {
+			p:    *subParams(),
+			attr: subAttributes(withSubEndpoint(subEmailProtocol, subEmailEndpoint)),
+			want: true,
+		},
+		"EndpointChanged": {
+			p:    *subParams(),
+			attr: subAttributes(withSubEndpoint(subEmailProtocol, "abc@xyz.com")),
+			want: false,
+		},
+		"ProtocolChanged": {
+			p:    *subParams(),
+			attr: subAttributes(withSubEndpoint("email-json", subEmailEndpoint)),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSNSSubscriptionEndpointUpToDate(tc.p, *tc.attr)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsSNSSubscriptionEndpointUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,11 +17,16 @@ limitations under the License.
 package sns
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/aws/awserr"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
@@ -50,6 +55,43 @@ const (
 	TopicSubscriptionsDeleted TopicAttributes = "SubscriptionsDeleted"
 	// TopicARN is the ARN for the SNS Topic
 	TopicARN TopicAttributes = "TopicArn"
+	// TopicTracingConfig is the X-Ray tracing mode of SNS Topic
+	TopicTracingConfig TopicAttributes = "TracingConfig"
+	// TopicSignatureVersion is the version of the signature of the messages
+	// delivered from SNS Topic
+	TopicSignatureVersion TopicAttributes = "SignatureVersion"
+	// TopicFifoTopic is whether SNS Topic is a FIFO topic
+	TopicFifoTopic TopicAttributes = "FifoTopic"
+	// TopicArchivePolicy is the message archiving policy of SNS Topic
+	TopicArchivePolicy TopicAttributes = "ArchivePolicy"
+	// TopicFifoThroughputScope is the scope of the throughput quota of SNS
+	// Topic
+	TopicFifoThroughputScope TopicAttributes = "FifoThroughputScope"
+	// TopicContentBasedDeduplication is whether SNS Topic deduplicates
+	// messages by their content
+	TopicContentBasedDeduplication TopicAttributes = "ContentBasedDeduplication"
+	// TopicHTTPSuccessFeedbackRoleArn is the role SNS Topic logs successful
+	// deliveries to HTTP(S) endpoints with
+	TopicHTTPSuccessFeedbackRoleArn TopicAttributes = "HTTPSuccessFeedbackRoleArn"
+	// TopicHTTPSuccessFeedbackSampleRate is the percentage of successful
+	// deliveries to HTTP(S) endpoints SNS Topic logs
+	TopicHTTPSuccessFeedbackSampleRate TopicAttributes = "HTTPSuccessFeedbackSampleRate"
+	// TopicHTTPFailureFeedbackRoleArn is the role SNS Topic logs failed
+	// deliveries to HTTP(S) endpoints with
+	TopicHTTPFailureFeedbackRoleArn TopicAttributes = "HTTPFailureFeedbackRoleArn"
+)
+
+// FIFOTopicSuffix is the suffix of the names of FIFO topics. The names of
+// standard topics cannot contain dots.
+const FIFOTopicSuffix = ".fifo"
+
+const (
+	errArchivePolicyNotFIFO             = "archivePolicy can only be set on FIFO topics, whose names end in " + FIFOTopicSuffix
+	errFifoThroughputScopeNotFIFO       = "fifoThroughputScope can only be set on FIFO topics, whose names end in " + FIFOTopicSuffix
+	errContentBasedDeduplicationNotFIFO = "contentBasedDeduplication can only be set on FIFO topics, whose names end in " + FIFOTopicSuffix
+	errSampleRateOutOfRange             = "httpSuccessFeedbackSampleRate must be between 0 and 100, not %d"
+	errFeedbackRoleNotRole              = "%s must be the ARN of an IAM role, not %q"
+	errSignatureVersion                 = "signatureVersion must be 1 or 2, not %q"
 )
 
 // TopicClient is the external client used for AWS SNSTopic
@@ -58,11 +100,13 @@ type TopicClient interface {
 	DeleteTopicRequest(*sns.DeleteTopicInput) sns.DeleteTopicRequest
 	GetTopicAttributesRequest(*sns.GetTopicAttributesInput) sns.GetTopicAttributesRequest
 	SetTopicAttributesRequest(*sns.SetTopicAttributesInput) sns.SetTopicAttributesRequest
+	GetDataProtectionPolicyRequest(*GetDataProtectionPolicyInput) GetDataProtectionPolicyRequest
+	PutDataProtectionPolicyRequest(*PutDataProtectionPolicyInput) PutDataProtectionPolicyRequest
 }
 
 // NewTopicClient returns a new client using AWS credentials as JSON encoded data.
 func NewTopicClient(cfg aws.Config) TopicClient {
-	return sns.New(cfg)
+	return &topicClient{Client: sns.New(cfg)}
 }
 
 // GenerateCreateTopicInput prepares input for CreateTopicRequest
@@ -70,6 +114,9 @@ func GenerateCreateTopicInput(p *v1alpha1.SNSTopicParameters) *sns.CreateTopicIn
 	input := &sns.CreateTopicInput{
 		Name: &p.Name,
 	}
+	if IsFIFOTopic(p) {
+		input.Attributes = map[string]string{string(TopicFifoTopic): "true"}
+	}
 
 	if len(p.Tags) != 0 {
 		input.Tags = make([]sns.Tag, len(p.Tags))
@@ -91,6 +138,18 @@ func LateInitializeTopicAttr(in *v1alpha1.SNSTopicParameters, attrs map[string]s
 	in.DeliveryPolicy = awsclients.LateInitializeStringPtr(in.DeliveryPolicy, aws.String(attrs[string(TopicDeliveryPolicy)]))
 	in.KMSMasterKeyID = awsclients.LateInitializeStringPtr(in.KMSMasterKeyID, aws.String(attrs[string(TopicKmsMasterKeyID)]))
 	in.Policy = awsclients.LateInitializeStringPtr(in.Policy, aws.String(attrs[string(TopicPolicy)]))
+	in.TracingConfig = awsclients.LateInitializeStringPtr(in.TracingConfig, awsclients.String(attrs[string(TopicTracingConfig)]))
+	in.SignatureVersion = awsclients.LateInitializeStringPtr(in.SignatureVersion, awsclients.String(attrs[string(TopicSignatureVersion)]))
+	in.ArchivePolicy = awsclients.LateInitializeStringPtr(in.ArchivePolicy, awsclients.String(attrs[string(TopicArchivePolicy)]))
+	in.FifoThroughputScope = awsclients.LateInitializeStringPtr(in.FifoThroughputScope, awsclients.String(attrs[string(TopicFifoThroughputScope)]))
+	if b, err := strconv.ParseBool(attrs[string(TopicContentBasedDeduplication)]); err == nil {
+		in.ContentBasedDeduplication = awsclients.LateInitializeBoolPtr(in.ContentBasedDeduplication, aws.Bool(b))
+	}
+	in.HTTPSuccessFeedbackRoleARN = awsclients.LateInitializeStringPtr(in.HTTPSuccessFeedbackRoleARN, awsclients.String(attrs[string(TopicHTTPSuccessFeedbackRoleArn)]))
+	if r, err := strconv.ParseInt(attrs[string(TopicHTTPSuccessFeedbackSampleRate)], 10, 64); err == nil {
+		in.HTTPSuccessFeedbackSampleRate = awsclients.LateInitializeInt64Ptr(in.HTTPSuccessFeedbackSampleRate, aws.Int64(r))
+	}
+	in.HTTPFailureFeedbackRoleARN = awsclients.LateInitializeStringPtr(in.HTTPFailureFeedbackRoleARN, awsclients.String(attrs[string(TopicHTTPFailureFeedbackRoleArn)]))
 
 }
 
@@ -99,17 +158,23 @@ func LateInitializeTopicAttr(in *v1alpha1.SNSTopicParameters, attrs map[string]s
 // This is needed as currently AWS SDK allows to set Attribute Topics one at a time.
 // Please see https://docs.aws.amazon.com/sns/latest/api/API_SetTopicAttributes.html
 // So we need to compare each topic attribute and call SetTopicAttribute for ones which has
-// changed.
-func GetChangedAttributes(p v1alpha1.SNSTopicParameters, attrs map[string]string) map[string]string {
-	topicAttrs := getTopicAttributes(p)
+// changed. It returns an error if an attribute would be rejected by SNS.
+func GetChangedAttributes(p v1alpha1.SNSTopicParameters, attrs map[string]string) (map[string]string, error) {
+	topicAttrs, err := getTopicAttributes(p)
+	if err != nil {
+		return nil, err
+	}
 	changedAttrs := make(map[string]string)
 	for k, v := range topicAttrs {
+		if k == string(TopicArchivePolicy) && isJSONEqual(v, attrs[k]) {
+			continue
+		}
 		if v != attrs[k] {
 			changedAttrs[k] = v
 		}
 	}
 
-	return changedAttrs
+	return changedAttrs, nil
 }
 
 // GenerateTopicObservation is used to produce SNSTopicObservation from attributes
@@ -140,10 +205,82 @@ func IsSNSTopicUpToDate(p v1alpha1.SNSTopicParameters, attr map[string]string) b
 	return aws.StringValue(p.DeliveryPolicy) == attr[string(TopicDeliveryPolicy)] &&
 		aws.StringValue(p.DisplayName) == attr[string(TopicDisplayName)] &&
 		aws.StringValue(p.KMSMasterKeyID) == attr[string(TopicKmsMasterKeyID)] &&
-		aws.StringValue(p.Policy) == attr[string(TopicPolicy)]
+		aws.StringValue(p.Policy) == attr[string(TopicPolicy)] &&
+		(p.TracingConfig == nil || aws.StringValue(p.TracingConfig) == attr[string(TopicTracingConfig)]) &&
+		(p.SignatureVersion == nil || aws.StringValue(p.SignatureVersion) == attr[string(TopicSignatureVersion)]) &&
+		(p.ArchivePolicy == nil || isJSONEqual(aws.StringValue(p.ArchivePolicy), attr[string(TopicArchivePolicy)])) &&
+		(p.FifoThroughputScope == nil || aws.StringValue(p.FifoThroughputScope) == attr[string(TopicFifoThroughputScope)]) &&
+		(p.ContentBasedDeduplication == nil || strconv.FormatBool(aws.BoolValue(p.ContentBasedDeduplication)) == attr[string(TopicContentBasedDeduplication)]) &&
+		(p.HTTPSuccessFeedbackRoleARN == nil || aws.StringValue(p.HTTPSuccessFeedbackRoleARN) == attr[string(TopicHTTPSuccessFeedbackRoleArn)]) &&
+		(p.HTTPSuccessFeedbackSampleRate == nil || strconv.FormatInt(aws.Int64Value(p.HTTPSuccessFeedbackSampleRate), 10) == attr[string(TopicHTTPSuccessFeedbackSampleRate)]) &&
+		(p.HTTPFailureFeedbackRoleARN == nil || aws.StringValue(p.HTTPFailureFeedbackRoleARN) == attr[string(TopicHTTPFailureFeedbackRoleArn)])
+}
+
+// IsFIFOTopic returns true if the supplied parameters are those of a FIFO
+// topic.
+func IsFIFOTopic(p *v1alpha1.SNSTopicParameters) bool {
+	return strings.HasSuffix(p.Name, FIFOTopicSuffix)
+}
+
+// ValidateTopic returns an error if the supplied parameters would be rejected
+// by SNS.
+func ValidateTopic(p *v1alpha1.SNSTopicParameters) error {
+	if IsFIFOTopic(p) {
+		return nil
+	}
+	switch {
+	case p.ArchivePolicy != nil:
+		return errors.New(errArchivePolicyNotFIFO)
+	case p.FifoThroughputScope != nil:
+		return errors.New(errFifoThroughputScopeNotFIFO)
+	case p.ContentBasedDeduplication != nil:
+		return errors.New(errContentBasedDeduplicationNotFIFO)
+	}
+	return nil
+}
+
+// isJSONEqual returns true if the supplied strings are the same JSON document,
+// regardless of formatting and the order of keys. Strings that are not valid
+// JSON are compared as they are.
+func isJSONEqual(a, b string) bool {
+	var ja, jb interface{}
+	if json.Unmarshal([]byte(a), &ja) != nil || json.Unmarshal([]byte(b), &jb) != nil {
+		return a == b
+	}
+	return cmp.Equal(ja, jb)
+}
+
+// validateFeedbackRole returns an error if the supplied feedback role of the
+// named parameter is not the ARN of an IAM role, which SNS could not assume.
+// It only checks the format of the ARN; whether the role exists and may be
+// assumed by SNS is reported by SNS when the attributes are set.
+func validateFeedbackRole(param string, role *string) error {
+	if role == nil {
+		return nil
+	}
+	a, err := arn.Parse(*role)
+	if err != nil || a.Service != "iam" || !strings.HasPrefix(a.Resource, "role/") {
+		return errors.Errorf(errFeedbackRoleNotRole, param, *role)
+	}
+	return nil
 }
 
-func getTopicAttributes(p v1alpha1.SNSTopicParameters) map[string]string {
+// getTopicAttributes returns the attributes of a topic with the supplied
+// parameters. It returns an error if an attribute would be rejected by SNS,
+// so that it is reported before the attributes are set.
+func getTopicAttributes(p v1alpha1.SNSTopicParameters) (map[string]string, error) {
+	if v := p.SignatureVersion; v != nil && *v != "1" && *v != "2" {
+		return nil, errors.Errorf(errSignatureVersion, *v)
+	}
+	if r := p.HTTPSuccessFeedbackSampleRate; r != nil && (*r < 0 || *r > 100) {
+		return nil, errors.Errorf(errSampleRateOutOfRange, *r)
+	}
+	if err := validateFeedbackRole("httpSuccessFeedbackRoleArn", p.HTTPSuccessFeedbackRoleARN); err != nil {
+		return nil, err
+	}
+	if err := validateFeedbackRole("httpFailureFeedbackRoleArn", p.HTTPFailureFeedbackRoleARN); err != nil {
+		return nil, err
+	}
 
 	topicAttr := make(map[string]string)
 
@@ -151,8 +288,32 @@ func getTopicAttributes(p v1alpha1.SNSTopicParameters) map[string]string {
 	topicAttr[string(TopicDisplayName)] = aws.StringValue(p.DisplayName)
 	topicAttr[string(TopicKmsMasterKeyID)] = aws.StringValue(p.KMSMasterKeyID)
 	topicAttr[string(TopicPolicy)] = aws.StringValue(p.Policy)
+	if p.TracingConfig != nil {
+		topicAttr[string(TopicTracingConfig)] = aws.StringValue(p.TracingConfig)
+	}
+	if p.SignatureVersion != nil {
+		topicAttr[string(TopicSignatureVersion)] = aws.StringValue(p.SignatureVersion)
+	}
+	if p.ArchivePolicy != nil {
+		topicAttr[string(TopicArchivePolicy)] = aws.StringValue(p.ArchivePolicy)
+	}
+	if p.FifoThroughputScope != nil {
+		topicAttr[string(TopicFifoThroughputScope)] = aws.StringValue(p.FifoThroughputScope)
+	}
+	if p.ContentBasedDeduplication != nil {
+		topicAttr[string(TopicContentBasedDeduplication)] = strconv.FormatBool(aws.BoolValue(p.ContentBasedDeduplication))
+	}
+	if p.HTTPSuccessFeedbackRoleARN != nil {
+		topicAttr[string(TopicHTTPSuccessFeedbackRoleArn)] = aws.StringValue(p.HTTPSuccessFeedbackRoleARN)
+	}
+	if p.HTTPSuccessFeedbackSampleRate != nil {
+		topicAttr[string(TopicHTTPSuccessFeedbackSampleRate)] = strconv.FormatInt(aws.Int64Value(p.HTTPSuccessFeedbackSampleRate), 10)
+	}
+	if p.HTTPFailureFeedbackRoleARN != nil {
+		topicAttr[string(TopicHTTPFailureFeedbackRoleArn)] = aws.StringValue(p.HTTPFailureFeedbackRoleARN)
+	}
 
-	return topicAttr
+	return topicAttr, nil
 }
 
 // IsTopicNotFound returns true if the error code indicates that the item was not found
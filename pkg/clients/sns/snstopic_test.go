@@ -23,6 +23,9 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
 )
@@ -42,6 +45,21 @@ var (
 	tagValue2         = "value-2"
 )
 
+var (
+	tracingPassThrough = "PassThrough"
+	tracingActive      = "Active"
+	signatureV1        = "1"
+	signatureV2        = "2"
+	fifoTopicName      = "some-topic.fifo"
+	archivePolicy      = `{"MessageRetentionPeriod": "30"}`
+	archivePolicy2     = `{"MessageRetentionPeriod":"30"}`
+	archivePolicy60    = `{"MessageRetentionPeriod":"60"}`
+	scopeTopic         = "Topic"
+	scopeMessageGroup  = "MessageGroup"
+	dedupTrue          = "true"
+	dedupFalse         = "false"
+)
+
 // Topic Attribute Modifier
 type topicAttrModifier func(*map[string]string)
 
@@ -82,6 +100,36 @@ func withAttrDisplayName(s *string) topicAttrModifier {
 	}
 }
 
+func withAttrTracingConfig(s *string) topicAttrModifier {
+	return func(attr *map[string]string) {
+		(*attr)[string(TopicTracingConfig)] = *s
+	}
+}
+
+func withAttrSignatureVersion(s *string) topicAttrModifier {
+	return func(attr *map[string]string) {
+		(*attr)[string(TopicSignatureVersion)] = *s
+	}
+}
+
+func withAttrArchivePolicy(s *string) topicAttrModifier {
+	return func(attr *map[string]string) {
+		(*attr)[string(TopicArchivePolicy)] = *s
+	}
+}
+
+func withAttrFifoThroughputScope(s *string) topicAttrModifier {
+	return func(attr *map[string]string) {
+		(*attr)[string(TopicFifoThroughputScope)] = *s
+	}
+}
+
+func withAttrContentBasedDeduplication(s *string) topicAttrModifier {
+	return func(attr *map[string]string) {
+		(*attr)[string(TopicContentBasedDeduplication)] = *s
+	}
+}
+
 // topic Observation Modifier
 type topicObservationModifier func(*v1alpha1.SNSTopicObservation)
 
@@ -162,6 +210,13 @@ func TestGenerateCreateTopicInput(t *testing.T) {
 				},
 			},
 		},
+		"FIFOTopic": {
+			in: v1alpha1.SNSTopicParameters{Name: fifoTopicName},
+			out: awssns.CreateTopicInput{
+				Name:       aws.String(fifoTopicName),
+				Attributes: map[string]string{string(TopicFifoTopic): "true"},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -211,11 +266,109 @@ func TestGetChangedAttributes(t *testing.T) {
 				withAttrDisplayName(&topicDisplayName),
 			),
 		},
+		"EnableActiveTracing": {
+			args: args{
+				p: v1alpha1.SNSTopicParameters{
+					Name:          topicName,
+					DisplayName:   &topicDisplayName,
+					TracingConfig: &tracingActive,
+				},
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrTracingConfig(&tracingPassThrough),
+				),
+			},
+			want: topicAttributes(
+				withAttrTracingConfig(&tracingActive),
+			),
+		},
+		"SetSignatureVersion": {
+			args: args{
+				p: v1alpha1.SNSTopicParameters{
+					Name:             topicName,
+					DisplayName:      &topicDisplayName,
+					SignatureVersion: &signatureV2,
+				},
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+				),
+			},
+			want: topicAttributes(
+				withAttrSignatureVersion(&signatureV2),
+			),
+		},
+		"ChangeSignatureVersion": {
+			args: args{
+				p: v1alpha1.SNSTopicParameters{
+					Name:             topicName,
+					DisplayName:      &topicDisplayName,
+					SignatureVersion: &signatureV1,
+				},
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrSignatureVersion(&signatureV2),
+				),
+			},
+			want: topicAttributes(
+				withAttrSignatureVersion(&signatureV1),
+			),
+		},
+		"EnableArchiving": {
+			args: args{
+				p: v1alpha1.SNSTopicParameters{
+					Name:          fifoTopicName,
+					DisplayName:   &topicDisplayName,
+					ArchivePolicy: &archivePolicy,
+				},
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+				),
+			},
+			want: topicAttributes(
+				withAttrArchivePolicy(&archivePolicy),
+			),
+		},
+		"EquivalentArchivePolicy": {
+			args: args{
+				p: v1alpha1.SNSTopicParameters{
+					Name:          fifoTopicName,
+					DisplayName:   &topicDisplayName,
+					ArchivePolicy: &archivePolicy,
+				},
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrArchivePolicy(&archivePolicy2),
+				),
+			},
+			want: topicAttributes(),
+		},
+		"EnableHighThroughput": {
+			args: args{
+				p: v1alpha1.SNSTopicParameters{
+					Name:                      fifoTopicName,
+					DisplayName:               &topicDisplayName,
+					FifoThroughputScope:       &scopeMessageGroup,
+					ContentBasedDeduplication: aws.Bool(true),
+				},
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrFifoThroughputScope(&scopeTopic),
+					withAttrContentBasedDeduplication(&dedupFalse),
+				),
+			},
+			want: topicAttributes(
+				withAttrFifoThroughputScope(&scopeMessageGroup),
+				withAttrContentBasedDeduplication(&dedupTrue),
+			),
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			c := GetChangedAttributes(tc.args.p, *tc.args.attr)
+			c, err := GetChangedAttributes(tc.args.p, *tc.args.attr)
+			if err != nil {
+				t.Fatalf("GetChangedAttributes(...): unexpected error: %s", err)
+			}
 			if diff := cmp.Diff(*tc.want, c); diff != "" {
 				t.Errorf("GetChangedAttributes(...): -want, +got:\n%s", diff)
 			}
@@ -223,6 +376,108 @@ func TestGetChangedAttributes(t *testing.T) {
 	}
 }
 
+func TestGetChangedDeliveryLoggingAttributes(t *testing.T) {
+	role := "arn:aws:iam::123456789012:role/sns-delivery-logging"
+
+	type want struct {
+		attrs map[string]string
+		err   error
+	}
+
+	cases := map[string]struct {
+		p    v1alpha1.SNSTopicParameters
+		want want
+	}{
+		"EnableSuccessLogging": {
+			p: v1alpha1.SNSTopicParameters{
+				HTTPSuccessFeedbackRoleARN:    &role,
+				HTTPSuccessFeedbackSampleRate: aws.Int64(100),
+			},
+			want: want{attrs: map[string]string{
+				string(TopicHTTPSuccessFeedbackRoleArn):    role,
+				string(TopicHTTPSuccessFeedbackSampleRate): "100",
+			}},
+		},
+		"EnableFailureLogging": {
+			p: v1alpha1.SNSTopicParameters{
+				HTTPFailureFeedbackRoleARN: &role,
+			},
+			want: want{attrs: map[string]string{
+				string(TopicHTTPFailureFeedbackRoleArn): role,
+			}},
+		},
+		"SampleRateAboveRange": {
+			p: v1alpha1.SNSTopicParameters{
+				HTTPSuccessFeedbackRoleARN:    &role,
+				HTTPSuccessFeedbackSampleRate: aws.Int64(101),
+			},
+			want: want{err: errors.Errorf(errSampleRateOutOfRange, 101)},
+		},
+		"SampleRateBelowRange": {
+			p: v1alpha1.SNSTopicParameters{
+				HTTPSuccessFeedbackSampleRate: aws.Int64(-1),
+			},
+			want: want{err: errors.Errorf(errSampleRateOutOfRange, -1)},
+		},
+		"SuccessRoleNotARN": {
+			p: v1alpha1.SNSTopicParameters{
+				HTTPSuccessFeedbackRoleARN: aws.String("sns-delivery-logging"),
+			},
+			want: want{err: errors.Errorf(errFeedbackRoleNotRole, "httpSuccessFeedbackRoleArn", "sns-delivery-logging")},
+		},
+		"FailureRoleNotRole": {
+			p: v1alpha1.SNSTopicParameters{
+				HTTPFailureFeedbackRoleARN: aws.String("arn:aws:iam::123456789012:user/sns"),
+			},
+			want: want{err: errors.Errorf(errFeedbackRoleNotRole, "httpFailureFeedbackRoleArn", "arn:aws:iam::123456789012:user/sns")},
+		},
+		"InvalidSignatureVersion": {
+			p: v1alpha1.SNSTopicParameters{
+				SignatureVersion: aws.String("3"),
+			},
+			want: want{err: errors.Errorf(errSignatureVersion, "3")},
+		},
+		"EmptySignatureVersion": {
+			p: v1alpha1.SNSTopicParameters{
+				SignatureVersion: aws.String(""),
+			},
+			want: want{err: errors.Errorf(errSignatureVersion, "")},
+		},
+		"SignatureVersionNotNumber": {
+			p: v1alpha1.SNSTopicParameters{
+				SignatureVersion: aws.String("v2"),
+			},
+			want: want{err: errors.Errorf(errSignatureVersion, "v2")},
+		},
+		"SignatureVersionZero": {
+			p: v1alpha1.SNSTopicParameters{
+				SignatureVersion: aws.String("0"),
+			},
+			want: want{err: errors.Errorf(errSignatureVersion, "0")},
+		},
+		"ValidSignatureVersion": {
+			p: v1alpha1.SNSTopicParameters{
+				SignatureVersion: aws.String("2"),
+			},
+			want: want{attrs: map[string]string{
+				string(TopicSignatureVersion): "2",
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := GetChangedAttributes(tc.p, map[string]string{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GetChangedAttributes(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.attrs, c); diff != "" {
+				t.Errorf("GetChangedAttributes(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGenerateTopicObservation(t *testing.T) {
 	cases := map[string]struct {
 		in  *map[string]string
@@ -299,6 +554,108 @@ func TestIsSNSTopicUpToDate(t *testing.T) {
 			},
 			want: false,
 		},
+		"TracingConfigUnset": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrTracingConfig(&tracingPassThrough),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName: &topicDisplayName,
+				},
+			},
+			want: true,
+		},
+		"TracingConfigChanged": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrTracingConfig(&tracingPassThrough),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName:   &topicDisplayName,
+					TracingConfig: &tracingActive,
+				},
+			},
+			want: false,
+		},
+		"SignatureVersionUnset": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrSignatureVersion(&signatureV1),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName: &topicDisplayName,
+				},
+			},
+			want: true,
+		},
+		"SignatureVersionChanged": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrSignatureVersion(&signatureV1),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName:      &topicDisplayName,
+					SignatureVersion: &signatureV2,
+				},
+			},
+			want: false,
+		},
+		"ArchivePolicyStructurallyEqual": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrArchivePolicy(&archivePolicy2),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName:   &topicDisplayName,
+					ArchivePolicy: &archivePolicy,
+				},
+			},
+			want: true,
+		},
+		"ArchivePolicyChanged": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrArchivePolicy(&archivePolicy2),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName:   &topicDisplayName,
+					ArchivePolicy: &archivePolicy60,
+				},
+			},
+			want: false,
+		},
+		"FifoThroughputScopeChanged": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrFifoThroughputScope(&scopeTopic),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName:         &topicDisplayName,
+					FifoThroughputScope: &scopeMessageGroup,
+				},
+			},
+			want: false,
+		},
+		"ContentBasedDeduplicationUnchanged": {
+			args: args{
+				attr: topicAttributes(
+					withAttrDisplayName(&topicDisplayName),
+					withAttrContentBasedDeduplication(&dedupFalse),
+				),
+				p: v1alpha1.SNSTopicParameters{
+					DisplayName:               &topicDisplayName,
+					ContentBasedDeduplication: aws.Bool(false),
+				},
+			},
+			want: true,
+		},
 	}
 
 	for name, tc := range cases {
@@ -310,3 +667,165 @@ func TestIsSNSTopicUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitializeTopicAttr(t *testing.T) {
+	type args struct {
+		p    *v1alpha1.SNSTopicParameters
+		attr *map[string]string
+	}
+
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.SNSTopicParameters
+	}{
+		"DefaultTracingConfig": {
+			args: args{
+				p: &v1alpha1.SNSTopicParameters{
+					DisplayName: &topicDisplayName,
+				},
+				attr: topicAttributes(
+					withAttrTracingConfig(&tracingPassThrough),
+				),
+			},
+			want: &v1alpha1.SNSTopicParameters{
+				DisplayName:    &topicDisplayName,
+				DeliveryPolicy: &empty,
+				KMSMasterKeyID: &empty,
+				Policy:         &empty,
+				TracingConfig:  &tracingPassThrough,
+			},
+		},
+		"TracingConfigSet": {
+			args: args{
+				p: &v1alpha1.SNSTopicParameters{
+					DisplayName:   &topicDisplayName,
+					TracingConfig: &tracingActive,
+				},
+				attr: topicAttributes(
+					withAttrTracingConfig(&tracingPassThrough),
+				),
+			},
+			want: &v1alpha1.SNSTopicParameters{
+				DisplayName:    &topicDisplayName,
+				DeliveryPolicy: &empty,
+				KMSMasterKeyID: &empty,
+				Policy:         &empty,
+				TracingConfig:  &tracingActive,
+			},
+		},
+		"DefaultSignatureVersion": {
+			args: args{
+				p: &v1alpha1.SNSTopicParameters{
+					DisplayName: &topicDisplayName,
+				},
+				attr: topicAttributes(
+					withAttrSignatureVersion(&signatureV1),
+				),
+			},
+			want: &v1alpha1.SNSTopicParameters{
+				DisplayName:      &topicDisplayName,
+				DeliveryPolicy:   &empty,
+				KMSMasterKeyID:   &empty,
+				Policy:           &empty,
+				SignatureVersion: &signatureV1,
+			},
+		},
+		"DefaultArchivePolicy": {
+			args: args{
+				p: &v1alpha1.SNSTopicParameters{
+					DisplayName: &topicDisplayName,
+				},
+				attr: topicAttributes(
+					withAttrArchivePolicy(&archivePolicy2),
+				),
+			},
+			want: &v1alpha1.SNSTopicParameters{
+				DisplayName:    &topicDisplayName,
+				DeliveryPolicy: &empty,
+				KMSMasterKeyID: &empty,
+				Policy:         &empty,
+				ArchivePolicy:  &archivePolicy2,
+			},
+		},
+		"DefaultFIFOAttributes": {
+			args: args{
+				p: &v1alpha1.SNSTopicParameters{
+					DisplayName: &topicDisplayName,
+				},
+				attr: topicAttributes(
+					withAttrFifoThroughputScope(&scopeTopic),
+					withAttrContentBasedDeduplication(&dedupFalse),
+				),
+			},
+			want: &v1alpha1.SNSTopicParameters{
+				DisplayName:               &topicDisplayName,
+				DeliveryPolicy:            &empty,
+				KMSMasterKeyID:            &empty,
+				Policy:                    &empty,
+				FifoThroughputScope:       &scopeTopic,
+				ContentBasedDeduplication: aws.Bool(false),
+			},
+		},
+		"NoTracingConfig": {
+			args: args{
+				p: &v1alpha1.SNSTopicParameters{
+					DisplayName: &topicDisplayName,
+				},
+				attr: topicAttributes(),
+			},
+			want: &v1alpha1.SNSTopicParameters{
+				DisplayName:    &topicDisplayName,
+				DeliveryPolicy: &empty,
+				KMSMasterKeyID: &empty,
+				Policy:         &empty,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeTopicAttr(tc.args.p, *tc.args.attr)
+			if diff := cmp.Diff(tc.want, tc.args.p); diff != "" {
+				t.Errorf("LateInitializeTopicAttr(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTopic(t *testing.T) {
+	cases := map[string]struct {
+		p    *v1alpha1.SNSTopicParameters
+		want error
+	}{
+		"ArchivingFIFOTopic": {
+			p: &v1alpha1.SNSTopicParameters{Name: fifoTopicName, ArchivePolicy: &archivePolicy},
+		},
+		"ArchivingStandardTopic": {
+			p:    &v1alpha1.SNSTopicParameters{Name: topicName, ArchivePolicy: &archivePolicy},
+			want: errors.New(errArchivePolicyNotFIFO),
+		},
+		"HighThroughputFIFOTopic": {
+			p: &v1alpha1.SNSTopicParameters{Name: fifoTopicName, FifoThroughputScope: &scopeMessageGroup, ContentBasedDeduplication: aws.Bool(true)},
+		},
+		"ThroughputScopeOnStandardTopic": {
+			p:    &v1alpha1.SNSTopicParameters{Name: topicName, FifoThroughputScope: &scopeMessageGroup},
+			want: errors.New(errFifoThroughputScopeNotFIFO),
+		},
+		"DeduplicationOnStandardTopic": {
+			p:    &v1alpha1.SNSTopicParameters{Name: topicName, ContentBasedDeduplication: aws.Bool(true)},
+			want: errors.New(errContentBasedDeduplicationNotFIFO),
+		},
+		"StandardTopic": {
+			p: &v1alpha1.SNSTopicParameters{Name: topicName},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateTopic(tc.p)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateTopic(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/acm/v1alpha1"
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/acm"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
@@ -56,20 +57,22 @@ const (
 )
 
 // SetupCertificate adds a controller that reconciles Certificates.
-func SetupCertificate(mgr ctrl.Manager, l logging.Logger) error {
+func SetupCertificate(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.CertificateGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.Certificate{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.CertificateGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: acm.NewClient}),
+			managed.WithExternalConnecter(reconciler.NewTimeoutConnecter(&connector{client: mgr.GetClient(), newClientFn: acm.NewClient}, record)),
 			managed.WithConnectionPublishers(),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 type connector struct {
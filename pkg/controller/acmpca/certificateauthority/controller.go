@@ -36,6 +36,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/acmpca/v1alpha1"
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/acmpca"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
@@ -54,22 +55,24 @@ const (
 )
 
 // SetupCertificateAuthority adds a controller that reconciles ACMPCA.
-func SetupCertificateAuthority(mgr ctrl.Manager, l logging.Logger) error {
+func SetupCertificateAuthority(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.CertificateAuthorityGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.CertificateAuthority{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.CertificateAuthorityGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: acmpca.NewClient}),
+			managed.WithExternalConnecter(reconciler.NewTimeoutConnecter(&connector{client: mgr.GetClient(), newClientFn: acmpca.NewClient}, record)),
 			managed.WithConnectionPublishers(),
 
 			// TODO: implement tag initializer
 
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 type connector struct {
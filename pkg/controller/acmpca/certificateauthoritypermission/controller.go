@@ -34,6 +34,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/acmpca/v1alpha1"
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/acmpca"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
@@ -46,20 +47,22 @@ const (
 )
 
 // SetupCertificateAuthorityPermission adds a controller that reconciles ACMPCA.
-func SetupCertificateAuthorityPermission(mgr ctrl.Manager, l logging.Logger) error {
+func SetupCertificateAuthorityPermission(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.CertificateAuthorityPermissionGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.CertificateAuthorityPermission{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.CertificateAuthorityPermissionGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: acmpca.NewCAPermissionClient}),
+			managed.WithExternalConnecter(reconciler.NewTimeoutConnecter(&connector{client: mgr.GetClient(), newClientFn: acmpca.NewCAPermissionClient}, record)),
 			managed.WithConnectionPublishers(),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 type connector struct {
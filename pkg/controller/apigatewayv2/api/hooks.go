@@ -18,10 +18,18 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -32,27 +40,144 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	errUpdate = "cannot update API in AWS"
+	errTag    = "cannot tag API in AWS"
+	errUntag  = "cannot untag API in AWS"
+
+	msgTagsDrifted = "tags %s differ from those of the API in AWS and are not corrected in observe-only mode"
+)
+
+// AnnotationKeyObserveOnlyTags is the annotation that makes an API report
+// tags that were changed outside of Crossplane, e.g. in the console, rather
+// than correct them. This allows another system to own the tags of the API.
+const AnnotationKeyObserveOnlyTags = "apigatewayv2.aws.crossplane.io/observe-only-tags"
+
+// TypeTagsSynced indicates whether the tags of an API match those of the API
+// in AWS. It is only reported by APIs whose tags are observe-only.
+const TypeTagsSynced v1alpha1.ConditionType = "TagsSynced"
+
+// Reasons of the TagsSynced condition.
+const (
+	ReasonTagsSynced  v1alpha1.ConditionReason = "TagsSynced"
+	ReasonTagsDrifted v1alpha1.ConditionReason = "TagsDrifted"
 )
 
 // SetupAPI adds a controller that reconciles API.
-func SetupAPI(mgr ctrl.Manager, l logging.Logger) error {
+func SetupAPI(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.APIGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.API{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.APIGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.APIKind)),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.API) error {
 	return nil
 }
-func (*external) postObserve(_ context.Context, cr *svcapitypes.API, _ *svcsdk.GetApisOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+func (e *external) postObserve(ctx context.Context, cr *svcapitypes.API, resp *svcsdk.GetApisOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 	cr.SetConditions(v1alpha1.Available())
-	return obs, err
+	obs.ResourceUpToDate = isUpToDate(cr, resp)
+	if len(resp.Items) == 0 {
+		return obs, nil
+	}
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	drifted := driftedTags(tags, resp.Items[0].Tags)
+	switch {
+	case isTagsObserveOnly(cr):
+		cr.SetConditions(tagsSynced(drifted))
+	case len(drifted) != 0:
+		obs.ResourceUpToDate = false
+	}
+	if !isTagsObserveOnly(cr) && cr.GetCondition(TypeTagsSynced).Status == corev1.ConditionFalse {
+		cr.SetConditions(tagsSynced(nil))
+	}
+	return obs, nil
+}
+
+// desiredTags returns the tags of the supplied API merged with the default tags
+// of its ProviderConfig. Default tags are merged at request time rather than
+// written to the spec, so that changes to them apply to existing APIs.
+func (e *external) desiredTags(ctx context.Context, cr *svcapitypes.API) (map[string]*string, error) {
+	defaults, err := aws.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	return aws.MergeTagPtrs(cr.Spec.ForProvider.Tags, defaults), nil
+}
+
+// isTagsObserveOnly returns true if tags of the supplied API that were changed
+// outside of Crossplane should be reported rather than corrected.
+func isTagsObserveOnly(cr *svcapitypes.API) bool {
+	return cr.GetAnnotations()[AnnotationKeyObserveOnlyTags] == "true"
+}
+
+// driftedTags returns the sorted keys of the desired tags that differ from the
+// observed tags, and of the observed tags that are not desired.
+func driftedTags(desired, observed map[string]*string) []string {
+	add, remove := aws.DiffTags(stringMap(desired), stringMap(observed))
+	// Tags whose values changed are both removed and added.
+	drifted := remove
+	for k := range add {
+		if _, ok := observed[k]; !ok {
+			drifted = append(drifted, k)
+		}
+	}
+	sort.Strings(drifted)
+	return drifted
+}
+
+// tagsSynced returns a TagsSynced condition reporting the supplied drifted
+// tags, if any.
+func tagsSynced(drifted []string) v1alpha1.Condition {
+	if len(drifted) == 0 {
+		return v1alpha1.Condition{
+			Type:               TypeTagsSynced,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonTagsSynced,
+		}
+	}
+	return v1alpha1.Condition{
+		Type:               TypeTagsSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTagsDrifted,
+		Message:            fmt.Sprintf(msgTagsDrifted, strings.Join(drifted, ", ")),
+	}
+}
+
+func stringMap(in map[string]*string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = aws.StringValue(v)
+	}
+	return out
+}
+
+// apiARN returns the ARN of the API with the supplied ID, which is required to
+// tag it.
+func apiARN(region, id string) string {
+	partition := "aws"
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+	return fmt.Sprintf("arn:%s:apigateway:%s::/apis/%s", partition, region, id)
 }
 
 func (*external) filterList(cr *svcapitypes.API, list *svcsdk.GetApisOutput) *svcsdk.GetApisOutput {
@@ -65,7 +190,15 @@ func (*external) filterList(cr *svcapitypes.API, list *svcsdk.GetApisOutput) *sv
 	return res
 }
 
-func (*external) preCreate(context.Context, *svcapitypes.API) error {
+// preCreate adds the default tags of the ProviderConfig to the tags the API is
+// created with. The managed reconciler does not persist the spec of the API
+// after creating it, so they are not written to the spec.
+func (e *external) preCreate(ctx context.Context, cr *svcapitypes.API) error {
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return err
+	}
+	cr.Spec.ForProvider.Tags = tags
 	return nil
 }
 
@@ -77,13 +210,81 @@ func (*external) preUpdate(context.Context, *svcapitypes.API) error {
 	return nil
 }
 
-func (*external) postUpdate(_ context.Context, _ *svcapitypes.API, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
-	return upd, err
+// postUpdate updates the description and version of the API and, unless they
+// are observe-only, its tags. Only the API itself is fetched, by its ID, so
+// that the API is not updated unless it differs from the spec.
+func (e *external) postUpdate(ctx context.Context, cr *svcapitypes.API, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	resp, err := e.client.GetApiWithContext(ctx, &svcsdk.GetApiInput{ApiId: cr.Status.AtProvider.APIID})
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribe)
+	}
+	if !isAPIUpToDate(cr, resp.Description, resp.Version) {
+		if _, err := e.client.UpdateApiWithContext(ctx, generateUpdateAPIInput(cr)); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+		}
+	}
+	if isTagsObserveOnly(cr) {
+		return upd, nil
+	}
+	arn := awsgo.String(apiARN(cr.Spec.ForProvider.Region, aws.StringValue(cr.Status.AtProvider.APIID)))
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	add, remove := aws.DiffTags(stringMap(tags), stringMap(resp.Tags))
+	if len(remove) != 0 {
+		sort.Strings(remove)
+		if _, err := e.client.UntagResourceWithContext(ctx, &svcsdk.UntagResourceInput{ResourceArn: arn, TagKeys: awsgo.StringSlice(remove)}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUntag)
+		}
+	}
+	if len(add) != 0 {
+		if _, err := e.client.TagResourceWithContext(ctx, &svcsdk.TagResourceInput{ResourceArn: arn, Tags: awsgo.StringMap(add)}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTag)
+		}
+	}
+	return upd, nil
 }
-func lateInitialize(*svcapitypes.APIParameters, *svcsdk.GetApisOutput) error {
+
+func lateInitialize(cr *svcapitypes.APIParameters, resp *svcsdk.GetApisOutput) error {
+	if len(resp.Items) == 0 {
+		return nil
+	}
+	api := resp.Items[0]
+	cr.Description = aws.LateInitializeStringPtr(cr.Description, api.Description)
+	cr.Version = aws.LateInitializeStringPtr(cr.Version, api.Version)
 	return nil
 }
 
+// isUpToDate returns whether the mutable fields of the API are in sync with
+// the observed API.
+func isUpToDate(cr *svcapitypes.API, resp *svcsdk.GetApisOutput) bool {
+	if len(resp.Items) == 0 {
+		return true
+	}
+	return isAPIUpToDate(cr, resp.Items[0].Description, resp.Items[0].Version)
+}
+
+// isAPIUpToDate returns whether the description and version of the API are in
+// sync with the supplied observed description and version.
+func isAPIUpToDate(cr *svcapitypes.API, description, version *string) bool {
+	if aws.StringValue(cr.Spec.ForProvider.Description) != aws.StringValue(description) {
+		return false
+	}
+	return aws.StringValue(cr.Spec.ForProvider.Version) == aws.StringValue(version)
+}
+
+func generateUpdateAPIInput(cr *svcapitypes.API) *svcsdk.UpdateApiInput {
+	return &svcsdk.UpdateApiInput{
+		ApiId:       cr.Status.AtProvider.APIID,
+		Description: cr.Spec.ForProvider.Description,
+		Version:     cr.Spec.ForProvider.Version,
+	}
+}
+
 func preGenerateGetApisInput(_ *svcapitypes.API, obj *svcsdk.GetApisInput) *svcsdk.GetApisInput {
 	return obj
 }
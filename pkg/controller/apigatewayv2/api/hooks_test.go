@@ -0,0 +1,412 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+var (
+	apiName = "cool-api"
+	apiID   = "abc123"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockGetApis       func(*svcsdk.GetApisInput) (*svcsdk.GetApisOutput, error)
+	MockGetApi        func(*svcsdk.GetApiInput) (*svcsdk.GetApiOutput, error)
+	MockUpdateApi     func(*svcsdk.UpdateApiInput) (*svcsdk.UpdateApiOutput, error) //nolint:golint
+	MockTagResource   func(*svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error)
+	MockUntagResource func(*svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error)
+}
+
+func (m *mockClient) GetApisWithContext(_ context.Context, in *svcsdk.GetApisInput, _ ...request.Option) (*svcsdk.GetApisOutput, error) {
+	return m.MockGetApis(in)
+}
+
+func (m *mockClient) GetApiWithContext(_ context.Context, in *svcsdk.GetApiInput, _ ...request.Option) (*svcsdk.GetApiOutput, error) {
+	return m.MockGetApi(in)
+}
+
+func (m *mockClient) UpdateApiWithContext(_ context.Context, in *svcsdk.UpdateApiInput, _ ...request.Option) (*svcsdk.UpdateApiOutput, error) { //nolint:golint
+	return m.MockUpdateApi(in)
+}
+
+func (m *mockClient) TagResourceWithContext(_ context.Context, in *svcsdk.TagResourceInput, _ ...request.Option) (*svcsdk.TagResourceOutput, error) {
+	return m.MockTagResource(in)
+}
+
+func (m *mockClient) UntagResourceWithContext(_ context.Context, in *svcsdk.UntagResourceInput, _ ...request.Option) (*svcsdk.UntagResourceOutput, error) {
+	return m.MockUntagResource(in)
+}
+
+type apiModifier func(*svcapitypes.API)
+
+func withDescription(d string) apiModifier {
+	return func(r *svcapitypes.API) { r.Spec.ForProvider.Description = &d }
+}
+
+func withVersion(v string) apiModifier {
+	return func(r *svcapitypes.API) { r.Spec.ForProvider.Version = &v }
+}
+
+func withTags(tags map[string]string) apiModifier {
+	return func(r *svcapitypes.API) { r.Spec.ForProvider.Tags = awsgo.StringMap(tags) }
+}
+
+func withProviderConfig(name string) apiModifier {
+	return func(r *svcapitypes.API) { r.SetProviderConfigReference(&v1alpha1.Reference{Name: name}) }
+}
+
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*apisv1beta1.ProviderConfig).Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
+func withObserveOnlyTags() apiModifier {
+	return func(r *svcapitypes.API) {
+		meta.AddAnnotations(r, map[string]string{AnnotationKeyObserveOnlyTags: "true"})
+	}
+}
+
+func api(m ...apiModifier) *svcapitypes.API {
+	cr := &svcapitypes.API{}
+	meta.SetExternalName(cr, apiName)
+	cr.Status.AtProvider.APIID = &apiID
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func getApis(description, version *string) func(*svcsdk.GetApisInput) (*svcsdk.GetApisOutput, error) {
+	return func(_ *svcsdk.GetApisInput) (*svcsdk.GetApisOutput, error) {
+		return &svcsdk.GetApisOutput{Items: []*svcsdk.Api{{
+			ApiId:       &apiID,
+			Name:        &apiName,
+			Description: description,
+			Version:     version,
+		}}}, nil
+	}
+}
+
+func getAPI(description, version *string, tags map[string]string) func(*svcsdk.GetApiInput) (*svcsdk.GetApiOutput, error) {
+	return func(in *svcsdk.GetApiInput) (*svcsdk.GetApiOutput, error) {
+		if aws.StringValue(in.ApiId) != apiID {
+			return nil, errBoom
+		}
+		return &svcsdk.GetApiOutput{
+			ApiId:       &apiID,
+			Name:        &apiName,
+			Description: description,
+			Version:     version,
+			Tags:        awsgo.StringMap(tags),
+		}, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *svcapitypes.API
+		result managed.ExternalObservation
+	}
+
+	cases := map[string]struct {
+		client *mockClient
+		cr     *svcapitypes.API
+		want
+	}{
+		"UpToDate": {
+			client: &mockClient{MockGetApis: getApis(aws.String("desc"), aws.String("v1"))},
+			cr:     api(withDescription("desc"), withVersion("v1")),
+			want: want{
+				cr: api(withDescription("desc"), withVersion("v1")),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"DescriptionChanged": {
+			client: &mockClient{MockGetApis: getApis(aws.String("old"), aws.String("v1"))},
+			cr:     api(withDescription("new"), withVersion("v1")),
+			want: want{
+				cr: api(withDescription("new"), withVersion("v1")),
+				result: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"VersionChanged": {
+			client: &mockClient{MockGetApis: getApis(aws.String("desc"), aws.String("v1"))},
+			cr:     api(withDescription("desc"), withVersion("v2")),
+			want: want{
+				cr: api(withDescription("desc"), withVersion("v2")),
+				result: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"LateInitialized": {
+			client: &mockClient{MockGetApis: getApis(aws.String("desc"), aws.String("v1"))},
+			cr:     api(),
+			want: want{
+				cr: api(withDescription("desc"), withVersion("v1")),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr.Spec, tc.cr.Spec); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		input *svcsdk.UpdateApiInput
+		err   error
+	}
+
+	cases := map[string]struct {
+		err error
+		cr  *svcapitypes.API
+		want
+	}{
+		"UpToDate": {
+			cr:   api(withDescription("old"), withVersion("v1")),
+			want: want{},
+		},
+		"Successful": {
+			cr: api(withDescription("new"), withVersion("v2")),
+			want: want{
+				input: &svcsdk.UpdateApiInput{
+					ApiId:       &apiID,
+					Description: aws.String("new"),
+					Version:     aws.String("v2"),
+				},
+			},
+		},
+		"Failed": {
+			err: errBoom,
+			cr:  api(withDescription("new")),
+			want: want{
+				input: &svcsdk.UpdateApiInput{
+					ApiId:       &apiID,
+					Description: aws.String("new"),
+				},
+				err: errors.Wrap(errBoom, errUpdate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var input *svcsdk.UpdateApiInput
+			e := &external{client: &mockClient{
+				MockGetApi: getAPI(aws.String("old"), aws.String("v1"), nil),
+				MockUpdateApi: func(in *svcsdk.UpdateApiInput) (*svcsdk.UpdateApiOutput, error) {
+					input = in
+					return &svcsdk.UpdateApiOutput{}, tc.err
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.input, input); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTagDrift(t *testing.T) {
+	arn := "arn:aws:apigateway:us-east-1::/apis/" + apiID
+	withRegion := func(r *svcapitypes.API) { r.Spec.ForProvider.Region = "us-east-1" }
+
+	type want struct {
+		upToDate   bool
+		conditions []v1alpha1.Condition
+		tag        *svcsdk.TagResourceInput
+		untag      *svcsdk.UntagResourceInput
+	}
+
+	cases := map[string]struct {
+		kube     client.Client
+		cr       *svcapitypes.API
+		observed map[string]string
+		want
+	}{
+		"CorrectDrift": {
+			cr:       api(withRegion, withTags(map[string]string{"team": "cool"})),
+			observed: map[string]string{"team": "other", "console": "added"},
+			want: want{
+				upToDate:   false,
+				conditions: []v1alpha1.Condition{v1alpha1.Available()},
+				tag:        &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"team": "cool"})},
+				untag:      &svcsdk.UntagResourceInput{ResourceArn: &arn, TagKeys: awsgo.StringSlice([]string{"console", "team"})},
+			},
+		},
+		"DefaultTagsDrift": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:       api(withRegion, withProviderConfig("default"), withTags(map[string]string{"team": "cool"})),
+			observed: map[string]string{"team": "cool"},
+			want: want{
+				upToDate:   false,
+				conditions: []v1alpha1.Condition{v1alpha1.Available()},
+				tag:        &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"env": "prod"})},
+			},
+		},
+		"ObserveOnlyDrift": {
+			cr:       api(withRegion, withObserveOnlyTags(), withTags(map[string]string{"team": "cool"})),
+			observed: map[string]string{"team": "other", "console": "added"},
+			want: want{
+				upToDate:   true,
+				conditions: []v1alpha1.Condition{v1alpha1.Available(), tagsSynced([]string{"console", "team"})},
+			},
+		},
+		"ObserveOnlyInSync": {
+			cr:       api(withRegion, withObserveOnlyTags(), withTags(map[string]string{"team": "cool"})),
+			observed: map[string]string{"team": "cool"},
+			want: want{
+				upToDate:   true,
+				conditions: []v1alpha1.Condition{v1alpha1.Available(), tagsSynced(nil)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var tag *svcsdk.TagResourceInput
+			var untag *svcsdk.UntagResourceInput
+			e := &external{kube: tc.kube, client: &mockClient{
+				MockGetApis: func(*svcsdk.GetApisInput) (*svcsdk.GetApisOutput, error) {
+					return &svcsdk.GetApisOutput{Items: []*svcsdk.Api{{ApiId: &apiID, Name: &apiName, Tags: awsgo.StringMap(tc.observed)}}}, nil
+				},
+				MockGetApi: getAPI(nil, nil, tc.observed),
+				MockTagResource: func(in *svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error) {
+					tag = in
+					return &svcsdk.TagResourceOutput{}, nil
+				},
+				MockUntagResource: func(in *svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error) {
+					untag = in
+					return &svcsdk.UntagResourceOutput{}, nil
+				},
+			}}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.cr.Status.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("conditions: -want, +got:\n%s", diff)
+			}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("Update(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.tag, tag); diff != "" {
+				t.Errorf("TagResource(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.untag, untag); diff != "" {
+				t.Errorf("UntagResource(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPreCreate(t *testing.T) {
+	cases := map[string]struct {
+		kube client.Client
+		cr   *svcapitypes.API
+		want map[string]*string
+		err  error
+	}{
+		"DefaultTags": {
+			kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:   api(withProviderConfig("default"), withTags(map[string]string{"team": "cool"})),
+			want: awsgo.StringMap(map[string]string{"team": "cool", "env": "prod"}),
+		},
+		"NoProviderConfig": {
+			cr:   api(withTags(map[string]string{"team": "cool"})),
+			want: awsgo.StringMap(map[string]string{"team": "cool"}),
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   api(withProviderConfig("default")),
+			err:  errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube}
+			err := e.preCreate(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("preCreate(...): -want, +got:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, tc.cr.Spec.ForProvider.Tags); diff != "" {
+				t.Errorf("tags: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
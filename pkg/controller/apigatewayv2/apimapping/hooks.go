@@ -20,6 +20,7 @@ import (
 	"context"
 
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -31,20 +32,27 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	errRecreate = "cannot delete APIMapping of a recreated API in order to recreate it"
 )
 
 // SetupAPIMapping adds a controller that reconciles APIMapping.
-func SetupAPIMapping(mgr ctrl.Manager, l logging.Logger) error {
+func SetupAPIMapping(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.APIMappingGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.APIMapping{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.APIMappingGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.APIMappingKind)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.APIMapping) error {
@@ -55,9 +63,20 @@ func (*external) postObserve(_ context.Context, cr *svcapitypes.APIMapping, _ *s
 		return managed.ExternalObservation{}, err
 	}
 	cr.SetConditions(v1alpha1.Available())
+	if isAPIRecreated(cr) {
+		obs.ResourceUpToDate = false
+	}
 	return obs, nil
 }
 
+// isAPIRecreated returns true if the API that the supplied APIMapping maps to
+// is not the one it was created for, e.g. because the referenced API was
+// deleted and created again with a new ID.
+func isAPIRecreated(cr *svcapitypes.APIMapping) bool {
+	return cr.Spec.ForProvider.APIID != nil && cr.Status.AtProvider.APIID != nil &&
+		aws.StringValue(cr.Spec.ForProvider.APIID) != aws.StringValue(cr.Status.AtProvider.APIID)
+}
+
 func (*external) filterList(cr *svcapitypes.APIMapping, list *svcsdk.GetApiMappingsOutput) *svcsdk.GetApiMappingsOutput {
 	res := &svcsdk.GetApiMappingsOutput{}
 	for _, am := range list.Items {
@@ -81,8 +100,14 @@ func (e *external) postCreate(_ context.Context, cr *svcapitypes.APIMapping, res
 	return cre, nil
 }
 
-func (*external) preUpdate(context.Context, *svcapitypes.APIMapping) error {
-	return nil
+func (e *external) preUpdate(ctx context.Context, cr *svcapitypes.APIMapping) error {
+	if !isAPIRecreated(cr) {
+		return nil
+	}
+	// The mapping is deleted so that the next observation finds that it no
+	// longer exists and creates it again for the current API.
+	_, err := e.client.DeleteApiMappingWithContext(ctx, GenerateDeleteApiMappingInput(cr))
+	return errors.Wrap(resource.Ignore(IsNotFound, err), errRecreate)
 }
 
 func (*external) postUpdate(_ context.Context, _ *svcapitypes.APIMapping, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apimapping
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+var (
+	apiMappingID = "abc123"
+	domainName   = "example.org"
+	oldAPIID     = "old456"
+	newAPIID     = "new789"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockGetAPIMappings   func(*svcsdk.GetApiMappingsInput) (*svcsdk.GetApiMappingsOutput, error)
+	MockDeleteAPIMapping func(*svcsdk.DeleteApiMappingInput) (*svcsdk.DeleteApiMappingOutput, error)
+}
+
+func (m *mockClient) GetApiMappingsWithContext(_ context.Context, in *svcsdk.GetApiMappingsInput, _ ...request.Option) (*svcsdk.GetApiMappingsOutput, error) {
+	return m.MockGetAPIMappings(in)
+}
+
+func (m *mockClient) DeleteApiMappingWithContext(_ context.Context, in *svcsdk.DeleteApiMappingInput, _ ...request.Option) (*svcsdk.DeleteApiMappingOutput, error) {
+	return m.MockDeleteAPIMapping(in)
+}
+
+func apiMapping(apiID string) *svcapitypes.APIMapping {
+	cr := &svcapitypes.APIMapping{}
+	meta.SetExternalName(cr, apiMappingID)
+	cr.Spec.ForProvider.APIID = &apiID
+	cr.Spec.ForProvider.DomainName = &domainName
+	return cr
+}
+
+func getAPIMappings(apiID string) func(*svcsdk.GetApiMappingsInput) (*svcsdk.GetApiMappingsOutput, error) {
+	return func(_ *svcsdk.GetApiMappingsInput) (*svcsdk.GetApiMappingsOutput, error) {
+		return &svcsdk.GetApiMappingsOutput{Items: []*svcsdk.ApiMapping{{ApiId: &apiID, ApiMappingId: &apiMappingID}}}, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := map[string]struct {
+		client *mockClient
+		cr     *svcapitypes.APIMapping
+		want   managed.ExternalObservation
+	}{
+		"UpToDate": {
+			client: &mockClient{MockGetAPIMappings: getAPIMappings(newAPIID)},
+			cr:     apiMapping(newAPIID),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"APIRecreated": {
+			client: &mockClient{MockGetAPIMappings: getAPIMappings(oldAPIID)},
+			cr:     apiMapping(newAPIID),
+			want:   managed.ExternalObservation{ResourceExists: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		deleted *svcsdk.DeleteApiMappingInput
+		err     error
+	}
+
+	cases := map[string]struct {
+		cr        *svcapitypes.APIMapping
+		observed  string
+		deleteErr error
+		want
+	}{
+		"APIRecreated": {
+			cr:       apiMapping(newAPIID),
+			observed: oldAPIID,
+			want: want{
+				deleted: &svcsdk.DeleteApiMappingInput{ApiMappingId: &apiMappingID, DomainName: &domainName},
+			},
+		},
+		"APIUnchanged": {
+			cr:       apiMapping(newAPIID),
+			observed: newAPIID,
+		},
+		"DeleteFailed": {
+			cr:        apiMapping(newAPIID),
+			observed:  oldAPIID,
+			deleteErr: errBoom,
+			want: want{
+				deleted: &svcsdk.DeleteApiMappingInput{ApiMappingId: &apiMappingID, DomainName: &domainName},
+				err:     errors.Wrap(errors.Wrap(errBoom, errRecreate), "pre-update failed"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted *svcsdk.DeleteApiMappingInput
+			e := &external{client: &mockClient{
+				MockDeleteAPIMapping: func(in *svcsdk.DeleteApiMappingInput) (*svcsdk.DeleteApiMappingOutput, error) {
+					deleted = in
+					return &svcsdk.DeleteApiMappingOutput{}, tc.deleteErr
+				},
+			}}
+			tc.cr.Status.AtProvider.APIID = aws.String(tc.observed)
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,9 +18,22 @@ package authorizer
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	awsgo "github.com/aws/aws-sdk-go/aws"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -31,32 +44,432 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
+const (
+	// AnnotationKeyProbeJWTIssuer is the annotation that enables probing the
+	// OpenID Connect discovery endpoint of the issuer of a JWT authorizer.
+	AnnotationKeyProbeJWTIssuer = "apigatewayv2.aws.crossplane.io/probe-jwt-issuer"
+
+	// TypeJWTIssuerReachable indicates whether the issuer of a JWT authorizer
+	// could be reached.
+	TypeJWTIssuerReachable v1alpha1.ConditionType = "JWTIssuerReachable"
+
+	reasonIssuerReachable   v1alpha1.ConditionReason = "IssuerReachable"
+	reasonIssuerUnreachable v1alpha1.ConditionReason = "IssuerUnreachable"
+
+	discoveryPath = "/.well-known/openid-configuration"
+
+	errNewDiscoveryRequest = "cannot create OpenID Connect discovery request"
+	errDiscoveryRequest    = "cannot get OpenID Connect discovery document"
+	errDiscoveryStatus     = "unexpected OpenID Connect discovery response status %d"
+	errDiscoveryDocument   = "cannot decode OpenID Connect discovery document"
+	errDiscoveryNoJWKS     = "OpenID Connect discovery document has no jwks_uri"
+
+	errListRoutes         = "cannot list Routes"
+	errGetAuthorizer      = "cannot get Authorizer in AWS"
+	errUpdate             = "cannot update Authorizer in AWS"
+	errRecreate           = "cannot delete Authorizer in AWS in order to recreate it"
+	errReferencedByRoutes = "cannot delete Authorizer while it is referenced by Routes %v"
+
+	msgImmutableFieldsChanged    = "cannot change %s of an existing authorizer; set immutableFieldUpdatePolicy to Recreate, or delete and recreate it, to apply the change"
+	msgImmutableFieldsRecreating = "recreating the authorizer to change %s"
+
+	msgScopesNotJWT      = "Routes %v declare authorization scopes, which are only supported by JWT authorizers"
+	msgScopesNoIssuer    = "Routes %v declare authorization scopes, but the JWT configuration of the authorizer has no issuer or audience to validate the tokens that carry them"
+	msgScopesRouteNotJWT = "Routes %v declare authorization scopes, but their authorizationType is not JWT"
+)
+
+// TypeRouteScopesConsistent indicates whether the authorization scopes that
+// Routes referencing an authorizer declare can be enforced by it. It is only
+// set if such Routes declare scopes.
+const TypeRouteScopesConsistent v1alpha1.ConditionType = "RouteScopesConsistent"
+
+// Reasons of the RouteScopesConsistent condition.
+const (
+	ReasonRouteScopesConsistent   v1alpha1.ConditionReason = "RouteScopesConsistent"
+	ReasonRouteScopesInconsistent v1alpha1.ConditionReason = "RouteScopesInconsistent"
+)
+
+// TypeImmutableFieldsSynced indicates whether the fields of an authorizer
+// that cannot be updated match those of the authorizer in AWS.
+const TypeImmutableFieldsSynced v1alpha1.ConditionType = "ImmutableFieldsSynced"
+
+// Reasons of the ImmutableFieldsSynced condition.
+const (
+	ReasonImmutableFieldsUnchanged  v1alpha1.ConditionReason = "ImmutableFieldsUnchanged"
+	ReasonImmutableFieldsChanged    v1alpha1.ConditionReason = "ImmutableFieldsChanged"
+	ReasonImmutableFieldsRecreating v1alpha1.ConditionReason = "ImmutableFieldsRecreating"
+)
+
+// issuerClient is used to probe the issuers of JWT authorizers. The timeout
+// bounds how long an unreachable issuer may delay an observation.
+var issuerClient = &http.Client{Timeout: 5 * time.Second}
+
 // SetupAuthorizer adds a controller that reconciles Authorizer.
-func SetupAuthorizer(mgr ctrl.Manager, l logging.Logger) error {
+func SetupAuthorizer(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.AuthorizerGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.Authorizer{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.AuthorizerGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&routeGuardConnector{connector: &connector{kube: mgr.GetClient()}}, record, l.WithValues("controller", name), svcapitypes.AuthorizerKind)),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
+}
+
+// A routeGuardConnector produces ExternalClients that do not delete an
+// Authorizer while Routes still reference it, which AWS would reject.
+type routeGuardConnector struct {
+	connector *connector
+}
+
+func (c *routeGuardConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.connector.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &routeGuard{ExternalClient: ec, kube: c.connector.kube}, nil
+}
+
+type routeGuard struct {
+	managed.ExternalClient
+	kube client.Client
+}
+
+func (g *routeGuard) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*svcapitypes.Authorizer)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+	routes, err := referencingRoutes(ctx, g.kube, cr)
+	if err != nil {
+		return err
+	}
+	if len(routes) > 0 {
+		cr.SetConditions(v1alpha1.Deleting())
+		return errors.Errorf(errReferencedByRoutes, routes)
+	}
+	return g.ExternalClient.Delete(ctx, mg)
+}
+
+// referencingRoutes returns the names of the Routes that reference the
+// supplied Authorizer, either by reference or by its ID.
+func referencingRoutes(ctx context.Context, kube client.Client, cr *svcapitypes.Authorizer) ([]string, error) {
+	routes, err := listReferencingRoutes(ctx, kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, r := range routes {
+		names = append(names, r.GetName())
+	}
+	return names, nil
+}
+
+// listReferencingRoutes returns the Routes that reference the supplied
+// Authorizer, either by reference or by its ID.
+func listReferencingRoutes(ctx context.Context, kube client.Client, cr *svcapitypes.Authorizer) ([]svcapitypes.Route, error) {
+	l := &svcapitypes.RouteList{}
+	if err := kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListRoutes)
+	}
+	var routes []svcapitypes.Route
+	for _, r := range l.Items {
+		p := r.Spec.ForProvider
+		switch {
+		case p.AuthorizerIDRef != nil && p.AuthorizerIDRef.Name == cr.GetName():
+		case cr.Status.AtProvider.AuthorizerID != nil &&
+			aws.StringValue(p.AuthorizerID) == aws.StringValue(cr.Status.AtProvider.AuthorizerID) &&
+			aws.StringValue(p.APIID) == aws.StringValue(cr.Spec.ForProvider.APIID):
+		default:
+			continue
+		}
+		routes = append(routes, r)
+	}
+	return routes, nil
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.Authorizer) error {
 	return nil
 }
-func (*external) postObserve(_ context.Context, cr *svcapitypes.Authorizer, _ *svcsdk.GetAuthorizersOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+func (e *external) postObserve(ctx context.Context, cr *svcapitypes.Authorizer, resp *svcsdk.GetAuthorizersOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 	cr.SetConditions(v1alpha1.Available())
+	if resp != nil && len(resp.Items) > 0 {
+		observed := observedConfig(resp.Items[0])
+		cr.Status.AtProvider.ConfigHash = aws.String(configHash(observed))
+		p := &cr.Spec.ForProvider
+		changed := changedImmutableFields(p, observed.Type)
+		cr.SetConditions(immutableFieldsSynced(changed, isRecreatedOnImmutableFieldUpdate(p)))
+		if len(changed) > 0 && !isRecreatedOnImmutableFieldUpdate(p) {
+			// Changes to immutable fields are only reported, so they must
+			// not cause updates that AWS would reject.
+			p = p.DeepCopy()
+			p.AuthorizerType = observed.Type
+		}
+		obs.ResourceUpToDate = isUpToDate(p, observed)
+
+		routes, err := listReferencingRoutes(ctx, e.kube, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if c, ok := routeScopesConsistency(observed, routes); ok {
+			cr.SetConditions(c)
+		}
+	}
+	if cr.GetAnnotations()[AnnotationKeyProbeJWTIssuer] == "true" {
+		if issuer := jwtIssuer(cr); issuer != "" {
+			cr.SetConditions(issuerReachability(probeIssuer(ctx, issuerClient, issuer)))
+		}
+	}
 	return obs, nil
 }
 
+// The fields of AuthorizerParameters that UpdateAuthorizer can change are
+// authorizerCredentialsArn, authorizerPayloadFormatVersion,
+// authorizerResultTtlInSeconds, authorizerUri, enableSimpleResponses,
+// identitySource, identityValidationExpression and jwtConfiguration. The
+// authorizerType of an existing authorizer cannot be changed, because the
+// other fields that are valid depend on it; the authorizer must be recreated
+// instead. The apiId of an authorizer cannot be changed either, but a changed
+// apiId refers to another API, whose authorizers are observed instead.
+
+// changedImmutableFields returns the names of the fields of the supplied
+// parameters that cannot be updated, but differ from the supplied observed
+// authorizer type. Fields that are not specified are not compared.
+func changedImmutableFields(p *svcapitypes.AuthorizerParameters, observedType *string) []string {
+	if p.AuthorizerType != nil && aws.StringValue(p.AuthorizerType) != aws.StringValue(observedType) {
+		return []string{"authorizerType"}
+	}
+	return nil
+}
+
+// isRecreatedOnImmutableFieldUpdate returns true if an authorizer with the
+// supplied parameters is recreated when its immutable fields are changed.
+func isRecreatedOnImmutableFieldUpdate(p *svcapitypes.AuthorizerParameters) bool {
+	return p.ImmutableFieldUpdatePolicy != nil && *p.ImmutableFieldUpdatePolicy == svcapitypes.ImmutableFieldUpdatePolicyRecreate
+}
+
+// immutableFieldsSynced returns an ImmutableFieldsSynced condition reporting
+// the supplied changed immutable fields, if any, and whether the authorizer
+// is recreated to apply them.
+func immutableFieldsSynced(changed []string, recreate bool) v1alpha1.Condition {
+	c := v1alpha1.Condition{
+		Type:               TypeImmutableFieldsSynced,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutableFieldsUnchanged,
+	}
+	switch {
+	case len(changed) == 0:
+	case recreate:
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonImmutableFieldsRecreating
+		c.Message = fmt.Sprintf(msgImmutableFieldsRecreating, strings.Join(changed, ", "))
+	default:
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonImmutableFieldsChanged
+		c.Message = fmt.Sprintf(msgImmutableFieldsChanged, strings.Join(changed, ", "))
+	}
+	return c
+}
+
+// routeScopesConsistency returns a RouteScopesConsistent condition that reports
+// whether an authorizer with the supplied observed configuration can enforce
+// the authorization scopes that the supplied Routes referencing it declare. No
+// condition is returned if none of the Routes declare scopes.
+func routeScopesConsistency(observed authorizerConfig, routes []svcapitypes.Route) (v1alpha1.Condition, bool) {
+	var scoped, notJWT []string
+	for _, r := range routes {
+		if len(r.Spec.ForProvider.AuthorizationScopes) == 0 {
+			continue
+		}
+		scoped = append(scoped, r.GetName())
+		if aws.StringValue(r.Spec.ForProvider.AuthorizationType) != svcsdk.AuthorizationTypeJwt {
+			notJWT = append(notJWT, r.GetName())
+		}
+	}
+	if len(scoped) == 0 {
+		return v1alpha1.Condition{}, false
+	}
+	var msgs []string
+	switch {
+	case aws.StringValue(observed.Type) != svcsdk.AuthorizerTypeJwt:
+		msgs = append(msgs, fmt.Sprintf(msgScopesNotJWT, scoped))
+	case aws.StringValue(observed.JWTIssuer) == "" || len(observed.JWTAudience) == 0:
+		msgs = append(msgs, fmt.Sprintf(msgScopesNoIssuer, scoped))
+	}
+	if len(notJWT) > 0 {
+		msgs = append(msgs, fmt.Sprintf(msgScopesRouteNotJWT, notJWT))
+	}
+	c := v1alpha1.Condition{
+		Type:               TypeRouteScopesConsistent,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRouteScopesConsistent,
+	}
+	if len(msgs) > 0 {
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonRouteScopesInconsistent
+		c.Message = strings.Join(msgs, "; ")
+	}
+	return c, true
+}
+
+// An authorizerConfig is the configuration of an authorizer that is compared
+// to determine whether it is up to date.
+type authorizerConfig struct {
+	CredentialsARN               *string  `json:"credentialsArn,omitempty"`
+	PayloadFormatVersion         *string  `json:"payloadFormatVersion,omitempty"`
+	ResultTTLInSeconds           *int64   `json:"resultTtlInSeconds,omitempty"`
+	Type                         *string  `json:"type,omitempty"`
+	URI                          *string  `json:"uri,omitempty"`
+	EnableSimpleResponses        *bool    `json:"enableSimpleResponses,omitempty"`
+	IdentitySource               []string `json:"identitySource,omitempty"`
+	IdentityValidationExpression *string  `json:"identityValidationExpression,omitempty"`
+	JWTAudience                  []string `json:"jwtAudience,omitempty"`
+	JWTIssuer                    *string  `json:"jwtIssuer,omitempty"`
+}
+
+func desiredConfig(p *svcapitypes.AuthorizerParameters) authorizerConfig {
+	c := authorizerConfig{
+		CredentialsARN:               p.AuthorizerCredentialsARN,
+		PayloadFormatVersion:         p.AuthorizerPayloadFormatVersion,
+		ResultTTLInSeconds:           p.AuthorizerResultTtlInSeconds,
+		Type:                         p.AuthorizerType,
+		URI:                          p.AuthorizerURI,
+		EnableSimpleResponses:        p.EnableSimpleResponses,
+		IdentitySource:               awsgo.StringValueSlice(p.IDentitySource),
+		IdentityValidationExpression: p.IDentityValidationExpression,
+	}
+	if j := p.JWTConfiguration; j != nil {
+		c.JWTAudience = awsgo.StringValueSlice(j.Audience)
+		c.JWTIssuer = j.Issuer
+	}
+	return c
+}
+
+func observedConfig(a *svcsdk.Authorizer) authorizerConfig {
+	c := authorizerConfig{
+		CredentialsARN:               a.AuthorizerCredentialsArn,
+		PayloadFormatVersion:         a.AuthorizerPayloadFormatVersion,
+		ResultTTLInSeconds:           a.AuthorizerResultTtlInSeconds,
+		Type:                         a.AuthorizerType,
+		URI:                          a.AuthorizerUri,
+		EnableSimpleResponses:        a.EnableSimpleResponses,
+		IdentitySource:               awsgo.StringValueSlice(a.IdentitySource),
+		IdentityValidationExpression: a.IdentityValidationExpression,
+	}
+	if j := a.JwtConfiguration; j != nil {
+		c.JWTAudience = awsgo.StringValueSlice(j.Audience)
+		c.JWTIssuer = j.Issuer
+	}
+	return c
+}
+
+// configHash returns a hash of the supplied authorizer configuration.
+func configHash(c authorizerConfig) string {
+	// Marshalling a struct of strings, numbers and bools cannot fail.
+	b, _ := json.Marshal(c) // nolint:errcheck
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// isUpToDate returns whether the observed configuration of an authorizer
+// matches its desired parameters. Matching hashes mean that the whole
+// configuration is the same, so the configurations are only compared field by
+// field if the hashes differ. Parameters that are not specified are not
+// compared.
+func isUpToDate(p *svcapitypes.AuthorizerParameters, observed authorizerConfig) bool {
+	desired := desiredConfig(p)
+	if configHash(desired) == configHash(observed) {
+		return true
+	}
+	switch {
+	case desired.CredentialsARN != nil && aws.StringValue(desired.CredentialsARN) != aws.StringValue(observed.CredentialsARN):
+		return false
+	case desired.PayloadFormatVersion != nil && aws.StringValue(desired.PayloadFormatVersion) != aws.StringValue(observed.PayloadFormatVersion):
+		return false
+	case desired.ResultTTLInSeconds != nil && aws.Int64Value(desired.ResultTTLInSeconds) != aws.Int64Value(observed.ResultTTLInSeconds):
+		return false
+	case desired.Type != nil && aws.StringValue(desired.Type) != aws.StringValue(observed.Type):
+		return false
+	case desired.URI != nil && aws.StringValue(desired.URI) != aws.StringValue(observed.URI):
+		return false
+	case desired.EnableSimpleResponses != nil && awsgo.BoolValue(desired.EnableSimpleResponses) != awsgo.BoolValue(observed.EnableSimpleResponses):
+		return false
+	case desired.IdentitySource != nil && !cmp.Equal(desired.IdentitySource, observed.IdentitySource, cmpopts.EquateEmpty()):
+		return false
+	case desired.IdentityValidationExpression != nil && aws.StringValue(desired.IdentityValidationExpression) != aws.StringValue(observed.IdentityValidationExpression):
+		return false
+	case desired.JWTAudience != nil && !cmp.Equal(desired.JWTAudience, observed.JWTAudience, cmpopts.EquateEmpty()):
+		return false
+	case desired.JWTIssuer != nil && aws.StringValue(desired.JWTIssuer) != aws.StringValue(observed.JWTIssuer):
+		return false
+	}
+	return true
+}
+
+func jwtIssuer(cr *svcapitypes.Authorizer) string {
+	if cr.Spec.ForProvider.JWTConfiguration == nil {
+		return ""
+	}
+	return aws.StringValue(cr.Spec.ForProvider.JWTConfiguration.Issuer)
+}
+
+// probeIssuer returns an error if the OpenID Connect discovery document of the
+// supplied issuer cannot be retrieved.
+func probeIssuer(ctx context.Context, c *http.Client, issuer string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return errors.Wrap(err, errNewDiscoveryRequest)
+	}
+	rsp, err := c.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errDiscoveryRequest)
+	}
+	defer rsp.Body.Close() // nolint:errcheck
+	if rsp.StatusCode != http.StatusOK {
+		return errors.Errorf(errDiscoveryStatus, rsp.StatusCode)
+	}
+	doc := struct {
+		JWKSURI string `json:"jwks_uri"`
+	}{}
+	if err := json.NewDecoder(rsp.Body).Decode(&doc); err != nil {
+		return errors.Wrap(err, errDiscoveryDocument)
+	}
+	if doc.JWKSURI == "" {
+		return errors.New(errDiscoveryNoJWKS)
+	}
+	return nil
+}
+
+// issuerReachability returns a condition that reports the result of probing
+// the issuer of a JWT authorizer.
+func issuerReachability(err error) v1alpha1.Condition {
+	if err != nil {
+		return v1alpha1.Condition{
+			Type:               TypeJWTIssuerReachable,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonIssuerUnreachable,
+			Message:            err.Error(),
+		}
+	}
+	return v1alpha1.Condition{
+		Type:               TypeJWTIssuerReachable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonIssuerReachable,
+	}
+}
+
 func (*external) filterList(cr *svcapitypes.Authorizer, list *svcsdk.GetAuthorizersOutput) *svcsdk.GetAuthorizersOutput {
 	res := &svcsdk.GetAuthorizersOutput{}
 	for _, authorizer := range list.Items {
@@ -79,8 +492,53 @@ func (*external) preUpdate(context.Context, *svcapitypes.Authorizer) error {
 	return nil
 }
 
-func (*external) postUpdate(_ context.Context, _ *svcapitypes.Authorizer, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
-	return upd, err
+func (e *external) postUpdate(ctx context.Context, cr *svcapitypes.Authorizer, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	p := cr.Spec.ForProvider
+	o, err := e.client.GetAuthorizerWithContext(ctx, &svcsdk.GetAuthorizerInput{ApiId: p.APIID, AuthorizerId: cr.Status.AtProvider.AuthorizerID})
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetAuthorizer)
+	}
+	if len(changedImmutableFields(&p, o.AuthorizerType)) > 0 {
+		if isRecreatedOnImmutableFieldUpdate(&p) {
+			return upd, e.recreate(ctx, cr)
+		}
+		p.AuthorizerType = o.AuthorizerType
+	}
+	input := &svcsdk.UpdateAuthorizerInput{
+		ApiId:                          p.APIID,
+		AuthorizerId:                   cr.Status.AtProvider.AuthorizerID,
+		AuthorizerCredentialsArn:       p.AuthorizerCredentialsARN,
+		AuthorizerPayloadFormatVersion: p.AuthorizerPayloadFormatVersion,
+		AuthorizerResultTtlInSeconds:   p.AuthorizerResultTtlInSeconds,
+		AuthorizerType:                 p.AuthorizerType,
+		AuthorizerUri:                  p.AuthorizerURI,
+		EnableSimpleResponses:          p.EnableSimpleResponses,
+		IdentitySource:                 p.IDentitySource,
+		IdentityValidationExpression:   p.IDentityValidationExpression,
+	}
+	if j := p.JWTConfiguration; j != nil {
+		input.JwtConfiguration = &svcsdk.JWTConfiguration{Audience: j.Audience, Issuer: j.Issuer}
+	}
+	_, err = e.client.UpdateAuthorizerWithContext(ctx, input)
+	return upd, errors.Wrap(err, errUpdate)
+}
+
+// recreate deletes the supplied authorizer, unless Routes still reference it,
+// so that the next observation finds that it no longer exists and creates it
+// again with its desired immutable fields.
+func (e *external) recreate(ctx context.Context, cr *svcapitypes.Authorizer) error {
+	routes, err := referencingRoutes(ctx, e.kube, cr)
+	if err != nil {
+		return err
+	}
+	if len(routes) > 0 {
+		return errors.Wrap(errors.Errorf(errReferencedByRoutes, routes), errRecreate)
+	}
+	_, err = e.client.DeleteAuthorizerWithContext(ctx, &svcsdk.DeleteAuthorizerInput{ApiId: cr.Spec.ForProvider.APIID, AuthorizerId: cr.Status.AtProvider.AuthorizerID})
+	return errors.Wrap(err, errRecreate)
 }
 func lateInitialize(*svcapitypes.AuthorizerParameters, *svcsdk.GetAuthorizersOutput) error {
 	return nil
@@ -0,0 +1,579 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+var (
+	apiID        = "abc123"
+	authorizerID = "def456"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockDeleteAuthorizer func(*svcsdk.DeleteAuthorizerInput) (*svcsdk.DeleteAuthorizerOutput, error)
+	MockGetAuthorizer    func(*svcsdk.GetAuthorizerInput) (*svcsdk.GetAuthorizerOutput, error)
+	MockUpdateAuthorizer func(*svcsdk.UpdateAuthorizerInput) (*svcsdk.UpdateAuthorizerOutput, error)
+}
+
+func (m *mockClient) DeleteAuthorizerWithContext(_ context.Context, in *svcsdk.DeleteAuthorizerInput, _ ...request.Option) (*svcsdk.DeleteAuthorizerOutput, error) {
+	return m.MockDeleteAuthorizer(in)
+}
+
+func (m *mockClient) GetAuthorizerWithContext(_ context.Context, in *svcsdk.GetAuthorizerInput, _ ...request.Option) (*svcsdk.GetAuthorizerOutput, error) {
+	return m.MockGetAuthorizer(in)
+}
+
+func (m *mockClient) UpdateAuthorizerWithContext(_ context.Context, in *svcsdk.UpdateAuthorizerInput, _ ...request.Option) (*svcsdk.UpdateAuthorizerOutput, error) {
+	return m.MockUpdateAuthorizer(in)
+}
+
+func authorizer(issuer string, probe bool) *svcapitypes.Authorizer {
+	cr := &svcapitypes.Authorizer{}
+	cr.Spec.ForProvider.JWTConfiguration = &svcapitypes.JWTConfiguration{Issuer: &issuer}
+	if probe {
+		cr.SetAnnotations(map[string]string{AnnotationKeyProbeJWTIssuer: "true"})
+	}
+	return cr
+}
+
+func TestPostObserveIssuerReachability(t *testing.T) {
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != discoveryPath {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"issuer":"https://example.org","jwks_uri":"https://example.org/jwks"}`))
+	}))
+	defer issuer.Close()
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	type want struct {
+		status corev1.ConditionStatus
+		reason v1alpha1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		cr   *svcapitypes.Authorizer
+		want want
+	}{
+		"Reachable": {
+			cr:   authorizer(issuer.URL, true),
+			want: want{status: corev1.ConditionTrue, reason: reasonIssuerReachable},
+		},
+		"ReachableWithTrailingSlash": {
+			cr:   authorizer(issuer.URL+"/", true),
+			want: want{status: corev1.ConditionTrue, reason: reasonIssuerReachable},
+		},
+		"NoDiscoveryDocument": {
+			cr:   authorizer(notFound.URL, true),
+			want: want{status: corev1.ConditionFalse, reason: reasonIssuerUnreachable},
+		},
+		"Unreachable": {
+			cr:   authorizer(unreachable.URL, true),
+			want: want{status: corev1.ConditionFalse, reason: reasonIssuerUnreachable},
+		},
+		"ProbeNotEnabled": {
+			cr:   authorizer(unreachable.URL, false),
+			want: want{status: corev1.ConditionUnknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: &test.MockClient{MockList: listRoutes()}}
+			if _, err := e.postObserve(context.Background(), tc.cr, &svcsdk.GetAuthorizersOutput{}, managed.ExternalObservation{}, nil); err != nil {
+				t.Fatalf("postObserve(...): unexpected error: %s", err)
+			}
+			c := tc.cr.GetCondition(TypeJWTIssuerReachable)
+			if diff := cmp.Diff(tc.want.status, c.Status); diff != "" {
+				t.Errorf("status: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, c.Reason); diff != "" {
+				t.Errorf("reason: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func route(name string, m func(*svcapitypes.RouteParameters)) svcapitypes.Route {
+	r := svcapitypes.Route{}
+	r.SetName(name)
+	m(&r.Spec.ForProvider)
+	return r
+}
+
+func listRoutes(routes ...svcapitypes.Route) test.MockListFn {
+	return func(_ context.Context, obj runtime.Object, _ ...client.ListOption) error {
+		obj.(*svcapitypes.RouteList).Items = routes
+		return nil
+	}
+}
+
+func TestPostObserveRouteScopes(t *testing.T) {
+	jwt := func(issuer string, audience ...string) *svcsdk.Authorizer {
+		return &svcsdk.Authorizer{
+			AuthorizerType:   awsgo.String(svcsdk.AuthorizerTypeJwt),
+			JwtConfiguration: &svcsdk.JWTConfiguration{Issuer: awsgo.String(issuer), Audience: awsgo.StringSlice(audience)},
+		}
+	}
+	scoped := func(name, authType string) svcapitypes.Route {
+		return route(name, func(p *svcapitypes.RouteParameters) {
+			p.AuthorizerIDRef = &v1alpha1.Reference{Name: "cool-authorizer"}
+			p.AuthorizationType = awsgo.String(authType)
+			p.AuthorizationScopes = awsgo.StringSlice([]string{"read:things"})
+		})
+	}
+	unscoped := route("unscoped", func(p *svcapitypes.RouteParameters) {
+		p.AuthorizerIDRef = &v1alpha1.Reference{Name: "cool-authorizer"}
+		p.AuthorizationType = awsgo.String(svcsdk.AuthorizationTypeJwt)
+	})
+
+	cases := map[string]struct {
+		observed *svcsdk.Authorizer
+		routes   []svcapitypes.Route
+		want     v1alpha1.Condition
+	}{
+		"Consistent": {
+			observed: jwt("https://example.org", "cool"),
+			routes:   []svcapitypes.Route{scoped("scoped", svcsdk.AuthorizationTypeJwt), unscoped},
+			want: v1alpha1.Condition{
+				Type:   TypeRouteScopesConsistent,
+				Status: corev1.ConditionTrue,
+				Reason: ReasonRouteScopesConsistent,
+			},
+		},
+		"AuthorizerNotJWT": {
+			observed: &svcsdk.Authorizer{AuthorizerType: awsgo.String(svcsdk.AuthorizerTypeRequest)},
+			routes:   []svcapitypes.Route{scoped("scoped", svcsdk.AuthorizationTypeJwt)},
+			want: v1alpha1.Condition{
+				Type:    TypeRouteScopesConsistent,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonRouteScopesInconsistent,
+				Message: fmt.Sprintf(msgScopesNotJWT, []string{"scoped"}),
+			},
+		},
+		"NoAudience": {
+			observed: jwt("https://example.org"),
+			routes:   []svcapitypes.Route{scoped("scoped", svcsdk.AuthorizationTypeJwt)},
+			want: v1alpha1.Condition{
+				Type:    TypeRouteScopesConsistent,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonRouteScopesInconsistent,
+				Message: fmt.Sprintf(msgScopesNoIssuer, []string{"scoped"}),
+			},
+		},
+		"RouteNotJWT": {
+			observed: jwt("https://example.org", "cool"),
+			routes:   []svcapitypes.Route{scoped("scoped", svcsdk.AuthorizationTypeJwt), scoped("custom", svcsdk.AuthorizationTypeCustom)},
+			want: v1alpha1.Condition{
+				Type:    TypeRouteScopesConsistent,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonRouteScopesInconsistent,
+				Message: fmt.Sprintf(msgScopesRouteNotJWT, []string{"custom"}),
+			},
+		},
+		"NoScopes": {
+			observed: jwt("https://example.org"),
+			routes:   []svcapitypes.Route{unscoped},
+			want:     v1alpha1.Condition{Type: TypeRouteScopesConsistent, Status: corev1.ConditionUnknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &svcapitypes.Authorizer{}
+			cr.SetName("cool-authorizer")
+			e := &external{kube: &test.MockClient{MockList: listRoutes(tc.routes...)}}
+			if _, err := e.postObserve(context.Background(), cr, &svcsdk.GetAuthorizersOutput{Items: []*svcsdk.Authorizer{tc.observed}}, managed.ExternalObservation{ResourceExists: true}, nil); err != nil {
+				t.Fatalf("postObserve(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, cr.GetCondition(TypeRouteScopesConsistent), test.EquateConditions()); diff != "" {
+				t.Errorf("condition: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		deleted bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		kube client.Client
+		want want
+	}{
+		"NoReferencingRoutes": {
+			kube: &test.MockClient{MockList: listRoutes(
+				route("other-api", func(p *svcapitypes.RouteParameters) {
+					p.APIID = aws.String("other")
+					p.AuthorizerID = &authorizerID
+				}),
+			)},
+			want: want{deleted: true},
+		},
+		"ReferencedByID": {
+			kube: &test.MockClient{MockList: listRoutes(
+				route("cool-route", func(p *svcapitypes.RouteParameters) {
+					p.APIID = &apiID
+					p.AuthorizerID = &authorizerID
+				}),
+			)},
+			want: want{err: errors.Errorf(errReferencedByRoutes, []string{"cool-route"})},
+		},
+		"ReferencedByRef": {
+			kube: &test.MockClient{MockList: listRoutes(
+				route("cool-route", func(p *svcapitypes.RouteParameters) {
+					p.AuthorizerIDRef = &v1alpha1.Reference{Name: "cool-authorizer"}
+				}),
+			)},
+			want: want{err: errors.Errorf(errReferencedByRoutes, []string{"cool-route"})},
+		},
+		"ListFailed": {
+			kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want: want{err: errors.Wrap(errBoom, errListRoutes)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &svcapitypes.Authorizer{}
+			cr.SetName("cool-authorizer")
+			cr.Spec.ForProvider.APIID = &apiID
+			cr.Status.AtProvider.AuthorizerID = &authorizerID
+
+			deleted := false
+			g := &routeGuard{kube: tc.kube, ExternalClient: &external{client: &mockClient{
+				MockDeleteAuthorizer: func(_ *svcsdk.DeleteAuthorizerInput) (*svcsdk.DeleteAuthorizerOutput, error) {
+					deleted = true
+					return &svcsdk.DeleteAuthorizerOutput{}, nil
+				},
+			}}}
+			err := g.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("err: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("DeleteAuthorizer called: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func jwtAuthorizer(issuer string, audience ...string) *svcsdk.Authorizer {
+	return &svcsdk.Authorizer{
+		AuthorizerId:     &authorizerID,
+		AuthorizerType:   awsgo.String("JWT"),
+		IdentitySource:   awsgo.StringSlice([]string{"$request.header.Authorization"}),
+		JwtConfiguration: &svcsdk.JWTConfiguration{Issuer: &issuer, Audience: awsgo.StringSlice(audience)},
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	base := configHash(observedConfig(jwtAuthorizer("https://example.org", "cool")))
+
+	cases := map[string]struct {
+		a    *svcsdk.Authorizer
+		same bool
+	}{
+		"Unchanged": {
+			a:    jwtAuthorizer("https://example.org", "cool"),
+			same: true,
+		},
+		"IssuerChanged": {
+			a: jwtAuthorizer("https://example.com", "cool"),
+		},
+		"AudienceAdded": {
+			a: jwtAuthorizer("https://example.org", "cool", "cooler"),
+		},
+		"TTLSet": {
+			a: func() *svcsdk.Authorizer {
+				a := jwtAuthorizer("https://example.org", "cool")
+				a.AuthorizerResultTtlInSeconds = awsgo.Int64(300)
+				return a
+			}(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := configHash(observedConfig(tc.a))
+			if diff := cmp.Diff(tc.same, got == base); diff != "" {
+				t.Errorf("same hash: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostObserveUpToDate(t *testing.T) {
+	desired := func(issuer string, audience ...string) *svcapitypes.Authorizer {
+		cr := &svcapitypes.Authorizer{}
+		cr.Spec.ForProvider.AuthorizerType = awsgo.String("JWT")
+		cr.Spec.ForProvider.IDentitySource = awsgo.StringSlice([]string{"$request.header.Authorization"})
+		cr.Spec.ForProvider.JWTConfiguration = &svcapitypes.JWTConfiguration{Issuer: &issuer, Audience: awsgo.StringSlice(audience)}
+		return cr
+	}
+
+	cases := map[string]struct {
+		cr       *svcapitypes.Authorizer
+		observed *svcsdk.Authorizer
+		want     bool
+	}{
+		"UpToDate": {
+			cr:       desired("https://example.org", "cool"),
+			observed: jwtAuthorizer("https://example.org", "cool"),
+			want:     true,
+		},
+		"UnspecifiedFieldsIgnored": {
+			cr: desired("https://example.org", "cool"),
+			observed: func() *svcsdk.Authorizer {
+				a := jwtAuthorizer("https://example.org", "cool")
+				a.AuthorizerPayloadFormatVersion = awsgo.String("2.0")
+				return a
+			}(),
+			want: true,
+		},
+		"IssuerChanged": {
+			cr:       desired("https://example.com", "cool"),
+			observed: jwtAuthorizer("https://example.org", "cool"),
+		},
+		"AudienceChanged": {
+			cr:       desired("https://example.org", "cool", "cooler"),
+			observed: jwtAuthorizer("https://example.org", "cool"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: &test.MockClient{MockList: listRoutes()}}
+			obs, err := e.postObserve(context.Background(), tc.cr, &svcsdk.GetAuthorizersOutput{Items: []*svcsdk.Authorizer{tc.observed}}, managed.ExternalObservation{ResourceExists: true}, nil)
+			if err != nil {
+				t.Fatalf("postObserve(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, obs.ResourceUpToDate); diff != "" {
+				t.Errorf("ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(aws.String(configHash(observedConfig(tc.observed))), tc.cr.Status.AtProvider.ConfigHash); diff != "" {
+				t.Errorf("ConfigHash: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostObserveImmutableFields(t *testing.T) {
+	desired := func(typ string, policy *svcapitypes.ImmutableFieldUpdatePolicy) *svcapitypes.Authorizer {
+		cr := &svcapitypes.Authorizer{}
+		cr.Spec.ForProvider.AuthorizerType = awsgo.String(typ)
+		cr.Spec.ForProvider.IDentitySource = awsgo.StringSlice([]string{"$request.header.Authorization"})
+		cr.Spec.ForProvider.JWTConfiguration = &svcapitypes.JWTConfiguration{Issuer: awsgo.String("https://example.org")}
+		cr.Spec.ForProvider.ImmutableFieldUpdatePolicy = policy
+		return cr
+	}
+	recreate := svcapitypes.ImmutableFieldUpdatePolicyRecreate
+	ignore := svcapitypes.ImmutableFieldUpdatePolicyIgnore
+
+	type want struct {
+		upToDate bool
+		status   corev1.ConditionStatus
+		reason   v1alpha1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		cr   *svcapitypes.Authorizer
+		want want
+	}{
+		"Unchanged": {
+			cr:   desired("JWT", &recreate),
+			want: want{upToDate: true, status: corev1.ConditionTrue, reason: ReasonImmutableFieldsUnchanged},
+		},
+		"ChangedAndIgnoredByDefault": {
+			cr:   desired("REQUEST", nil),
+			want: want{upToDate: true, status: corev1.ConditionFalse, reason: ReasonImmutableFieldsChanged},
+		},
+		"ChangedAndIgnored": {
+			cr:   desired("REQUEST", &ignore),
+			want: want{upToDate: true, status: corev1.ConditionFalse, reason: ReasonImmutableFieldsChanged},
+		},
+		"ChangedAndRecreated": {
+			cr:   desired("REQUEST", &recreate),
+			want: want{status: corev1.ConditionFalse, reason: ReasonImmutableFieldsRecreating},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: &test.MockClient{MockList: listRoutes()}}
+			obs, err := e.postObserve(context.Background(), tc.cr, &svcsdk.GetAuthorizersOutput{Items: []*svcsdk.Authorizer{jwtAuthorizer("https://example.org")}}, managed.ExternalObservation{ResourceExists: true}, nil)
+			if err != nil {
+				t.Fatalf("postObserve(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, obs.ResourceUpToDate); diff != "" {
+				t.Errorf("ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+			c := tc.cr.GetCondition(TypeImmutableFieldsSynced)
+			if diff := cmp.Diff(tc.want.status, c.Status); diff != "" {
+				t.Errorf("status: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, c.Reason); diff != "" {
+				t.Errorf("reason: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostUpdate(t *testing.T) {
+	issuer := "https://example.org"
+	authorizer := func(typ string, policy *svcapitypes.ImmutableFieldUpdatePolicy) *svcapitypes.Authorizer {
+		cr := &svcapitypes.Authorizer{}
+		cr.Spec.ForProvider.APIID = &apiID
+		cr.Spec.ForProvider.AuthorizerType = awsgo.String(typ)
+		cr.Spec.ForProvider.JWTConfiguration = &svcapitypes.JWTConfiguration{Issuer: &issuer}
+		cr.Spec.ForProvider.ImmutableFieldUpdatePolicy = policy
+		cr.Status.AtProvider.AuthorizerID = &authorizerID
+		return cr
+	}
+	update := func(typ string) *svcsdk.UpdateAuthorizerInput {
+		return &svcsdk.UpdateAuthorizerInput{
+			ApiId:            &apiID,
+			AuthorizerId:     &authorizerID,
+			AuthorizerType:   awsgo.String(typ),
+			JwtConfiguration: &svcsdk.JWTConfiguration{Issuer: &issuer},
+		}
+	}
+	recreate := svcapitypes.ImmutableFieldUpdatePolicyRecreate
+	referenced := route("cool-route", func(p *svcapitypes.RouteParameters) {
+		p.APIID = &apiID
+		p.AuthorizerID = &authorizerID
+	})
+
+	type want struct {
+		update  *svcsdk.UpdateAuthorizerInput
+		deleted bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		cr        *svcapitypes.Authorizer
+		kube      client.Client
+		getErr    error
+		updateErr error
+		deleteErr error
+		want      want
+	}{
+		"MutableFieldsUpdated": {
+			cr:   authorizer("JWT", nil),
+			want: want{update: update("JWT")},
+		},
+		"MutableFieldsUpdatedWithRecreatePolicy": {
+			cr:   authorizer("JWT", &recreate),
+			want: want{update: update("JWT")},
+		},
+		"ImmutableFieldsIgnored": {
+			cr:   authorizer("REQUEST", nil),
+			want: want{update: update("JWT")},
+		},
+		"ImmutableFieldsRecreated": {
+			cr:   authorizer("REQUEST", &recreate),
+			kube: &test.MockClient{MockList: listRoutes()},
+			want: want{deleted: true},
+		},
+		"RecreateBlockedByRoutes": {
+			cr:   authorizer("REQUEST", &recreate),
+			kube: &test.MockClient{MockList: listRoutes(referenced)},
+			want: want{err: errors.Wrap(errors.Errorf(errReferencedByRoutes, []string{"cool-route"}), errRecreate)},
+		},
+		"RecreateFailed": {
+			cr:        authorizer("REQUEST", &recreate),
+			kube:      &test.MockClient{MockList: listRoutes()},
+			deleteErr: errBoom,
+			want:      want{deleted: true, err: errors.Wrap(errBoom, errRecreate)},
+		},
+		"GetFailed": {
+			cr:     authorizer("JWT", nil),
+			getErr: errBoom,
+			want:   want{err: errors.Wrap(errBoom, errGetAuthorizer)},
+		},
+		"UpdateFailed": {
+			cr:        authorizer("JWT", nil),
+			updateErr: errBoom,
+			want:      want{update: update("JWT"), err: errors.Wrap(errBoom, errUpdate)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *svcsdk.UpdateAuthorizerInput
+			deleted := false
+			e := &external{kube: tc.kube, client: &mockClient{
+				MockGetAuthorizer: func(_ *svcsdk.GetAuthorizerInput) (*svcsdk.GetAuthorizerOutput, error) {
+					return &svcsdk.GetAuthorizerOutput{AuthorizerId: &authorizerID, AuthorizerType: awsgo.String("JWT")}, tc.getErr
+				},
+				MockUpdateAuthorizer: func(in *svcsdk.UpdateAuthorizerInput) (*svcsdk.UpdateAuthorizerOutput, error) {
+					got = in
+					return &svcsdk.UpdateAuthorizerOutput{}, tc.updateErr
+				},
+				MockDeleteAuthorizer: func(_ *svcsdk.DeleteAuthorizerInput) (*svcsdk.DeleteAuthorizerOutput, error) {
+					deleted = true
+					return &svcsdk.DeleteAuthorizerOutput{}, tc.deleteErr
+				},
+			}}
+			_, err := e.postUpdate(context.Background(), tc.cr, managed.ExternalUpdate{}, nil)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("postUpdate(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.update, got); diff != "" {
+				t.Errorf("UpdateAuthorizerInput: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("deleted: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -30,20 +30,23 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 // SetupDeployment adds a controller that reconciles Deployment.
-func SetupDeployment(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDeployment(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.DeploymentGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.Deployment{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.DeploymentGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.DeploymentKind)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.Deployment) error {
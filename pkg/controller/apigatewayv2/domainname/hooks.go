@@ -18,8 +18,13 @@ package domainname
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -31,32 +36,77 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	errTag   = "cannot tag DomainName in AWS"
+	errUntag = "cannot untag DomainName in AWS"
 )
 
 // SetupDomainName adds a controller that reconciles DomainName.
-func SetupDomainName(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDomainName(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.DomainNameGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.DomainName{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.DomainNameGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.DomainNameKind)),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.DomainName) error {
 	return nil
 }
-func (*external) postObserve(_ context.Context, cr *svcapitypes.DomainName, _ *svcsdk.GetDomainNamesOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+func (e *external) postObserve(ctx context.Context, cr *svcapitypes.DomainName, resp *svcsdk.GetDomainNamesOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 	cr.SetConditions(v1alpha1.Available())
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	add, remove := aws.DiffTags(stringMap(tags), stringMap(resp.Items[0].Tags))
+	obs.ResourceUpToDate = len(add) == 0 && len(remove) == 0
 	return obs, nil
 }
 
+// desiredTags returns the tags of the supplied domain name merged with the
+// default tags of its ProviderConfig. Default tags are merged at request time
+// rather than written to the spec, so that changes to them apply to existing
+// domain names.
+func (e *external) desiredTags(ctx context.Context, cr *svcapitypes.DomainName) (map[string]*string, error) {
+	defaults, err := aws.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	return aws.MergeTagPtrs(cr.Spec.ForProvider.Tags, defaults), nil
+}
+
+func stringMap(in map[string]*string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = aws.StringValue(v)
+	}
+	return out
+}
+
+// domainNameARN returns the ARN of the domain name with the supplied name,
+// which is required to tag it.
+func domainNameARN(region, name string) string {
+	partition := "aws"
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+	return fmt.Sprintf("arn:%s:apigateway:%s::/domainnames/%s", partition, region, name)
+}
+
 func (*external) filterList(cr *svcapitypes.DomainName, list *svcsdk.GetDomainNamesOutput) *svcsdk.GetDomainNamesOutput {
 	res := &svcsdk.GetDomainNamesOutput{}
 	for _, dn := range list.Items {
@@ -67,7 +117,15 @@ func (*external) filterList(cr *svcapitypes.DomainName, list *svcsdk.GetDomainNa
 	return res
 }
 
-func (*external) preCreate(context.Context, *svcapitypes.DomainName) error {
+// preCreate adds the default tags of the ProviderConfig to the tags the domain
+// name is created with. The managed reconciler does not persist the spec of
+// the domain name after creating it, so they are not written to the spec.
+func (e *external) preCreate(ctx context.Context, cr *svcapitypes.DomainName) error {
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return err
+	}
+	cr.Spec.ForProvider.Tags = tags
 	return nil
 }
 
@@ -79,9 +137,38 @@ func (*external) preUpdate(context.Context, *svcapitypes.DomainName) error {
 	return nil
 }
 
-func (*external) postUpdate(_ context.Context, _ *svcapitypes.DomainName, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
-	return upd, err
+func (e *external) postUpdate(ctx context.Context, cr *svcapitypes.DomainName, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	resp, err := e.client.GetDomainNamesWithContext(ctx, GenerateGetDomainNamesInput(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribe)
+	}
+	resp = e.filterList(cr, resp)
+	if len(resp.Items) == 0 {
+		return upd, nil
+	}
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	add, remove := aws.DiffTags(stringMap(tags), stringMap(resp.Items[0].Tags))
+	arn := awsgo.String(domainNameARN(cr.Spec.ForProvider.Region, meta.GetExternalName(cr)))
+	if len(remove) != 0 {
+		sort.Strings(remove)
+		if _, err := e.client.UntagResourceWithContext(ctx, &svcsdk.UntagResourceInput{ResourceArn: arn, TagKeys: awsgo.StringSlice(remove)}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUntag)
+		}
+	}
+	if len(add) != 0 {
+		if _, err := e.client.TagResourceWithContext(ctx, &svcsdk.TagResourceInput{ResourceArn: arn, Tags: awsgo.StringMap(add)}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTag)
+		}
+	}
+	return upd, nil
 }
+
 func lateInitialize(*svcapitypes.DomainNameParameters, *svcsdk.GetDomainNamesOutput) error {
 	return nil
 }
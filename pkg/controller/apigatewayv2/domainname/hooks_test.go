@@ -0,0 +1,284 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainname
+
+import (
+	"context"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
+)
+
+var (
+	domainName = "api.example.com"
+	region     = "us-east-1"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockGetDomainNames func(*svcsdk.GetDomainNamesInput) (*svcsdk.GetDomainNamesOutput, error)
+	MockTagResource    func(*svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error)
+	MockUntagResource  func(*svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error)
+}
+
+func (m *mockClient) GetDomainNamesWithContext(_ context.Context, in *svcsdk.GetDomainNamesInput, _ ...request.Option) (*svcsdk.GetDomainNamesOutput, error) {
+	return m.MockGetDomainNames(in)
+}
+
+func (m *mockClient) TagResourceWithContext(_ context.Context, in *svcsdk.TagResourceInput, _ ...request.Option) (*svcsdk.TagResourceOutput, error) {
+	return m.MockTagResource(in)
+}
+
+func (m *mockClient) UntagResourceWithContext(_ context.Context, in *svcsdk.UntagResourceInput, _ ...request.Option) (*svcsdk.UntagResourceOutput, error) {
+	return m.MockUntagResource(in)
+}
+
+func domain(tags map[string]string) *svcapitypes.DomainName {
+	cr := &svcapitypes.DomainName{}
+	meta.SetExternalName(cr, domainName)
+	cr.Spec.ForProvider.Region = region
+	if tags != nil {
+		cr.Spec.ForProvider.Tags = awsgo.StringMap(tags)
+	}
+	return cr
+}
+
+// withProviderConfig returns the supplied domain name referencing the supplied
+// ProviderConfig.
+func withProviderConfig(cr *svcapitypes.DomainName, name string) *svcapitypes.DomainName {
+	cr.SetProviderConfigReference(&runtimev1alpha1.Reference{Name: name})
+	return cr
+}
+
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*apisv1beta1.ProviderConfig).Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
+func getDomainNames(tags map[string]string) func(*svcsdk.GetDomainNamesInput) (*svcsdk.GetDomainNamesOutput, error) {
+	return func(_ *svcsdk.GetDomainNamesInput) (*svcsdk.GetDomainNamesOutput, error) {
+		dn := &svcsdk.DomainName{DomainName: &domainName}
+		if tags != nil {
+			dn.Tags = awsgo.StringMap(tags)
+		}
+		return &svcsdk.GetDomainNamesOutput{Items: []*svcsdk.DomainName{dn}}, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		upToDate bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		kube     client.Client
+		cr       *svcapitypes.DomainName
+		observed map[string]string
+		want     want
+	}{
+		"UpToDate": {
+			cr:       domain(map[string]string{"team": "cool"}),
+			observed: map[string]string{"team": "cool"},
+			want:     want{upToDate: true},
+		},
+		"TagChanged": {
+			cr:       domain(map[string]string{"team": "cool"}),
+			observed: map[string]string{"team": "uncool"},
+		},
+		"DefaultTagsUpToDate": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:       withProviderConfig(domain(map[string]string{"team": "cool"}), "default"),
+			observed: map[string]string{"team": "cool", "env": "prod"},
+			want:     want{upToDate: true},
+		},
+		"DefaultTagsChanged": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"env": "prod"})},
+			cr:       withProviderConfig(domain(nil), "default"),
+			observed: map[string]string{"env": "dev"},
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(domain(nil), "default"),
+			want: want{err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: &mockClient{MockGetDomainNames: getDomainNames(tc.observed)}}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	arn := "arn:aws:apigateway:us-east-1::/domainnames/" + domainName
+
+	type want struct {
+		tag   *svcsdk.TagResourceInput
+		untag *svcsdk.UntagResourceInput
+		err   error
+	}
+
+	cases := map[string]struct {
+		kube     client.Client
+		cr       *svcapitypes.DomainName
+		observed map[string]string
+		tagErr   error
+		untagErr error
+		want     want
+	}{
+		"Unchanged": {
+			cr:       domain(map[string]string{"team": "cool"}),
+			observed: map[string]string{"team": "cool"},
+		},
+		"AddAndRemoveTags": {
+			cr:       domain(map[string]string{"team": "cooler"}),
+			observed: map[string]string{"team": "cool", "b": "x", "a": "y"},
+			want: want{
+				untag: &svcsdk.UntagResourceInput{ResourceArn: &arn, TagKeys: awsgo.StringSlice([]string{"a", "b", "team"})},
+				tag:   &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"team": "cooler"})},
+			},
+		},
+		"AddDefaultTag": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:       withProviderConfig(domain(map[string]string{"team": "cool"}), "default"),
+			observed: map[string]string{"team": "cool"},
+			want: want{
+				tag: &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"env": "prod"})},
+			},
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(domain(nil), "default"),
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+			},
+		},
+		"TagFailed": {
+			cr:     domain(map[string]string{"team": "cool"}),
+			tagErr: errBoom,
+			want: want{
+				tag: &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"team": "cool"})},
+				err: errors.Wrap(errBoom, errTag),
+			},
+		},
+		"UntagFailed": {
+			cr:       domain(nil),
+			observed: map[string]string{"team": "cool"},
+			untagErr: errBoom,
+			want: want{
+				untag: &svcsdk.UntagResourceInput{ResourceArn: &arn, TagKeys: awsgo.StringSlice([]string{"team"})},
+				err:   errors.Wrap(errBoom, errUntag),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var tag *svcsdk.TagResourceInput
+			var untag *svcsdk.UntagResourceInput
+			e := &external{kube: tc.kube, client: &mockClient{
+				MockGetDomainNames: getDomainNames(tc.observed),
+				MockTagResource: func(in *svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error) {
+					tag = in
+					return &svcsdk.TagResourceOutput{}, tc.tagErr
+				},
+				MockUntagResource: func(in *svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error) {
+					untag = in
+					return &svcsdk.UntagResourceOutput{}, tc.untagErr
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.tag, tag); diff != "" {
+				t.Errorf("tag: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.untag, untag); diff != "" {
+				t.Errorf("untag: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPreCreate(t *testing.T) {
+	cases := map[string]struct {
+		kube client.Client
+		cr   *svcapitypes.DomainName
+		want map[string]*string
+		err  error
+	}{
+		"DefaultTags": {
+			kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:   withProviderConfig(domain(map[string]string{"team": "cool"}), "default"),
+			want: awsgo.StringMap(map[string]string{"team": "cool", "env": "prod"}),
+		},
+		"NoProviderConfig": {
+			cr:   domain(map[string]string{"team": "cool"}),
+			want: awsgo.StringMap(map[string]string{"team": "cool"}),
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(domain(nil), "default"),
+			err:  errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube}
+			err := e.preCreate(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("preCreate(...): -want, +got:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, tc.cr.Spec.ForProvider.Tags); diff != "" {
+				t.Errorf("tags: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,7 +19,10 @@ package integration
 import (
 	"context"
 
+	awsgo "github.com/aws/aws-sdk-go/aws"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -31,30 +34,45 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	// minTimeoutInMillis and maxTimeoutInMillis are the bounds AWS enforces
+	// on the integration timeout.
+	minTimeoutInMillis = 50
+	maxTimeoutInMillis = 30000
+
+	errGet            = "cannot get Integration in AWS"
+	errUpdate         = "cannot update Integration in AWS"
+	errInvalidTimeout = "timeoutInMillis must be between %d and %d, got %d"
 )
 
 // SetupIntegration adds a controller that reconciles Integration.
-func SetupIntegration(mgr ctrl.Manager, l logging.Logger) error {
+func SetupIntegration(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.IntegrationGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.Integration{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.IntegrationGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.IntegrationKind)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.Integration) error {
 	return nil
 }
-func (*external) postObserve(_ context.Context, cr *svcapitypes.Integration, _ *svcsdk.GetIntegrationsOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+func (*external) postObserve(_ context.Context, cr *svcapitypes.Integration, resp *svcsdk.GetIntegrationsOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 	cr.SetConditions(v1alpha1.Available())
+	obs.ResourceUpToDate = isUpToDate(cr, resp)
 	return obs, nil
 }
 
@@ -69,8 +87,8 @@ func (*external) filterList(cr *svcapitypes.Integration, list *svcsdk.GetIntegra
 	return res
 }
 
-func (*external) preCreate(context.Context, *svcapitypes.Integration) error {
-	return nil
+func (*external) preCreate(_ context.Context, cr *svcapitypes.Integration) error {
+	return validateTimeout(cr.Spec.ForProvider.TimeoutInMillis)
 }
 
 func (e *external) postCreate(_ context.Context, cr *svcapitypes.Integration, resp *svcsdk.CreateIntegrationOutput, cre managed.ExternalCreation, err error) (managed.ExternalCreation, error) {
@@ -82,17 +100,127 @@ func (e *external) postCreate(_ context.Context, cr *svcapitypes.Integration, re
 	return cre, nil
 }
 
-func (*external) preUpdate(context.Context, *svcapitypes.Integration) error {
-	return nil
+func (*external) preUpdate(_ context.Context, cr *svcapitypes.Integration) error {
+	return validateTimeout(cr.Spec.ForProvider.TimeoutInMillis)
+}
+
+func (e *external) postUpdate(ctx context.Context, cr *svcapitypes.Integration, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	o, err := e.client.GetIntegrationWithContext(ctx, &svcsdk.GetIntegrationInput{
+		ApiId:         cr.Spec.ForProvider.APIID,
+		IntegrationId: aws.String(meta.GetExternalName(cr)),
+	})
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGet)
+	}
+	_, err = e.client.UpdateIntegrationWithContext(ctx, generateUpdateIntegrationInput(cr, o))
+	return upd, errors.Wrap(err, errUpdate)
 }
 
-func (*external) postUpdate(_ context.Context, _ *svcapitypes.Integration, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
-	return upd, err
+func lateInitialize(cr *svcapitypes.IntegrationParameters, resp *svcsdk.GetIntegrationsOutput) error {
+	if len(resp.Items) == 0 {
+		return nil
+	}
+	i := resp.Items[0]
+	cr.IntegrationMethod = aws.LateInitializeStringPtr(cr.IntegrationMethod, i.IntegrationMethod)
+	cr.TimeoutInMillis = aws.LateInitializeInt64Ptr(cr.TimeoutInMillis, i.TimeoutInMillis)
+	if cr.TLSConfig == nil && i.TlsConfig != nil {
+		cr.TLSConfig = &svcapitypes.TLSConfigInput{ServerNameToVerify: i.TlsConfig.ServerNameToVerify}
+	}
+	return nil
 }
-func lateInitialize(*svcapitypes.IntegrationParameters, *svcsdk.GetIntegrationsOutput) error {
+
+// validateTimeout returns an error if the supplied timeout is outside of the
+// bounds AWS allows.
+func validateTimeout(t *int64) error {
+	if t == nil {
+		return nil
+	}
+	if *t < minTimeoutInMillis || *t > maxTimeoutInMillis {
+		return errors.Errorf(errInvalidTimeout, minTimeoutInMillis, maxTimeoutInMillis, *t)
+	}
 	return nil
 }
 
+// isUpToDate returns whether the method, timeout, TLS configuration, request
+// parameters and request templates of the integration are in sync with the
+// observed integration.
+func isUpToDate(cr *svcapitypes.Integration, resp *svcsdk.GetIntegrationsOutput) bool {
+	if len(resp.Items) == 0 {
+		return true
+	}
+	i := resp.Items[0]
+	p := cr.Spec.ForProvider
+	if aws.StringValue(p.IntegrationMethod) != aws.StringValue(i.IntegrationMethod) {
+		return false
+	}
+	if aws.Int64Value(p.TimeoutInMillis) != aws.Int64Value(i.TimeoutInMillis) {
+		return false
+	}
+	if !cmp.Equal(stringMap(p.RequestParameters), stringMap(i.RequestParameters)) ||
+		!cmp.Equal(stringMap(p.RequestTemplates), stringMap(i.RequestTemplates)) {
+		return false
+	}
+	var want, got string
+	if p.TLSConfig != nil {
+		want = aws.StringValue(p.TLSConfig.ServerNameToVerify)
+	}
+	if i.TlsConfig != nil {
+		got = aws.StringValue(i.TlsConfig.ServerNameToVerify)
+	}
+	return want == got
+}
+
+// stringMap returns the supplied map of string pointers as a map of strings.
+// Nil and empty maps both mean that there are no entries.
+func stringMap(in map[string]*string) map[string]string {
+	out := map[string]string{}
+	for k, v := range in {
+		out[k] = aws.StringValue(v)
+	}
+	return out
+}
+
+// diffStringMap returns the entries that must be sent to update the observed
+// request parameters or templates to the desired ones. They are merged into
+// the existing ones by UpdateIntegration, so entries that should be removed
+// are sent with an empty value. It returns nil if there is nothing to update.
+func diffStringMap(desired, observed map[string]*string) map[string]*string {
+	want, got := stringMap(desired), stringMap(observed)
+	diff := map[string]*string{}
+	for k, v := range want {
+		if gv, ok := got[k]; !ok || gv != v {
+			diff[k] = awsgo.String(v)
+		}
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			diff[k] = awsgo.String("")
+		}
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+	return diff
+}
+
+func generateUpdateIntegrationInput(cr *svcapitypes.Integration, o *svcsdk.GetIntegrationOutput) *svcsdk.UpdateIntegrationInput {
+	u := &svcsdk.UpdateIntegrationInput{
+		ApiId:             cr.Spec.ForProvider.APIID,
+		IntegrationId:     aws.String(meta.GetExternalName(cr)),
+		IntegrationMethod: cr.Spec.ForProvider.IntegrationMethod,
+		TimeoutInMillis:   cr.Spec.ForProvider.TimeoutInMillis,
+		RequestParameters: diffStringMap(cr.Spec.ForProvider.RequestParameters, o.RequestParameters),
+		RequestTemplates:  diffStringMap(cr.Spec.ForProvider.RequestTemplates, o.RequestTemplates),
+	}
+	if cr.Spec.ForProvider.TLSConfig != nil {
+		u.TlsConfig = &svcsdk.TlsConfigInput{ServerNameToVerify: cr.Spec.ForProvider.TLSConfig.ServerNameToVerify}
+	}
+	return u
+}
+
 func preGenerateGetIntegrationsInput(_ *svcapitypes.Integration, obj *svcsdk.GetIntegrationsInput) *svcsdk.GetIntegrationsInput {
 	return obj
 }
@@ -0,0 +1,400 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+var (
+	apiID         = "abc123"
+	integrationID = "def456"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockGetIntegrations   func(*svcsdk.GetIntegrationsInput) (*svcsdk.GetIntegrationsOutput, error)
+	MockGetIntegration    func(*svcsdk.GetIntegrationInput) (*svcsdk.GetIntegrationOutput, error)
+	MockCreateIntegration func(*svcsdk.CreateIntegrationInput) (*svcsdk.CreateIntegrationOutput, error)
+	MockUpdateIntegration func(*svcsdk.UpdateIntegrationInput) (*svcsdk.UpdateIntegrationOutput, error)
+}
+
+func (m *mockClient) GetIntegrationsWithContext(_ context.Context, in *svcsdk.GetIntegrationsInput, _ ...request.Option) (*svcsdk.GetIntegrationsOutput, error) {
+	return m.MockGetIntegrations(in)
+}
+
+func (m *mockClient) GetIntegrationWithContext(_ context.Context, in *svcsdk.GetIntegrationInput, _ ...request.Option) (*svcsdk.GetIntegrationOutput, error) {
+	return m.MockGetIntegration(in)
+}
+
+func (m *mockClient) CreateIntegrationWithContext(_ context.Context, in *svcsdk.CreateIntegrationInput, _ ...request.Option) (*svcsdk.CreateIntegrationOutput, error) {
+	return m.MockCreateIntegration(in)
+}
+
+func (m *mockClient) UpdateIntegrationWithContext(_ context.Context, in *svcsdk.UpdateIntegrationInput, _ ...request.Option) (*svcsdk.UpdateIntegrationOutput, error) {
+	return m.MockUpdateIntegration(in)
+}
+
+type integrationModifier func(*svcapitypes.Integration)
+
+func withTimeout(t int64) integrationModifier {
+	return func(r *svcapitypes.Integration) { r.Spec.ForProvider.TimeoutInMillis = &t }
+}
+
+func withMethod(m string) integrationModifier {
+	return func(r *svcapitypes.Integration) { r.Spec.ForProvider.IntegrationMethod = &m }
+}
+
+func withServerName(n string) integrationModifier {
+	return func(r *svcapitypes.Integration) {
+		r.Spec.ForProvider.TLSConfig = &svcapitypes.TLSConfigInput{ServerNameToVerify: &n}
+	}
+}
+
+func integration(m ...integrationModifier) *svcapitypes.Integration {
+	cr := &svcapitypes.Integration{}
+	meta.SetExternalName(cr, integrationID)
+	cr.Spec.ForProvider.APIID = &apiID
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func getIntegrations(i svcsdk.Integration) func(*svcsdk.GetIntegrationsInput) (*svcsdk.GetIntegrationsOutput, error) {
+	i.IntegrationId = &integrationID
+	return func(_ *svcsdk.GetIntegrationsInput) (*svcsdk.GetIntegrationsOutput, error) {
+		return &svcsdk.GetIntegrationsOutput{Items: []*svcsdk.Integration{&i}}, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *svcapitypes.Integration
+		result managed.ExternalObservation
+	}
+
+	cases := map[string]struct {
+		client *mockClient
+		cr     *svcapitypes.Integration
+		want
+	}{
+		"UpToDate": {
+			client: &mockClient{MockGetIntegrations: getIntegrations(svcsdk.Integration{
+				IntegrationMethod: aws.String("POST"),
+				TimeoutInMillis:   aws.Int64(5000),
+				TlsConfig:         &svcsdk.TlsConfig{ServerNameToVerify: aws.String("example.org")},
+			})},
+			cr: integration(withMethod("POST"), withTimeout(5000), withServerName("example.org")),
+			want: want{
+				cr: integration(withMethod("POST"), withTimeout(5000), withServerName("example.org")),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"TimeoutChanged": {
+			client: &mockClient{MockGetIntegrations: getIntegrations(svcsdk.Integration{
+				IntegrationMethod: aws.String("POST"),
+				TimeoutInMillis:   aws.Int64(30000),
+			})},
+			cr: integration(withMethod("POST"), withTimeout(5000)),
+			want: want{
+				cr: integration(withMethod("POST"), withTimeout(5000)),
+				result: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"ServerNameChanged": {
+			client: &mockClient{MockGetIntegrations: getIntegrations(svcsdk.Integration{
+				IntegrationMethod: aws.String("POST"),
+				TimeoutInMillis:   aws.Int64(5000),
+				TlsConfig:         &svcsdk.TlsConfig{ServerNameToVerify: aws.String("example.org")},
+			})},
+			cr: integration(withMethod("POST"), withTimeout(5000), withServerName("example.com")),
+			want: want{
+				cr: integration(withMethod("POST"), withTimeout(5000), withServerName("example.com")),
+				result: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"LateInitialized": {
+			client: &mockClient{MockGetIntegrations: getIntegrations(svcsdk.Integration{
+				IntegrationMethod: aws.String("POST"),
+				TimeoutInMillis:   aws.Int64(30000),
+			})},
+			cr: integration(),
+			want: want{
+				cr: integration(withMethod("POST"), withTimeout(30000)),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr.Spec, tc.cr.Spec); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		cr   *svcapitypes.Integration
+		want error
+	}{
+		"ValidTimeout": {
+			cr: integration(withTimeout(50)),
+		},
+		"TimeoutTooShort": {
+			cr:   integration(withTimeout(49)),
+			want: errors.Wrap(errors.Errorf(errInvalidTimeout, minTimeoutInMillis, maxTimeoutInMillis, 49), "pre-create failed"),
+		},
+		"TimeoutTooLong": {
+			cr:   integration(withTimeout(30001)),
+			want: errors.Wrap(errors.Errorf(errInvalidTimeout, minTimeoutInMillis, maxTimeoutInMillis, 30001), "pre-create failed"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: &mockClient{
+				MockCreateIntegration: func(_ *svcsdk.CreateIntegrationInput) (*svcsdk.CreateIntegrationOutput, error) {
+					return &svcsdk.CreateIntegrationOutput{IntegrationId: &integrationID}, nil
+				},
+			}}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		input *svcsdk.UpdateIntegrationInput
+		err   error
+	}
+
+	cases := map[string]struct {
+		cr *svcapitypes.Integration
+		want
+	}{
+		"Successful": {
+			cr: integration(withMethod("GET"), withTimeout(10000), withServerName("example.org")),
+			want: want{
+				input: &svcsdk.UpdateIntegrationInput{
+					ApiId:             &apiID,
+					IntegrationId:     &integrationID,
+					IntegrationMethod: aws.String("GET"),
+					TimeoutInMillis:   aws.Int64(10000),
+					TlsConfig:         &svcsdk.TlsConfigInput{ServerNameToVerify: aws.String("example.org")},
+				},
+			},
+		},
+		"InvalidTimeout": {
+			cr: integration(withTimeout(30001)),
+			want: want{
+				err: errors.Wrap(errors.Errorf(errInvalidTimeout, minTimeoutInMillis, maxTimeoutInMillis, 30001), "pre-update failed"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var input *svcsdk.UpdateIntegrationInput
+			e := &external{client: &mockClient{
+				MockGetIntegration: func(_ *svcsdk.GetIntegrationInput) (*svcsdk.GetIntegrationOutput, error) {
+					return &svcsdk.GetIntegrationOutput{}, nil
+				},
+				MockUpdateIntegration: func(in *svcsdk.UpdateIntegrationInput) (*svcsdk.UpdateIntegrationOutput, error) {
+					input = in
+					return &svcsdk.UpdateIntegrationOutput{}, nil
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.input, input); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func withRequestParameters(p map[string]string) integrationModifier {
+	return func(r *svcapitypes.Integration) { r.Spec.ForProvider.RequestParameters = awsgo.StringMap(p) }
+}
+
+func withRequestTemplates(t map[string]string) integrationModifier {
+	return func(r *svcapitypes.Integration) { r.Spec.ForProvider.RequestTemplates = awsgo.StringMap(t) }
+}
+
+func TestObserveParameterMappings(t *testing.T) {
+	cases := map[string]struct {
+		cr       *svcapitypes.Integration
+		observed svcsdk.Integration
+		want     bool
+	}{
+		"UpToDate": {
+			cr:       integration(withRequestParameters(map[string]string{"append:header.env": "prod"}), withRequestTemplates(map[string]string{"application/json": "{}"})),
+			observed: svcsdk.Integration{RequestParameters: awsgo.StringMap(map[string]string{"append:header.env": "prod"}), RequestTemplates: awsgo.StringMap(map[string]string{"application/json": "{}"})},
+			want:     true,
+		},
+		"NilAndEmptyAreEqual": {
+			cr:       integration(withRequestParameters(map[string]string{})),
+			observed: svcsdk.Integration{},
+			want:     true,
+		},
+		"ParameterAdded": {
+			cr:       integration(withRequestParameters(map[string]string{"append:header.env": "prod"})),
+			observed: svcsdk.Integration{},
+		},
+		"ParameterChanged": {
+			cr:       integration(withRequestParameters(map[string]string{"append:header.env": "prod"})),
+			observed: svcsdk.Integration{RequestParameters: awsgo.StringMap(map[string]string{"append:header.env": "dev"})},
+		},
+		"ParameterRemoved": {
+			cr:       integration(),
+			observed: svcsdk.Integration{RequestParameters: awsgo.StringMap(map[string]string{"append:header.env": "prod"})},
+		},
+		"TemplateChanged": {
+			cr:       integration(withRequestTemplates(map[string]string{"application/json": "{}"})),
+			observed: svcsdk.Integration{RequestTemplates: awsgo.StringMap(map[string]string{"application/json": "{ }"})},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: &mockClient{MockGetIntegrations: getIntegrations(tc.observed)}}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, o.ResourceUpToDate); diff != "" {
+				t.Errorf("ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateParameterMappings(t *testing.T) {
+	type want struct {
+		parameters map[string]*string
+		templates  map[string]*string
+		err        error
+	}
+
+	cases := map[string]struct {
+		cr       *svcapitypes.Integration
+		observed *svcsdk.GetIntegrationOutput
+		getErr   error
+		want
+	}{
+		"AddParameter": {
+			cr:       integration(withRequestParameters(map[string]string{"append:header.env": "prod", "overwrite:path": "/cool"})),
+			observed: &svcsdk.GetIntegrationOutput{RequestParameters: awsgo.StringMap(map[string]string{"append:header.env": "prod"})},
+			want:     want{parameters: awsgo.StringMap(map[string]string{"overwrite:path": "/cool"})},
+		},
+		"ChangeParameter": {
+			cr:       integration(withRequestParameters(map[string]string{"append:header.env": "prod"})),
+			observed: &svcsdk.GetIntegrationOutput{RequestParameters: awsgo.StringMap(map[string]string{"append:header.env": "dev"})},
+			want:     want{parameters: awsgo.StringMap(map[string]string{"append:header.env": "prod"})},
+		},
+		"RemoveParameter": {
+			cr:       integration(withRequestParameters(map[string]string{"append:header.env": "prod"})),
+			observed: &svcsdk.GetIntegrationOutput{RequestParameters: awsgo.StringMap(map[string]string{"append:header.env": "prod", "overwrite:path": "/cool"})},
+			want:     want{parameters: awsgo.StringMap(map[string]string{"overwrite:path": ""})},
+		},
+		"RemoveAllTemplates": {
+			cr:       integration(),
+			observed: &svcsdk.GetIntegrationOutput{RequestTemplates: awsgo.StringMap(map[string]string{"application/json": "{}"})},
+			want:     want{templates: awsgo.StringMap(map[string]string{"application/json": ""})},
+		},
+		"Unchanged": {
+			cr:       integration(withRequestParameters(map[string]string{"append:header.env": "prod"})),
+			observed: &svcsdk.GetIntegrationOutput{RequestParameters: awsgo.StringMap(map[string]string{"append:header.env": "prod"})},
+		},
+		"GetFailed": {
+			cr:     integration(),
+			getErr: errBoom,
+			want:   want{err: errors.Wrap(errBoom, errGet)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			input := &svcsdk.UpdateIntegrationInput{}
+			e := &external{client: &mockClient{
+				MockGetIntegration: func(_ *svcsdk.GetIntegrationInput) (*svcsdk.GetIntegrationOutput, error) {
+					return tc.observed, tc.getErr
+				},
+				MockUpdateIntegration: func(in *svcsdk.UpdateIntegrationInput) (*svcsdk.UpdateIntegrationOutput, error) {
+					input = in
+					return &svcsdk.UpdateIntegrationOutput{}, nil
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.parameters, input.RequestParameters); diff != "" {
+				t.Errorf("RequestParameters: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.templates, input.RequestTemplates); diff != "" {
+				t.Errorf("RequestTemplates: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,8 +18,12 @@ package route
 
 import (
 	"context"
+	"sort"
 
+	awsgo "github.com/aws/aws-sdk-go/aws"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -31,33 +35,176 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	errUpdate          = "cannot update Route in AWS"
+	errDeleteParameter = "cannot delete request parameter of Route in AWS"
 )
 
 // SetupRoute adds a controller that reconciles Route.
-func SetupRoute(mgr ctrl.Manager, l logging.Logger) error {
+func SetupRoute(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.RouteGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.Route{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.RouteGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.RouteKind)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.Route) error {
 	return nil
 }
-func (*external) postObserve(_ context.Context, cr *svcapitypes.Route, _ *svcsdk.GetRoutesOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+func (*external) postObserve(_ context.Context, cr *svcapitypes.Route, resp *svcsdk.GetRoutesOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 	cr.SetConditions(v1alpha1.Available())
+	obs.ResourceUpToDate = isUpToDate(cr, resp)
 	return obs, nil
 }
 
+// An authorization is the authorization configuration of a route.
+type authorization struct {
+	authorizationType string
+	authorizerID      string
+	scopes            []string
+}
+
+// desiredAuthorization returns the authorization configuration the supplied
+// route should have. Routes without an authorization type are not authorized,
+// only routes that use an authorizer keep its ID and only JWT authorizers
+// support authorization scopes.
+func desiredAuthorization(p svcapitypes.RouteParameters) authorization {
+	a := authorization{authorizationType: svcsdk.AuthorizationTypeNone}
+	if p.AuthorizationType != nil {
+		a.authorizationType = *p.AuthorizationType
+	}
+	switch a.authorizationType {
+	case svcsdk.AuthorizationTypeJwt:
+		a.authorizerID = aws.StringValue(p.AuthorizerID)
+		a.scopes = sortedScopes(p.AuthorizationScopes)
+	case svcsdk.AuthorizationTypeCustom:
+		a.authorizerID = aws.StringValue(p.AuthorizerID)
+	}
+	return a
+}
+
+func observedAuthorization(r *svcsdk.Route) authorization {
+	a := authorization{
+		authorizationType: aws.StringValue(r.AuthorizationType),
+		authorizerID:      aws.StringValue(r.AuthorizerId),
+		scopes:            sortedScopes(r.AuthorizationScopes),
+	}
+	if a.authorizationType == "" {
+		a.authorizationType = svcsdk.AuthorizationTypeNone
+	}
+	return a
+}
+
+func sortedScopes(in []*string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = aws.StringValue(s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// requiredParameters returns whether each of the supplied request parameters
+// is required. Parameters without constraints are not required.
+func requiredParameters(in map[string]*svcapitypes.ParameterConstraints) map[string]bool {
+	out := make(map[string]bool, len(in))
+	for k, c := range in {
+		out[k] = c != nil && awsgo.BoolValue(c.Required)
+	}
+	return out
+}
+
+func observedRequiredParameters(in map[string]*svcsdk.ParameterConstraints) map[string]bool {
+	out := make(map[string]bool, len(in))
+	for k, c := range in {
+		out[k] = c != nil && awsgo.BoolValue(c.Required)
+	}
+	return out
+}
+
+// removedParameters returns the sorted keys of the observed request parameters
+// of the supplied route that it no longer declares.
+func removedParameters(cr *svcapitypes.Route, r *svcsdk.Route) []string {
+	var removed []string
+	for k := range r.RequestParameters {
+		if _, ok := cr.Spec.ForProvider.RequestParameters[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+func stringMap(in map[string]*string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = aws.StringValue(v)
+	}
+	return out
+}
+
+// isUpToDate returns whether the authorization configuration, the request
+// parameters and the model bindings of the route are in sync with the
+// observed route.
+func isUpToDate(cr *svcapitypes.Route, resp *svcsdk.GetRoutesOutput) bool {
+	if len(resp.Items) == 0 {
+		return true
+	}
+	want, got := desiredAuthorization(cr.Spec.ForProvider), observedAuthorization(resp.Items[0])
+	return want.authorizationType == got.authorizationType &&
+		want.authorizerID == got.authorizerID &&
+		cmp.Equal(want.scopes, got.scopes) &&
+		cmp.Equal(requiredParameters(cr.Spec.ForProvider.RequestParameters), observedRequiredParameters(resp.Items[0].RequestParameters)) &&
+		cmp.Equal(stringMap(cr.Spec.ForProvider.RequestModels), stringMap(resp.Items[0].RequestModels))
+}
+
+// generateUpdateRouteInput returns the input to update the authorization
+// configuration, the request parameters and the model bindings of the
+// supplied route. The authorizer ID and the scopes are cleared explicitly when
+// the authorization type does not support them, so that a route moving e.g.
+// from JWT to NONE does not keep its authorizer. Request parameters that were
+// removed must be deleted separately.
+func generateUpdateRouteInput(cr *svcapitypes.Route) *svcsdk.UpdateRouteInput {
+	a := desiredAuthorization(cr.Spec.ForProvider)
+	u := &svcsdk.UpdateRouteInput{
+		ApiId:               cr.Spec.ForProvider.APIID,
+		RouteId:             aws.String(meta.GetExternalName(cr)),
+		AuthorizationType:   aws.String(a.authorizationType),
+		AuthorizerId:        aws.String(a.authorizerID),
+		AuthorizationScopes: []*string{},
+	}
+	for i := range a.scopes {
+		u.AuthorizationScopes = append(u.AuthorizationScopes, &a.scopes[i])
+	}
+	if len(cr.Spec.ForProvider.RequestParameters) != 0 {
+		u.RequestParameters = make(map[string]*svcsdk.ParameterConstraints, len(cr.Spec.ForProvider.RequestParameters))
+		for k, required := range requiredParameters(cr.Spec.ForProvider.RequestParameters) {
+			u.RequestParameters[k] = &svcsdk.ParameterConstraints{Required: aws.Bool(required, aws.FieldRequired)}
+		}
+	}
+	if len(cr.Spec.ForProvider.RequestModels) != 0 {
+		u.RequestModels = cr.Spec.ForProvider.RequestModels
+	}
+	return u
+}
+
 func (*external) filterList(cr *svcapitypes.Route, list *svcsdk.GetRoutesOutput) *svcsdk.GetRoutesOutput {
 	res := &svcsdk.GetRoutesOutput{}
 	for _, route := range list.Items {
@@ -88,8 +235,28 @@ func (*external) preUpdate(context.Context, *svcapitypes.Route) error {
 	return nil
 }
 
-func (*external) postUpdate(_ context.Context, _ *svcapitypes.Route, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
-	return upd, err
+func (e *external) postUpdate(ctx context.Context, cr *svcapitypes.Route, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	resp, err := e.client.GetRoutesWithContext(ctx, GenerateGetRoutesInput(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribe)
+	}
+	if _, err := e.client.UpdateRouteWithContext(ctx, generateUpdateRouteInput(cr)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+	routes := e.filterList(cr, resp).Items
+	if len(routes) == 0 {
+		return upd, nil
+	}
+	for _, k := range removedParameters(cr, routes[0]) {
+		in := &svcsdk.DeleteRouteRequestParameterInput{ApiId: cr.Spec.ForProvider.APIID, RouteId: aws.String(meta.GetExternalName(cr)), RequestParameterKey: aws.String(k)}
+		if _, err := e.client.DeleteRouteRequestParameterWithContext(ctx, in); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errDeleteParameter)
+		}
+	}
+	return upd, nil
 }
 func lateInitialize(*svcapitypes.RouteParameters, *svcsdk.GetRoutesOutput) error {
 	return nil
@@ -0,0 +1,386 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+var (
+	apiID        = "abc123"
+	routeID      = "def456"
+	authorizerID = "ghi789"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockGetRoutes                   func(*svcsdk.GetRoutesInput) (*svcsdk.GetRoutesOutput, error)
+	MockUpdateRoute                 func(*svcsdk.UpdateRouteInput) (*svcsdk.UpdateRouteOutput, error)
+	MockDeleteRouteRequestParameter func(*svcsdk.DeleteRouteRequestParameterInput) (*svcsdk.DeleteRouteRequestParameterOutput, error)
+}
+
+func (m *mockClient) GetRoutesWithContext(_ context.Context, in *svcsdk.GetRoutesInput, _ ...request.Option) (*svcsdk.GetRoutesOutput, error) {
+	return m.MockGetRoutes(in)
+}
+
+func (m *mockClient) UpdateRouteWithContext(_ context.Context, in *svcsdk.UpdateRouteInput, _ ...request.Option) (*svcsdk.UpdateRouteOutput, error) {
+	return m.MockUpdateRoute(in)
+}
+
+func (m *mockClient) DeleteRouteRequestParameterWithContext(_ context.Context, in *svcsdk.DeleteRouteRequestParameterInput, _ ...request.Option) (*svcsdk.DeleteRouteRequestParameterOutput, error) {
+	return m.MockDeleteRouteRequestParameter(in)
+}
+
+type routeModifier func(*svcapitypes.Route)
+
+func withAuthorizationType(t string) routeModifier {
+	return func(r *svcapitypes.Route) { r.Spec.ForProvider.AuthorizationType = &t }
+}
+
+func withAuthorizerID(id string) routeModifier {
+	return func(r *svcapitypes.Route) { r.Spec.ForProvider.AuthorizerID = &id }
+}
+
+func withScopes(s ...string) routeModifier {
+	return func(r *svcapitypes.Route) { r.Spec.ForProvider.AuthorizationScopes = awsgo.StringSlice(s) }
+}
+
+func withRequestParameters(p map[string]bool) routeModifier {
+	return func(r *svcapitypes.Route) {
+		r.Spec.ForProvider.RequestParameters = map[string]*svcapitypes.ParameterConstraints{}
+		for k, required := range p {
+			r.Spec.ForProvider.RequestParameters[k] = &svcapitypes.ParameterConstraints{Required: awsgo.Bool(required)}
+		}
+	}
+}
+
+func withRequestModels(m map[string]string) routeModifier {
+	return func(r *svcapitypes.Route) { r.Spec.ForProvider.RequestModels = awsgo.StringMap(m) }
+}
+
+func route(m ...routeModifier) *svcapitypes.Route {
+	cr := &svcapitypes.Route{}
+	meta.SetExternalName(cr, routeID)
+	cr.Spec.ForProvider.APIID = &apiID
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func getRoutes(r svcsdk.Route) func(*svcsdk.GetRoutesInput) (*svcsdk.GetRoutesOutput, error) {
+	r.RouteId = &routeID
+	return func(_ *svcsdk.GetRoutesInput) (*svcsdk.GetRoutesOutput, error) {
+		return &svcsdk.GetRoutesOutput{Items: []*svcsdk.Route{&r}}, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := map[string]struct {
+		client *mockClient
+		cr     *svcapitypes.Route
+		want   managed.ExternalObservation
+	}{
+		"NoneUpToDate": {
+			client: &mockClient{MockGetRoutes: getRoutes(svcsdk.Route{AuthorizationType: aws.String(svcsdk.AuthorizationTypeNone)})},
+			cr:     route(),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"JWTUpToDate": {
+			client: &mockClient{MockGetRoutes: getRoutes(svcsdk.Route{
+				AuthorizationType:   aws.String(svcsdk.AuthorizationTypeJwt),
+				AuthorizerId:        &authorizerID,
+				AuthorizationScopes: awsgo.StringSlice([]string{"write", "read"}),
+			})},
+			cr:   route(withAuthorizationType(svcsdk.AuthorizationTypeJwt), withAuthorizerID(authorizerID), withScopes("read", "write")),
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"NoneToJWT": {
+			client: &mockClient{MockGetRoutes: getRoutes(svcsdk.Route{AuthorizationType: aws.String(svcsdk.AuthorizationTypeNone)})},
+			cr:     route(withAuthorizationType(svcsdk.AuthorizationTypeJwt), withAuthorizerID(authorizerID), withScopes("read")),
+			want:   managed.ExternalObservation{ResourceExists: true},
+		},
+		"JWTToNone": {
+			client: &mockClient{MockGetRoutes: getRoutes(svcsdk.Route{
+				AuthorizationType:   aws.String(svcsdk.AuthorizationTypeJwt),
+				AuthorizerId:        &authorizerID,
+				AuthorizationScopes: awsgo.StringSlice([]string{"read"}),
+			})},
+			cr:   route(withAuthorizationType(svcsdk.AuthorizationTypeNone)),
+			want: managed.ExternalObservation{ResourceExists: true},
+		},
+		"JWTToCustomStillScoped": {
+			client: &mockClient{MockGetRoutes: getRoutes(svcsdk.Route{
+				AuthorizationType:   aws.String(svcsdk.AuthorizationTypeCustom),
+				AuthorizerId:        &authorizerID,
+				AuthorizationScopes: awsgo.StringSlice([]string{"read"}),
+			})},
+			cr:   route(withAuthorizationType(svcsdk.AuthorizationTypeCustom), withAuthorizerID(authorizerID), withScopes("read")),
+			want: managed.ExternalObservation{ResourceExists: true},
+		},
+		"CustomToIAM": {
+			client: &mockClient{MockGetRoutes: getRoutes(svcsdk.Route{
+				AuthorizationType: aws.String(svcsdk.AuthorizationTypeCustom),
+				AuthorizerId:      &authorizerID,
+			})},
+			cr:   route(withAuthorizationType(svcsdk.AuthorizationTypeAwsIam), withAuthorizerID(authorizerID)),
+			want: managed.ExternalObservation{ResourceExists: true},
+		},
+		"IAMUpToDateIgnoresAuthorizer": {
+			client: &mockClient{MockGetRoutes: getRoutes(svcsdk.Route{AuthorizationType: aws.String(svcsdk.AuthorizationTypeAwsIam)})},
+			cr:     route(withAuthorizationType(svcsdk.AuthorizationTypeAwsIam), withAuthorizerID(authorizerID)),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		input *svcsdk.UpdateRouteInput
+		err   error
+	}
+
+	cases := map[string]struct {
+		cr        *svcapitypes.Route
+		updateErr error
+		want
+	}{
+		"ToJWT": {
+			cr: route(withAuthorizationType(svcsdk.AuthorizationTypeJwt), withAuthorizerID(authorizerID), withScopes("write", "read")),
+			want: want{
+				input: &svcsdk.UpdateRouteInput{
+					ApiId:               &apiID,
+					RouteId:             &routeID,
+					AuthorizationType:   aws.String(svcsdk.AuthorizationTypeJwt),
+					AuthorizerId:        &authorizerID,
+					AuthorizationScopes: awsgo.StringSlice([]string{"read", "write"}),
+				},
+			},
+		},
+		"ToCustom": {
+			cr: route(withAuthorizationType(svcsdk.AuthorizationTypeCustom), withAuthorizerID(authorizerID), withScopes("read")),
+			want: want{
+				input: &svcsdk.UpdateRouteInput{
+					ApiId:               &apiID,
+					RouteId:             &routeID,
+					AuthorizationType:   aws.String(svcsdk.AuthorizationTypeCustom),
+					AuthorizerId:        &authorizerID,
+					AuthorizationScopes: []*string{},
+				},
+			},
+		},
+		"ToIAM": {
+			cr: route(withAuthorizationType(svcsdk.AuthorizationTypeAwsIam), withAuthorizerID(authorizerID)),
+			want: want{
+				input: &svcsdk.UpdateRouteInput{
+					ApiId:               &apiID,
+					RouteId:             &routeID,
+					AuthorizationType:   aws.String(svcsdk.AuthorizationTypeAwsIam),
+					AuthorizerId:        aws.String(""),
+					AuthorizationScopes: []*string{},
+				},
+			},
+		},
+		"ToNone": {
+			cr: route(),
+			want: want{
+				input: &svcsdk.UpdateRouteInput{
+					ApiId:               &apiID,
+					RouteId:             &routeID,
+					AuthorizationType:   aws.String(svcsdk.AuthorizationTypeNone),
+					AuthorizerId:        aws.String(""),
+					AuthorizationScopes: []*string{},
+				},
+			},
+		},
+		"UpdateFailed": {
+			cr:        route(),
+			updateErr: errBoom,
+			want: want{
+				input: &svcsdk.UpdateRouteInput{
+					ApiId:               &apiID,
+					RouteId:             &routeID,
+					AuthorizationType:   aws.String(svcsdk.AuthorizationTypeNone),
+					AuthorizerId:        aws.String(""),
+					AuthorizationScopes: []*string{},
+				},
+				err: errors.Wrap(errBoom, errUpdate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var input *svcsdk.UpdateRouteInput
+			e := &external{client: &mockClient{
+				MockGetRoutes: getRoutes(svcsdk.Route{}),
+				MockUpdateRoute: func(in *svcsdk.UpdateRouteInput) (*svcsdk.UpdateRouteOutput, error) {
+					input = in
+					return &svcsdk.UpdateRouteOutput{}, tc.updateErr
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.input, input); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequestParametersAndModels(t *testing.T) {
+	param := "route.request.querystring.id"
+	otherParam := "route.request.header.x-cool"
+	none := route()
+
+	type want struct {
+		upToDate bool
+		input    *svcsdk.UpdateRouteInput
+		deleted  []string
+	}
+
+	update := func(params map[string]bool, models map[string]string) *svcsdk.UpdateRouteInput {
+		u := generateUpdateRouteInput(none)
+		if params != nil {
+			u.RequestParameters = map[string]*svcsdk.ParameterConstraints{}
+			for k, required := range params {
+				u.RequestParameters[k] = &svcsdk.ParameterConstraints{Required: awsgo.Bool(required)}
+			}
+		}
+		if models != nil {
+			u.RequestModels = awsgo.StringMap(models)
+		}
+		return u
+	}
+
+	cases := map[string]struct {
+		cr       *svcapitypes.Route
+		observed svcsdk.Route
+		want
+	}{
+		"UpToDate": {
+			cr: route(withRequestParameters(map[string]bool{param: true}), withRequestModels(map[string]string{"$default": "CoolModel"})),
+			observed: svcsdk.Route{
+				RequestParameters: map[string]*svcsdk.ParameterConstraints{param: {Required: awsgo.Bool(true)}},
+				RequestModels:     awsgo.StringMap(map[string]string{"$default": "CoolModel"}),
+			},
+			want: want{
+				upToDate: true,
+				input:    update(map[string]bool{param: true}, map[string]string{"$default": "CoolModel"}),
+			},
+		},
+		"AddRequiredParameter": {
+			cr:       route(withRequestParameters(map[string]bool{param: true})),
+			observed: svcsdk.Route{},
+			want:     want{input: update(map[string]bool{param: true}, nil)},
+		},
+		"RequireParameter": {
+			cr:       route(withRequestParameters(map[string]bool{param: true})),
+			observed: svcsdk.Route{RequestParameters: map[string]*svcsdk.ParameterConstraints{param: {Required: awsgo.Bool(false)}}},
+			want:     want{input: update(map[string]bool{param: true}, nil)},
+		},
+		"RemoveParameter": {
+			cr: route(withRequestParameters(map[string]bool{param: false})),
+			observed: svcsdk.Route{RequestParameters: map[string]*svcsdk.ParameterConstraints{
+				param:      {Required: awsgo.Bool(false)},
+				otherParam: {Required: awsgo.Bool(true)},
+			}},
+			want: want{
+				input:   update(map[string]bool{param: false}, nil),
+				deleted: []string{otherParam},
+			},
+		},
+		"AddModelBinding": {
+			cr:       route(withRequestModels(map[string]string{"$default": "CoolModel"})),
+			observed: svcsdk.Route{},
+			want:     want{input: update(nil, map[string]string{"$default": "CoolModel"})},
+		},
+		"RemoveModelBinding": {
+			cr:       route(withRequestModels(map[string]string{"$default": "CoolModel"})),
+			observed: svcsdk.Route{RequestModels: awsgo.StringMap(map[string]string{"$default": "CoolModel", "application/xml": "OtherModel"})},
+			want:     want{input: update(nil, map[string]string{"$default": "CoolModel"})},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.observed.AuthorizationType = aws.String(svcsdk.AuthorizationTypeNone)
+			var input *svcsdk.UpdateRouteInput
+			var deleted []string
+			e := &external{client: &mockClient{
+				MockGetRoutes: getRoutes(tc.observed),
+				MockUpdateRoute: func(in *svcsdk.UpdateRouteInput) (*svcsdk.UpdateRouteOutput, error) {
+					input = in
+					return &svcsdk.UpdateRouteOutput{}, nil
+				},
+				MockDeleteRouteRequestParameter: func(in *svcsdk.DeleteRouteRequestParameterInput) (*svcsdk.DeleteRouteRequestParameterOutput, error) {
+					deleted = append(deleted, awsgo.StringValue(in.RequestParameterKey))
+					return &svcsdk.DeleteRouteRequestParameterOutput{}, nil
+				},
+			}}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("Update(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.input, input); diff != "" {
+				t.Errorf("UpdateRoute(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("DeleteRouteRequestParameter(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,8 +18,14 @@ package stage
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -31,27 +37,246 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	errUpdate            = "cannot update Stage in AWS"
+	errTag               = "cannot tag Stage in AWS"
+	errUntag             = "cannot untag Stage in AWS"
+	errThrottlingBurst   = "defaultRouteSettings.throttlingBurstLimit must be between 0 and %d"
+	errThrottlingRate    = "defaultRouteSettings.throttlingRateLimit must be between 0 and %d"
+	errInvalidParameters = "invalid Stage parameters"
+	errAutoDeployPinned  = "autoDeploy and deploymentID cannot both be set; a stage either deploys automatically or is pinned to a deployment"
+)
+
+// The bounds of the default route throttling settings of a stage. Requests
+// beyond these are throttled by the account level limits anyway.
+const (
+	maxThrottlingBurstLimit = 5000
+	maxThrottlingRateLimit  = 10000
 )
 
 // SetupStage adds a controller that reconciles Stage.
-func SetupStage(mgr ctrl.Manager, l logging.Logger) error {
+func SetupStage(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.StageGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.Stage{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.StageGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.StageKind)),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
+}
+
+// validateRouteSettings returns an error if the supplied route settings are
+// outside the bounds accepted by AWS.
+func validateRouteSettings(rs *svcapitypes.RouteSettings) error {
+	if rs == nil {
+		return nil
+	}
+	if b := rs.ThrottlingBurstLimit; b != nil && (*b < 0 || *b > maxThrottlingBurstLimit) {
+		return errors.Errorf(errThrottlingBurst, maxThrottlingBurstLimit)
+	}
+	if r := rs.ThrottlingRateLimit; r != nil && (*r < 0 || *r > maxThrottlingRateLimit) {
+		return errors.Errorf(errThrottlingRate, maxThrottlingRateLimit)
+	}
+	return nil
+}
+
+// validate returns an error if the supplied stage parameters would be rejected
+// by AWS.
+func validate(p *svcapitypes.StageParameters) error {
+	if awsgo.BoolValue(p.AutoDeploy) && p.DeploymentID != nil {
+		return errors.New(errAutoDeployPinned)
+	}
+	return validateRouteSettings(p.DefaultRouteSettings)
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.Stage) error {
 	return nil
 }
-func (*external) postObserve(_ context.Context, cr *svcapitypes.Stage, _ *svcsdk.GetStagesOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+func (e *external) postObserve(ctx context.Context, cr *svcapitypes.Stage, resp *svcsdk.GetStagesOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 	cr.SetConditions(v1alpha1.Available())
-	return obs, err
+	obs.ResourceUpToDate = isUpToDate(cr, resp)
+	if !obs.ResourceUpToDate || len(resp.Items) == 0 {
+		return obs, nil
+	}
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	add, remove := aws.DiffTags(stringMap(tags), stringMap(resp.Items[0].Tags))
+	obs.ResourceUpToDate = len(add) == 0 && len(remove) == 0
+	return obs, nil
+}
+
+// desiredTags returns the tags of the supplied stage merged with the default
+// tags of its ProviderConfig. Default tags are merged at request time rather
+// than written to the spec, so that changes to them apply to existing stages.
+func (e *external) desiredTags(ctx context.Context, cr *svcapitypes.Stage) (map[string]*string, error) {
+	defaults, err := aws.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	return aws.MergeTagPtrs(cr.Spec.ForProvider.Tags, defaults), nil
+}
+
+func stringMap(in map[string]*string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = aws.StringValue(v)
+	}
+	return out
+}
+
+// stageARN returns the ARN of the stage with the supplied name of the API with
+// the supplied ID, which is required to tag it.
+func stageARN(region, apiID, name string) string {
+	partition := "aws"
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+	return fmt.Sprintf("arn:%s:apigateway:%s::/apis/%s/stages/%s", partition, region, apiID, name)
+}
+
+// stageVariables returns the supplied stage variables as a map of strings.
+// Nil and empty maps both mean that there are no stage variables.
+func stageVariables(in map[string]*string) map[string]string {
+	out := map[string]string{}
+	for k, v := range in {
+		out[k] = aws.StringValue(v)
+	}
+	return out
+}
+
+// isUpToDate returns whether the stage variables, default route settings and
+// deployment of the stage are in sync with the observed stage.
+func isUpToDate(cr *svcapitypes.Stage, resp *svcsdk.GetStagesOutput) bool {
+	if len(resp.Items) == 0 {
+		return true
+	}
+	return cmp.Equal(stageVariables(cr.Spec.ForProvider.StageVariables), stageVariables(resp.Items[0].StageVariables)) &&
+		isRouteSettingsUpToDate(cr.Spec.ForProvider.DefaultRouteSettings, resp.Items[0].DefaultRouteSettings) &&
+		isDeploymentUpToDate(&cr.Spec.ForProvider, resp.Items[0])
+}
+
+// isDeploymentUpToDate returns whether the observed stage deploys
+// automatically, or is pinned to a deployment, as desired. A stage that
+// neither enables auto-deploy nor pins a deployment is always up to date.
+func isDeploymentUpToDate(p *svcapitypes.StageParameters, observed *svcsdk.Stage) bool {
+	switch {
+	case awsgo.BoolValue(p.AutoDeploy):
+		return awsgo.BoolValue(observed.AutoDeploy)
+	case p.DeploymentID != nil:
+		return !awsgo.BoolValue(observed.AutoDeploy) && awsgo.StringValue(p.DeploymentID) == awsgo.StringValue(observed.DeploymentId)
+	case p.AutoDeploy != nil:
+		return !awsgo.BoolValue(observed.AutoDeploy)
+	}
+	return true
+}
+
+// generateDeploymentUpdates returns the updates that transition the observed
+// stage to deploying automatically, or to being pinned to a deployment, in
+// the order they must be made. AWS does not allow a deployment to be pinned
+// while auto-deploy is enabled, so pinning a deployment disables auto-deploy
+// first. The deployment of a stage that deploys automatically is managed by
+// AWS, so enabling auto-deploy does not send a deployment.
+func generateDeploymentUpdates(cr *svcapitypes.Stage, observed *svcsdk.Stage) []*svcsdk.UpdateStageInput {
+	p := &cr.Spec.ForProvider
+	if isDeploymentUpToDate(p, observed) {
+		return nil
+	}
+	input := func() *svcsdk.UpdateStageInput {
+		return &svcsdk.UpdateStageInput{ApiId: p.APIID, StageName: aws.String(meta.GetExternalName(cr))}
+	}
+	if awsgo.BoolValue(p.AutoDeploy) {
+		in := input()
+		in.AutoDeploy = awsgo.Bool(true)
+		return []*svcsdk.UpdateStageInput{in}
+	}
+	var updates []*svcsdk.UpdateStageInput
+	if awsgo.BoolValue(observed.AutoDeploy) {
+		in := input()
+		in.AutoDeploy = awsgo.Bool(false)
+		updates = append(updates, in)
+	}
+	if p.DeploymentID != nil && awsgo.StringValue(p.DeploymentID) != awsgo.StringValue(observed.DeploymentId) {
+		in := input()
+		in.DeploymentId = p.DeploymentID
+		updates = append(updates, in)
+	}
+	return updates
+}
+
+// isRouteSettingsUpToDate returns whether the observed route settings match
+// the desired ones. Settings that are not specified are not compared.
+func isRouteSettingsUpToDate(desired *svcapitypes.RouteSettings, observed *svcsdk.RouteSettings) bool {
+	if desired == nil {
+		return true
+	}
+	if observed == nil {
+		observed = &svcsdk.RouteSettings{}
+	}
+	switch {
+	case desired.DataTraceEnabled != nil && awsgo.BoolValue(desired.DataTraceEnabled) != awsgo.BoolValue(observed.DataTraceEnabled):
+		return false
+	case desired.DetailedMetricsEnabled != nil && awsgo.BoolValue(desired.DetailedMetricsEnabled) != awsgo.BoolValue(observed.DetailedMetricsEnabled):
+		return false
+	case desired.LoggingLevel != nil && awsgo.StringValue(desired.LoggingLevel) != awsgo.StringValue(observed.LoggingLevel):
+		return false
+	case desired.ThrottlingBurstLimit != nil && awsgo.Int64Value(desired.ThrottlingBurstLimit) != awsgo.Int64Value(observed.ThrottlingBurstLimit):
+		return false
+	case desired.ThrottlingRateLimit != nil && awsgo.Float64Value(desired.ThrottlingRateLimit) != awsgo.Float64Value(observed.ThrottlingRateLimit):
+		return false
+	}
+	return true
+}
+
+// generateRouteSettings returns the SDK representation of the supplied route
+// settings.
+func generateRouteSettings(rs *svcapitypes.RouteSettings) *svcsdk.RouteSettings {
+	if rs == nil {
+		return nil
+	}
+	return &svcsdk.RouteSettings{
+		DataTraceEnabled:       rs.DataTraceEnabled,
+		DetailedMetricsEnabled: rs.DetailedMetricsEnabled,
+		LoggingLevel:           rs.LoggingLevel,
+		ThrottlingBurstLimit:   rs.ThrottlingBurstLimit,
+		ThrottlingRateLimit:    rs.ThrottlingRateLimit,
+	}
+}
+
+// diffStageVariables returns the stage variables that must be sent to update
+// the observed stage variables to the desired ones. Stage variables are
+// merged into the existing ones by UpdateStage, so variables that should be
+// removed are sent with an empty value. It returns nil if there is nothing to
+// update.
+func diffStageVariables(desired, observed map[string]*string) map[string]*string {
+	want, got := stageVariables(desired), stageVariables(observed)
+	diff := map[string]*string{}
+	for k, v := range want {
+		if gv, ok := got[k]; !ok || gv != v {
+			diff[k] = awsgo.String(v)
+		}
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			diff[k] = awsgo.String("")
+		}
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+	return diff
 }
 
 func (*external) filterList(cr *svcapitypes.Stage, list *svcsdk.GetStagesOutput) *svcsdk.GetStagesOutput {
@@ -64,7 +289,19 @@ func (*external) filterList(cr *svcapitypes.Stage, list *svcsdk.GetStagesOutput)
 	return res
 }
 
-func (*external) preCreate(context.Context, *svcapitypes.Stage) error {
+// preCreate validates the stage and adds the default tags of the
+// ProviderConfig to the tags it is created with. The managed reconciler does
+// not persist the spec of the stage after creating it, so they are not written
+// to the spec.
+func (e *external) preCreate(ctx context.Context, cr *svcapitypes.Stage) error {
+	if err := validate(&cr.Spec.ForProvider); err != nil {
+		return errors.Wrap(err, errInvalidParameters)
+	}
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return err
+	}
+	cr.Spec.ForProvider.Tags = tags
 	return nil
 }
 
@@ -72,12 +309,64 @@ func (*external) postCreate(_ context.Context, _ *svcapitypes.Stage, _ *svcsdk.C
 	return cre, err
 }
 
-func (*external) preUpdate(context.Context, *svcapitypes.Stage) error {
-	return nil
+func (*external) preUpdate(_ context.Context, cr *svcapitypes.Stage) error {
+	return errors.Wrap(validate(&cr.Spec.ForProvider), errInvalidParameters)
 }
 
-func (*external) postUpdate(_ context.Context, _ *svcapitypes.Stage, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
-	return upd, err
+func (e *external) postUpdate(ctx context.Context, cr *svcapitypes.Stage, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	resp, err := e.client.GetStagesWithContext(ctx, GenerateGetStagesInput(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribe)
+	}
+	resp = e.filterList(cr, resp)
+	if len(resp.Items) == 0 {
+		return upd, nil
+	}
+	for _, in := range generateDeploymentUpdates(cr, resp.Items[0]) {
+		if _, err := e.client.UpdateStageWithContext(ctx, in); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+		}
+	}
+	input := &svcsdk.UpdateStageInput{
+		ApiId:          cr.Spec.ForProvider.APIID,
+		StageName:      aws.String(meta.GetExternalName(cr)),
+		StageVariables: diffStageVariables(cr.Spec.ForProvider.StageVariables, resp.Items[0].StageVariables),
+	}
+	if !isRouteSettingsUpToDate(cr.Spec.ForProvider.DefaultRouteSettings, resp.Items[0].DefaultRouteSettings) {
+		input.DefaultRouteSettings = generateRouteSettings(cr.Spec.ForProvider.DefaultRouteSettings)
+	}
+	if input.StageVariables != nil || input.DefaultRouteSettings != nil {
+		if _, err := e.client.UpdateStageWithContext(ctx, input); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+		}
+	}
+	return upd, e.updateTags(ctx, cr, resp.Items[0])
+}
+
+// updateTags tags and untags the supplied observed stage so that its tags
+// match the desired tags of the supplied stage.
+func (e *external) updateTags(ctx context.Context, cr *svcapitypes.Stage, observed *svcsdk.Stage) error {
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return err
+	}
+	add, remove := aws.DiffTags(stringMap(tags), stringMap(observed.Tags))
+	arn := awsgo.String(stageARN(cr.Spec.ForProvider.Region, aws.StringValue(cr.Spec.ForProvider.APIID), meta.GetExternalName(cr)))
+	if len(remove) != 0 {
+		sort.Strings(remove)
+		if _, err := e.client.UntagResourceWithContext(ctx, &svcsdk.UntagResourceInput{ResourceArn: arn, TagKeys: awsgo.StringSlice(remove)}); err != nil {
+			return errors.Wrap(err, errUntag)
+		}
+	}
+	if len(add) != 0 {
+		if _, err := e.client.TagResourceWithContext(ctx, &svcsdk.TagResourceInput{ResourceArn: arn, Tags: awsgo.StringMap(add)}); err != nil {
+			return errors.Wrap(err, errTag)
+		}
+	}
+	return nil
 }
 func lateInitialize(*svcapitypes.StageParameters, *svcsdk.GetStagesOutput) error {
 	return nil
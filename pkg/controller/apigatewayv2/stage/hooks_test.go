@@ -0,0 +1,707 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stage
+
+import (
+	"context"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
+	aws "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+var (
+	apiID     = "abc123"
+	stageName = "prod"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockGetStages     func(*svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error)
+	MockUpdateStage   func(*svcsdk.UpdateStageInput) (*svcsdk.UpdateStageOutput, error)
+	MockTagResource   func(*svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error)
+	MockUntagResource func(*svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error)
+}
+
+func (m *mockClient) GetStagesWithContext(_ context.Context, in *svcsdk.GetStagesInput, _ ...request.Option) (*svcsdk.GetStagesOutput, error) {
+	return m.MockGetStages(in)
+}
+
+func (m *mockClient) UpdateStageWithContext(_ context.Context, in *svcsdk.UpdateStageInput, _ ...request.Option) (*svcsdk.UpdateStageOutput, error) {
+	return m.MockUpdateStage(in)
+}
+
+func (m *mockClient) TagResourceWithContext(_ context.Context, in *svcsdk.TagResourceInput, _ ...request.Option) (*svcsdk.TagResourceOutput, error) {
+	return m.MockTagResource(in)
+}
+
+func (m *mockClient) UntagResourceWithContext(_ context.Context, in *svcsdk.UntagResourceInput, _ ...request.Option) (*svcsdk.UntagResourceOutput, error) {
+	return m.MockUntagResource(in)
+}
+
+func stage(vars map[string]string) *svcapitypes.Stage {
+	cr := &svcapitypes.Stage{}
+	meta.SetExternalName(cr, stageName)
+	cr.Spec.ForProvider.APIID = &apiID
+	if vars != nil {
+		cr.Spec.ForProvider.StageVariables = awsgo.StringMap(vars)
+	}
+	return cr
+}
+
+func getStages(vars map[string]string) func(*svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+	return func(_ *svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+		s := &svcsdk.Stage{StageName: &stageName}
+		if vars != nil {
+			s.StageVariables = awsgo.StringMap(vars)
+		}
+		return &svcsdk.GetStagesOutput{Items: []*svcsdk.Stage{s}}, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := map[string]struct {
+		cr       *svcapitypes.Stage
+		observed map[string]string
+		want     managed.ExternalObservation
+	}{
+		"UpToDate": {
+			cr:       stage(map[string]string{"env": "prod"}),
+			observed: map[string]string{"env": "prod"},
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"NilAndEmptyAreEqual": {
+			cr:       stage(nil),
+			observed: map[string]string{},
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"VariableChanged": {
+			cr:       stage(map[string]string{"env": "prod"}),
+			observed: map[string]string{"env": "dev"},
+			want:     managed.ExternalObservation{ResourceExists: true},
+		},
+		"VariableRemoved": {
+			cr:       stage(nil),
+			observed: map[string]string{"env": "prod"},
+			want:     managed.ExternalObservation{ResourceExists: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: &mockClient{MockGetStages: getStages(tc.observed)}}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		input *svcsdk.UpdateStageInput
+		err   error
+	}
+
+	update := func(vars map[string]string) *svcsdk.UpdateStageInput {
+		return &svcsdk.UpdateStageInput{
+			ApiId:          &apiID,
+			StageName:      &stageName,
+			StageVariables: awsgo.StringMap(vars),
+		}
+	}
+
+	cases := map[string]struct {
+		cr        *svcapitypes.Stage
+		observed  map[string]string
+		updateErr error
+		want
+	}{
+		"AddVariable": {
+			cr:       stage(map[string]string{"env": "prod", "region": "eu"}),
+			observed: map[string]string{"env": "prod"},
+			want:     want{input: update(map[string]string{"region": "eu"})},
+		},
+		"UpdateVariable": {
+			cr:       stage(map[string]string{"env": "prod"}),
+			observed: map[string]string{"env": "dev"},
+			want:     want{input: update(map[string]string{"env": "prod"})},
+		},
+		"RemoveVariable": {
+			cr:       stage(map[string]string{"env": "prod"}),
+			observed: map[string]string{"env": "prod", "region": "eu"},
+			want:     want{input: update(map[string]string{"region": ""})},
+		},
+		"RemoveAllVariables": {
+			cr:       stage(nil),
+			observed: map[string]string{"env": "prod"},
+			want:     want{input: update(map[string]string{"env": ""})},
+		},
+		"Unchanged": {
+			cr:       stage(map[string]string{}),
+			observed: nil,
+		},
+		"UpdateFailed": {
+			cr:        stage(map[string]string{"env": "prod"}),
+			observed:  map[string]string{"env": "dev"},
+			updateErr: errBoom,
+			want: want{
+				input: update(map[string]string{"env": "prod"}),
+				err:   errors.Wrap(errBoom, errUpdate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var input *svcsdk.UpdateStageInput
+			e := &external{client: &mockClient{
+				MockGetStages: getStages(tc.observed),
+				MockUpdateStage: func(in *svcsdk.UpdateStageInput) (*svcsdk.UpdateStageOutput, error) {
+					input = in
+					return &svcsdk.UpdateStageOutput{}, tc.updateErr
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.input, input); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(aws.String(apiID), tc.cr.Spec.ForProvider.APIID); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func routeSettings(burst int64, rate float64) *svcapitypes.RouteSettings {
+	return &svcapitypes.RouteSettings{
+		DetailedMetricsEnabled: awsgo.Bool(true),
+		ThrottlingBurstLimit:   awsgo.Int64(burst),
+		ThrottlingRateLimit:    awsgo.Float64(rate),
+	}
+}
+
+func getStagesWithRouteSettings(rs *svcsdk.RouteSettings) func(*svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+	return func(_ *svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+		return &svcsdk.GetStagesOutput{Items: []*svcsdk.Stage{{StageName: &stageName, DefaultRouteSettings: rs}}}, nil
+	}
+}
+
+func TestObserveDefaultRouteSettings(t *testing.T) {
+	cases := map[string]struct {
+		desired  *svcapitypes.RouteSettings
+		observed *svcsdk.RouteSettings
+		want     bool
+	}{
+		"UpToDate": {
+			desired:  routeSettings(100, 50),
+			observed: generateRouteSettings(routeSettings(100, 50)),
+			want:     true,
+		},
+		"Unspecified": {
+			observed: generateRouteSettings(routeSettings(100, 50)),
+			want:     true,
+		},
+		"NotSet": {
+			desired: routeSettings(100, 50),
+		},
+		"ThrottlingChanged": {
+			desired:  routeSettings(200, 50),
+			observed: generateRouteSettings(routeSettings(100, 50)),
+		},
+		"UnspecifiedSettingsIgnored": {
+			desired:  &svcapitypes.RouteSettings{ThrottlingBurstLimit: awsgo.Int64(100)},
+			observed: generateRouteSettings(routeSettings(100, 50)),
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := stage(nil)
+			cr.Spec.ForProvider.DefaultRouteSettings = tc.desired
+			e := &external{client: &mockClient{MockGetStages: getStagesWithRouteSettings(tc.observed)}}
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, o.ResourceUpToDate); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateDefaultRouteSettings(t *testing.T) {
+	type want struct {
+		input *svcsdk.UpdateStageInput
+		err   error
+	}
+
+	update := func(rs *svcapitypes.RouteSettings) *svcsdk.UpdateStageInput {
+		return &svcsdk.UpdateStageInput{
+			ApiId:                &apiID,
+			StageName:            &stageName,
+			DefaultRouteSettings: generateRouteSettings(rs),
+		}
+	}
+
+	cases := map[string]struct {
+		desired  *svcapitypes.RouteSettings
+		observed *svcsdk.RouteSettings
+		want
+	}{
+		"SetThrottling": {
+			desired: routeSettings(100, 50),
+			want:    want{input: update(routeSettings(100, 50))},
+		},
+		"ChangeThrottling": {
+			desired:  routeSettings(200, 75.5),
+			observed: generateRouteSettings(routeSettings(100, 50)),
+			want:     want{input: update(routeSettings(200, 75.5))},
+		},
+		"Unchanged": {
+			desired:  routeSettings(100, 50),
+			observed: generateRouteSettings(routeSettings(100, 50)),
+		},
+		"BurstLimitTooHigh": {
+			desired: routeSettings(maxThrottlingBurstLimit+1, 50),
+			want:    want{err: errors.Wrap(errors.Wrap(errors.Errorf(errThrottlingBurst, maxThrottlingBurstLimit), errInvalidParameters), "pre-update failed")},
+		},
+		"NegativeRateLimit": {
+			desired: routeSettings(100, -1),
+			want:    want{err: errors.Wrap(errors.Wrap(errors.Errorf(errThrottlingRate, maxThrottlingRateLimit), errInvalidParameters), "pre-update failed")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := stage(nil)
+			cr.Spec.ForProvider.DefaultRouteSettings = tc.desired
+			var input *svcsdk.UpdateStageInput
+			e := &external{client: &mockClient{
+				MockGetStages: getStagesWithRouteSettings(tc.observed),
+				MockUpdateStage: func(in *svcsdk.UpdateStageInput) (*svcsdk.UpdateStageOutput, error) {
+					input = in
+					return &svcsdk.UpdateStageOutput{}, nil
+				},
+			}}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.input, input); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func getStagesWithDeployment(autoDeploy bool, deploymentID *string) func(*svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+	return func(_ *svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+		return &svcsdk.GetStagesOutput{Items: []*svcsdk.Stage{{StageName: &stageName, AutoDeploy: &autoDeploy, DeploymentId: deploymentID}}}, nil
+	}
+}
+
+func TestObserveDeployment(t *testing.T) {
+	cases := map[string]struct {
+		autoDeploy   *bool
+		deploymentID *string
+		observedAuto bool
+		observedID   *string
+		want         bool
+	}{
+		"AutoDeployEnabled": {
+			autoDeploy:   awsgo.Bool(true),
+			observedAuto: true,
+			observedID:   awsgo.String("auto"),
+			want:         true,
+		},
+		"AutoDeployNotYetEnabled": {
+			autoDeploy: awsgo.Bool(true),
+			observedID: awsgo.String("pinned"),
+		},
+		"Pinned": {
+			deploymentID: awsgo.String("pinned"),
+			observedID:   awsgo.String("pinned"),
+			want:         true,
+		},
+		"PinnedWhileAutoDeploying": {
+			deploymentID: awsgo.String("pinned"),
+			observedAuto: true,
+			observedID:   awsgo.String("pinned"),
+		},
+		"PinnedToOtherDeployment": {
+			deploymentID: awsgo.String("pinned"),
+			observedID:   awsgo.String("other"),
+		},
+		"Unspecified": {
+			observedAuto: true,
+			want:         true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := stage(nil)
+			cr.Spec.ForProvider.AutoDeploy = tc.autoDeploy
+			cr.Spec.ForProvider.DeploymentID = tc.deploymentID
+			e := &external{client: &mockClient{MockGetStages: getStagesWithDeployment(tc.observedAuto, tc.observedID)}}
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, o.ResourceUpToDate); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateDeployment(t *testing.T) {
+	type want struct {
+		inputs []*svcsdk.UpdateStageInput
+		err    error
+	}
+
+	update := func(autoDeploy *bool, deploymentID *string) *svcsdk.UpdateStageInput {
+		return &svcsdk.UpdateStageInput{
+			ApiId:        &apiID,
+			StageName:    &stageName,
+			AutoDeploy:   autoDeploy,
+			DeploymentId: deploymentID,
+		}
+	}
+
+	cases := map[string]struct {
+		autoDeploy   *bool
+		deploymentID *string
+		observedAuto bool
+		observedID   *string
+		updateErr    error
+		want
+	}{
+		"PinnedToAutoDeploy": {
+			autoDeploy: awsgo.Bool(true),
+			observedID: awsgo.String("pinned"),
+			want:       want{inputs: []*svcsdk.UpdateStageInput{update(awsgo.Bool(true), nil)}},
+		},
+		"AutoDeployToPinned": {
+			deploymentID: awsgo.String("pinned"),
+			observedAuto: true,
+			observedID:   awsgo.String("auto"),
+			want: want{inputs: []*svcsdk.UpdateStageInput{
+				update(awsgo.Bool(false), nil),
+				update(nil, awsgo.String("pinned")),
+			}},
+		},
+		"AutoDeployToPinnedCurrentDeployment": {
+			autoDeploy:   awsgo.Bool(false),
+			deploymentID: awsgo.String("auto"),
+			observedAuto: true,
+			observedID:   awsgo.String("auto"),
+			want:         want{inputs: []*svcsdk.UpdateStageInput{update(awsgo.Bool(false), nil)}},
+		},
+		"RepinDeployment": {
+			deploymentID: awsgo.String("pinned"),
+			observedID:   awsgo.String("other"),
+			want:         want{inputs: []*svcsdk.UpdateStageInput{update(nil, awsgo.String("pinned"))}},
+		},
+		"Unchanged": {
+			deploymentID: awsgo.String("pinned"),
+			observedID:   awsgo.String("pinned"),
+		},
+		"BothSet": {
+			autoDeploy:   awsgo.Bool(true),
+			deploymentID: awsgo.String("pinned"),
+			want:         want{err: errors.Wrap(errors.Wrap(errors.New(errAutoDeployPinned), errInvalidParameters), "pre-update failed")},
+		},
+		"DisableAutoDeployFailed": {
+			deploymentID: awsgo.String("pinned"),
+			observedAuto: true,
+			updateErr:    errBoom,
+			want: want{
+				inputs: []*svcsdk.UpdateStageInput{update(awsgo.Bool(false), nil)},
+				err:    errors.Wrap(errBoom, errUpdate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := stage(nil)
+			cr.Spec.ForProvider.AutoDeploy = tc.autoDeploy
+			cr.Spec.ForProvider.DeploymentID = tc.deploymentID
+			var inputs []*svcsdk.UpdateStageInput
+			e := &external{client: &mockClient{
+				MockGetStages: getStagesWithDeployment(tc.observedAuto, tc.observedID),
+				MockUpdateStage: func(in *svcsdk.UpdateStageInput) (*svcsdk.UpdateStageOutput, error) {
+					inputs = append(inputs, in)
+					return &svcsdk.UpdateStageOutput{}, tc.updateErr
+				},
+			}}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.inputs, inputs); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func stageWithTags(tags map[string]string) *svcapitypes.Stage {
+	cr := stage(nil)
+	cr.Spec.ForProvider.Region = "us-east-1"
+	if tags != nil {
+		cr.Spec.ForProvider.Tags = awsgo.StringMap(tags)
+	}
+	return cr
+}
+
+// withProviderConfig returns the supplied stage referencing the supplied
+// ProviderConfig.
+func withProviderConfig(cr *svcapitypes.Stage, name string) *svcapitypes.Stage {
+	cr.SetProviderConfigReference(&runtimev1alpha1.Reference{Name: name})
+	return cr
+}
+
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*apisv1beta1.ProviderConfig).Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
+func getStagesWithTags(tags map[string]string) func(*svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+	return func(_ *svcsdk.GetStagesInput) (*svcsdk.GetStagesOutput, error) {
+		s := &svcsdk.Stage{StageName: &stageName}
+		if tags != nil {
+			s.Tags = awsgo.StringMap(tags)
+		}
+		return &svcsdk.GetStagesOutput{Items: []*svcsdk.Stage{s}}, nil
+	}
+}
+
+func TestObserveTags(t *testing.T) {
+	cases := map[string]struct {
+		kube     client.Client
+		cr       *svcapitypes.Stage
+		observed map[string]string
+		want     managed.ExternalObservation
+		err      error
+	}{
+		"UpToDate": {
+			cr:       stageWithTags(map[string]string{"team": "cool"}),
+			observed: map[string]string{"team": "cool"},
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"TagChanged": {
+			cr:       stageWithTags(map[string]string{"team": "cool"}),
+			observed: map[string]string{"team": "uncool"},
+			want:     managed.ExternalObservation{ResourceExists: true},
+		},
+		"DefaultTagsUpToDate": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:       withProviderConfig(stageWithTags(map[string]string{"team": "cool"}), "default"),
+			observed: map[string]string{"team": "cool", "env": "prod"},
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"DefaultTagsChanged": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"env": "prod"})},
+			cr:       withProviderConfig(stageWithTags(nil), "default"),
+			observed: map[string]string{"env": "dev"},
+			want:     managed.ExternalObservation{ResourceExists: true},
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(stageWithTags(nil), "default"),
+			err:  errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: &mockClient{MockGetStages: getStagesWithTags(tc.observed)}}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateTags(t *testing.T) {
+	arn := "arn:aws:apigateway:us-east-1::/apis/abc123/stages/prod"
+
+	type want struct {
+		tag   *svcsdk.TagResourceInput
+		untag *svcsdk.UntagResourceInput
+		err   error
+	}
+
+	cases := map[string]struct {
+		kube     client.Client
+		cr       *svcapitypes.Stage
+		observed map[string]string
+		tagErr   error
+		untagErr error
+		want
+	}{
+		"Unchanged": {
+			cr:       stageWithTags(map[string]string{"team": "cool"}),
+			observed: map[string]string{"team": "cool"},
+		},
+		"AddAndRemoveTags": {
+			cr:       stageWithTags(map[string]string{"team": "cooler"}),
+			observed: map[string]string{"team": "cool", "b": "x", "a": "y"},
+			want: want{
+				untag: &svcsdk.UntagResourceInput{ResourceArn: &arn, TagKeys: awsgo.StringSlice([]string{"a", "b", "team"})},
+				tag:   &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"team": "cooler"})},
+			},
+		},
+		"AddDefaultTag": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:       withProviderConfig(stageWithTags(map[string]string{"team": "cool"}), "default"),
+			observed: map[string]string{"team": "cool"},
+			want: want{
+				tag: &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"env": "prod"})},
+			},
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(stageWithTags(nil), "default"),
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+			},
+		},
+		"TagFailed": {
+			cr:     stageWithTags(map[string]string{"team": "cool"}),
+			tagErr: errBoom,
+			want: want{
+				tag: &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"team": "cool"})},
+				err: errors.Wrap(errBoom, errTag),
+			},
+		},
+		"UntagFailed": {
+			cr:       stageWithTags(nil),
+			observed: map[string]string{"team": "cool"},
+			untagErr: errBoom,
+			want: want{
+				untag: &svcsdk.UntagResourceInput{ResourceArn: &arn, TagKeys: awsgo.StringSlice([]string{"team"})},
+				err:   errors.Wrap(errBoom, errUntag),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var tag *svcsdk.TagResourceInput
+			var untag *svcsdk.UntagResourceInput
+			e := &external{kube: tc.kube, client: &mockClient{
+				MockGetStages: getStagesWithTags(tc.observed),
+				MockTagResource: func(in *svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error) {
+					tag = in
+					return &svcsdk.TagResourceOutput{}, tc.tagErr
+				},
+				MockUntagResource: func(in *svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error) {
+					untag = in
+					return &svcsdk.UntagResourceOutput{}, tc.untagErr
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.tag, tag); diff != "" {
+				t.Errorf("tag: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.untag, untag); diff != "" {
+				t.Errorf("untag: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPreCreate(t *testing.T) {
+	cases := map[string]struct {
+		kube client.Client
+		cr   *svcapitypes.Stage
+		want map[string]*string
+		err  error
+	}{
+		"DefaultTags": {
+			kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:   withProviderConfig(stageWithTags(map[string]string{"team": "cool"}), "default"),
+			want: awsgo.StringMap(map[string]string{"team": "cool", "env": "prod"}),
+		},
+		"NoProviderConfig": {
+			cr:   stageWithTags(map[string]string{"team": "cool"}),
+			want: awsgo.StringMap(map[string]string{"team": "cool"}),
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(stageWithTags(nil), "default"),
+			err:  errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube}
+			err := e.preCreate(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("preCreate(...): -want, +got:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, tc.cr.Spec.ForProvider.Tags); diff != "" {
+				t.Errorf("tags: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
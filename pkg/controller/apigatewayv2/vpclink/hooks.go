@@ -18,9 +18,18 @@ package vpclink
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -32,25 +41,47 @@ import (
 
 	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	errTag   = "cannot tag VPCLink in AWS"
+	errUntag = "cannot untag VPCLink in AWS"
+
+	msgImmutableFieldsChanged = "cannot change %s of an existing VPC link; delete and recreate it to apply the change"
+)
+
+// TypeImmutableFieldsSynced indicates whether the fields of a VPC link that
+// cannot be changed once it exists, i.e. its security groups and subnets,
+// match those of the VPC link in AWS.
+const TypeImmutableFieldsSynced v1alpha1.ConditionType = "ImmutableFieldsSynced"
+
+// Reasons of the ImmutableFieldsSynced condition.
+const (
+	ReasonImmutableFieldsUnchanged v1alpha1.ConditionReason = "ImmutableFieldsUnchanged"
+	ReasonImmutableFieldsChanged   v1alpha1.ConditionReason = "ImmutableFieldsChanged"
 )
 
 // SetupVPCLink adds a controller that reconciles VPCLink.
-func SetupVPCLink(mgr ctrl.Manager, l logging.Logger) error {
+func SetupVPCLink(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(svcapitypes.VPCLinkGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&svcapitypes.VPCLink{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(svcapitypes.VPCLinkGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient()}, record, l.WithValues("controller", name), svcapitypes.VPCLinkKind)),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 func (*external) preObserve(context.Context, *svcapitypes.VPCLink) error {
 	return nil
 }
-func (e *external) postObserve(_ context.Context, cr *svcapitypes.VPCLink, resp *svcsdk.GetVpcLinksOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
+func (e *external) postObserve(ctx context.Context, cr *svcapitypes.VPCLink, resp *svcsdk.GetVpcLinksOutput, obs managed.ExternalObservation, err error) (managed.ExternalObservation, error) {
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
@@ -61,9 +92,82 @@ func (e *external) postObserve(_ context.Context, cr *svcapitypes.VPCLink, resp
 	if aws.StringValue(vl[0].VpcLinkStatus) == "AVAILABLE" {
 		cr.SetConditions(v1alpha1.Available())
 	}
+	cr.SetConditions(immutableFieldsSynced(changedImmutableFields(&cr.Spec.ForProvider, vl[0])))
+	// Only the tags of a VPC link can be updated.
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	add, remove := aws.DiffTags(stringMap(tags), stringMap(vl[0].Tags))
+	obs.ResourceUpToDate = len(add) == 0 && len(remove) == 0
 	return obs, nil
 }
 
+// desiredTags returns the tags of the supplied VPC link merged with the default
+// tags of its ProviderConfig. Default tags are merged at request time rather
+// than written to the spec, so that changes to them apply to existing VPC
+// links.
+func (e *external) desiredTags(ctx context.Context, cr *svcapitypes.VPCLink) (map[string]*string, error) {
+	defaults, err := aws.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	return aws.MergeTagPtrs(cr.Spec.ForProvider.Tags, defaults), nil
+}
+
+// changedImmutableFields returns the names of the fields of the supplied
+// parameters that cannot be updated, but differ from the supplied VPC link.
+// The order of security groups and subnets is not significant.
+func changedImmutableFields(p *svcapitypes.VPCLinkParameters, vl *svcsdk.VpcLink) []string {
+	sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	var changed []string
+	if !cmp.Equal(p.SecurityGroupIDs, awsgo.StringValueSlice(vl.SecurityGroupIds), sortStrings, cmpopts.EquateEmpty()) {
+		changed = append(changed, "securityGroupIds")
+	}
+	if !cmp.Equal(p.SubnetIDs, awsgo.StringValueSlice(vl.SubnetIds), sortStrings, cmpopts.EquateEmpty()) {
+		changed = append(changed, "subnetIds")
+	}
+	return changed
+}
+
+// immutableFieldsSynced returns an ImmutableFieldsSynced condition reporting
+// the supplied changed immutable fields, if any.
+func immutableFieldsSynced(changed []string) v1alpha1.Condition {
+	if len(changed) == 0 {
+		return v1alpha1.Condition{
+			Type:               TypeImmutableFieldsSynced,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonImmutableFieldsUnchanged,
+		}
+	}
+	return v1alpha1.Condition{
+		Type:               TypeImmutableFieldsSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutableFieldsChanged,
+		Message:            fmt.Sprintf(msgImmutableFieldsChanged, strings.Join(changed, ", ")),
+	}
+}
+
+func stringMap(in map[string]*string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = aws.StringValue(v)
+	}
+	return out
+}
+
+// vpcLinkARN returns the ARN of the VPC link with the supplied ID, which is
+// required to tag it.
+func vpcLinkARN(region, id string) string {
+	partition := "aws"
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+	return fmt.Sprintf("arn:%s:apigateway:%s::/vpclinks/%s", partition, region, id)
+}
+
 func (*external) filterList(cr *svcapitypes.VPCLink, list *svcsdk.GetVpcLinksOutput) *svcsdk.GetVpcLinksOutput {
 	res := &svcsdk.GetVpcLinksOutput{}
 	for _, vl := range list.Items {
@@ -75,7 +179,15 @@ func (*external) filterList(cr *svcapitypes.VPCLink, list *svcsdk.GetVpcLinksOut
 	return res
 }
 
-func (*external) preCreate(context.Context, *svcapitypes.VPCLink) error {
+// preCreate adds the default tags of the ProviderConfig to the tags the VPC
+// link is created with. The managed reconciler does not persist the spec of
+// the VPC link after creating it, so they are not written to the spec.
+func (e *external) preCreate(ctx context.Context, cr *svcapitypes.VPCLink) error {
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return err
+	}
+	cr.Spec.ForProvider.Tags = tags
 	return nil
 }
 
@@ -87,8 +199,36 @@ func (*external) preUpdate(context.Context, *svcapitypes.VPCLink) error {
 	return nil
 }
 
-func (*external) postUpdate(_ context.Context, _ *svcapitypes.VPCLink, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
-	return upd, err
+func (e *external) postUpdate(ctx context.Context, cr *svcapitypes.VPCLink, upd managed.ExternalUpdate, err error) (managed.ExternalUpdate, error) {
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	resp, err := e.client.GetVpcLinksWithContext(ctx, GenerateGetVpcLinksInput(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribe)
+	}
+	vl := e.filterList(cr, resp).Items
+	if len(vl) != 1 {
+		return upd, nil
+	}
+	arn := awsgo.String(vpcLinkARN(cr.Spec.ForProvider.Region, aws.StringValue(vl[0].VpcLinkId)))
+	tags, err := e.desiredTags(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	add, remove := aws.DiffTags(stringMap(tags), stringMap(vl[0].Tags))
+	if len(remove) != 0 {
+		sort.Strings(remove)
+		if _, err := e.client.UntagResourceWithContext(ctx, &svcsdk.UntagResourceInput{ResourceArn: arn, TagKeys: awsgo.StringSlice(remove)}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUntag)
+		}
+	}
+	if len(add) != 0 {
+		if _, err := e.client.TagResourceWithContext(ctx, &svcsdk.TagResourceInput{ResourceArn: arn, Tags: awsgo.StringMap(add)}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTag)
+		}
+	}
+	return upd, nil
 }
 func lateInitialize(*svcapitypes.VPCLinkParameters, *svcsdk.GetVpcLinksOutput) error {
 	return nil
@@ -0,0 +1,335 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpclink
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	svcapitypes "github.com/crossplane/provider-aws/apis/apigatewayv2/v1alpha1"
+	ec2 "github.com/crossplane/provider-aws/apis/ec2/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
+)
+
+var (
+	linkName = "cool-link"
+	linkID   = "abc123"
+	region   = "us-east-1"
+
+	errBoom = errors.New("boom")
+)
+
+type mockClient struct {
+	svcsdkapi.ApiGatewayV2API
+
+	MockGetVpcLinks   func(*svcsdk.GetVpcLinksInput) (*svcsdk.GetVpcLinksOutput, error)
+	MockTagResource   func(*svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error)
+	MockUntagResource func(*svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error)
+}
+
+func (m *mockClient) GetVpcLinksWithContext(_ context.Context, in *svcsdk.GetVpcLinksInput, _ ...request.Option) (*svcsdk.GetVpcLinksOutput, error) {
+	return m.MockGetVpcLinks(in)
+}
+
+func (m *mockClient) TagResourceWithContext(_ context.Context, in *svcsdk.TagResourceInput, _ ...request.Option) (*svcsdk.TagResourceOutput, error) {
+	return m.MockTagResource(in)
+}
+
+func (m *mockClient) UntagResourceWithContext(_ context.Context, in *svcsdk.UntagResourceInput, _ ...request.Option) (*svcsdk.UntagResourceOutput, error) {
+	return m.MockUntagResource(in)
+}
+
+func vpcLink(sgs, subnets []string, tags map[string]string) *svcapitypes.VPCLink {
+	cr := &svcapitypes.VPCLink{}
+	meta.SetExternalName(cr, linkName)
+	cr.Spec.ForProvider.Region = region
+	cr.Spec.ForProvider.SecurityGroupIDs = sgs
+	cr.Spec.ForProvider.SubnetIDs = subnets
+	if tags != nil {
+		cr.Spec.ForProvider.Tags = awsgo.StringMap(tags)
+	}
+	return cr
+}
+
+// withProviderConfig returns the supplied VPC link referencing the supplied
+// ProviderConfig.
+func withProviderConfig(cr *svcapitypes.VPCLink, name string) *svcapitypes.VPCLink {
+	cr.SetProviderConfigReference(&runtimev1alpha1.Reference{Name: name})
+	return cr
+}
+
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*apisv1beta1.ProviderConfig).Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
+func getVpcLinks(sgs, subnets []string, tags map[string]string) func(*svcsdk.GetVpcLinksInput) (*svcsdk.GetVpcLinksOutput, error) {
+	return func(_ *svcsdk.GetVpcLinksInput) (*svcsdk.GetVpcLinksOutput, error) {
+		return &svcsdk.GetVpcLinksOutput{Items: []*svcsdk.VpcLink{{
+			Name:             &linkName,
+			VpcLinkId:        &linkID,
+			VpcLinkStatus:    awsgo.String(svcsdk.VpcLinkStatusAvailable),
+			SecurityGroupIds: awsgo.StringSlice(sgs),
+			SubnetIds:        awsgo.StringSlice(subnets),
+			Tags:             awsgo.StringMap(tags),
+		}}}, nil
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	cr := &svcapitypes.VPCLink{}
+	cr.Spec.ForProvider.SecurityGroupIDRefs = []runtimev1alpha1.Reference{{Name: "cool-sg"}}
+	cr.Spec.ForProvider.SubnetIDRefs = []runtimev1alpha1.Reference{{Name: "cool-subnet-a"}, {Name: "cool-subnet-b"}}
+
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			switch o := obj.(type) {
+			case *ec2.SecurityGroup:
+				meta.SetExternalName(o, "sg-"+key.Name)
+			case *ec2.Subnet:
+				meta.SetExternalName(o, "subnet-"+key.Name)
+			default:
+				return errBoom
+			}
+			return nil
+		},
+	}
+	if err := cr.ResolveReferences(context.Background(), kube); err != nil {
+		t.Fatalf("ResolveReferences(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"sg-cool-sg"}, cr.Spec.ForProvider.SecurityGroupIDs); diff != "" {
+		t.Errorf("SecurityGroupIDs: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"subnet-cool-subnet-a", "subnet-cool-subnet-b"}, cr.Spec.ForProvider.SubnetIDs); diff != "" {
+		t.Errorf("SubnetIDs: -want, +got:\n%s", diff)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		upToDate bool
+		synced   runtimev1alpha1.Condition
+	}
+
+	cases := map[string]struct {
+		kube     client.Client
+		cr       *svcapitypes.VPCLink
+		observed func(*svcsdk.GetVpcLinksInput) (*svcsdk.GetVpcLinksOutput, error)
+		want     want
+	}{
+		"UpToDate": {
+			cr:       vpcLink([]string{"sg-a", "sg-b"}, []string{"subnet-a"}, map[string]string{"team": "cool"}),
+			observed: getVpcLinks([]string{"sg-b", "sg-a"}, []string{"subnet-a"}, map[string]string{"team": "cool"}),
+			want:     want{upToDate: true, synced: immutableFieldsSynced(nil)},
+		},
+		"TagsChanged": {
+			cr:       vpcLink([]string{"sg-a"}, []string{"subnet-a"}, map[string]string{"team": "cooler"}),
+			observed: getVpcLinks([]string{"sg-a"}, []string{"subnet-a"}, map[string]string{"team": "cool"}),
+			want:     want{synced: immutableFieldsSynced(nil)},
+		},
+		"DefaultTagsUpToDate": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:       withProviderConfig(vpcLink([]string{"sg-a"}, []string{"subnet-a"}, map[string]string{"team": "cool"}), "default"),
+			observed: getVpcLinks([]string{"sg-a"}, []string{"subnet-a"}, map[string]string{"team": "cool", "env": "prod"}),
+			want:     want{upToDate: true, synced: immutableFieldsSynced(nil)},
+		},
+		"DefaultTagsChanged": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"env": "prod"})},
+			cr:       withProviderConfig(vpcLink([]string{"sg-a"}, []string{"subnet-a"}, map[string]string{"team": "cool"}), "default"),
+			observed: getVpcLinks([]string{"sg-a"}, []string{"subnet-a"}, map[string]string{"team": "cool", "env": "dev"}),
+			want:     want{synced: immutableFieldsSynced(nil)},
+		},
+		"SecurityGroupsChanged": {
+			cr:       vpcLink([]string{"sg-c"}, []string{"subnet-a"}, nil),
+			observed: getVpcLinks([]string{"sg-a"}, []string{"subnet-a"}, nil),
+			want:     want{upToDate: true, synced: immutableFieldsSynced([]string{"securityGroupIds"})},
+		},
+		"SecurityGroupsAndSubnetsChanged": {
+			cr:       vpcLink([]string{"sg-c"}, []string{"subnet-b"}, nil),
+			observed: getVpcLinks([]string{"sg-a"}, []string{"subnet-a"}, nil),
+			want:     want{upToDate: true, synced: immutableFieldsSynced([]string{"securityGroupIds", "subnetIds"})},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: &mockClient{MockGetVpcLinks: tc.observed}}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.synced, tc.cr.GetCondition(TypeImmutableFieldsSynced), test.EquateConditions()); diff != "" {
+				t.Errorf("ImmutableFieldsSynced: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImmutableFieldsSynced(t *testing.T) {
+	c := immutableFieldsSynced([]string{"securityGroupIds", "subnetIds"})
+	if diff := cmp.Diff(corev1.ConditionFalse, c.Status); diff != "" {
+		t.Errorf("Status: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(fmt.Sprintf(msgImmutableFieldsChanged, "securityGroupIds, subnetIds"), c.Message); diff != "" {
+		t.Errorf("Message: -want, +got:\n%s", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	arn := "arn:aws:apigateway:us-east-1::/vpclinks/" + linkID
+
+	type want struct {
+		tag   *svcsdk.TagResourceInput
+		untag *svcsdk.UntagResourceInput
+		err   error
+	}
+
+	cases := map[string]struct {
+		kube     client.Client
+		cr       *svcapitypes.VPCLink
+		observed map[string]string
+		tagErr   error
+		want     want
+	}{
+		"AddTag": {
+			cr:       vpcLink(nil, nil, map[string]string{"team": "cool", "env": "prod"}),
+			observed: map[string]string{"team": "cool"},
+			want: want{
+				tag: &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"env": "prod"})},
+			},
+		},
+		"ChangeAndRemoveTags": {
+			cr:       vpcLink(nil, nil, map[string]string{"team": "cooler"}),
+			observed: map[string]string{"team": "cool", "env": "prod"},
+			want: want{
+				untag: &svcsdk.UntagResourceInput{ResourceArn: &arn, TagKeys: awsgo.StringSlice([]string{"env", "team"})},
+				tag:   &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"team": "cooler"})},
+			},
+		},
+		"AddDefaultTag": {
+			kube:     &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:       withProviderConfig(vpcLink(nil, nil, map[string]string{"team": "cool"}), "default"),
+			observed: map[string]string{"team": "cool"},
+			want: want{
+				tag: &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"env": "prod"})},
+			},
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(vpcLink(nil, nil, nil), "default"),
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+			},
+		},
+		"TagFailed": {
+			cr:     vpcLink(nil, nil, map[string]string{"team": "cool"}),
+			tagErr: errBoom,
+			want: want{
+				tag: &svcsdk.TagResourceInput{ResourceArn: &arn, Tags: awsgo.StringMap(map[string]string{"team": "cool"})},
+				err: errors.Wrap(errBoom, errTag),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var tag *svcsdk.TagResourceInput
+			var untag *svcsdk.UntagResourceInput
+			e := &external{kube: tc.kube, client: &mockClient{
+				MockGetVpcLinks: getVpcLinks(nil, nil, tc.observed),
+				MockTagResource: func(in *svcsdk.TagResourceInput) (*svcsdk.TagResourceOutput, error) {
+					tag = in
+					return &svcsdk.TagResourceOutput{}, tc.tagErr
+				},
+				MockUntagResource: func(in *svcsdk.UntagResourceInput) (*svcsdk.UntagResourceOutput, error) {
+					untag = in
+					return &svcsdk.UntagResourceOutput{}, nil
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.tag, tag); diff != "" {
+				t.Errorf("TagResourceInput: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.untag, untag); diff != "" {
+				t.Errorf("UntagResourceInput: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPreCreate(t *testing.T) {
+	cases := map[string]struct {
+		kube client.Client
+		cr   *svcapitypes.VPCLink
+		want map[string]*string
+		err  error
+	}{
+		"DefaultTags": {
+			kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod"})},
+			cr:   withProviderConfig(vpcLink(nil, nil, map[string]string{"team": "cool"}), "default"),
+			want: awsgo.StringMap(map[string]string{"team": "cool", "env": "prod"}),
+		},
+		"NoProviderConfig": {
+			cr:   vpcLink(nil, nil, map[string]string{"team": "cool"}),
+			want: awsgo.StringMap(map[string]string{"team": "cool"}),
+		},
+		"GetProviderConfigFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:   withProviderConfig(vpcLink(nil, nil, nil), "default"),
+			err:  errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube}
+			err := e.preCreate(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("preCreate(...): -want, +got:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, tc.cr.Spec.ForProvider.Tags); diff != "" {
+				t.Errorf("tags: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -52,6 +54,7 @@ import (
 	"github.com/crossplane/provider-aws/pkg/controller/ec2/vpc"
 	"github.com/crossplane/provider-aws/pkg/controller/ecr/repository"
 	"github.com/crossplane/provider-aws/pkg/controller/eks"
+	"github.com/crossplane/provider-aws/pkg/controller/eks/fargateprofile"
 	"github.com/crossplane/provider-aws/pkg/controller/eks/nodegroup"
 	"github.com/crossplane/provider-aws/pkg/controller/elasticloadbalancing/elb"
 	"github.com/crossplane/provider-aws/pkg/controller/elasticloadbalancing/elbattachment"
@@ -72,12 +75,13 @@ import (
 	"github.com/crossplane/provider-aws/pkg/controller/s3"
 	"github.com/crossplane/provider-aws/pkg/controller/s3/bucketpolicy"
 	"github.com/crossplane/provider-aws/pkg/controller/sqs/queue"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
-// Setup creates all AWS controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
+// Setup creates all AWS controllers with the supplied logger and options and
+// adds them to the supplied manager.
+func Setup(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
+	for _, setup := range []func(ctrl.Manager, logging.Logger, reconciler.Options) error{
 		config.Setup,
 		cache.SetupReplicationGroup,
 		cachesubnetgroup.SetupCacheSubnetGroup,
@@ -87,6 +91,7 @@ func Setup(mgr ctrl.Manager, l logging.Logger) error {
 		elb.SetupELB,
 		elbattachment.SetupELBAttachment,
 		nodegroup.SetupNodeGroup,
+		fargateprofile.SetupFargateProfile,
 		s3.SetupBucket,
 		bucketpolicy.SetupBucketPolicy,
 		iamaccesskey.SetupIAMAccessKey,
@@ -130,10 +135,13 @@ func Setup(mgr ctrl.Manager, l logging.Logger) error {
 		routeresponse.SetupRouteResponse,
 		vpclink.SetupVPCLink,
 	} {
-		if err := setup(mgr, l); err != nil {
+		if err := setup(mgr, l, o); err != nil {
 			return err
 		}
 	}
 
+	if o.PostReconcile != nil {
+		return o.PostReconcile.Watch(context.Background(), mgr.GetCache(), mgr.GetScheme())
+	}
 	return nil
 }
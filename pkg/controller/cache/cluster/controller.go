@@ -34,6 +34,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/cache/v1alpha1"
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/elasticache"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 // Error strings.
@@ -47,19 +48,21 @@ const (
 )
 
 // SetupCacheCluster adds a controller that reconciles CacheCluster.
-func SetupCacheCluster(mgr ctrl.Manager, l logging.Logger) error {
+func SetupCacheCluster(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.CacheClusterGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.CacheCluster{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.CacheClusterGroupVersionKind),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: elasticache.NewClient}),
+			managed.WithExternalConnecter(reconciler.NewTimeoutConnecter(&connector{kube: mgr.GetClient(), newClientFn: elasticache.NewClient}, record)),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		))
+			managed.WithRecorder(record),
+		)))
 }
 
 type connector struct {
@@ -38,6 +38,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/cache/v1beta1"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/elasticache"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 // Error strings.
@@ -53,20 +54,22 @@ const (
 )
 
 // SetupReplicationGroup adds a controller that reconciles ReplicationGroups.
-func SetupReplicationGroup(mgr ctrl.Manager, l logging.Logger) error {
+func SetupReplicationGroup(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1beta1.ReplicationGroupGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1beta1.ReplicationGroup{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.ReplicationGroupGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: elasticache.NewClient}),
+			managed.WithExternalConnecter(reconciler.NewConnectionKeysConnecter(reconciler.NewTimeoutConnecter(&connector{kube: mgr.GetClient(), newClientFn: elasticache.NewClient}, record), record)),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), &tagger{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		))
+			managed.WithRecorder(record),
+		)))
 }
 
 type connector struct {
@@ -38,6 +38,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/database/v1beta1"
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	dbsg "github.com/crossplane/provider-aws/pkg/clients/dbsubnetgroup"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
@@ -53,19 +54,21 @@ const (
 )
 
 // SetupDBSubnetGroup adds a controller that reconciles DBSubnetGroups.
-func SetupDBSubnetGroup(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDBSubnetGroup(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1beta1.DBSubnetGroupGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1beta1.DBSubnetGroup{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.DBSubnetGroupGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: dbsg.NewClient}),
+			managed.WithExternalConnecter(reconciler.NewTimeoutConnecter(&connector{kube: mgr.GetClient(), newClientFn: dbsg.NewClient}, record)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 type connector struct {
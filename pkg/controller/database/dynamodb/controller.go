@@ -36,6 +36,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/database/v1alpha1"
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/dynamodb"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
@@ -49,17 +50,19 @@ const (
 )
 
 // SetupDynamoTable adds a controller that reconciles DynamoTable.
-func SetupDynamoTable(mgr ctrl.Manager, l logging.Logger) error {
+func SetupDynamoTable(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.DynamoTableGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.DynamoTable{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.DynamoTableGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: dynamodb.NewClient}),
+			managed.WithExternalConnecter(reconciler.NewTimeoutConnecter(&connector{kube: mgr.GetClient(), newClientFn: dynamodb.NewClient}, record)),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 type connector struct {
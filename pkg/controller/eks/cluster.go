@@ -24,7 +24,9 @@ import (
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -36,6 +38,7 @@ import (
 	"github.com/crossplane/provider-aws/apis/eks/v1beta1"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/eks"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
@@ -53,25 +56,31 @@ const (
 )
 
 // SetupCluster adds a controller that reconciles Clusters.
-func SetupCluster(mgr ctrl.Manager, l logging.Logger) error {
+func SetupCluster(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1beta1.ClusterGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1beta1.Cluster{}).
-		Complete(managed.NewReconciler(mgr,
+		For(&v1beta1.Cluster{}, builder.WithPredicates(reconciler.CoalescePredicate(o))).
+		Watches(&source.Kind{Type: &v1beta1.Cluster{}}, &reconciler.BackoffResetHandler{CoalesceWindow: o.CoalesceWindow}).
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.ClusterGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: eks.NewEKSClient, newSTSClientFn: eks.NewSTSClient}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient(), newClientFn: eks.NewEKSClient, newSTSClientFn: eks.NewSTSClient, cache: eks.NewUpToDateCache()}, record, l.WithValues("controller", name), v1beta1.ClusterKind, reconciler.WithConnecterHistory(reconciler.DefaultHistorySize), reconciler.WithConnecterConnectionKeys())),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), &tagger{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 type connector struct {
 	kube           client.Client
 	newClientFn    func(config aws.Config) eks.Client
 	newSTSClientFn func(config aws.Config) eks.STSClient
+
+	// cache is shared by all external clients so that it outlives reconciles.
+	cache *eks.UpToDateCache
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -83,13 +92,14 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, err
 	}
-	return &external{client: c.newClientFn(*cfg), sts: c.newSTSClientFn(*cfg), kube: c.kube}, nil
+	return &external{client: c.newClientFn(*cfg), sts: c.newSTSClientFn(*cfg), kube: c.kube, cache: c.cache}, nil
 }
 
 type external struct {
 	client eks.Client
 	sts    eks.STSClient
 	kube   client.Client
+	cache  *eks.UpToDateCache
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -99,6 +109,9 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	rsp, err := e.client.DescribeClusterRequest(&awseks.DescribeClusterInput{Name: aws.String(meta.GetExternalName(cr))}).Send(ctx)
+	if eks.IsErrorNotFound(err) {
+		e.cache.Forget(cr)
+	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(eks.IsErrorNotFound, err), errDescribeFailed)
 	}
@@ -122,7 +135,11 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	default:
 		cr.Status.SetConditions(runtimev1alpha1.Unavailable())
 	}
-	upToDate, err := eks.IsUpToDate(&cr.Spec.ForProvider, rsp.Cluster)
+	p, err := e.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	upToDate, err := e.cache.IsUpToDate(cr, p, rsp.Cluster)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errUpToDateFailed)
 	}
@@ -143,7 +160,11 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if cr.Status.AtProvider.Status == v1beta1.ClusterStatusCreating {
 		return managed.ExternalCreation{}, nil
 	}
-	_, err := e.client.CreateClusterRequest(eks.GenerateCreateClusterInput(meta.GetExternalName(cr), &cr.Spec.ForProvider)).Send(ctx)
+	p, err := e.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	_, err = e.client.CreateClusterRequest(eks.GenerateCreateClusterInput(meta.GetExternalName(cr), p)).Send(ctx)
 	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 }
 
@@ -163,7 +184,11 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if err != nil || rsp.Cluster == nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribeFailed)
 	}
-	add, remove := awsclients.DiffTags(cr.Spec.ForProvider.Tags, rsp.Cluster.Tags)
+	p, err := e.desiredParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	add, remove := awsclients.DiffTags(p.Tags, rsp.Cluster.Tags)
 	if len(remove) != 0 {
 		if _, err := e.client.UntagResourceRequest(&awseks.UntagResourceInput{ResourceArn: rsp.Cluster.Arn, TagKeys: remove}).Send(ctx); err != nil {
 			return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(eks.IsErrorInUse, err), errAddTagsFailed)
@@ -174,7 +199,7 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 			return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(eks.IsErrorInUse, err), errAddTagsFailed)
 		}
 	}
-	patch, err := eks.CreatePatch(rsp.Cluster, &cr.Spec.ForProvider)
+	patch, err := eks.CreatePatch(rsp.Cluster, p)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errPatchCreationFailed)
 	}
@@ -186,12 +211,27 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(eks.IsErrorInUse, err), errUpdateConfigFailed)
 }
 
+// desiredParameters returns the parameters of the supplied cluster with the
+// default tags of its ProviderConfig merged into its tags. Default tags are
+// merged here rather than written to the spec, so that changes to them apply
+// to existing clusters.
+func (e *external) desiredParameters(ctx context.Context, cr *v1beta1.Cluster) (*v1beta1.ClusterParameters, error) {
+	defaults, err := awsclients.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	p := cr.Spec.ForProvider.DeepCopy()
+	p.Tags = awsclients.MergeTags(p.Tags, defaults)
+	return p, nil
+}
+
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1beta1.Cluster)
 	if !ok {
 		return errors.New(errNotEKSCluster)
 	}
 	cr.SetConditions(runtimev1alpha1.Deleting())
+	e.cache.Forget(cr)
 	if cr.Status.AtProvider.Status == v1beta1.ClusterStatusDeleting {
 		return nil
 	}
@@ -208,11 +248,15 @@ func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotEKSCluster)
 	}
+	current := cr.Spec.ForProvider.DeepCopy()
 	if cr.Spec.ForProvider.Tags == nil {
 		cr.Spec.ForProvider.Tags = map[string]string{}
 	}
 	for k, v := range resource.GetExternalTags(mg) {
 		cr.Spec.ForProvider.Tags[k] = v
 	}
+	if reflect.DeepEqual(current, &cr.Spec.ForProvider) {
+		return nil
+	}
 	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateFailed)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -34,6 +35,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-aws/apis/eks/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
 	"github.com/crossplane/provider-aws/pkg/clients/eks"
 	"github.com/crossplane/provider-aws/pkg/clients/eks/fake"
 )
@@ -78,6 +80,10 @@ func withConfig(c v1beta1.VpcConfigRequest) clusterModifier {
 	return func(r *v1beta1.Cluster) { r.Spec.ForProvider.ResourcesVpcConfig = c }
 }
 
+func withProviderConfig(name string) clusterModifier {
+	return func(r *v1beta1.Cluster) { r.SetProviderConfigReference(&runtimev1alpha1.Reference{Name: name}) }
+}
+
 func cluster(m ...clusterModifier) *v1beta1.Cluster {
 	cr := &v1beta1.Cluster{}
 	for _, f := range m {
@@ -86,6 +92,13 @@ func cluster(m ...clusterModifier) *v1beta1.Cluster {
 	return cr
 }
 
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*apisv1beta1.ProviderConfig).Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
 var _ managed.ExternalClient = &external{}
 var _ managed.ExternalConnecter = &connector{}
 
@@ -126,6 +139,60 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"DefaultTagsDrifted": {
+			args: args{
+				kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"env": "prod"})},
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+						return awseks.DescribeClusterRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeClusterOutput{
+								Cluster: &awseks.Cluster{
+									Status: awseks.ClusterStatusActive,
+									Tags:   map[string]string{"foo": "bar"},
+								},
+							}},
+						}
+					},
+				},
+				cr: cluster(withProviderConfig("default"), withTags(map[string]string{"foo": "bar"})),
+			},
+			want: want{
+				cr: cluster(
+					withProviderConfig("default"),
+					withTags(map[string]string{"foo": "bar"}),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1beta1.ClusterStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: eks.GetConnectionDetails(&awseks.Cluster{}, &sts.Client{}),
+				},
+			},
+		},
+		"GetProviderConfigFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+						return awseks.DescribeClusterRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeClusterOutput{
+								Cluster: &awseks.Cluster{
+									Status: awseks.ClusterStatusActive,
+								},
+							}},
+						}
+					},
+				},
+				cr: cluster(withProviderConfig("default")),
+			},
+			want: want{
+				cr: cluster(
+					withProviderConfig("default"),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1beta1.ClusterStatusActive)),
+				err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+			},
+		},
 		"DeletingState": {
 			args: args{
 				eks: &fake.MockClient{
@@ -269,7 +336,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{kube: tc.kube, client: tc.eks}
+			e := &external{kube: tc.kube, client: tc.eks, cache: eks.NewUpToDateCache()}
 			o, err := e.Observe(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -342,7 +409,7 @@ func TestCreate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{kube: tc.kube, client: tc.eks}
+			e := &external{kube: tc.kube, client: tc.eks, cache: eks.NewUpToDateCache()}
 			o, err := e.Create(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -398,6 +465,43 @@ func TestUpdate(t *testing.T) {
 					withTags(map[string]string{"foo": "bar"})),
 			},
 		},
+		"SuccessfulAddDefaultTags": {
+			args: args{
+				kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"foo": "baz", "env": "prod"})},
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: func(input *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+						return awseks.DescribeClusterRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeClusterOutput{
+								Cluster: &awseks.Cluster{
+									Tags: map[string]string{"foo": "bar"},
+								},
+							}},
+						}
+					},
+					MockUpdateClusterConfigRequest: func(input *awseks.UpdateClusterConfigInput) awseks.UpdateClusterConfigRequest {
+						return awseks.UpdateClusterConfigRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateClusterConfigOutput{}},
+						}
+					},
+					MockTagResourceRequest: func(input *awseks.TagResourceInput) awseks.TagResourceRequest {
+						if diff := cmp.Diff(map[string]string{"env": "prod"}, input.Tags); diff != "" {
+							t.Errorf("TagResource: -want, +got:\n%s", diff)
+						}
+						return awseks.TagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.TagResourceOutput{}},
+						}
+					},
+				},
+				cr: cluster(
+					withProviderConfig("default"),
+					withTags(map[string]string{"foo": "bar"})),
+			},
+			want: want{
+				cr: cluster(
+					withProviderConfig("default"),
+					withTags(map[string]string{"foo": "bar"})),
+			},
+		},
 		"SuccessfulRemoveTags": {
 			args: args{
 				eks: &fake.MockClient{
@@ -593,7 +697,7 @@ func TestUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{kube: tc.kube, client: tc.eks}
+			e := &external{kube: tc.kube, client: tc.eks, cache: eks.NewUpToDateCache()}
 			u, err := e.Update(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -678,7 +782,7 @@ func TestDelete(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{kube: tc.kube, client: tc.eks}
+			e := &external{kube: tc.kube, client: tc.eks, cache: eks.NewUpToDateCache()}
 			err := e.Delete(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -710,6 +814,15 @@ func TestInitialize(t *testing.T) {
 				cr: cluster(withTags(resource.GetExternalTags(cluster()), (map[string]string{"foo": "bar"}))),
 			},
 		},
+		"Unchanged": {
+			args: args{
+				cr:   cluster(withTags(resource.GetExternalTags(cluster()), map[string]string{"foo": "bar"})),
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			},
+			want: want{
+				cr: cluster(withTags(resource.GetExternalTags(cluster()), map[string]string{"foo": "bar"})),
+			},
+		},
 		"UpdateFailed": {
 			args: args{
 				cr:   cluster(),
@@ -0,0 +1,243 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fargateprofile
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
+	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/eks"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
+)
+
+const (
+	errNotEKSFargateProfile = "managed resource is not an EKS Fargate profile custom resource"
+	errKubeUpdateFailed     = "cannot update EKS Fargate profile custom resource"
+	errInvalidTags          = "invalid EKS Fargate profile tags"
+
+	errCreateFailed     = "cannot create EKS Fargate profile"
+	errAddTagsFailed    = "cannot add tags to EKS Fargate profile"
+	errRemoveTagsFailed = "cannot remove tags from EKS Fargate profile"
+	errDeleteFailed     = "cannot delete EKS Fargate profile"
+	errDescribeFailed   = "cannot describe EKS Fargate profile"
+)
+
+// SetupFargateProfile adds a controller that reconciles FargateProfiles.
+func SetupFargateProfile(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
+	name := managed.ControllerName(v1alpha1.FargateProfileKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.FargateProfile{}, builder.WithPredicates(reconciler.CoalescePredicate(o))).
+		Watches(&source.Kind{Type: &v1alpha1.FargateProfile{}}, &reconciler.BackoffResetHandler{CoalesceWindow: o.CoalesceWindow}).
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.FargateProfileGroupVersionKind),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient(), newClientFn: eks.NewFargateProfileClient}, record, l.WithValues("controller", name), v1alpha1.FargateProfileKind)),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), &tagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(reconciler.NewReferenceCache(mgr.GetClient(), mgr.GetCache(), o.ReferenceCacheTTL))),
+			reconciler.WithOptions(o),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(record))))
+}
+
+type connector struct {
+	kube        client.Client
+	newClientFn func(config aws.Config) eks.FargateProfileClient
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.FargateProfile)
+	if !ok {
+		return nil, errors.New(errNotEKSFargateProfile)
+	}
+	cfg, err := awsclients.GetConfig(ctx, c.kube, mg, cr.Spec.ForProvider.Region)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: c.newClientFn(*cfg), kube: c.kube}, nil
+}
+
+type external struct {
+	client eks.FargateProfileClient
+	kube   client.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.FargateProfile)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEKSFargateProfile)
+	}
+
+	rsp, err := e.client.DescribeFargateProfileRequest(&awseks.DescribeFargateProfileInput{FargateProfileName: aws.String(meta.GetExternalName(cr)), ClusterName: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(eks.IsErrorNotFound, err), errDescribeFailed)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	eks.LateInitializeFargateProfile(&cr.Spec.ForProvider, rsp.FargateProfile)
+	if !reflect.DeepEqual(current, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errKubeUpdateFailed)
+		}
+	}
+
+	p, err := e.resolveParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = eks.GenerateFargateProfileObservation(rsp.FargateProfile)
+	// Any of the statuses we don't explicitly address should be considered as
+	// the Fargate profile being unavailable.
+	switch cr.Status.AtProvider.Status { // nolint:exhaustive
+	case v1alpha1.FargateProfileStatusActive:
+		cr.Status.SetConditions(runtimev1alpha1.Available())
+	case v1alpha1.FargateProfileStatusCreating:
+		cr.Status.SetConditions(runtimev1alpha1.Creating())
+	case v1alpha1.FargateProfileStatusDeleting:
+		cr.Status.SetConditions(runtimev1alpha1.Deleting())
+	default:
+		cr.Status.SetConditions(runtimev1alpha1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: eks.IsFargateProfileUpToDate(p, rsp.FargateProfile),
+	}, nil
+}
+
+// resolveParameters returns the parameters of the supplied Fargate profile
+// with the default tags of its ProviderConfig. They are merged here rather
+// than written to the spec, so that changes to them apply to existing Fargate
+// profiles.
+func (e *external) resolveParameters(ctx context.Context, cr *v1alpha1.FargateProfile) (*v1alpha1.FargateProfileParameters, error) {
+	defaults, err := awsclients.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	p := cr.Spec.ForProvider.DeepCopy()
+	p.Tags = awsclients.MergeTags(p.Tags, defaults)
+	return p, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.FargateProfile)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEKSFargateProfile)
+	}
+	cr.SetConditions(runtimev1alpha1.Creating())
+	if cr.Status.AtProvider.Status == v1alpha1.FargateProfileStatusCreating {
+		return managed.ExternalCreation{}, nil
+	}
+	p, err := e.resolveParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := eks.ValidateTags(p.Tags); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidTags)
+	}
+	_, err = e.client.CreateFargateProfileRequest(eks.GenerateCreateFargateProfileInput(meta.GetExternalName(cr), p)).Send(ctx)
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+}
+
+// Update only syncs the tags of the Fargate profile. Its other fields cannot
+// be changed once it is created.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.FargateProfile)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotEKSFargateProfile)
+	}
+	rsp, err := e.client.DescribeFargateProfileRequest(&awseks.DescribeFargateProfileInput{FargateProfileName: aws.String(meta.GetExternalName(cr)), ClusterName: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribeFailed)
+	}
+	if rsp.FargateProfile == nil {
+		return managed.ExternalUpdate{}, errors.New(errDescribeFailed)
+	}
+	p, err := e.resolveParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := eks.ValidateTags(p.Tags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidTags)
+	}
+	add, remove := awsclients.DiffTags(p.Tags, eks.FilterAWSManagedTags(rsp.FargateProfile.Tags))
+	if len(remove) != 0 {
+		if _, err := e.client.UntagResourceRequest(&awseks.UntagResourceInput{ResourceArn: rsp.FargateProfile.FargateProfileArn, TagKeys: remove}).Send(ctx); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRemoveTagsFailed)
+		}
+	}
+	if len(add) != 0 {
+		if _, err := e.client.TagResourceRequest(&awseks.TagResourceInput{ResourceArn: rsp.FargateProfile.FargateProfileArn, Tags: add}).Send(ctx); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errAddTagsFailed)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.FargateProfile)
+	if !ok {
+		return errors.New(errNotEKSFargateProfile)
+	}
+	cr.SetConditions(runtimev1alpha1.Deleting())
+	if cr.Status.AtProvider.Status == v1alpha1.FargateProfileStatusDeleting {
+		return nil
+	}
+	_, err := e.client.DeleteFargateProfileRequest(&awseks.DeleteFargateProfileInput{FargateProfileName: aws.String(meta.GetExternalName(cr)), ClusterName: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+	return errors.Wrap(resource.Ignore(eks.IsErrorNotFound, err), errDeleteFailed)
+}
+
+type tagger struct {
+	kube client.Client
+}
+
+func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.FargateProfile)
+	if !ok {
+		return errors.New(errNotEKSFargateProfile)
+	}
+	current := cr.Spec.ForProvider.DeepCopy()
+	if cr.Spec.ForProvider.Tags == nil {
+		cr.Spec.ForProvider.Tags = map[string]string{}
+	}
+	for k, v := range resource.GetExternalTags(mg) {
+		cr.Spec.ForProvider.Tags[k] = v
+	}
+	if reflect.DeepEqual(current, &cr.Spec.ForProvider) {
+		return nil
+	}
+	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateFailed)
+}
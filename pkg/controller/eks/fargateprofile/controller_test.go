@@ -0,0 +1,571 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fargateprofile
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
+	"github.com/crossplane/provider-aws/pkg/clients/eks"
+	"github.com/crossplane/provider-aws/pkg/clients/eks/fake"
+)
+
+var (
+	profileArn = "arn:aws:eks:us-east-1:123456789012:fargateprofile/cool-cluster/cool-profile/abc"
+	subnetID   = "subnet-cool"
+
+	errBoom = errors.New("boom")
+)
+
+type args struct {
+	eks  eks.FargateProfileClient
+	kube client.Client
+	cr   *v1alpha1.FargateProfile
+}
+
+type fargateProfileModifier func(*v1alpha1.FargateProfile)
+
+func withConditions(c ...runtimev1alpha1.Condition) fargateProfileModifier {
+	return func(r *v1alpha1.FargateProfile) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withTags(t map[string]string) fargateProfileModifier {
+	return func(r *v1alpha1.FargateProfile) { r.Spec.ForProvider.Tags = t }
+}
+
+func withProviderConfig(name string) fargateProfileModifier {
+	return func(r *v1alpha1.FargateProfile) { r.SetProviderConfigReference(&runtimev1alpha1.Reference{Name: name}) }
+}
+
+func withSubnets(s ...string) fargateProfileModifier {
+	return func(r *v1alpha1.FargateProfile) { r.Spec.ForProvider.Subnets = s }
+}
+
+func withStatus(s v1alpha1.FargateProfileStatusType) fargateProfileModifier {
+	return func(r *v1alpha1.FargateProfile) { r.Status.AtProvider.Status = s }
+}
+
+func withArn(arn string) fargateProfileModifier {
+	return func(r *v1alpha1.FargateProfile) { r.Status.AtProvider.FargateProfileArn = arn }
+}
+
+func fargateProfile(m ...fargateProfileModifier) *v1alpha1.FargateProfile {
+	cr := &v1alpha1.FargateProfile{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func describeFargateProfile(fp *awseks.FargateProfile, err error) func(*awseks.DescribeFargateProfileInput) awseks.DescribeFargateProfileRequest {
+	return func(_ *awseks.DescribeFargateProfileInput) awseks.DescribeFargateProfileRequest {
+		return awseks.DescribeFargateProfileRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeFargateProfileOutput{FargateProfile: fp}, Error: err},
+		}
+	}
+}
+
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*apisv1beta1.ProviderConfig).Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connector{}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.FargateProfile
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAvailable": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						FargateProfileArn: &profileArn,
+						Status:            awseks.FargateProfileStatusActive,
+					}, nil),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.FargateProfileStatusActive),
+					withArn(profileArn)),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Creating": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						Status: awseks.FargateProfileStatusCreating,
+					}, nil),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(
+					withConditions(runtimev1alpha1.Creating()),
+					withStatus(v1alpha1.FargateProfileStatusCreating)),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Deleting": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						Status: awseks.FargateProfileStatusDeleting,
+					}, nil),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(
+					withConditions(runtimev1alpha1.Deleting()),
+					withStatus(v1alpha1.FargateProfileStatusDeleting)),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"CreateFailed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						Status: awseks.FargateProfileStatusCreateFailed,
+					}, nil),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(
+					withConditions(runtimev1alpha1.Unavailable()),
+					withStatus(v1alpha1.FargateProfileStatusCreateFailed)),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"TagsOutOfDate": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						Status: awseks.FargateProfileStatusActive,
+						Tags:   map[string]string{"foo": "bar"},
+					}, nil),
+				},
+				cr: fargateProfile(withTags(map[string]string{"foo": "baz"})),
+			},
+			want: want{
+				cr: fargateProfile(
+					withTags(map[string]string{"foo": "baz"}),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.FargateProfileStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"LateInitSubnets": {
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						Status:  awseks.FargateProfileStatusActive,
+						Subnets: []string{subnetID},
+					}, nil),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(
+					withSubnets(subnetID),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.FargateProfileStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"LateInitFailed": {
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						Status:  awseks.FargateProfileStatusActive,
+						Subnets: []string{subnetID},
+					}, nil),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr:  fargateProfile(withSubnets(subnetID)),
+				err: errors.Wrap(errBoom, errKubeUpdateFailed),
+			},
+		},
+		"NotFound": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(nil, errors.New(awseks.ErrCodeResourceNotFoundException)),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(),
+			},
+		},
+		"DescribeFailed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(nil, errBoom),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr:  fargateProfile(),
+				err: errors.Wrap(errBoom, errDescribeFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.eks}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr  *v1alpha1.FargateProfile
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				eks: &fake.MockClient{
+					MockCreateFargateProfileRequest: func(_ *awseks.CreateFargateProfileInput) awseks.CreateFargateProfileRequest {
+						return awseks.CreateFargateProfileRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateFargateProfileOutput{}},
+						}
+					},
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"AlreadyCreating": {
+			args: args{
+				cr: fargateProfile(withStatus(v1alpha1.FargateProfileStatusCreating)),
+			},
+			want: want{
+				cr: fargateProfile(withStatus(v1alpha1.FargateProfileStatusCreating),
+					withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"Failed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockCreateFargateProfileRequest: func(_ *awseks.CreateFargateProfileInput) awseks.CreateFargateProfileRequest {
+						return awseks.CreateFargateProfileRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr:  fargateProfile(withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.eks}
+			_, err := e.Create(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		result managed.ExternalUpdate
+		tagged map[string]string
+		untag  []string
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"UpToDate": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						FargateProfileArn: &profileArn,
+						Tags:              map[string]string{"foo": "bar"},
+					}, nil),
+				},
+				cr: fargateProfile(withTags(map[string]string{"foo": "bar"})),
+			},
+			want: want{
+				result: managed.ExternalUpdate{},
+			},
+		},
+		"TagsChanged": {
+			args: args{
+				cr: fargateProfile(withTags(map[string]string{"foo": "bar", "new": "tag"})),
+			},
+			want: want{
+				result: managed.ExternalUpdate{},
+				tagged: map[string]string{"new": "tag"},
+				untag:  []string{"old"},
+			},
+		},
+		"DefaultTags": {
+			args: args{
+				kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"foo": "baz", "env": "prod"})},
+				cr:   fargateProfile(withProviderConfig("default"), withTags(map[string]string{"foo": "bar"})),
+			},
+			want: want{
+				result: managed.ExternalUpdate{},
+				tagged: map[string]string{"env": "prod"},
+				untag:  []string{"old"},
+			},
+		},
+		"GetProviderConfigFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cr:   fargateProfile(withProviderConfig("default")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+			},
+		},
+		"DescribeFailed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(nil, errBoom),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errDescribeFailed),
+			},
+		},
+		"NoProfileDescribed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(nil, nil),
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				err: errors.New(errDescribeFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var tagged map[string]string
+			var untag []string
+			c := tc.eks
+			if c == nil {
+				c = &fake.MockClient{
+					MockDescribeFargateProfileRequest: describeFargateProfile(&awseks.FargateProfile{
+						FargateProfileArn: &profileArn,
+						Tags:              map[string]string{"foo": "bar", "old": "tag"},
+					}, nil),
+					MockTagResourceRequest: func(i *awseks.TagResourceInput) awseks.TagResourceRequest {
+						tagged = i.Tags
+						return awseks.TagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.TagResourceOutput{}},
+						}
+					},
+					MockUntagResourceRequest: func(i *awseks.UntagResourceInput) awseks.UntagResourceRequest {
+						untag = i.TagKeys
+						return awseks.UntagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UntagResourceOutput{}},
+						}
+					},
+				}
+			}
+			e := &external{kube: tc.kube, client: c}
+			u, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, u); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.tagged, tagged); diff != "" {
+				t.Errorf("tagged: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.untag, untag); diff != "" {
+				t.Errorf("untag: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1alpha1.FargateProfile
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDeleteFargateProfileRequest: func(_ *awseks.DeleteFargateProfileInput) awseks.DeleteFargateProfileRequest {
+						return awseks.DeleteFargateProfileRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DeleteFargateProfileOutput{}},
+						}
+					},
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"AlreadyDeleting": {
+			args: args{
+				cr: fargateProfile(withStatus(v1alpha1.FargateProfileStatusDeleting)),
+			},
+			want: want{
+				cr: fargateProfile(withStatus(v1alpha1.FargateProfileStatusDeleting),
+					withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"AlreadyDeleted": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDeleteFargateProfileRequest: func(_ *awseks.DeleteFargateProfileInput) awseks.DeleteFargateProfileRequest {
+						return awseks.DeleteFargateProfileRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(awseks.ErrCodeResourceNotFoundException)},
+						}
+					},
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr: fargateProfile(withConditions(runtimev1alpha1.Deleting())),
+			},
+		},
+		"Failed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDeleteFargateProfileRequest: func(_ *awseks.DeleteFargateProfileInput) awseks.DeleteFargateProfileRequest {
+						return awseks.DeleteFargateProfileRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: fargateProfile(),
+			},
+			want: want{
+				cr:  fargateProfile(withConditions(runtimev1alpha1.Deleting())),
+				err: errors.Wrap(errBoom, errDeleteFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, client: tc.eks}
+			err := e.Delete(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
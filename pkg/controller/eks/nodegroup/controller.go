@@ -18,13 +18,22 @@ package nodegroup
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsautoscaling "github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -35,40 +44,200 @@ import (
 
 	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
 	awsclients "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/autoscaling"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
 	"github.com/crossplane/provider-aws/pkg/clients/eks"
+	"github.com/crossplane/provider-aws/pkg/clients/iam"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
-	errNotEKSNodeGroup  = "managed resource is not an EKS node group custom resource"
-	errKubeUpdateFailed = "cannot update EKS node group custom resource"
-
-	errCreateFailed        = "cannot create EKS node group"
-	errUpdateConfigFailed  = "cannot update EKS node group configuration"
-	errUpdateVersionFailed = "cannot update EKS node group version"
-	errAddTagsFailed       = "cannot add tags to EKS node group"
-	errDeleteFailed        = "cannot delete EKS node group"
-	errDescribeFailed      = "cannot describe EKS node group"
+	errNotEKSNodeGroup   = "managed resource is not an EKS node group custom resource"
+	errKubeUpdateFailed  = "cannot update EKS node group custom resource"
+	errPatchCreateTime   = "cannot record the create time of EKS node group"
+	errGetClass          = "cannot get NodeGroupClass of EKS node group"
+	errInvalidParameters = "invalid EKS node group parameters"
+	errInvalidTags       = "invalid EKS node group tags"
+
+	errCreateFailed            = "cannot create EKS node group"
+	errUpdateConfigFailed      = "cannot update EKS node group configuration"
+	errUpdateVersionFailed     = "cannot update EKS node group version"
+	errAddTagsFailed           = "cannot add tags to EKS node group"
+	errDeleteFailed            = "cannot delete EKS node group"
+	errDescribeFailed          = "cannot describe EKS node group"
+	errDescribeCluster         = "cannot describe EKS cluster of node group"
+	errDescribeSubnets         = "cannot describe subnets of EKS node group"
+	errDescribeInstances       = "cannot describe instances of EKS node group"
+	errDescribeInstanceTypes   = "cannot describe instance types of EKS node group"
+	errDescribeOfferings       = "cannot describe instance type offerings of EKS node group"
+	errInstanceTypesNotOffered = "instance types %v of EKS node group are not offered in all of its availability zones %v"
+	errListInstanceProfiles    = "cannot list instance profiles of EKS node group role"
+	errGetNodeRole             = "cannot get EKS node group role"
+	errDescribeASGs            = "cannot describe Auto Scaling groups of EKS node group"
+	errTagASGs                 = "cannot add Cluster Autoscaler discovery tags to Auto Scaling groups of EKS node group"
+	errParseTrustPolicy        = "cannot parse trust policy of EKS node group role"
+	errNodeRoleNotTrusted      = "the trust policy of EKS node group role %s does not allow " + eks.EC2ServicePrincipal + " to assume it"
+	errDescribeUpdate          = "cannot describe update of EKS node group"
+	errGetScalingConfigSource  = "cannot get scaling config source ConfigMap of EKS node group"
+	errSubnetsNotInVPC         = "subnets %v are not in VPC %s of EKS cluster %s"
+	errDescribeVPC             = "cannot describe VPC of EKS cluster of node group"
+	errServiceCIDR             = "cannot parse service CIDR of EKS cluster of node group"
+	errRequiredNodeLabels      = "cannot parse required node labels of EKS node group"
+	errMaintenanceWindow       = "cannot parse maintenance window of EKS node group"
+	errStuckDeleting           = "EKS node group has been deleting for longer than its deletion grace period of %s"
+
+	msgWaitingForNodes = "waiting for nodes: %d of at least %d running"
+	msgSubnetCapacity  = "the node group may add %d nodes, but its subnets only have %d free IP addresses"
+	msgServiceCIDR     = "subnets %v overlap the service CIDR %s of the EKS cluster"
+	msgNodeLabels      = "the labels of the node group do not match the required node labels %v; workloads that select them cannot be scheduled onto its nodes"
+	msgLaunchFailed    = "instances of the node group failed to launch; check the instance limits of the account and the free IP addresses of its subnets: %s"
+	msgJoinFailed      = "instances of the node group launched but failed to join the cluster; check that the node role is mapped in the aws-auth ConfigMap and that the nodes can reach the cluster API server: %s"
+	msgCreateBlocked   = "cannot create EKS node group: the node group limit of the account or cluster has been reached. Request a limit increase or delete unused node groups, then change this node group to retry"
+	msgObserveOnly     = "not updating EKS node group in observe-only mode; see status.diff for the changes that would be made"
+	msgUpdateDeferred  = "waiting for EKS cluster to become ACTIVE before updating the node group; it is %s"
+	msgMaintenance     = "waiting for maintenance window %s before updating the Kubernetes version of the node group to %s"
+
+	recommendSubnetCapacity = "Add subnets with free IP addresses to the node group or lower its maximum size: %s"
+	recommendServiceCIDR    = "Move the node group to subnets that do not overlap the service CIDR of its cluster: %s"
+	recommendNodeLabels     = "Add the required labels to the node group, or update the workloads that select them: %s"
+	recommendUpdateFailed   = "Resolve why the most recent update of the node group failed, then change the node group to retry it: %s"
+)
+
+// ReasonCreateBlocked indicates that a node group cannot be created until the
+// node group limit of its account or cluster is raised.
+const ReasonCreateBlocked runtimev1alpha1.ConditionReason = "ResourceLimitExceeded"
+
+// TypeNodeRoleTrust indicates whether the trust policy of the referenced node
+// role of a node group allows EC2 instances to assume it. EKS would otherwise
+// only reject the node group some time after it was requested.
+const TypeNodeRoleTrust runtimev1alpha1.ConditionType = "NodeRoleTrust"
+
+// Reasons of the NodeRoleTrust condition.
+const (
+	ReasonNodeRoleTrusted    runtimev1alpha1.ConditionReason = "NodeRoleTrusted"
+	ReasonNodeRoleNotTrusted runtimev1alpha1.ConditionReason = "NodeRoleNotTrusted"
+)
+
+// TypeInstanceTypesOffered indicates whether the instance types of a node
+// group are offered in all availability zones of its subnets. EKS would
+// otherwise only fail to create the node group without naming the instance
+// type. It is only set if the instance type offering check is enabled.
+const TypeInstanceTypesOffered runtimev1alpha1.ConditionType = "InstanceTypesOffered"
+
+// Reasons of the InstanceTypesOffered condition.
+const (
+	ReasonInstanceTypesOffered    runtimev1alpha1.ConditionReason = "InstanceTypesOffered"
+	ReasonInstanceTypesNotOffered runtimev1alpha1.ConditionReason = "InstanceTypesNotOffered"
+)
+
+// TypeUpdated indicates whether the most recent version or configuration
+// update of a node group succeeded. The reason of a failed update is the error
+// code reported by EKS, e.g. PodEvictionFailure.
+const TypeUpdated runtimev1alpha1.ConditionType = "Updated"
+
+// TypeSubnetCapacity indicates whether the subnets of a node group have enough
+// free IP addresses for it to scale to its maximum size. It is only set if the
+// subnet capacity check is enabled.
+const TypeSubnetCapacity runtimev1alpha1.ConditionType = "SubnetCapacity"
+
+// Reasons of the SubnetCapacity condition.
+const (
+	ReasonSufficientSubnetCapacity   runtimev1alpha1.ConditionReason = "SufficientSubnetCapacity"
+	ReasonInsufficientSubnetCapacity runtimev1alpha1.ConditionReason = "InsufficientSubnetCapacity"
+)
+
+// TypeServiceCIDR indicates whether the subnets of a node group are clear of
+// the service CIDR of its cluster. Nodes in overlapping subnets cannot reach
+// some services. It is only set if the service CIDR check is enabled.
+const TypeServiceCIDR runtimev1alpha1.ConditionType = "ServiceCIDR"
+
+// Reasons of the ServiceCIDR condition.
+const (
+	ReasonNoServiceCIDROverlap runtimev1alpha1.ConditionReason = "NoServiceCIDROverlap"
+	ReasonServiceCIDROverlap   runtimev1alpha1.ConditionReason = "ServiceCIDROverlap"
+)
+
+// TypeStuckDeleting indicates that a node group has been deleting for longer
+// than its deletion grace period. It is only set once that happens.
+const TypeStuckDeleting runtimev1alpha1.ConditionType = "StuckDeleting"
+
+// ReasonDeletionGracePeriodExceeded is the reason of the StuckDeleting
+// condition.
+const ReasonDeletionGracePeriodExceeded runtimev1alpha1.ConditionReason = "DeletionGracePeriodExceeded"
+
+// TypeNodeLabels indicates whether the labels of a node group match the node
+// labels required by the workloads that are scheduled onto it. It is only set
+// if the required node labels are annotated.
+const TypeNodeLabels runtimev1alpha1.ConditionType = "NodeLabels"
+
+// Reasons of the NodeLabels condition.
+const (
+	ReasonNodeLabelsMatch    runtimev1alpha1.ConditionReason = "NodeLabelsMatch"
+	ReasonNodeLabelsMismatch runtimev1alpha1.ConditionReason = "NodeLabelsMismatch"
+)
+
+// Reasons of the Updated condition of updates that did not fail.
+const (
+	ReasonUpdateSucceeded runtimev1alpha1.ConditionReason = "UpdateSucceeded"
+	ReasonUpdateCancelled runtimev1alpha1.ConditionReason = "UpdateCancelled"
+)
+
+// Reasons of the Updated condition of updates that are deferred until the
+// cluster of a node group has finished creating or updating, and of deferred
+// updates that were resumed.
+const (
+	ReasonUpdateDeferred runtimev1alpha1.ConditionReason = "UpdateDeferred"
+	ReasonUpdateResumed  runtimev1alpha1.ConditionReason = "UpdateResumed"
+)
+
+// ReasonWaitingForMaintenanceWindow is the reason of the Updated condition of
+// version updates that are deferred until the maintenance window of a node
+// group starts.
+const ReasonWaitingForMaintenanceWindow runtimev1alpha1.ConditionReason = "WaitingForMaintenanceWindow"
+
+const (
+	reasonStuckDeleting event.Reason = "StuckDeletingNodeGroup"
+	reasonObserveOnly   event.Reason = "ObserveOnly"
+
+	reasonCreateTimeNotRecorded event.Reason = "CreateTimeNotRecorded"
 )
 
 // SetupNodeGroup adds a controller that reconciles NodeGroups.
-func SetupNodeGroup(mgr ctrl.Manager, l logging.Logger) error {
+func SetupNodeGroup(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.NodeGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.NodeGroup{}).
-		Complete(managed.NewReconciler(mgr,
+		For(&v1alpha1.NodeGroup{}, builder.WithPredicates(reconciler.CoalescePredicate(o))).
+		Watches(&source.Kind{Type: &v1alpha1.NodeGroup{}}, &reconciler.BackoffResetHandler{CoalesceWindow: o.CoalesceWindow}).
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.NodeGroupGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newEKSClientFn: eks.NewEKSClient}),
-			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), &tagger{kube: mgr.GetClient()}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			reconciler.WithConnecter(newConnecter(&connector{kube: mgr.GetClient(), newEKSClientFn: eks.NewEKSClient, newSubnetClientFn: ec2.NewSubnetClient, newVPCClientFn: ec2.NewVPCClient, newInstanceClientFn: ec2.NewInstanceClient, newProfileClientFn: iam.NewInstanceProfileClient, newRoleClientFn: iam.NewRoleClient, newASGClientFn: autoscaling.NewGroupClient, record: record}, record, l.WithValues("controller", name))),
+			managed.WithInitializers(&defaulter{kube: mgr.GetClient()}, managed.InitializerFn(validate), managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), &tagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(reconciler.NewReferenceCache(mgr.GetClient(), mgr.GetCache(), o.ReferenceCacheTTL))),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
+}
+
+// newConnecter wraps the supplied ExternalConnecter with the behaviour that is
+// shared by the ExternalConnecters of all controllers, recording reconcile
+// history and renaming connection detail keys as annotated.
+func newConnecter(c managed.ExternalConnecter, r event.Recorder, l logging.Logger) managed.ExternalConnecter {
+	return reconciler.NewConnecter(c, r, l, v1alpha1.NodeGroupKind, reconciler.WithConnecterHistory(reconciler.DefaultHistorySize), reconciler.WithConnecterConnectionKeys())
 }
 
 type connector struct {
-	kube           client.Client
-	newEKSClientFn func(config aws.Config) eks.Client
+	kube                client.Client
+	newEKSClientFn      func(config aws.Config) eks.Client
+	newSubnetClientFn   func(config aws.Config) ec2.SubnetClient
+	newVPCClientFn      func(config aws.Config) ec2.VPCClient
+	newInstanceClientFn func(config aws.Config) ec2.InstanceClient
+	newProfileClientFn  func(config aws.Config) iam.InstanceProfileClient
+	newRoleClientFn     func(config aws.Config) iam.RoleClient
+	newASGClientFn      func(config aws.Config) autoscaling.GroupClient
+	record              event.Recorder
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -80,12 +249,20 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, err
 	}
-	return &external{client: c.newEKSClientFn(*cfg), kube: c.kube}, nil
+	return &external{client: c.newEKSClientFn(*cfg), subnets: c.newSubnetClientFn(*cfg), vpcs: c.newVPCClientFn(*cfg), instances: c.newInstanceClientFn(*cfg), profiles: c.newProfileClientFn(*cfg), roles: c.newRoleClientFn(*cfg), asgs: c.newASGClientFn(*cfg), kube: c.kube, record: c.record, now: time.Now}, nil
 }
 
 type external struct {
-	client eks.Client
-	kube   client.Client
+	client    eks.Client
+	subnets   ec2.SubnetClient
+	vpcs      ec2.VPCClient
+	instances ec2.InstanceClient
+	profiles  iam.InstanceProfileClient
+	roles     iam.RoleClient
+	asgs      autoscaling.GroupClient
+	kube      client.Client
+	record    event.Recorder
+	now       func() time.Time
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -95,10 +272,23 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	rsp, err := e.client.DescribeNodegroupRequest(&awseks.DescribeNodegroupInput{NodegroupName: aws.String(meta.GetExternalName(cr)), ClusterName: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+	if eks.IsErrorNotFound(err) && !meta.WasDeleted(cr) && eks.IsWithinCreateGracePeriod(cr, e.now()) {
+		// EKS may not return a node group that was just created. We report
+		// it as existing so that it is not created again.
+		cr.SetConditions(runtimev1alpha1.Creating())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(eks.IsErrorNotFound, err), errDescribeFailed)
 	}
 
+	if meta.WasDeleted(cr) && e.isStuckDeleting(cr, rsp.Nodegroup) &&
+		cr.GetAnnotations()[eks.AnnotationKeyRemoveFinalizerAfterGracePeriod] == "true" {
+		// We report the node group as gone so that the managed reconciler
+		// removes our finalizer, leaving the external node group behind.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
 	current := cr.Spec.ForProvider.DeepCopy()
 	eks.LateInitializeNodeGroup(&cr.Spec.ForProvider, rsp.Nodegroup)
 	if !reflect.DeepEqual(current, &cr.Spec.ForProvider) {
@@ -107,23 +297,77 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}
 	}
 
+	p, err := e.resolveParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	cr.Status.AtProvider = eks.GenerateNodeGroupObservation(rsp.Nodegroup)
+	if cr.GetAnnotations()[eks.AnnotationKeyObserveCapacity] == "true" {
+		eks.ObserveCapacity(&cr.Status.AtProvider, rsp.Nodegroup)
+	}
+	observeAMIID := cr.GetAnnotations()[eks.AnnotationKeyObserveAMIID] == "true"
+	if observeAMIID || cr.Spec.ForProvider.MinReadyNodes != nil {
+		irsp, err := e.instances.DescribeInstancesRequest(eks.GenerateDescribeNodeGroupInstancesInput(cr.Spec.ForProvider.ClusterName, meta.GetExternalName(cr))).Send(ctx)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errDescribeInstances)
+		}
+		if observeAMIID {
+			cr.Status.AtProvider.AMIID = eks.GetAMIID(irsp.Reservations)
+		}
+		if cr.Spec.ForProvider.MinReadyNodes != nil {
+			cr.Status.AtProvider.ReadyNodes = aws.Int64(eks.CountRunningInstances(irsp.Reservations))
+		}
+	}
+	if cr.GetAnnotations()[eks.AnnotationKeyObserveInstanceProfile] == "true" && cr.Status.AtProvider.NodeRoleArn != "" {
+		prsp, err := e.profiles.ListInstanceProfilesForRoleRequest(eks.GenerateListInstanceProfilesForRoleInput(cr.Status.AtProvider.NodeRoleArn)).Send(ctx)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListInstanceProfiles)
+		}
+		cr.Status.AtProvider.InstanceProfileName = eks.GetInstanceProfileName(prsp.InstanceProfiles)
+	}
+	if err := e.observeMaxPods(ctx, cr, rsp.Nodegroup); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := e.observeUpdate(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := e.checkSubnetCapacity(ctx, cr, p, rsp.Nodegroup); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := e.checkServiceCIDR(ctx, cr, rsp.Nodegroup); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := checkNodeLabels(cr, p); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	untagged, err := e.getUntaggedAutoScalingGroups(ctx, cr, rsp.Nodegroup)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.Diff = eks.DiffNodeGroup(p, rsp.Nodegroup)
 	// Any of the statuses we don't explicitly address should be considered as
 	// the node group being unavailable.
 	switch cr.Status.AtProvider.Status { // nolint:exhaustive
 	case v1alpha1.NodeGroupStatusActive:
+		if want, ready := cr.Spec.ForProvider.MinReadyNodes, cr.Status.AtProvider.ReadyNodes; want != nil && aws.Int64Value(ready) < *want {
+			cr.Status.SetConditions(runtimev1alpha1.Unavailable().WithMessage(fmt.Sprintf(msgWaitingForNodes, aws.Int64Value(ready), *want)))
+			break
+		}
 		cr.Status.SetConditions(runtimev1alpha1.Available())
 	case v1alpha1.NodeGroupStatusCreating:
 		cr.Status.SetConditions(runtimev1alpha1.Creating())
 	case v1alpha1.NodeGroupStatusDeleting:
 		cr.Status.SetConditions(runtimev1alpha1.Deleting())
 	default:
-		cr.Status.SetConditions(runtimev1alpha1.Unavailable())
+		cr.Status.SetConditions(unavailable(cr.Status.AtProvider))
 	}
+	cr.Status.Recommendations = recommend(cr)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: eks.IsNodeGroupUpToDate(&cr.Spec.ForProvider, rsp.Nodegroup),
+		ResourceExists:    true,
+		ResourceUpToDate:  eks.IsNodeGroupUpToDate(p, rsp.Nodegroup) && len(untagged) == 0,
+		ConnectionDetails: eks.GetNodeGroupConnectionDetails(rsp.Nodegroup),
 	}, nil
 }
 
@@ -132,12 +376,472 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotEKSNodeGroup)
 	}
+	// Creation is not retried after it failed terminally, until the node
+	// group is changed. We return an error so that the managed reconciler
+	// does not report the node group as successfully created.
+	if g := cr.Status.CreateBlockedGeneration; g != nil && *g == cr.GetGeneration() {
+		return managed.ExternalCreation{}, errors.New(msgCreateBlocked)
+	}
+	cr.Status.CreateBlockedGeneration = nil
 	cr.SetConditions(runtimev1alpha1.Creating())
 	if cr.Status.AtProvider.Status == v1alpha1.NodeGroupStatusCreating {
 		return managed.ExternalCreation{}, nil
 	}
-	_, err := e.client.CreateNodegroupRequest(eks.GenerateCreateNodeGroupInput(meta.GetExternalName(cr), &cr.Spec.ForProvider)).Send(ctx)
-	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	if err := e.validateSubnets(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := e.checkNodeRoleTrust(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := e.checkInstanceTypeOfferings(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	p, err := e.resolveParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := eks.ValidateTags(p.Tags); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidTags)
+	}
+	// A node group without a version inherits that of its cluster. We create
+	// it with that version explicitly, so that there is a version to compare
+	// against once the node group exists and is observed.
+	if p.Version == nil {
+		rsp, err := e.client.DescribeClusterRequest(&awseks.DescribeClusterInput{Name: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errDescribeCluster)
+		}
+		if rsp.Cluster != nil && rsp.Cluster.Version != nil {
+			p = p.DeepCopy()
+			p.Version = rsp.Cluster.Version
+		}
+	}
+	_, err = e.client.CreateNodegroupRequest(eks.GenerateCreateNodeGroupInput(meta.GetExternalName(cr), p)).Send(ctx)
+	if eks.IsErrorTerminal(err) {
+		g := cr.GetGeneration()
+		cr.Status.CreateBlockedGeneration = &g
+		cr.SetConditions(createBlocked())
+		return managed.ExternalCreation{}, errors.Wrap(err, msgCreateBlocked)
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	// The managed reconciler does not persist the annotations of the node
+	// group after it is created, so we patch its create time ourselves. The
+	// patch is best effort; the node group exists regardless, and Observe
+	// only loses its grace period if the patch fails. We patch a copy so
+	// that the status set above is not overwritten, then carry its resource
+	// version back so that the managed reconciler's status update does not
+	// conflict with our patch.
+	orig := cr.DeepCopy()
+	eks.SetCreateTime(cr, e.now())
+	patched := cr.DeepCopy()
+	if err := e.kube.Patch(ctx, patched, client.MergeFrom(orig)); err != nil {
+		e.record.Event(cr, event.Warning(reasonCreateTimeNotRecorded, errors.Wrap(err, errPatchCreateTime)))
+		return managed.ExternalCreation{}, nil
+	}
+	cr.SetResourceVersion(patched.GetResourceVersion())
+	return managed.ExternalCreation{}, nil
+}
+
+// unavailable returns the Unavailable condition of a node group with the
+// supplied observation. The code and message of its first health issue, if
+// any, are used as the reason and message of the condition, so that they show
+// why the node group is unavailable. Issues that show its instances failed to
+// launch or to join the cluster take precedence, and are reported with a
+// message that tells the two apart.
+func unavailable(o v1alpha1.NodeGroupObservation) runtimev1alpha1.Condition {
+	c := runtimev1alpha1.Unavailable()
+	if i, b := eks.GetBootstrapIssue(o.Health); i != nil {
+		c.Reason = runtimev1alpha1.ConditionReason(b)
+		c.Message = fmt.Sprintf(msgLaunchFailed, i.Code+": "+i.Message)
+		if b == v1alpha1.NodeBootstrapJoinFailed {
+			c.Message = fmt.Sprintf(msgJoinFailed, i.Code+": "+i.Message)
+		}
+		return c
+	}
+	if len(o.Health.Issues) > 0 && o.Health.Issues[0].Code != "" {
+		c.Reason = runtimev1alpha1.ConditionReason(o.Health.Issues[0].Code)
+		c.Message = o.Health.Issues[0].Message
+	}
+	return c
+}
+
+// createBlocked returns a condition that indicates the node group cannot be
+// created until the node group limit is raised.
+func createBlocked() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCreateBlocked,
+		Message:            msgCreateBlocked,
+	}
+}
+
+// observeUpdate sets the Updated condition of the supplied node group once its
+// most recent update has completed, surfacing why it failed if it did.
+func (e *external) observeUpdate(ctx context.Context, cr *v1alpha1.NodeGroup) error {
+	if cr.Status.LastUpdateID == nil {
+		return nil
+	}
+	rsp, err := e.client.DescribeUpdateRequest(&awseks.DescribeUpdateInput{
+		Name:          &cr.Spec.ForProvider.ClusterName,
+		NodegroupName: aws.String(meta.GetExternalName(cr)),
+		UpdateId:      cr.Status.LastUpdateID,
+	}).Send(ctx)
+	if eks.IsErrorNotFound(err) {
+		cr.Status.LastUpdateID = nil
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errDescribeUpdate)
+	}
+	if rsp.Update == nil {
+		return nil
+	}
+	switch rsp.Update.Status { // nolint:exhaustive
+	case awseks.UpdateStatusSuccessful:
+		cr.SetConditions(updated(corev1.ConditionTrue, ReasonUpdateSucceeded, ""))
+	case awseks.UpdateStatusCancelled:
+		cr.SetConditions(updated(corev1.ConditionFalse, ReasonUpdateCancelled, ""))
+	case awseks.UpdateStatusFailed:
+		code, msg := eks.DescribeUpdateFailure(rsp.Update)
+		cr.SetConditions(updated(corev1.ConditionFalse, runtimev1alpha1.ConditionReason(code), msg))
+	default:
+		return nil
+	}
+	cr.Status.LastUpdateID = nil
+	return nil
+}
+
+// checkSubnetCapacity sets the SubnetCapacity condition of the supplied node
+// group if the check is enabled, so that users are warned before the node
+// group runs out of IP addresses while scaling up.
+func (e *external) checkSubnetCapacity(ctx context.Context, cr *v1alpha1.NodeGroup, p *v1alpha1.NodeGroupParameters, ng *awseks.Nodegroup) error {
+	if cr.GetAnnotations()[eks.AnnotationKeyCheckSubnetCapacity] != "true" || len(ng.Subnets) == 0 {
+		return nil
+	}
+	rsp, err := e.subnets.DescribeSubnetsRequest(&awsec2.DescribeSubnetsInput{SubnetIds: ng.Subnets}).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errDescribeSubnets)
+	}
+	c := runtimev1alpha1.Condition{
+		Type:               TypeSubnetCapacity,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSufficientSubnetCapacity,
+	}
+	free, need := eks.CountAvailableIPAddresses(rsp.Subnets), eks.GetAdditionalNodes(p, ng)
+	if need > free {
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonInsufficientSubnetCapacity
+		c.Message = fmt.Sprintf(msgSubnetCapacity, need, free)
+	}
+	cr.SetConditions(c)
+	return nil
+}
+
+// checkServiceCIDR sets the ServiceCIDR condition of the supplied node group
+// if the check is enabled, so that users are warned that some of its nodes
+// cannot reach services of its cluster.
+func (e *external) checkServiceCIDR(ctx context.Context, cr *v1alpha1.NodeGroup, ng *awseks.Nodegroup) error {
+	cidr := cr.GetAnnotations()[eks.AnnotationKeyCheckServiceCIDR]
+	if cidr == "" || cidr == "false" || len(ng.Subnets) == 0 {
+		return nil
+	}
+	if cidr == "true" {
+		var err error
+		if cidr, err = e.getDefaultServiceCIDR(ctx, cr); err != nil || cidr == "" {
+			return err
+		}
+	}
+	rsp, err := e.subnets.DescribeSubnetsRequest(&awsec2.DescribeSubnetsInput{SubnetIds: ng.Subnets}).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errDescribeSubnets)
+	}
+	overlapping, err := eks.GetSubnetsOverlappingCIDR(cidr, rsp.Subnets)
+	if err != nil {
+		return errors.Wrap(err, errServiceCIDR)
+	}
+	c := runtimev1alpha1.Condition{
+		Type:               TypeServiceCIDR,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoServiceCIDROverlap,
+	}
+	if len(overlapping) > 0 {
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonServiceCIDROverlap
+		c.Message = fmt.Sprintf(msgServiceCIDR, overlapping, cidr)
+	}
+	cr.SetConditions(c)
+	return nil
+}
+
+// observeMaxPods observes the max pods per node of the supplied node group.
+// The network interface limits of its instance types are only looked up in EC2
+// if enabled, so that instance types that are missing from the default table
+// can be observed.
+func (e *external) observeMaxPods(ctx context.Context, cr *v1alpha1.NodeGroup, ng *awseks.Nodegroup) error {
+	if len(ng.InstanceTypes) == 0 {
+		return nil
+	}
+	limits := eks.DefaultENILimits
+	if cr.GetAnnotations()[eks.AnnotationKeyLookupMaxPods] == "true" {
+		rsp, err := e.instances.DescribeInstanceTypesRequest(eks.GenerateDescribeInstanceTypesInput(ng.InstanceTypes)).Send(ctx)
+		if err != nil {
+			return errors.Wrap(err, errDescribeInstanceTypes)
+		}
+		limits = eks.GetENILimits(rsp.InstanceTypes)
+	}
+	cr.Status.AtProvider.MaxPodsPerNode = eks.GetMaxPodsPerNode(ng.InstanceTypes, limits)
+	return nil
+}
+
+// checkNodeLabels sets the NodeLabels condition of the supplied node group if
+// its required node labels are annotated, so that users are warned that the
+// workloads that select them cannot be scheduled onto its nodes. The desired
+// labels are checked, so that a mismatch is reported before it is applied.
+func checkNodeLabels(cr *v1alpha1.NodeGroup, p *v1alpha1.NodeGroupParameters) error {
+	required := cr.GetAnnotations()[eks.AnnotationKeyRequiredNodeLabels]
+	if required == "" {
+		return nil
+	}
+	missing, err := eks.GetMissingNodeLabels(required, p.Labels)
+	if err != nil {
+		return errors.Wrap(err, errRequiredNodeLabels)
+	}
+	c := runtimev1alpha1.Condition{
+		Type:               TypeNodeLabels,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNodeLabelsMatch,
+	}
+	if len(missing) > 0 {
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonNodeLabelsMismatch
+		c.Message = fmt.Sprintf(msgNodeLabels, missing)
+	}
+	cr.SetConditions(c)
+	return nil
+}
+
+// getDefaultServiceCIDR returns the service CIDR EKS picks by default for the
+// cluster of the supplied node group, which depends on the primary CIDR of its
+// VPC. It returns an empty string if the VPC of the cluster is not known.
+func (e *external) getDefaultServiceCIDR(ctx context.Context, cr *v1alpha1.NodeGroup) (string, error) {
+	crsp, err := e.client.DescribeClusterRequest(&awseks.DescribeClusterInput{Name: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, errDescribeCluster)
+	}
+	if crsp.Cluster == nil || crsp.Cluster.ResourcesVpcConfig == nil || crsp.Cluster.ResourcesVpcConfig.VpcId == nil {
+		return "", nil
+	}
+	vrsp, err := e.vpcs.DescribeVpcsRequest(&awsec2.DescribeVpcsInput{VpcIds: []string{aws.StringValue(crsp.Cluster.ResourcesVpcConfig.VpcId)}}).Send(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, errDescribeVPC)
+	}
+	if len(vrsp.Vpcs) == 0 {
+		return "", nil
+	}
+	cidr, err := eks.GetDefaultServiceCIDR(aws.StringValue(vrsp.Vpcs[0].CidrBlock))
+	return cidr, errors.Wrap(err, errServiceCIDR)
+}
+
+// recommend returns actions that may resolve the issues detected with the
+// supplied node group, i.e. its health issues and the issues reported by its
+// conditions.
+func recommend(cr *v1alpha1.NodeGroup) []string {
+	var r []string
+	for _, i := range cr.Status.AtProvider.Health.Issues {
+		r = append(r, eks.GetHealthIssueRecommendation(i))
+	}
+	if c := cr.GetCondition(TypeSubnetCapacity); c.Status == corev1.ConditionFalse {
+		r = append(r, fmt.Sprintf(recommendSubnetCapacity, c.Message))
+	}
+	if c := cr.GetCondition(TypeServiceCIDR); c.Status == corev1.ConditionFalse {
+		r = append(r, fmt.Sprintf(recommendServiceCIDR, c.Message))
+	}
+	if c := cr.GetCondition(TypeNodeLabels); c.Status == corev1.ConditionFalse {
+		r = append(r, fmt.Sprintf(recommendNodeLabels, c.Message))
+	}
+	if c := cr.GetCondition(TypeUpdated); c.Status == corev1.ConditionFalse && c.Reason != ReasonUpdateCancelled && c.Reason != ReasonUpdateDeferred && c.Reason != ReasonWaitingForMaintenanceWindow {
+		r = append(r, fmt.Sprintf(recommendUpdateFailed, c.Message))
+	}
+	return r
+}
+
+// updated returns an Updated condition with the supplied status, reason and
+// message.
+func updated(s corev1.ConditionStatus, r runtimev1alpha1.ConditionReason, msg string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeUpdated,
+		Status:             s,
+		LastTransitionTime: metav1.Now(),
+		Reason:             r,
+		Message:            msg,
+	}
+}
+
+// resolveParameters returns the parameters of the supplied node group with the
+// default tags of its ProviderConfig and, if enabled, the Cluster Autoscaler
+// discovery tags, with any tag templates resolved using the attributes of its
+// cluster, with the version of its cluster if the node group follows it, and
+// with the sizes read from its scaling config source. The cluster is only
+// described if there is something to resolve. Tags are merged here rather than
+// written to the spec, so that changes to them apply to existing node groups.
+func (e *external) resolveParameters(ctx context.Context, cr *v1alpha1.NodeGroup) (*v1alpha1.NodeGroupParameters, error) {
+	defaults, err := awsclients.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	p := cr.Spec.ForProvider.DeepCopy()
+	p.Tags = awsclients.MergeTags(p.Tags, defaults)
+	if eks.IsAutoscalerDiscoveryEnabled(p) {
+		p.Tags = awsclients.MergeTags(p.Tags, eks.GetAutoscalerDiscoveryTags(p.ClusterName))
+	}
+	templates := eks.HasTagTemplates(p.Tags)
+	follow := eks.IsClusterVersionFollowed(p)
+	if templates || follow {
+		rsp, err := e.client.DescribeClusterRequest(&awseks.DescribeClusterInput{Name: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, errDescribeCluster)
+		}
+		if templates {
+			p.Tags = eks.ResolveTagTemplates(p.Tags, rsp.Cluster)
+		}
+		if follow && rsp.Cluster != nil {
+			p.Version = rsp.Cluster.Version
+		}
+	}
+	if src := p.ScalingConfigFrom; src != nil {
+		cm := &corev1.ConfigMap{}
+		if err := e.kube.Get(ctx, types.NamespacedName{Namespace: src.Namespace, Name: src.Name}, cm); err != nil {
+			return nil, errors.Wrap(err, errGetScalingConfigSource)
+		}
+		if err := eks.ResolveScalingConfig(p, cm.Data); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// getUntaggedAutoScalingGroups returns the names of the Auto Scaling groups of
+// the supplied node group that lack the Cluster Autoscaler discovery tags, if
+// the node group should be discovered by the Cluster Autoscaler.
+func (e *external) getUntaggedAutoScalingGroups(ctx context.Context, cr *v1alpha1.NodeGroup, ng *awseks.Nodegroup) ([]string, error) {
+	if !eks.IsAutoscalerDiscoveryEnabled(&cr.Spec.ForProvider) {
+		return nil, nil
+	}
+	names := eks.GetAutoScalingGroupNames(ng)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	rsp, err := e.asgs.DescribeAutoScalingGroupsRequest(&awsautoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: names}).Send(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errDescribeASGs)
+	}
+	return eks.GetUntaggedAutoScalingGroups(rsp.AutoScalingGroups, eks.GetAutoscalerDiscoveryTags(cr.Spec.ForProvider.ClusterName)), nil
+}
+
+// validateSubnets returns an error if any of the subnets of the node group are
+// not in the VPC of its cluster. AWS would otherwise only reject the node group
+// some time after it was requested.
+func (e *external) validateSubnets(ctx context.Context, cr *v1alpha1.NodeGroup) error {
+	if len(cr.Spec.ForProvider.Subnets) == 0 {
+		return nil
+	}
+	crsp, err := e.client.DescribeClusterRequest(&awseks.DescribeClusterInput{Name: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errDescribeCluster)
+	}
+	if crsp.Cluster == nil || crsp.Cluster.ResourcesVpcConfig == nil || crsp.Cluster.ResourcesVpcConfig.VpcId == nil {
+		return nil
+	}
+	vpcID := aws.StringValue(crsp.Cluster.ResourcesVpcConfig.VpcId)
+	srsp, err := e.subnets.DescribeSubnetsRequest(&awsec2.DescribeSubnetsInput{SubnetIds: cr.Spec.ForProvider.Subnets}).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errDescribeSubnets)
+	}
+	if outside := eks.GetSubnetsOutsideVPC(vpcID, srsp.Subnets); len(outside) > 0 {
+		return errors.Errorf(errSubnetsNotInVPC, outside, vpcID, cr.Spec.ForProvider.ClusterName)
+	}
+	return nil
+}
+
+// checkNodeRoleTrust sets the NodeRoleTrust condition of the supplied node
+// group, and returns an error if its node role does not trust EC2. The role is
+// only checked if it is referenced, i.e. likely managed alongside the node
+// group, and thus likely to be new.
+func (e *external) checkNodeRoleTrust(ctx context.Context, cr *v1alpha1.NodeGroup) error {
+	if cr.Spec.ForProvider.NodeRoleRef == nil || cr.Spec.ForProvider.NodeRole == "" {
+		return nil
+	}
+	rsp, err := e.roles.GetRoleRequest(eks.GenerateGetRoleInput(cr.Spec.ForProvider.NodeRole)).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errGetNodeRole)
+	}
+	if rsp.Role == nil {
+		return nil
+	}
+	trusted, err := eks.IsTrustedByService(aws.StringValue(rsp.Role.AssumeRolePolicyDocument), eks.EC2ServicePrincipal)
+	if err != nil {
+		return errors.Wrap(err, errParseTrustPolicy)
+	}
+	c := runtimev1alpha1.Condition{
+		Type:               TypeNodeRoleTrust,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNodeRoleTrusted,
+	}
+	if !trusted {
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonNodeRoleNotTrusted
+		c.Message = fmt.Sprintf(errNodeRoleNotTrusted, cr.Spec.ForProvider.NodeRole)
+		cr.SetConditions(c)
+		return errors.New(c.Message)
+	}
+	cr.SetConditions(c)
+	return nil
+}
+
+// checkInstanceTypeOfferings sets the InstanceTypesOffered condition of the
+// supplied node group, and returns an error if any of its instance types is
+// not offered in all availability zones of its subnets. It only checks the
+// instance types that are specified, if the check is enabled.
+func (e *external) checkInstanceTypeOfferings(ctx context.Context, cr *v1alpha1.NodeGroup) error {
+	if cr.GetAnnotations()[eks.AnnotationKeyCheckInstanceTypeOfferings] != "true" ||
+		len(cr.Spec.ForProvider.InstanceTypes) == 0 || len(cr.Spec.ForProvider.Subnets) == 0 {
+		return nil
+	}
+	srsp, err := e.subnets.DescribeSubnetsRequest(&awsec2.DescribeSubnetsInput{SubnetIds: cr.Spec.ForProvider.Subnets}).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errDescribeSubnets)
+	}
+	zones := eks.GetAvailabilityZones(srsp.Subnets)
+	if len(zones) == 0 {
+		return nil
+	}
+	orsp, err := e.instances.DescribeInstanceTypeOfferingsRequest(eks.GenerateDescribeInstanceTypeOfferingsInput(cr.Spec.ForProvider.InstanceTypes, zones)).Send(ctx)
+	if err != nil {
+		return errors.Wrap(err, errDescribeOfferings)
+	}
+	c := runtimev1alpha1.Condition{
+		Type:               TypeInstanceTypesOffered,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInstanceTypesOffered,
+	}
+	if unoffered := eks.GetUnofferedInstanceTypes(cr.Spec.ForProvider.InstanceTypes, zones, orsp.InstanceTypeOfferings); len(unoffered) > 0 {
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonInstanceTypesNotOffered
+		c.Message = fmt.Sprintf(errInstanceTypesNotOffered, unoffered, zones)
+		cr.SetConditions(c)
+		return errors.New(c.Message)
+	}
+	cr.SetConditions(c)
+	return nil
 }
 
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -149,14 +853,44 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	case v1alpha1.NodeGroupStatusUpdating, v1alpha1.NodeGroupStatusCreating:
 		return managed.ExternalUpdate{}, nil
 	}
+	if cr.GetAnnotations()[eks.AnnotationKeyObserveOnly] == "true" {
+		e.record.Event(cr, event.Normal(reasonObserveOnly, msgObserveOnly))
+		return managed.ExternalUpdate{}, nil
+	}
+	// EKS rejects updates of node groups while their cluster is being
+	// created or updated, so we wait for the cluster instead.
+	clrsp, err := e.client.DescribeClusterRequest(&awseks.DescribeClusterInput{Name: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribeCluster)
+	}
+	if clrsp.Cluster == nil {
+		return managed.ExternalUpdate{}, errors.New(errDescribeCluster)
+	}
+	if s := clrsp.Cluster.Status; s == awseks.ClusterStatusCreating || s == awseks.ClusterStatusUpdating {
+		cr.SetConditions(updated(corev1.ConditionFalse, ReasonUpdateDeferred, fmt.Sprintf(msgUpdateDeferred, s)))
+		return managed.ExternalUpdate{}, nil
+	}
+	if cr.GetCondition(TypeUpdated).Reason == ReasonUpdateDeferred {
+		cr.SetConditions(updated(corev1.ConditionUnknown, ReasonUpdateResumed, ""))
+	}
 
 	// NOTE(hasheddan): we have to describe the node group again because
 	// different fields require different update methods.
 	rsp, err := e.client.DescribeNodegroupRequest(&awseks.DescribeNodegroupInput{NodegroupName: aws.String(meta.GetExternalName(cr)), ClusterName: &cr.Spec.ForProvider.ClusterName}).Send(ctx)
-	if err != nil || rsp.Nodegroup == nil {
+	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errDescribeFailed)
 	}
-	add, remove := awsclients.DiffTags(cr.Spec.ForProvider.Tags, rsp.Nodegroup.Tags)
+	if rsp.Nodegroup == nil {
+		return managed.ExternalUpdate{}, errors.New(errDescribeFailed)
+	}
+	p, err := e.resolveParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := eks.ValidateTags(p.Tags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidTags)
+	}
+	add, remove := awsclients.DiffTags(p.Tags, eks.FilterAWSManagedTags(rsp.Nodegroup.Tags))
 	if len(remove) != 0 {
 		if _, err := e.client.UntagResourceRequest(&awseks.UntagResourceInput{ResourceArn: rsp.Nodegroup.NodegroupArn, TagKeys: remove}).Send(ctx); err != nil {
 			return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(eks.IsErrorInUse, err), errAddTagsFailed)
@@ -167,17 +901,68 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 			return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(eks.IsErrorInUse, err), errAddTagsFailed)
 		}
 	}
-	if !reflect.DeepEqual(rsp.Nodegroup.Version, cr.Spec.ForProvider.Version) {
-		_, err := e.client.UpdateNodegroupVersionRequest(&awseks.UpdateNodegroupVersionInput{
+	untagged, err := e.getUntaggedAutoScalingGroups(ctx, cr, rsp.Nodegroup)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if len(untagged) != 0 {
+		in := eks.GenerateCreateOrUpdateAutoScalingGroupTagsInput(untagged, eks.GetAutoscalerDiscoveryTags(cr.Spec.ForProvider.ClusterName))
+		if _, err := e.asgs.CreateOrUpdateTagsRequest(in).Send(ctx); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errTagASGs)
+		}
+	}
+	if p.Version != nil && !reflect.DeepEqual(rsp.Nodegroup.Version, p.Version) {
+		within, err := e.isWithinMaintenanceWindow(p)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if !within {
+			// Version updates replace the nodes of the node group, so they
+			// wait for its maintenance window. Its configuration is still
+			// updated in the meantime.
+			cr.SetConditions(updated(corev1.ConditionFalse, ReasonWaitingForMaintenanceWindow, fmt.Sprintf(msgMaintenance, aws.StringValue(p.MaintenanceWindow), aws.StringValue(p.Version))))
+			return e.updateConfig(ctx, cr, p, rsp.Nodegroup)
+		}
+		if cr.GetCondition(TypeUpdated).Reason == ReasonWaitingForMaintenanceWindow {
+			cr.SetConditions(updated(corev1.ConditionUnknown, ReasonUpdateResumed, ""))
+		}
+		ursp, err := e.client.UpdateNodegroupVersionRequest(&awseks.UpdateNodegroupVersionInput{
 			ClusterName:   &cr.Spec.ForProvider.ClusterName,
 			NodegroupName: awsclients.String(meta.GetExternalName(cr)),
-			Version:       cr.Spec.ForProvider.Version}).Send(ctx)
+			Version:       p.Version}).Send(ctx)
+		if err == nil && ursp.Update != nil {
+			cr.Status.LastUpdateID = ursp.Update.Id
+		}
 		return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(eks.IsErrorInUse, err), errUpdateVersionFailed)
 	}
-	_, err = e.client.UpdateNodegroupConfigRequest(eks.GenerateUpdateNodeGroupConfigInput(meta.GetExternalName(cr), &cr.Spec.ForProvider, rsp.Nodegroup)).Send(ctx)
+	return e.updateConfig(ctx, cr, p, rsp.Nodegroup)
+}
+
+// updateConfig updates the labels and scaling configuration of the supplied
+// node group. They are sent in a single UpdateNodegroupConfig call so that a
+// failure cannot leave the node group with only part of the desired
+// configuration applied.
+func (e *external) updateConfig(ctx context.Context, cr *v1alpha1.NodeGroup, p *v1alpha1.NodeGroupParameters, ng *awseks.Nodegroup) (managed.ExternalUpdate, error) {
+	if eks.IsNodeGroupConfigUpToDate(p, ng) {
+		return managed.ExternalUpdate{}, nil
+	}
+	crsp, err := e.client.UpdateNodegroupConfigRequest(eks.GenerateUpdateNodeGroupConfigInput(meta.GetExternalName(cr), p, ng)).Send(ctx)
+	if err == nil && crsp.Update != nil {
+		cr.Status.LastUpdateID = crsp.Update.Id
+	}
 	return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(eks.IsErrorInUse, err), errUpdateConfigFailed)
 }
 
+// isWithinMaintenanceWindow returns true if the version of a node group with
+// the supplied parameters may be updated now.
+func (e *external) isWithinMaintenanceWindow(p *v1alpha1.NodeGroupParameters) (bool, error) {
+	if p.MaintenanceWindow == nil {
+		return true, nil
+	}
+	within, err := eks.IsWithinMaintenanceWindow(*p.MaintenanceWindow, e.now())
+	return within, errors.Wrap(err, errMaintenanceWindow)
+}
+
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.NodeGroup)
 	if !ok {
@@ -191,6 +976,80 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	return errors.Wrap(resource.Ignore(eks.IsErrorNotFound, err), errDeleteFailed)
 }
 
+// isStuckDeleting returns true if the supplied node group has been deleting
+// for longer than the deletion grace period of cr. The first time it does, it
+// sets the StuckDeleting condition of cr and emits a warning event.
+func (e *external) isStuckDeleting(cr *v1alpha1.NodeGroup, ng *awseks.Nodegroup) bool {
+	start, ok := eks.GetDeletionStartTime(cr, ng)
+	if !ok {
+		return false
+	}
+	grace := eks.GetDeletionGracePeriod(cr)
+	if e.now().Sub(start) <= grace {
+		return false
+	}
+	if cr.GetCondition(TypeStuckDeleting).Status == corev1.ConditionTrue {
+		return true
+	}
+	cr.SetConditions(stuckDeleting(grace))
+	e.record.Event(cr, event.Warning(reasonStuckDeleting, errors.Errorf(errStuckDeleting, grace)))
+	return true
+}
+
+// stuckDeleting returns a condition that indicates the node group has been
+// deleting for longer than the supplied grace period.
+func stuckDeleting(grace time.Duration) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeStuckDeleting,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionGracePeriodExceeded,
+		Message:            fmt.Sprintf(errStuckDeleting, grace),
+	}
+}
+
+// A defaulter applies the defaults of the NodeGroupClass a node group
+// references to the fields of the node group that are not set. It runs before
+// the node group is validated, so that the defaults are validated too.
+type defaulter struct {
+	kube client.Client
+}
+
+func (d *defaulter) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.NodeGroup)
+	if !ok {
+		return errors.New(errNotEKSNodeGroup)
+	}
+	ref := cr.Spec.ForProvider.ClassRef
+	if ref == nil || meta.WasDeleted(cr) {
+		return nil
+	}
+	c := &v1alpha1.NodeGroupClass{}
+	if err := d.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, c); err != nil {
+		return errors.Wrap(err, errGetClass)
+	}
+	p := cr.Spec.ForProvider.DeepCopy()
+	eks.ApplyNodeGroupDefaults(&cr.Spec.ForProvider, c.Spec.Defaults)
+	if reflect.DeepEqual(*p, cr.Spec.ForProvider) {
+		return nil
+	}
+	return errors.Wrap(d.kube.Update(ctx, cr), errKubeUpdateFailed)
+}
+
+// validate rejects node groups whose parameters EKS would reject, before
+// anything is written to the API server or AWS. Node groups that are being
+// deleted are not validated so that their deletion is never blocked.
+func validate(_ context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.NodeGroup)
+	if !ok {
+		return errors.New(errNotEKSNodeGroup)
+	}
+	if meta.WasDeleted(cr) {
+		return nil
+	}
+	return errors.Wrap(eks.ValidateNodeGroup(&cr.Spec.ForProvider), errInvalidParameters)
+}
+
 type tagger struct {
 	kube client.Client
 }
@@ -200,11 +1059,15 @@ func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotEKSNodeGroup)
 	}
+	current := cr.Spec.ForProvider.DeepCopy()
 	if cr.Spec.ForProvider.Tags == nil {
 		cr.Spec.ForProvider.Tags = map[string]string{}
 	}
 	for k, v := range resource.GetExternalTags(mg) {
 		cr.Spec.ForProvider.Tags[k] = v
 	}
+	if reflect.DeepEqual(current, &cr.Spec.ForProvider) {
+		return nil
+	}
 	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateFailed)
 }
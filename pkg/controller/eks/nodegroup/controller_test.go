@@ -18,40 +18,94 @@ package nodegroup
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsautoscaling "github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	awsiam "github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	ec2v1beta1 "github.com/crossplane/provider-aws/apis/ec2/v1beta1"
 	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
+	eksv1beta1 "github.com/crossplane/provider-aws/apis/eks/v1beta1"
+	iamv1beta1 "github.com/crossplane/provider-aws/apis/identity/v1beta1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
+	asfake "github.com/crossplane/provider-aws/pkg/clients/autoscaling/fake"
+	"github.com/crossplane/provider-aws/pkg/clients/ec2"
+	ec2fake "github.com/crossplane/provider-aws/pkg/clients/ec2/fake"
 	"github.com/crossplane/provider-aws/pkg/clients/eks"
 	"github.com/crossplane/provider-aws/pkg/clients/eks/fake"
+	"github.com/crossplane/provider-aws/pkg/clients/iam"
+	iamfake "github.com/crossplane/provider-aws/pkg/clients/iam/fake"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 var (
 	version           = "1.16"
 	desiredSize int64 = 3
+	minSize     int64 = 1
+	maxSize     int64 = 5
+
+	securityGroupID = "sg-cool"
+	subnetID        = "subnet-cool"
+	vpcID           = "vpc-cool"
+
+	nodeRoleName = "node-role"
+	nodeRoleArn  = "arn:aws:iam::123456789012:role/eks/" + nodeRoleName
+
+	updateID = "cool-update"
 
 	errBoom = errors.New("boom")
+
+	deletionTime     = metav1.Now()
+	modifiedRecently = time.Now().Add(-time.Minute)
+	modifiedLongAgo  = time.Now().Add(-time.Hour)
+	deletedRecently  = metav1.NewTime(time.Now().Add(-time.Minute))
+	deletedLongAgo   = metav1.NewTime(time.Now().Add(-time.Hour))
 )
 
 type args struct {
-	eks  eks.Client
-	kube client.Client
-	cr   *v1alpha1.NodeGroup
+	eks       eks.Client
+	subnets   ec2.SubnetClient
+	vpcs      ec2.VPCClient
+	instances ec2.InstanceClient
+	profiles  iam.InstanceProfileClient
+	roles     iam.RoleClient
+	kube      client.Client
+	now       func() time.Time
+	cr        *v1alpha1.NodeGroup
 }
 
 type nodeGroupModifier func(*v1alpha1.NodeGroup)
 
+func withResourceVersion(v string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.SetResourceVersion(v) }
+}
+
 func withConditions(c ...runtimev1alpha1.Condition) nodeGroupModifier {
 	return func(r *v1alpha1.NodeGroup) { r.Status.ConditionedStatus.Conditions = c }
 }
@@ -70,6 +124,10 @@ func withVersion(v *string) nodeGroupModifier {
 	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.Version = v }
 }
 
+func withLabels(l map[string]string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.Labels = l }
+}
+
 func withStatus(s v1alpha1.NodeGroupStatusType) nodeGroupModifier {
 	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.Status = s }
 }
@@ -78,6 +136,375 @@ func withScalingConfig(c *v1alpha1.NodeGroupScalingConfig) nodeGroupModifier {
 	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.ScalingConfig = c }
 }
 
+func withScalingConfigFrom(s *v1alpha1.ScalingConfigSource) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.ScalingConfigFrom = s }
+}
+
+func getScalingConfigSource(data map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		if cm, ok := obj.(*corev1.ConfigMap); ok {
+			cm.Data = data
+		}
+		return nil
+	}
+}
+
+func withObservedScalingConfig(c *v1alpha1.NodeGroupScalingConfig) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.ScalingConfig = c }
+}
+
+func withDiff(d ...v1alpha1.FieldDiff) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.Diff = d }
+}
+
+func withDeletionTimestamp(t *metav1.Time) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.SetDeletionTimestamp(t) }
+}
+
+func withModifiedAt(t time.Time) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.ModifiedAt = &metav1.Time{Time: t} }
+}
+
+func withSubnets(s ...string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.Subnets = s }
+}
+
+func describeCluster(vpcID string) func(*awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+	return func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+		return awseks.DescribeClusterRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeClusterOutput{
+				Cluster: &awseks.Cluster{
+					ResourcesVpcConfig: &awseks.VpcConfigResponse{VpcId: &vpcID},
+					Version:            &version,
+				},
+			}},
+		}
+	}
+}
+
+func describeSubnets(vpcID string) func(*awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+	return func(_ *awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+		return awsec2.DescribeSubnetsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeSubnetsOutput{
+				Subnets: []awsec2.Subnet{{SubnetId: &subnetID, VpcId: &vpcID}},
+			}},
+		}
+	}
+}
+
+func describeSubnetZones(zones ...string) func(*awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+	return func(_ *awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+		subnets := make([]awsec2.Subnet, len(zones))
+		for i := range zones {
+			subnets[i] = awsec2.Subnet{SubnetId: &subnetID, AvailabilityZone: &zones[i]}
+		}
+		return awsec2.DescribeSubnetsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeSubnetsOutput{Subnets: subnets}},
+		}
+	}
+}
+
+// describeOfferings returns the supplied offerings, formatted as
+// instance-type/zone.
+func describeOfferings(offerings ...string) func(*awsec2.DescribeInstanceTypeOfferingsInput) awsec2.DescribeInstanceTypeOfferingsRequest {
+	return func(_ *awsec2.DescribeInstanceTypeOfferingsInput) awsec2.DescribeInstanceTypeOfferingsRequest {
+		o := make([]awsec2.InstanceTypeOffering, len(offerings))
+		for i, off := range offerings {
+			parts := strings.SplitN(off, "/", 2)
+			o[i] = awsec2.InstanceTypeOffering{InstanceType: awsec2.InstanceType(parts[0]), Location: aws.String(parts[1]), LocationType: awsec2.LocationTypeAvailabilityZone}
+		}
+		return awsec2.DescribeInstanceTypeOfferingsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeInstanceTypeOfferingsOutput{InstanceTypeOfferings: o}},
+		}
+	}
+}
+
+func instanceTypesOffered(s corev1.ConditionStatus, r runtimev1alpha1.ConditionReason, msg string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{Type: TypeInstanceTypesOffered, Status: s, Reason: r, Message: msg}
+}
+
+func withObservedVersion(v string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.Version = v }
+}
+
+func withFollowClusterVersion() nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.FollowClusterVersion = aws.Bool(true) }
+}
+
+// describeClusterVersion returns a mock that describes a cluster running the
+// supplied Kubernetes version.
+func describeClusterVersion(v string) func(*awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+	return func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+		return awseks.DescribeClusterRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeClusterOutput{
+				Cluster: &awseks.Cluster{Version: &v},
+			}},
+		}
+	}
+}
+
+func describeClusterStatus(st awseks.ClusterStatus) func(*awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+	return func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+		return awseks.DescribeClusterRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeClusterOutput{
+				Cluster: &awseks.Cluster{Status: st},
+			}},
+		}
+	}
+}
+
+func withRecommendations(r ...string) nodeGroupModifier {
+	return func(n *v1alpha1.NodeGroup) { n.Status.Recommendations = r }
+}
+
+func unavailableIssue(code awseks.NodegroupIssueCode, msg string) runtimev1alpha1.Condition {
+	c := runtimev1alpha1.Unavailable()
+	c.Reason = runtimev1alpha1.ConditionReason(code)
+	c.Message = msg
+	return c
+}
+
+func TestUnavailable(t *testing.T) {
+	cases := map[string]struct {
+		o    v1alpha1.NodeGroupObservation
+		want runtimev1alpha1.Condition
+	}{
+		"NoIssues": {
+			want: runtimev1alpha1.Unavailable(),
+		},
+		"OtherIssue": {
+			o: v1alpha1.NodeGroupObservation{Health: v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{
+				{Code: string(awseks.NodegroupIssueCodeIamNodeRoleNotFound), Message: "role not found"},
+			}}},
+			want: unavailableIssue(awseks.NodegroupIssueCodeIamNodeRoleNotFound, "role not found"),
+		},
+		"InstancesFailedToLaunch": {
+			o: v1alpha1.NodeGroupObservation{Health: v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{
+				{Code: string(awseks.NodegroupIssueCodeIamNodeRoleNotFound), Message: "role not found"},
+				{Code: string(awseks.NodegroupIssueCodeInsufficientFreeAddresses), Message: "no free addresses"},
+			}}},
+			want: runtimev1alpha1.Condition{
+				Type:    runtimev1alpha1.TypeReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  runtimev1alpha1.ConditionReason(v1alpha1.NodeBootstrapLaunchFailed),
+				Message: fmt.Sprintf(msgLaunchFailed, "InsufficientFreeAddresses: no free addresses"),
+			},
+		},
+		"NodesFailedToJoin": {
+			o: v1alpha1.NodeGroupObservation{Health: v1alpha1.NodeGroupHealth{Issues: []v1alpha1.Issue{
+				{Code: string(awseks.NodegroupIssueCodeNodeCreationFailure), Message: "instances failed to join"},
+			}}},
+			want: runtimev1alpha1.Condition{
+				Type:    runtimev1alpha1.TypeReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  runtimev1alpha1.ConditionReason(v1alpha1.NodeBootstrapJoinFailed),
+				Message: fmt.Sprintf(msgJoinFailed, "NodeCreationFailure: instances failed to join"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := unavailable(tc.o)
+			if diff := cmp.Diff(tc.want, got, test.EquateConditions()); diff != "" {
+				t.Errorf("unavailable(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func withHealthIssues(i ...v1alpha1.Issue) nodeGroupModifier {
+	return func(n *v1alpha1.NodeGroup) { n.Status.AtProvider.Health.Issues = i }
+}
+
+func withAMIID(id string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.AMIID = id }
+}
+
+func withNodeRoleArn(arn string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.NodeRoleArn = arn }
+}
+
+func withInstanceProfileName(n string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.InstanceProfileName = n }
+}
+
+func withInstanceTypes(t ...string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.InstanceTypes = t }
+}
+
+func withAutoscalerDiscovery(cluster string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) {
+		r.Spec.ForProvider.ClusterName = cluster
+		r.Spec.ForProvider.AutoscalerDiscovery = aws.Bool(true)
+	}
+}
+
+func withObservedInstanceTypes(instanceTypes ...string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) {
+		r.Status.AtProvider.InstanceTypes = instanceTypes
+	}
+}
+
+func listInstanceProfiles(err error, names ...string) func(*awsiam.ListInstanceProfilesForRoleInput) awsiam.ListInstanceProfilesForRoleRequest {
+	return func(input *awsiam.ListInstanceProfilesForRoleInput) awsiam.ListInstanceProfilesForRoleRequest {
+		if err == nil && aws.StringValue(input.RoleName) != nodeRoleName {
+			err = errors.Errorf("unexpected role name %q", aws.StringValue(input.RoleName))
+		}
+		profiles := make([]awsiam.InstanceProfile, len(names))
+		for i := range names {
+			profiles[i] = awsiam.InstanceProfile{InstanceProfileName: &names[i]}
+		}
+		return awsiam.ListInstanceProfilesForRoleRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Error: err, Data: &awsiam.ListInstanceProfilesForRoleOutput{InstanceProfiles: profiles}},
+		}
+	}
+}
+
+func describeInstances(err error, imageIDs ...string) func(*awsec2.DescribeInstancesInput) awsec2.DescribeInstancesRequest {
+	instances := make([]awsec2.Instance, len(imageIDs))
+	for i := range imageIDs {
+		instances[i] = awsec2.Instance{ImageId: &imageIDs[i]}
+	}
+	return func(_ *awsec2.DescribeInstancesInput) awsec2.DescribeInstancesRequest {
+		return awsec2.DescribeInstancesRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Error: err, Data: &awsec2.DescribeInstancesOutput{
+				Reservations: []awsec2.Reservation{{Instances: instances}},
+			}},
+		}
+	}
+}
+
+func withMaxPodsPerNode(n int64) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.MaxPodsPerNode = &n }
+}
+
+func withMaintenanceWindow(w string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.MaintenanceWindow = &w }
+}
+
+func withMinReadyNodes(n int64) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.MinReadyNodes = &n }
+}
+
+func withReadyNodes(n int64) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.AtProvider.ReadyNodes = &n }
+}
+
+// describeNodes returns a mock that describes the supplied number of running
+// and pending instances.
+func describeNodes(running, pending int) func(*awsec2.DescribeInstancesInput) awsec2.DescribeInstancesRequest {
+	instances := make([]awsec2.Instance, 0, running+pending)
+	for i := 0; i < running; i++ {
+		instances = append(instances, awsec2.Instance{State: &awsec2.InstanceState{Name: awsec2.InstanceStateNameRunning}})
+	}
+	for i := 0; i < pending; i++ {
+		instances = append(instances, awsec2.Instance{State: &awsec2.InstanceState{Name: awsec2.InstanceStateNamePending}})
+	}
+	return func(_ *awsec2.DescribeInstancesInput) awsec2.DescribeInstancesRequest {
+		return awsec2.DescribeInstancesRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeInstancesOutput{
+				Reservations: []awsec2.Reservation{{Instances: instances}},
+			}},
+		}
+	}
+}
+
+func describeSubnetCapacity(free int64) func(*awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+	return func(_ *awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+		return awsec2.DescribeSubnetsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeSubnetsOutput{
+				Subnets: []awsec2.Subnet{{SubnetId: &subnetID, AvailableIpAddressCount: &free}},
+			}},
+		}
+	}
+}
+
+func describeSubnetCIDR(cidr string) func(*awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+	return func(_ *awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+		return awsec2.DescribeSubnetsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeSubnetsOutput{
+				Subnets: []awsec2.Subnet{{SubnetId: &subnetID, CidrBlock: &cidr}},
+			}},
+		}
+	}
+}
+
+func describeVPCCIDR(cidr string) func(*awsec2.DescribeVpcsInput) awsec2.DescribeVpcsRequest {
+	return func(_ *awsec2.DescribeVpcsInput) awsec2.DescribeVpcsRequest {
+		return awsec2.DescribeVpcsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeVpcsOutput{
+				Vpcs: []awsec2.Vpc{{VpcId: &vpcID, CidrBlock: &cidr}},
+			}},
+		}
+	}
+}
+
+func withRemoteAccessSecurityGroup(id string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) {
+		r.Status.AtProvider.Resources = v1alpha1.NodeGroupResources{RemoteAccessSecurityGroup: id}
+	}
+}
+
+func withProviderConfig(name string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.SetProviderConfigReference(&runtimev1alpha1.Reference{Name: name}) }
+}
+
+func withClass(name string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Spec.ForProvider.ClassRef = &runtimev1alpha1.Reference{Name: name} }
+}
+
+func withAnnotations(a map[string]string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.SetAnnotations(a) }
+}
+
+func withGeneration(g int64) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.SetGeneration(g) }
+}
+
+func createTime(ago time.Duration) string {
+	return time.Now().Add(ago).UTC().Format(time.RFC3339)
+}
+
+func withCreateBlockedGeneration(g int64) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.CreateBlockedGeneration = &g }
+}
+
+func withLastUpdateID(id string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) { r.Status.LastUpdateID = &id }
+}
+
+func withNodeRoleRef(arn string) nodeGroupModifier {
+	return func(r *v1alpha1.NodeGroup) {
+		r.Spec.ForProvider.NodeRole = arn
+		r.Spec.ForProvider.NodeRoleRef = &runtimev1alpha1.Reference{Name: "node-role"}
+	}
+}
+
+func getRole(doc string) func(*awsiam.GetRoleInput) awsiam.GetRoleRequest {
+	return func(_ *awsiam.GetRoleInput) awsiam.GetRoleRequest {
+		return awsiam.GetRoleRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsiam.GetRoleOutput{Role: &awsiam.Role{AssumeRolePolicyDocument: &doc}}},
+		}
+	}
+}
+
+func nodeRoleTrust(s corev1.ConditionStatus, r runtimev1alpha1.ConditionReason, msg string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{Type: TypeNodeRoleTrust, Status: s, Reason: r, Message: msg}
+}
+
+type eventCounter struct {
+	warnings int
+}
+
+func (c *eventCounter) Event(_ runtime.Object, e event.Event) {
+	if e.Type == event.TypeWarning {
+		c.warnings++
+	}
+}
+
+func (c *eventCounter) WithAnnotations(_ ...string) event.Recorder { return c }
+
 func nodeGroup(m ...nodeGroupModifier) *v1alpha1.NodeGroup {
 	cr := &v1alpha1.NodeGroup{}
 	for _, f := range m {
@@ -89,11 +516,105 @@ func nodeGroup(m ...nodeGroupModifier) *v1alpha1.NodeGroup {
 var _ managed.ExternalClient = &external{}
 var _ managed.ExternalConnecter = &connector{}
 
+func TestResolveReferences(t *testing.T) {
+	type want struct {
+		clusterName string
+		nodeRole    string
+		subnets     []string
+		keys        []client.ObjectKey
+		err         error
+	}
+
+	notFound := kerrors.NewNotFound(schema.GroupResource{Group: eksv1beta1.Group, Resource: "clusters"}, "cool-cluster")
+
+	cases := map[string]struct {
+		namespace string
+		err       error
+		want      want
+	}{
+		"Resolved": {
+			want: want{
+				clusterName: "cool-cluster-name",
+				nodeRole:    "arn:aws:iam::123456789012:role/cool-role",
+				subnets:     []string{"subnet-cool-subnet"},
+				keys:        []client.ObjectKey{{Name: "cool-cluster"}, {Name: "cool-role"}, {Name: "cool-subnet"}},
+			},
+		},
+		// Managed resources are cluster scoped, so references resolve to
+		// resources of any namespace, regardless of the referencer's.
+		"ReferencerInNamespace": {
+			namespace: "cool-namespace",
+			want: want{
+				clusterName: "cool-cluster-name",
+				nodeRole:    "arn:aws:iam::123456789012:role/cool-role",
+				subnets:     []string{"subnet-cool-subnet"},
+				keys:        []client.ObjectKey{{Name: "cool-cluster"}, {Name: "cool-role"}, {Name: "cool-subnet"}},
+			},
+		},
+		"ClusterNotFound": {
+			err: notFound,
+			want: want{
+				keys: []client.ObjectKey{{Name: "cool-cluster"}},
+				err:  errors.Wrap(errors.Wrap(notFound, "cannot get referenced resource"), "spec.forProvider.clusterName"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := nodeGroup()
+			cr.SetNamespace(tc.namespace)
+			cr.Spec.ForProvider.ClusterNameRef = &runtimev1alpha1.Reference{Name: "cool-cluster"}
+			cr.Spec.ForProvider.NodeRoleRef = &runtimev1alpha1.Reference{Name: "cool-role"}
+			cr.Spec.ForProvider.SubnetRefs = []runtimev1alpha1.Reference{{Name: "cool-subnet"}}
+
+			var keys []client.ObjectKey
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					keys = append(keys, key)
+					if tc.err != nil {
+						return tc.err
+					}
+					switch o := obj.(type) {
+					case *eksv1beta1.Cluster:
+						meta.SetExternalName(o, key.Name+"-name")
+					case *iamv1beta1.IAMRole:
+						o.Status.AtProvider.ARN = "arn:aws:iam::123456789012:role/" + key.Name
+					case *ec2v1beta1.Subnet:
+						meta.SetExternalName(o, "subnet-"+key.Name)
+					}
+					return nil
+				},
+			}
+			err := cr.ResolveReferences(context.Background(), kube)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ResolveReferences(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.keys, keys); diff != "" {
+				t.Errorf("keys: -want, +got:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.clusterName, cr.Spec.ForProvider.ClusterName); diff != "" {
+				t.Errorf("clusterName: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.nodeRole, cr.Spec.ForProvider.NodeRole); diff != "" {
+				t.Errorf("nodeRole: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.subnets, cr.Spec.ForProvider.Subnets); diff != "" {
+				t.Errorf("subnets: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type want struct {
-		cr     *v1alpha1.NodeGroup
-		result managed.ExternalObservation
-		err    error
+		cr       *v1alpha1.NodeGroup
+		result   managed.ExternalObservation
+		warnings int
+		err      error
 	}
 
 	cases := map[string]struct {
@@ -120,19 +641,23 @@ func TestObserve(t *testing.T) {
 					withConditions(runtimev1alpha1.Available()),
 					withStatus(v1alpha1.NodeGroupStatusActive)),
 				result: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
 				},
 			},
 		},
-		"DeletingState": {
+		"SuccessfulAvailableWithRemoteAccess": {
 			args: args{
 				eks: &fake.MockClient{
 					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
 								Nodegroup: &awseks.Nodegroup{
-									Status: awseks.NodegroupStatusDeleting,
+									Status: awseks.NodegroupStatusActive,
+									Resources: &awseks.NodegroupResources{
+										RemoteAccessSecurityGroup: &securityGroupID,
+									},
 								},
 							}},
 						}
@@ -142,232 +667,2073 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				cr: nodeGroup(
-					withConditions(runtimev1alpha1.Deleting()),
-					withStatus(v1alpha1.NodeGroupStatusDeleting)),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withRemoteAccessSecurityGroup(securityGroupID)),
 				result: managed.ExternalObservation{
 					ResourceExists:   true,
 					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						eks.ConnectionSecretRemoteAccessSecurityGroupIDKey: []byte(securityGroupID),
+					},
 				},
 			},
 		},
-		"FailedState": {
+		"DriftInStatus": {
 			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 				eks: &fake.MockClient{
 					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
 								Nodegroup: &awseks.Nodegroup{
-									Status: awseks.NodegroupStatusDegraded,
+									Status:  awseks.NodegroupStatusActive,
+									Version: &version,
 								},
 							}},
 						}
 					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(withVersion(aws.String("1.17"))),
 			},
 			want: want{
 				cr: nodeGroup(
-					withConditions(runtimev1alpha1.Unavailable()),
-					withStatus(v1alpha1.NodeGroupStatusDegraded)),
+					withVersion(aws.String("1.17")),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withObservedVersion(version),
+					withDiff(v1alpha1.FieldDiff{Field: "version", Desired: `"1.17"`, Observed: `"1.16"`})),
 				result: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ConnectionDetails: managed.ConnectionDetails{},
 				},
 			},
 		},
-		"FailedDescribeRequest": {
+		"ObserveOnlyReportsDrift": {
 			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 				eks: &fake.MockClient{
 					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusActive,
+									Version: &version,
+								},
+							}},
 						}
 					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveOnly: "true"}),
+					withVersion(aws.String("1.17"))),
 			},
 			want: want{
-				cr:  nodeGroup(),
-				err: errors.Wrap(errBoom, errDescribeFailed),
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveOnly: "true"}),
+					withVersion(aws.String("1.17")),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withObservedVersion(version),
+					withDiff(v1alpha1.FieldDiff{Field: "version", Desired: `"1.17"`, Observed: `"1.16"`})),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
 			},
 		},
-		"NotFound": {
+		"DiffClearedWhenUpToDate": {
 			args: args{
 				eks: &fake.MockClient{
 					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(awseks.ErrCodeResourceNotFoundException)},
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusActive,
+									Version: &version,
+								},
+							}},
 						}
 					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(
+					withVersion(&version),
+					withDiff(v1alpha1.FieldDiff{Field: "version", Desired: `"1.16"`, Observed: `"1.15"`})),
 			},
 			want: want{
-				cr: nodeGroup(),
+				cr: nodeGroup(
+					withVersion(&version),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withObservedVersion(version)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
 			},
 		},
-		"LateInitSuccess": {
+		"FollowClusterVersionUpToDate": {
 			args: args{
-				kube: &test.MockClient{
-					MockUpdate: test.NewMockUpdateFn(nil),
-				},
 				eks: &fake.MockClient{
 					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
 								Nodegroup: &awseks.Nodegroup{
-									Status:  awseks.NodegroupStatusCreating,
+									Status:  awseks.NodegroupStatusActive,
 									Version: &version,
 								},
 							}},
 						}
 					},
+					MockDescribeClusterRequest: describeClusterVersion(version),
+				},
+				cr: nodeGroup(withFollowClusterVersion()),
+			},
+			want: want{
+				cr: nodeGroup(
+					withFollowClusterVersion(),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withObservedVersion(version)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"FollowClusterVersionClusterUpgraded": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusActive,
+									Version: &version,
+								},
+							}},
+						}
+					},
+					MockDescribeClusterRequest: describeClusterVersion("1.17"),
+				},
+				cr: nodeGroup(withFollowClusterVersion()),
+			},
+			want: want{
+				cr: nodeGroup(
+					withFollowClusterVersion(),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withObservedVersion(version),
+					withDiff(v1alpha1.FieldDiff{Field: "version", Desired: `"1.17"`, Observed: `"1.16"`})),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"TemplatedTagsUpToDate": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+									Tags:   map[string]string{"k8s": "v1.16"},
+								},
+							}},
+						}
+					},
+					MockDescribeClusterRequest: describeCluster(vpcID),
+				},
+				cr: nodeGroup(withTags(map[string]string{"k8s": eks.TagTemplatePrefix + "v{cluster.version}"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withTags(map[string]string{"k8s": eks.TagTemplatePrefix + "v{cluster.version}"}),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"TemplatedTagsOutdated": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+									Tags:   map[string]string{"k8s": "v1.15"},
+								},
+							}},
+						}
+					},
+					MockDescribeClusterRequest: describeCluster(vpcID),
+				},
+				cr: nodeGroup(withTags(map[string]string{"k8s": eks.TagTemplatePrefix + "v{cluster.version}"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withTags(map[string]string{"k8s": eks.TagTemplatePrefix + "v{cluster.version}"}),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withDiff(v1alpha1.FieldDiff{Field: "tags", Desired: `{"k8s":"v1.16"}`, Observed: `{"k8s":"v1.15"}`})),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"ObserveAMIID": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+				},
+				instances: &ec2fake.MockInstanceClient{MockDescribe: describeInstances(nil, "ami-new", "ami-old", "ami-new")},
+				cr:        nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyObserveAMIID: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveAMIID: "true"}),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withAMIID("ami-new,ami-old")),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"ObserveCapacity": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:        awseks.NodegroupStatusActive,
+									InstanceTypes: []string{"t3.medium"},
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveCapacity: "true"}),
+					withInstanceTypes("t3.medium")),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveCapacity: "true"}),
+					withInstanceTypes("t3.medium"),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withObservedInstanceTypes("t3.medium"),
+					withMaxPodsPerNode(17)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"MaxPodsPerNodeLookedUp": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:        awseks.NodegroupStatusActive,
+									InstanceTypes: []string{"m6g.large"},
+								},
+							}},
+						}
+					},
+				},
+				instances: &ec2fake.MockInstanceClient{
+					MockDescribeInstanceTypes: func(_ *awsec2.DescribeInstanceTypesInput) awsec2.DescribeInstanceTypesRequest {
+						return awsec2.DescribeInstanceTypesRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsec2.DescribeInstanceTypesOutput{
+								InstanceTypes: []awsec2.InstanceTypeInfo{{
+									InstanceType: "m6g.large",
+									NetworkInfo:  &awsec2.NetworkInfo{MaximumNetworkInterfaces: aws.Int64(3), Ipv4AddressesPerInterface: aws.Int64(10)},
+								}},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyLookupMaxPods: "true"}),
+					withInstanceTypes("m6g.large")),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyLookupMaxPods: "true"}),
+					withInstanceTypes("m6g.large"),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withMaxPodsPerNode(29)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"MinReadyNodesWaiting": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+				},
+				instances: &ec2fake.MockInstanceClient{MockDescribe: describeNodes(1, 1)},
+				cr:        nodeGroup(withMinReadyNodes(2)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withMinReadyNodes(2),
+					withConditions(runtimev1alpha1.Unavailable().WithMessage(fmt.Sprintf(msgWaitingForNodes, 1, 2))),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withReadyNodes(1)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"MinReadyNodesMet": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+				},
+				instances: &ec2fake.MockInstanceClient{MockDescribe: describeNodes(2, 1)},
+				cr:        nodeGroup(withMinReadyNodes(2)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withMinReadyNodes(2),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withReadyNodes(2)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"ObserveNodeRole": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:   awseks.NodegroupStatusActive,
+									NodeRole: aws.String(nodeRoleArn),
+								},
+							}},
+						}
+					},
 				},
 				cr: nodeGroup(),
 			},
 			want: want{
 				cr: nodeGroup(
-					withStatus(v1alpha1.NodeGroupStatusCreating),
-					withConditions(runtimev1alpha1.Creating()),
-					withVersion(&version),
-				),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withNodeRoleArn(nodeRoleArn)),
 				result: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"ObserveInstanceProfile": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:   awseks.NodegroupStatusActive,
+									NodeRole: aws.String(nodeRoleArn),
+								},
+							}},
+						}
+					},
+				},
+				profiles: &iamfake.MockInstanceProfileClient{MockListInstanceProfilesForRoleRequest: listInstanceProfiles(nil, "profile-b", "profile-a")},
+				cr:       nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyObserveInstanceProfile: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveInstanceProfile: "true"}),
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withNodeRoleArn(nodeRoleArn),
+					withInstanceProfileName("profile-a,profile-b")),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"ObserveInstanceProfileFailed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:   awseks.NodegroupStatusActive,
+									NodeRole: aws.String(nodeRoleArn),
+								},
+							}},
+						}
+					},
+				},
+				profiles: &iamfake.MockInstanceProfileClient{MockListInstanceProfilesForRoleRequest: listInstanceProfiles(errBoom)},
+				cr:       nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyObserveInstanceProfile: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveInstanceProfile: "true"}),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withNodeRoleArn(nodeRoleArn)),
+				err: errors.Wrap(errBoom, errListInstanceProfiles),
+			},
+		},
+		"ObserveAMIIDFailed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+				},
+				instances: &ec2fake.MockInstanceClient{MockDescribe: describeInstances(errBoom)},
+				cr:        nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyObserveAMIID: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveAMIID: "true"}),
+					withStatus(v1alpha1.NodeGroupStatusActive)),
+				err: errors.Wrap(errBoom, errDescribeInstances),
+			},
+		},
+		"DeletingState": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusDeleting,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(runtimev1alpha1.Deleting()),
+					withStatus(v1alpha1.NodeGroupStatusDeleting)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"DeletingWithinGracePeriod": {
+			// The node group was last modified before it was deleted, which
+			// must not count towards its deletion grace period.
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:     awseks.NodegroupStatusDeleting,
+									ModifiedAt: &modifiedLongAgo,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withDeletionTimestamp(&deletedRecently)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedRecently),
+					withConditions(runtimev1alpha1.Deleting()),
+					withStatus(v1alpha1.NodeGroupStatusDeleting),
+					withModifiedAt(modifiedLongAgo)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"StuckDeleting": {
+			// The status of the node group changed recently, which must not
+			// reset its deletion grace period.
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:     awseks.NodegroupStatusDeleting,
+									ModifiedAt: &modifiedRecently,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withDeletionTimestamp(&deletedLongAgo)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withConditions(stuckDeleting(eks.DefaultDeletionGracePeriod), runtimev1alpha1.Deleting()),
+					withStatus(v1alpha1.NodeGroupStatusDeleting),
+					withModifiedAt(modifiedRecently)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				warnings: 1,
+			},
+		},
+		"StuckDeletingAlreadyReported": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:     awseks.NodegroupStatusDeleting,
+									ModifiedAt: &modifiedRecently,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withConditions(stuckDeleting(eks.DefaultDeletionGracePeriod))),
+			},
+			want: want{
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withConditions(stuckDeleting(eks.DefaultDeletionGracePeriod), runtimev1alpha1.Deleting()),
+					withStatus(v1alpha1.NodeGroupStatusDeleting),
+					withModifiedAt(modifiedRecently)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"StuckDeletingCustomGracePeriod": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:     awseks.NodegroupStatusDeleting,
+									ModifiedAt: &modifiedLongAgo,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withAnnotations(map[string]string{eks.AnnotationKeyDeletionGracePeriod: "2h"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withAnnotations(map[string]string{eks.AnnotationKeyDeletionGracePeriod: "2h"}),
+					withConditions(runtimev1alpha1.Deleting()),
+					withStatus(v1alpha1.NodeGroupStatusDeleting),
+					withModifiedAt(modifiedLongAgo)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"DeletingAtGracePeriodBoundary": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:     awseks.NodegroupStatusDeleting,
+									ModifiedAt: &modifiedLongAgo,
+								},
+							}},
+						}
+					},
+				},
+				now: func() time.Time { return deletedLongAgo.Add(eks.DefaultDeletionGracePeriod) },
+				cr:  nodeGroup(withDeletionTimestamp(&deletedLongAgo)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withConditions(runtimev1alpha1.Deleting()),
+					withStatus(v1alpha1.NodeGroupStatusDeleting),
+					withModifiedAt(modifiedLongAgo)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"StuckDeletingRemoveFinalizer": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:     awseks.NodegroupStatusDeleting,
+									ModifiedAt: &modifiedLongAgo,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withAnnotations(map[string]string{eks.AnnotationKeyRemoveFinalizerAfterGracePeriod: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withDeletionTimestamp(&deletedLongAgo),
+					withAnnotations(map[string]string{eks.AnnotationKeyRemoveFinalizerAfterGracePeriod: "true"}),
+					withConditions(stuckDeleting(eks.DefaultDeletionGracePeriod))),
+				result: managed.ExternalObservation{
+					ResourceExists: false,
+				},
+				warnings: 1,
+			},
+		},
+		"FailedState": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusDegraded,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(runtimev1alpha1.Unavailable()),
+					withStatus(v1alpha1.NodeGroupStatusDegraded)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"FailedDescribeRequest": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr:  nodeGroup(),
+				err: errors.Wrap(errBoom, errDescribeFailed),
+			},
+		},
+		"NotFound": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(awseks.ErrCodeResourceNotFoundException)},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr: nodeGroup(),
+			},
+		},
+		"NotFoundWithinCreateGracePeriod": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(awseks.ErrCodeResourceNotFoundException)},
+						}
+					},
+				},
+				cr: nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyCreateTime: createTime(-10 * time.Second)})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCreateTime: createTime(-10 * time.Second)}),
+					withConditions(runtimev1alpha1.Creating())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"NotFoundAfterCreateGracePeriod": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(awseks.ErrCodeResourceNotFoundException)},
+						}
+					},
+				},
+				cr: nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyCreateTime: createTime(-10 * time.Minute)})),
+			},
+			want: want{
+				cr: nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyCreateTime: createTime(-10 * time.Minute)})),
+			},
+		},
+		"LateInitSuccess": {
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusCreating,
+									Version: &version,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr: nodeGroup(
+					withStatus(v1alpha1.NodeGroupStatusCreating),
+					withConditions(runtimev1alpha1.Creating()),
+					withVersion(&version),
+					withObservedVersion(version),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"LateInitDesiredSizeNotWrittenBack": {
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+									ScalingConfig: &awseks.NodegroupScalingConfig{
+										DesiredSize: &desiredSize,
+										MinSize:     &minSize,
+										MaxSize:     &maxSize,
+									},
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr: nodeGroup(
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withConditions(runtimev1alpha1.Available()),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{MinSize: &minSize, MaxSize: &maxSize}),
+					withObservedScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"LateInitFailedKubeUpdate": {
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusCreating,
+									Version: &version,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr:  nodeGroup(withVersion(&version)),
+				err: errors.Wrap(errBoom, errKubeUpdateFailed),
+			},
+		},
+		"SufficientSubnetCapacity": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:        awseks.NodegroupStatusActive,
+									Subnets:       []string{subnetID},
+									ScalingConfig: &awseks.NodegroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize},
+								},
+							}},
+						}
+					},
+				},
+				subnets: &ec2fake.MockSubnetClient{MockDescribe: describeSubnetCapacity(2)},
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckSubnetCapacity: "true"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckSubnetCapacity: "true"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize}),
+					withObservedScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize}),
+					withConditions(runtimev1alpha1.Available(), runtimev1alpha1.Condition{
+						Type:   TypeSubnetCapacity,
+						Status: corev1.ConditionTrue,
+						Reason: ReasonSufficientSubnetCapacity,
+					}),
+					withStatus(v1alpha1.NodeGroupStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"InsufficientSubnetCapacity": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:        awseks.NodegroupStatusActive,
+									Subnets:       []string{subnetID},
+									ScalingConfig: &awseks.NodegroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize},
+								},
+							}},
+						}
+					},
+				},
+				subnets: &ec2fake.MockSubnetClient{MockDescribe: describeSubnetCapacity(1)},
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckSubnetCapacity: "true"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckSubnetCapacity: "true"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize}),
+					withObservedScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize, MinSize: &minSize, MaxSize: &maxSize}),
+					withConditions(runtimev1alpha1.Available(), runtimev1alpha1.Condition{
+						Type:    TypeSubnetCapacity,
+						Status:  corev1.ConditionFalse,
+						Reason:  ReasonInsufficientSubnetCapacity,
+						Message: fmt.Sprintf(msgSubnetCapacity, maxSize-desiredSize, 1),
+					}),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withRecommendations(fmt.Sprintf(recommendSubnetCapacity, fmt.Sprintf(msgSubnetCapacity, maxSize-desiredSize, 1)))),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"NoServiceCIDROverlap": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusActive,
+									Subnets: []string{subnetID},
+								},
+							}},
+						}
+					},
+					MockDescribeClusterRequest: describeCluster(vpcID),
+				},
+				// The VPC is within 10.0.0.0/8, so EKS picks 172.20.0.0/16.
+				vpcs:    &ec2fake.MockVPCClient{MockDescribe: describeVPCCIDR("10.0.0.0/16")},
+				subnets: &ec2fake.MockSubnetClient{MockDescribe: describeSubnetCIDR("10.0.1.0/24")},
+				cr:      nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyCheckServiceCIDR: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckServiceCIDR: "true"}),
+					withConditions(runtimev1alpha1.Available(), runtimev1alpha1.Condition{
+						Type:   TypeServiceCIDR,
+						Status: corev1.ConditionTrue,
+						Reason: ReasonNoServiceCIDROverlap,
+					}),
+					withStatus(v1alpha1.NodeGroupStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"DefaultServiceCIDROverlap": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusActive,
+									Subnets: []string{subnetID},
+								},
+							}},
+						}
+					},
+					MockDescribeClusterRequest: describeCluster(vpcID),
+				},
+				// The subnet is in a secondary CIDR of the VPC that overlaps
+				// 10.100.0.0/16, which EKS picks for a VPC outside 10.0.0.0/8.
+				vpcs:    &ec2fake.MockVPCClient{MockDescribe: describeVPCCIDR("192.168.0.0/16")},
+				subnets: &ec2fake.MockSubnetClient{MockDescribe: describeSubnetCIDR("10.100.8.0/24")},
+				cr:      nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyCheckServiceCIDR: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckServiceCIDR: "true"}),
+					withConditions(runtimev1alpha1.Available(), runtimev1alpha1.Condition{
+						Type:    TypeServiceCIDR,
+						Status:  corev1.ConditionFalse,
+						Reason:  ReasonServiceCIDROverlap,
+						Message: fmt.Sprintf(msgServiceCIDR, []string{subnetID}, eks.DefaultServiceCIDR),
+					}),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withRecommendations(fmt.Sprintf(recommendServiceCIDR, fmt.Sprintf(msgServiceCIDR, []string{subnetID}, eks.DefaultServiceCIDR)))),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"CustomServiceCIDROverlap": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status:  awseks.NodegroupStatusActive,
+									Subnets: []string{subnetID},
+								},
+							}},
+						}
+					},
+				},
+				subnets: &ec2fake.MockSubnetClient{MockDescribe: describeSubnetCIDR("10.0.0.0/16")},
+				cr:      nodeGroup(withAnnotations(map[string]string{eks.AnnotationKeyCheckServiceCIDR: "10.0.128.0/20"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckServiceCIDR: "10.0.128.0/20"}),
+					withConditions(runtimev1alpha1.Available(), runtimev1alpha1.Condition{
+						Type:    TypeServiceCIDR,
+						Status:  corev1.ConditionFalse,
+						Reason:  ReasonServiceCIDROverlap,
+						Message: fmt.Sprintf(msgServiceCIDR, []string{subnetID}, "10.0.128.0/20"),
+					}),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withRecommendations(fmt.Sprintf(recommendServiceCIDR, fmt.Sprintf(msgServiceCIDR, []string{subnetID}, "10.0.128.0/20")))),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"NodeLabelsMatch": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+									Labels: map[string]string{"team": "data", "gpu": "nvidia"},
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyRequiredNodeLabels: "team=data, gpu"}),
+					withLabels(map[string]string{"team": "data", "gpu": "nvidia"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyRequiredNodeLabels: "team=data, gpu"}),
+					withLabels(map[string]string{"team": "data", "gpu": "nvidia"}),
+					withConditions(runtimev1alpha1.Available(), runtimev1alpha1.Condition{
+						Type:   TypeNodeLabels,
+						Status: corev1.ConditionTrue,
+						Reason: ReasonNodeLabelsMatch,
+					}),
+					withStatus(v1alpha1.NodeGroupStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"NodeLabelsMismatch": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+									Labels: map[string]string{"team": "web"},
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyRequiredNodeLabels: "team=data,gpu"}),
+					withLabels(map[string]string{"team": "web"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyRequiredNodeLabels: "team=data,gpu"}),
+					withLabels(map[string]string{"team": "web"}),
+					withConditions(runtimev1alpha1.Available(), runtimev1alpha1.Condition{
+						Type:    TypeNodeLabels,
+						Status:  corev1.ConditionFalse,
+						Reason:  ReasonNodeLabelsMismatch,
+						Message: fmt.Sprintf(msgNodeLabels, []string{"gpu", "team=data"}),
+					}),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withRecommendations(fmt.Sprintf(recommendNodeLabels, fmt.Sprintf(msgNodeLabels, []string{"gpu", "team=data"})))),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"UpdateFailed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+					MockDescribeUpdateRequest: func(_ *awseks.DescribeUpdateInput) awseks.DescribeUpdateRequest {
+						return awseks.DescribeUpdateRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeUpdateOutput{
+								Update: &awseks.Update{Id: &updateID, Status: awseks.UpdateStatusFailed, Errors: []awseks.ErrorDetail{
+									{ErrorCode: awseks.ErrorCodePodEvictionFailure, ErrorMessage: aws.String("Reached max retries while trying to evict pods")},
+								}},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withLastUpdateID(updateID)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(runtimev1alpha1.Available(), updated(corev1.ConditionFalse, runtimev1alpha1.ConditionReason(awseks.ErrorCodePodEvictionFailure),
+						"update cool-update of EKS node group failed: PodEvictionFailure: Reached max retries while trying to evict pods "+eks.GetUpdateFailureGuidance(awseks.ErrorCodePodEvictionFailure))),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withRecommendations(fmt.Sprintf(recommendUpdateFailed,
+						"update cool-update of EKS node group failed: PodEvictionFailure: Reached max retries while trying to evict pods "+eks.GetUpdateFailureGuidance(awseks.ErrorCodePodEvictionFailure)))),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"HealthIssuesRecommended": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusDegraded,
+									Health: &awseks.NodegroupHealth{Issues: []awseks.Issue{{
+										Code:        awseks.NodegroupIssueCodeIamNodeRoleNotFound,
+										Message:     aws.String("role not found"),
+										ResourceIds: []string{nodeRoleArn},
+									}}},
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(unavailableIssue(awseks.NodegroupIssueCodeIamNodeRoleNotFound, "role not found")),
+					withStatus(v1alpha1.NodeGroupStatusDegraded),
+					withHealthIssues(v1alpha1.Issue{Code: string(awseks.NodegroupIssueCodeIamNodeRoleNotFound), Message: "role not found", ResourceIDs: []string{nodeRoleArn}}),
+					withRecommendations(eks.GetHealthIssueRecommendation(v1alpha1.Issue{Code: string(awseks.NodegroupIssueCodeIamNodeRoleNotFound), ResourceIDs: []string{nodeRoleArn}}))),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"HealthIssuesCleared": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusDegraded,
+									Health: &awseks.NodegroupHealth{Issues: []awseks.Issue{}},
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withConditions(unavailableIssue(awseks.NodegroupIssueCodeIamNodeRoleNotFound, "role not found")),
+					withHealthIssues(v1alpha1.Issue{Code: string(awseks.NodegroupIssueCodeIamNodeRoleNotFound), Message: "role not found"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(runtimev1alpha1.Unavailable()),
+					withStatus(v1alpha1.NodeGroupStatusDegraded)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"RecommendationsCleared": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withRecommendations("Recreate the node role of the node group with the same settings.")),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"UpdateSucceeded": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+					MockDescribeUpdateRequest: func(_ *awseks.DescribeUpdateInput) awseks.DescribeUpdateRequest {
+						return awseks.DescribeUpdateRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeUpdateOutput{
+								Update: &awseks.Update{Id: &updateID, Status: awseks.UpdateStatusSuccessful},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withLastUpdateID(updateID)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(runtimev1alpha1.Available(), updated(corev1.ConditionTrue, ReasonUpdateSucceeded, "")),
+					withStatus(v1alpha1.NodeGroupStatusActive)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"UpdateInProgress": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+					MockDescribeUpdateRequest: func(_ *awseks.DescribeUpdateInput) awseks.DescribeUpdateRequest {
+						return awseks.DescribeUpdateRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeUpdateOutput{
+								Update: &awseks.Update{Id: &updateID, Status: awseks.UpdateStatusInProgress},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withLastUpdateID(updateID)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withConditions(runtimev1alpha1.Available()),
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withLastUpdateID(updateID)),
+				result: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"FailedDescribeUpdate": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Status: awseks.NodegroupStatusActive,
+								},
+							}},
+						}
+					},
+					MockDescribeUpdateRequest: func(_ *awseks.DescribeUpdateInput) awseks.DescribeUpdateRequest {
+						return awseks.DescribeUpdateRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nodeGroup(withLastUpdateID(updateID)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withStatus(v1alpha1.NodeGroupStatusActive),
+					withLastUpdateID(updateID)),
+				err: errors.Wrap(errBoom, errDescribeUpdate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventCounter{}
+			now := tc.args.now
+			if now == nil {
+				now = time.Now
+			}
+			e := &external{kube: tc.kube, client: tc.eks, subnets: tc.subnets, vpcs: tc.vpcs, instances: tc.instances, profiles: tc.profiles, record: rec, now: now}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.warnings, rec.warnings); diff != "" {
+				t.Errorf("warnings: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr       *v1alpha1.NodeGroup
+		result   managed.ExternalCreation
+		err      error
+		warnings int
+		created  bool
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version)),
+			},
+			want: want{
+				created: true,
+				cr:      nodeGroup(withVersion(&version), withConditions(runtimev1alpha1.Creating())),
+				result:  managed.ExternalCreation{},
+			},
+		},
+		"SuccessfulInheritClusterVersion": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterVersion(version),
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						if aws.StringValue(input.Version) != version {
+							return awseks.CreateNodegroupRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+							}
+						}
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				created: true,
+				cr:      nodeGroup(withConditions(runtimev1alpha1.Creating())),
+				result:  managed.ExternalCreation{},
+			},
+		},
+		"LimitExceeded": {
+			args: args{
+				eks: &fake.MockClient{
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(awseks.ErrCodeResourceLimitExceededException)},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version), withGeneration(2)),
+			},
+			want: want{
+				cr: nodeGroup(withVersion(&version), withGeneration(2),
+					withConditions(createBlocked()), withCreateBlockedGeneration(2)),
+				err: errors.Wrap(errors.New(awseks.ErrCodeResourceLimitExceededException), msgCreateBlocked),
+			},
+		},
+		"CreateBlocked": {
+			args: args{
+				cr: nodeGroup(withVersion(&version), withGeneration(2),
+					withConditions(createBlocked()), withCreateBlockedGeneration(2)),
+			},
+			want: want{
+				cr: nodeGroup(withVersion(&version), withGeneration(2),
+					withConditions(createBlocked()), withCreateBlockedGeneration(2)),
+				err: errors.New(msgCreateBlocked),
+			},
+		},
+		"CreateUnblockedByChange": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version), withGeneration(3),
+					withConditions(createBlocked()), withCreateBlockedGeneration(2)),
+			},
+			want: want{
+				created: true,
+				cr: nodeGroup(withVersion(&version), withGeneration(3),
+					withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"SuccessfulNoNeedForCreate": {
+			args: args{
+				cr: nodeGroup(withStatus(v1alpha1.NodeGroupStatusCreating)),
+			},
+			want: want{
+				cr: nodeGroup(
+					withStatus(v1alpha1.NodeGroupStatusCreating),
+					withConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"SuccessfulSubnetsInClusterVPC": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeCluster(vpcID),
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				subnets: &ec2fake.MockSubnetClient{
+					MockDescribe: describeSubnets(vpcID),
+				},
+				cr: nodeGroup(withSubnets(subnetID)),
+			},
+			want: want{
+				created: true,
+				cr:      nodeGroup(withSubnets(subnetID), withConditions(runtimev1alpha1.Creating())),
+				result:  managed.ExternalCreation{},
+			},
+		},
+		"NodeRoleTrusted": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				roles: &iamfake.MockRoleClient{MockGetRoleRequest: getRole(url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`))},
+				cr:    nodeGroup(withVersion(&version), withNodeRoleRef(nodeRoleArn)),
+			},
+			want: want{
+				created: true,
+				cr: nodeGroup(withVersion(&version), withNodeRoleRef(nodeRoleArn),
+					withConditions(runtimev1alpha1.Creating(), nodeRoleTrust(corev1.ConditionTrue, ReasonNodeRoleTrusted, ""))),
+				result: managed.ExternalCreation{},
+			},
+		},
+		"NodeRoleNotTrusted": {
+			args: args{
+				roles: &iamfake.MockRoleClient{MockGetRoleRequest: getRole(url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"eks.amazonaws.com"},"Action":"sts:AssumeRole"}]}`))},
+				cr:    nodeGroup(withVersion(&version), withNodeRoleRef(nodeRoleArn)),
+			},
+			want: want{
+				cr: nodeGroup(withVersion(&version), withNodeRoleRef(nodeRoleArn),
+					withConditions(runtimev1alpha1.Creating(), nodeRoleTrust(corev1.ConditionFalse, ReasonNodeRoleNotTrusted, fmt.Sprintf(errNodeRoleNotTrusted, nodeRoleArn)))),
+				err: errors.Errorf(errNodeRoleNotTrusted, nodeRoleArn),
+			},
+		},
+		"InstanceTypesOffered": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterVersion(version),
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				subnets:   &ec2fake.MockSubnetClient{MockDescribe: describeSubnetZones("us-east-1a", "us-east-1b")},
+				instances: &ec2fake.MockInstanceClient{MockDescribeOfferings: describeOfferings("m5.large/us-east-1a", "m5.large/us-east-1b")},
+				cr: nodeGroup(withVersion(&version), withSubnets(subnetID), withInstanceTypes("m5.large"),
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckInstanceTypeOfferings: "true"})),
+			},
+			want: want{
+				created: true,
+				cr: nodeGroup(withVersion(&version), withSubnets(subnetID), withInstanceTypes("m5.large"),
+					withConditions(runtimev1alpha1.Creating(), instanceTypesOffered(corev1.ConditionTrue, ReasonInstanceTypesOffered, ""))),
+				result: managed.ExternalCreation{},
+			},
+		},
+		"InstanceTypesNotOffered": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterVersion(version),
+				},
+				subnets:   &ec2fake.MockSubnetClient{MockDescribe: describeSubnetZones("us-east-1a", "us-east-1e")},
+				instances: &ec2fake.MockInstanceClient{MockDescribeOfferings: describeOfferings("m5.large/us-east-1a", "m5.large/us-east-1e", "m4.large/us-east-1a")},
+				cr: nodeGroup(withVersion(&version), withSubnets(subnetID), withInstanceTypes("m5.large", "m4.large"),
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckInstanceTypeOfferings: "true"})),
+			},
+			want: want{
+				cr: nodeGroup(withVersion(&version), withSubnets(subnetID), withInstanceTypes("m5.large", "m4.large"),
+					withAnnotations(map[string]string{eks.AnnotationKeyCheckInstanceTypeOfferings: "true"}),
+					withConditions(runtimev1alpha1.Creating(), instanceTypesOffered(corev1.ConditionFalse, ReasonInstanceTypesNotOffered,
+						fmt.Sprintf(errInstanceTypesNotOffered, []string{"m4.large"}, []string{"us-east-1a", "us-east-1e"})))),
+				err: errors.Errorf(errInstanceTypesNotOffered, []string{"m4.large"}, []string{"us-east-1a", "us-east-1e"}),
+			},
+		},
+		"FailedSubnetsNotInClusterVPC": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeCluster(vpcID),
+				},
+				subnets: &ec2fake.MockSubnetClient{
+					MockDescribe: describeSubnets("vpc-other"),
+				},
+				cr: nodeGroup(withSubnets(subnetID)),
+			},
+			want: want{
+				cr:  nodeGroup(withSubnets(subnetID), withConditions(runtimev1alpha1.Creating())),
+				err: errors.Errorf(errSubnetsNotInVPC, []string{subnetID}, vpcID, ""),
+			},
+		},
+		"SuccessfulPatchResourceVersion": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: func(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+						obj.(*v1alpha1.NodeGroup).SetResourceVersion("2")
+						return nil
+					},
+				},
+				eks: &fake.MockClient{
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version), withResourceVersion("1")),
+			},
+			want: want{
+				created: true,
+				cr:      nodeGroup(withVersion(&version), withResourceVersion("2"), withConditions(runtimev1alpha1.Creating())),
+				result:  managed.ExternalCreation{},
+			},
+		},
+		"FailedDescribeCluster": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+						return awseks.DescribeClusterRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nodeGroup(withSubnets(subnetID)),
+			},
+			want: want{
+				cr:  nodeGroup(withSubnets(subnetID), withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errDescribeCluster),
+			},
+		},
+		"FailedPatchCreateTime": {
+			args: args{
+				kube: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(errBoom),
+				},
+				eks: &fake.MockClient{
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version)),
+			},
+			want: want{
+				cr:       nodeGroup(withVersion(&version), withConditions(runtimev1alpha1.Creating())),
+				created:  true,
+				warnings: 1,
+			},
+		},
+		"FailedRequest": {
+			args: args{
+				eks: &fake.MockClient{
+					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+						return awseks.CreateNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version)),
+			},
+			want: want{
+				cr:  nodeGroup(withVersion(&version), withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventCounter{}
+			e := &external{kube: tc.kube, client: tc.eks, subnets: tc.subnets, instances: tc.instances, roles: tc.roles, record: rec, now: time.Now}
+			o, err := e.Create(context.Background(), tc.args.cr)
+
+			// The create time is not deterministic, so we only check that
+			// it is recorded once the node group was created.
+			if diff := cmp.Diff(tc.want.created, eks.IsWithinCreateGracePeriod(tc.args.cr, time.Now())); diff != "" {
+				t.Errorf("created: -want, +got:\n%s", diff)
+			}
+			if tc.want.created {
+				tc.want.cr.SetAnnotations(tc.args.cr.GetAnnotations())
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.warnings, rec.warnings); diff != "" {
+				t.Errorf("warnings: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestNewConnecter(t *testing.T) {
+	sg := "sg-remote"
+	ng := &awseks.Nodegroup{Status: awseks.NodegroupStatusActive, Resources: &awseks.NodegroupResources{RemoteAccessSecurityGroup: &sg}}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		want        managed.ConnectionDetails
+	}{
+		"DefaultKeys": {
+			want: managed.ConnectionDetails{eks.ConnectionSecretRemoteAccessSecurityGroupIDKey: []byte(sg)},
+		},
+		"RemappedKeys": {
+			annotations: map[string]string{reconciler.AnnotationKeyConnectionSecretKeys: eks.ConnectionSecretRemoteAccessSecurityGroupIDKey + "=securityGroup"},
+			want:        managed.ConnectionDetails{"securityGroup": []byte(sg)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true, ConnectionDetails: eks.GetNodeGroupConnectionDetails(ng)}, nil
+					},
+				}, nil
+			})
+			cr := nodeGroup()
+			cr.SetAnnotations(tc.annotations)
+			ec, err := newConnecter(c, &eventCounter{}, logging.NewNopLogger()).Connect(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Connect(...): %s", err)
+			}
+			o, err := ec.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, o.ConnectionDetails); diff != "" {
+				t.Errorf("ConnectionDetails: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestCreateBlockedReconcile asserts that the managed reconciler does not
+// report a node group whose creation is blocked as successfully created.
+func TestCreateBlockedReconcile(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme(...): %s", err)
+	}
+
+	cases := map[string]struct {
+		eks eks.Client
+		cr  *v1alpha1.NodeGroup
+	}{
+		"LimitExceeded": {
+			eks: &fake.MockClient{
+				MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
+					return awseks.CreateNodegroupRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errors.New(awseks.ErrCodeResourceLimitExceededException)},
+					}
+				},
+			},
+			cr: nodeGroup(withVersion(&version), withGeneration(2)),
+		},
+		"CreateBlocked": {
+			cr: nodeGroup(withVersion(&version), withGeneration(2),
+				withConditions(createBlocked()), withCreateBlockedGeneration(2)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *v1alpha1.NodeGroup
+			m := &rtfake.Manager{
+				Client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+						tc.cr.DeepCopyInto(obj.(*v1alpha1.NodeGroup))
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+						got = obj.(*v1alpha1.NodeGroup)
+						return nil
+					},
+				},
+				Scheme: s,
+			}
+			rec := &eventRecorder{}
+			e := &external{client: tc.eks, record: rec, now: time.Now}
+			r := managed.NewReconciler(m, resource.ManagedKind(v1alpha1.NodeGroupGroupVersionKind),
+				managed.WithInitializers(),
+				managed.WithReferenceResolver(managed.ReferenceResolverFn(func(_ context.Context, _ resource.Managed) error { return nil })),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &managed.ExternalClientFns{
+						ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+							return managed.ExternalObservation{ResourceExists: false}, nil
+						},
+						CreateFn: e.Create,
+					}, nil
+				})),
+				managed.WithConnectionPublishers(),
+				managed.WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }}),
+				managed.WithRecorder(rec))
+
+			if _, err := r.Reconcile(reconcile.Request{}); err != nil {
+				t.Fatalf("Reconcile(...): %s", err)
+			}
+			for _, ev := range rec.events {
+				if ev.Type == event.TypeNormal {
+					t.Errorf("Reconcile(...): unexpected %s event: %s", ev.Reason, ev.Message)
+				}
+			}
+			if got == nil {
+				t.Fatalf("Reconcile(...): status was not updated")
+			}
+			if c := got.GetCondition(runtimev1alpha1.TypeSynced); c.Reason == runtimev1alpha1.ReasonReconcileSuccess {
+				t.Errorf("Reconcile(...): want a %s condition other than %s", runtimev1alpha1.TypeSynced, c.Reason)
+			}
+			if c := got.GetCondition(runtimev1alpha1.TypeReady); c.Reason != ReasonCreateBlocked {
+				t.Errorf("Reconcile(...): want a %s condition with reason %s, got %s", runtimev1alpha1.TypeReady, ReasonCreateBlocked, c.Reason)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		cr            *v1alpha1.NodeGroup
+		result        managed.ExternalUpdate
+		err           error
+		configUpdates int
+	}
+
+	var configUpdates int
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SuccessfulAddTags": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{},
+							}},
+						}
+					},
+					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						return awseks.UpdateNodegroupConfigRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
+						}
+					},
+					MockTagResourceRequest: func(input *awseks.TagResourceInput) awseks.TagResourceRequest {
+						return awseks.TagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.TagResourceOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withTags(map[string]string{"foo": "bar"})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withTags(map[string]string{"foo": "bar"})),
+			},
+		},
+		"SuccessfulRemoveTags": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{},
+							}},
+						}
+					},
+					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						return awseks.UpdateNodegroupConfigRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
+						}
+					},
+					MockUntagResourceRequest: func(input *awseks.UntagResourceInput) awseks.UntagResourceRequest {
+						return awseks.UntagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UntagResourceOutput{}},
+						}
+					},
 				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr: nodeGroup(),
 			},
 		},
-		"LateInitFailedKubeUpdate": {
+		"AWSManagedTagsNotRemoved": {
 			args: args{
-				kube: &test.MockClient{
-					MockUpdate: test.NewMockUpdateFn(errBoom),
-				},
 				eks: &fake.MockClient{
-					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
 								Nodegroup: &awseks.Nodegroup{
-									Status:  awseks.NodegroupStatusCreating,
-									Version: &version,
+									Tags: map[string]string{"foo": "bar", "aws:eks:cluster-name": "cool-cluster", "eks:nodegroup-name": "cool-group"},
 								},
 							}},
 						}
 					},
+					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						return awseks.UpdateNodegroupConfigRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
+						}
+					},
+					MockUntagResourceRequest: func(input *awseks.UntagResourceInput) awseks.UntagResourceRequest {
+						return awseks.UntagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(
+					withTags(map[string]string{"foo": "bar"})),
 			},
 			want: want{
-				cr:  nodeGroup(withVersion(&version)),
-				err: errors.Wrap(errBoom, errKubeUpdateFailed),
+				cr: nodeGroup(
+					withTags(map[string]string{"foo": "bar"})),
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{kube: tc.kube, client: tc.eks}
-			o, err := e.Observe(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestCreate(t *testing.T) {
-	type want struct {
-		cr     *v1alpha1.NodeGroup
-		result managed.ExternalCreation
-		err    error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
+		"DeferredWhileClusterUpdating": {
 			args: args{
 				eks: &fake.MockClient{
-					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
-						return awseks.CreateNodegroupRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.CreateNodegroupOutput{}},
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusUpdating),
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
 						}
 					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(withVersion(&version)),
 			},
 			want: want{
-				cr:     nodeGroup(withConditions(runtimev1alpha1.Creating())),
-				result: managed.ExternalCreation{},
+				cr: nodeGroup(
+					withVersion(&version),
+					withConditions(updated(corev1.ConditionFalse, ReasonUpdateDeferred, fmt.Sprintf(msgUpdateDeferred, awseks.ClusterStatusUpdating)))),
 			},
 		},
-		"SuccessfulNoNeedForCreate": {
+		"ResumedAfterDeferral": {
 			args: args{
-				cr: nodeGroup(withStatus(v1alpha1.NodeGroupStatusCreating)),
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{},
+							}},
+						}
+					},
+					MockUpdateNodegroupVersionRequest: func(input *awseks.UpdateNodegroupVersionInput) awseks.UpdateNodegroupVersionRequest {
+						return awseks.UpdateNodegroupVersionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupVersionOutput{
+								Update: &awseks.Update{Id: &updateID},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withVersion(&version),
+					withConditions(updated(corev1.ConditionFalse, ReasonUpdateDeferred, fmt.Sprintf(msgUpdateDeferred, awseks.ClusterStatusUpdating)))),
 			},
 			want: want{
 				cr: nodeGroup(
-					withStatus(v1alpha1.NodeGroupStatusCreating),
-					withConditions(runtimev1alpha1.Creating())),
+					withVersion(&version),
+					withLastUpdateID(updateID),
+					withConditions(updated(corev1.ConditionUnknown, ReasonUpdateResumed, ""))),
 			},
 		},
-		"FailedRequest": {
+		"FailedDescribeCluster": {
 			args: args{
 				eks: &fake.MockClient{
-					MockCreateNodegroupRequest: func(input *awseks.CreateNodegroupInput) awseks.CreateNodegroupRequest {
-						return awseks.CreateNodegroupRequest{
+					MockDescribeClusterRequest: func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+						return awseks.DescribeClusterRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
 						}
 					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(withVersion(&version)),
 			},
 			want: want{
-				cr:  nodeGroup(withConditions(runtimev1alpha1.Creating())),
-				err: errors.Wrap(errBoom, errCreateFailed),
+				cr:  nodeGroup(withVersion(&version)),
+				err: errors.Wrap(errBoom, errDescribeCluster),
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{kube: tc.kube, client: tc.eks}
-			o, err := e.Create(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestUpdate(t *testing.T) {
-	type want struct {
-		cr     *v1alpha1.NodeGroup
-		result managed.ExternalUpdate
-		err    error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"SuccessfulAddTags": {
+		"NoClusterDescribed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: func(_ *awseks.DescribeClusterInput) awseks.DescribeClusterRequest {
+						return awseks.DescribeClusterRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeClusterOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version)),
+			},
+			want: want{
+				cr:  nodeGroup(withVersion(&version)),
+				err: errors.New(errDescribeCluster),
+			},
+		},
+		"InvalidTags": {
 			args: args{
 				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
@@ -375,60 +2741,225 @@ func TestUpdate(t *testing.T) {
 							}},
 						}
 					},
+					MockTagResourceRequest: func(input *awseks.TagResourceInput) awseks.TagResourceRequest {
+						return awseks.TagResourceRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
+				},
+				cr: nodeGroup(withTags(map[string]string{"foo": strings.Repeat("x", eks.MaxTagValueLength+1)})),
+			},
+			want: want{
+				cr:  nodeGroup(withTags(map[string]string{"foo": strings.Repeat("x", eks.MaxTagValueLength+1)})),
+				err: errors.Wrap(eks.ValidateTags(map[string]string{"foo": strings.Repeat("x", eks.MaxTagValueLength+1)}), errInvalidTags),
+			},
+		},
+		"ObserveOnly": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{Version: aws.String("1.16")},
+							}},
+						}
+					},
+					MockUpdateNodegroupVersionRequest: func(input *awseks.UpdateNodegroupVersionInput) awseks.UpdateNodegroupVersionRequest {
+						return awseks.UpdateNodegroupVersionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+						}
+					},
 					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						configUpdates++
 						return awseks.UpdateNodegroupConfigRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
 						}
 					},
 					MockTagResourceRequest: func(input *awseks.TagResourceInput) awseks.TagResourceRequest {
 						return awseks.TagResourceRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.TagResourceOutput{}},
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
 						}
 					},
 				},
 				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveOnly: "true"}),
+					withVersion(aws.String("1.17")),
 					withTags(map[string]string{"foo": "bar"})),
 			},
 			want: want{
 				cr: nodeGroup(
+					withAnnotations(map[string]string{eks.AnnotationKeyObserveOnly: "true"}),
+					withVersion(aws.String("1.17")),
 					withTags(map[string]string{"foo": "bar"})),
 			},
 		},
-		"SuccessfulRemoveTags": {
+		"SuccessfulUpdateVersion": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockUpdateNodegroupVersionRequest: func(input *awseks.UpdateNodegroupVersionInput) awseks.UpdateNodegroupVersionRequest {
+						return awseks.UpdateNodegroupVersionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupVersionOutput{
+								Update: &awseks.Update{Id: &updateID},
+							}},
+						}
+					},
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withVersion(&version)),
+			},
+			want: want{
+				cr: nodeGroup(withVersion(&version), withLastUpdateID(updateID)),
+			},
+		},
+		"SuccessfulFollowClusterVersion": {
+			args: args{
+				eks: &fake.MockClient{
+					MockUpdateNodegroupVersionRequest: func(input *awseks.UpdateNodegroupVersionInput) awseks.UpdateNodegroupVersionRequest {
+						if aws.StringValue(input.Version) != "1.17" {
+							return awseks.UpdateNodegroupVersionRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
+							}
+						}
+						return awseks.UpdateNodegroupVersionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupVersionOutput{}},
+						}
+					},
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{Version: &version},
+							}},
+						}
+					},
+					MockDescribeClusterRequest: describeClusterVersion("1.17"),
+				},
+				cr: nodeGroup(withFollowClusterVersion()),
+			},
+			want: want{
+				cr: nodeGroup(withFollowClusterVersion()),
+			},
+		},
+		"SuccessfulUpdateNodeGroup": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						return awseks.UpdateNodegroupConfigRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
+						}
+					},
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
+			},
+			want: want{
+				cr: nodeGroup(withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
+			},
+		},
+		"SuccessfulUpdateLabelsAndScalingConfig": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						configUpdates++
+						want := &awseks.UpdateNodegroupConfigInput{
+							ClusterName:   aws.String(""),
+							NodegroupName: aws.String(""),
+							Labels: &awseks.UpdateLabelsPayload{
+								AddOrUpdateLabels: map[string]string{"cool": "label"},
+								RemoveLabels:      []string{"old"},
+							},
+							ScalingConfig: &awseks.NodegroupScalingConfig{DesiredSize: &desiredSize},
+						}
+						if diff := cmp.Diff(want, input); diff != "" {
+							t.Errorf("r: -want, +got:\n%s", diff)
+						}
+						return awseks.UpdateNodegroupConfigRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
+						}
+					},
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									Labels:        map[string]string{"old": "label"},
+									ScalingConfig: &awseks.NodegroupScalingConfig{},
+								},
+							}},
+						}
+					},
+				},
+				cr: nodeGroup(
+					withLabels(map[string]string{"cool": "label"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
+			},
+			want: want{
+				cr: nodeGroup(
+					withLabels(map[string]string{"cool": "label"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
+				configUpdates: 1,
+			},
+		},
+		"SuccessfulUpdateScalingConfigFromSource": {
 			args: args{
+				kube: &test.MockClient{
+					MockGet: getScalingConfigSource(map[string]string{eks.DefaultMinSizeKey: "2", eks.DefaultMaxSizeKey: "10"}),
+				},
 				eks: &fake.MockClient{
-					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
-						return awseks.DescribeNodegroupRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
-								Nodegroup: &awseks.Nodegroup{},
-							}},
-						}
-					},
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						configUpdates++
+						want := &awseks.UpdateNodegroupConfigInput{
+							ClusterName:   aws.String(""),
+							NodegroupName: aws.String(""),
+							ScalingConfig: &awseks.NodegroupScalingConfig{MinSize: aws.Int64(2), MaxSize: aws.Int64(10)},
+						}
+						if diff := cmp.Diff(want, input); diff != "" {
+							t.Errorf("r: -want, +got:\n%s", diff)
+						}
 						return awseks.UpdateNodegroupConfigRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
 						}
 					},
-					MockUntagResourceRequest: func(input *awseks.UntagResourceInput) awseks.UntagResourceRequest {
-						return awseks.UntagResourceRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UntagResourceOutput{}},
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{
+									ScalingConfig: &awseks.NodegroupScalingConfig{MinSize: &minSize, MaxSize: &maxSize},
+								},
+							}},
 						}
 					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{MinSize: &minSize, MaxSize: &maxSize}),
+					withScalingConfigFrom(&v1alpha1.ScalingConfigSource{Name: "cool", Namespace: "default"})),
 			},
 			want: want{
-				cr: nodeGroup(),
+				cr: nodeGroup(
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{MinSize: &minSize, MaxSize: &maxSize}),
+					withScalingConfigFrom(&v1alpha1.ScalingConfigSource{Name: "cool", Namespace: "default"})),
+				configUpdates: 1,
 			},
 		},
-		"SuccessfulUpdateVersion": {
+		"FailedGetScalingConfigSource": {
 			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
 				eks: &fake.MockClient{
-					MockUpdateNodegroupVersionRequest: func(input *awseks.UpdateNodegroupVersionInput) awseks.UpdateNodegroupVersionRequest {
-						return awseks.UpdateNodegroupVersionRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupVersionOutput{}},
-						}
-					},
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
@@ -437,32 +2968,36 @@ func TestUpdate(t *testing.T) {
 						}
 					},
 				},
-				cr: nodeGroup(withVersion(&version)),
+				cr: nodeGroup(withScalingConfigFrom(&v1alpha1.ScalingConfigSource{Name: "cool", Namespace: "default"})),
 			},
 			want: want{
-				cr: nodeGroup(withVersion(&version)),
+				cr:  nodeGroup(withScalingConfigFrom(&v1alpha1.ScalingConfigSource{Name: "cool", Namespace: "default"})),
+				err: errors.Wrap(errBoom, errGetScalingConfigSource),
 			},
 		},
-		"SuccessfulUpdateNodeGroup": {
+		"ConfigUpToDate": {
 			args: args{
 				eks: &fake.MockClient{
-					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
-						return awseks.UpdateNodegroupConfigRequest{
-							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{}},
-						}
-					},
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
-								Nodegroup: &awseks.Nodegroup{},
+								Nodegroup: &awseks.Nodegroup{
+									Labels:        map[string]string{"cool": "label"},
+									ScalingConfig: &awseks.NodegroupScalingConfig{DesiredSize: &desiredSize},
+								},
 							}},
 						}
 					},
 				},
-				cr: nodeGroup(withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
+				cr: nodeGroup(
+					withLabels(map[string]string{"cool": "label"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
 			},
 			want: want{
-				cr: nodeGroup(withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
+				cr: nodeGroup(
+					withLabels(map[string]string{"cool": "label"}),
+					withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
 			},
 		},
 		"AlreadyModifying": {
@@ -476,6 +3011,7 @@ func TestUpdate(t *testing.T) {
 		"FailedDescribe": {
 			args: args{
 				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
@@ -489,9 +3025,27 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errDescribeFailed),
 			},
 		},
+		"NoNodeGroupDescribed": {
+			args: args{
+				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{}},
+						}
+					},
+				},
+				cr: nodeGroup(),
+			},
+			want: want{
+				cr:  nodeGroup(),
+				err: errors.New(errDescribeFailed),
+			},
+		},
 		"FailedUpdateConfig": {
 			args: args{
 				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockUpdateNodegroupConfigRequest: func(input *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
 						return awseks.UpdateNodegroupConfigRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
@@ -505,16 +3059,17 @@ func TestUpdate(t *testing.T) {
 						}
 					},
 				},
-				cr: nodeGroup(),
+				cr: nodeGroup(withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
 			},
 			want: want{
-				cr:  nodeGroup(),
+				cr:  nodeGroup(withScalingConfig(&v1alpha1.NodeGroupScalingConfig{DesiredSize: &desiredSize})),
 				err: errors.Wrap(errBoom, errUpdateConfigFailed),
 			},
 		},
 		"FailedUpdateVersion": {
 			args: args{
 				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockUpdateNodegroupVersionRequest: func(input *awseks.UpdateNodegroupVersionInput) awseks.UpdateNodegroupVersionRequest {
 						return awseks.UpdateNodegroupVersionRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom},
@@ -538,6 +3093,7 @@ func TestUpdate(t *testing.T) {
 		"FailedRemoveTags": {
 			args: args{
 				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
@@ -563,6 +3119,7 @@ func TestUpdate(t *testing.T) {
 		"FailedAddTags": {
 			args: args{
 				eks: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
 					MockDescribeNodegroupRequest: func(input *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
 						return awseks.DescribeNodegroupRequest{
 							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
@@ -587,7 +3144,8 @@ func TestUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{kube: tc.kube, client: tc.eks}
+			configUpdates = 0
+			e := &external{kube: tc.kube, client: tc.eks, record: event.NewNopRecorder()}
 			u, err := e.Update(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -599,6 +3157,9 @@ func TestUpdate(t *testing.T) {
 			if diff := cmp.Diff(tc.want.result, u); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
+			if tc.want.configUpdates != 0 && tc.want.configUpdates != configUpdates {
+				t.Errorf("r: want %d UpdateNodegroupConfig calls, got %d", tc.want.configUpdates, configUpdates)
+			}
 		})
 	}
 }
@@ -704,6 +3265,15 @@ func TestInitialize(t *testing.T) {
 				cr: nodeGroup(withTags(resource.GetExternalTags(nodeGroup()), (map[string]string{"foo": "bar"}))),
 			},
 		},
+		"Unchanged": {
+			args: args{
+				cr:   nodeGroup(withTags(resource.GetExternalTags(nodeGroup()), map[string]string{"foo": "bar"})),
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			},
+			want: want{
+				cr: nodeGroup(withTags(resource.GetExternalTags(nodeGroup()), map[string]string{"foo": "bar"})),
+			},
+		},
 		"UpdateFailed": {
 			args: args{
 				cr:   nodeGroup(),
@@ -729,3 +3299,394 @@ func TestInitialize(t *testing.T) {
 		})
 	}
 }
+
+func getNodeGroupClass(d v1alpha1.NodeGroupDefaults) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+		if key.Name != "cool-class" {
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		obj.(*v1alpha1.NodeGroupClass).Spec.Defaults = d
+		return nil
+	}
+}
+
+func TestDefaulter(t *testing.T) {
+	defaults := v1alpha1.NodeGroupDefaults{
+		Labels:  map[string]string{"team": "platform"},
+		Subnets: []string{"cool-subnet"},
+	}
+
+	type want struct {
+		cr  *v1alpha1.NodeGroup
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NoClass": {
+			args: args{
+				cr:   nodeGroup(),
+				kube: &test.MockClient{},
+			},
+			want: want{
+				cr: nodeGroup(),
+			},
+		},
+		"UnsetFieldsDefaulted": {
+			args: args{
+				cr: nodeGroup(withClass("cool-class")),
+				kube: &test.MockClient{
+					MockGet:    getNodeGroupClass(defaults),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+			},
+			want: want{
+				cr: nodeGroup(withClass("cool-class"), withLabels(map[string]string{"team": "platform"}), withSubnets("cool-subnet")),
+			},
+		},
+		"SetFieldsNotOverridden": {
+			args: args{
+				cr: nodeGroup(withClass("cool-class"), withLabels(map[string]string{"team": "data"}), withSubnets("other-subnet")),
+				kube: &test.MockClient{
+					MockGet:    getNodeGroupClass(defaults),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+			},
+			want: want{
+				cr: nodeGroup(withClass("cool-class"), withLabels(map[string]string{"team": "data"}), withSubnets("other-subnet")),
+			},
+		},
+		"GetClassFailed": {
+			args: args{
+				cr:   nodeGroup(withClass("missing-class")),
+				kube: &test.MockClient{MockGet: getNodeGroupClass(defaults)},
+			},
+			want: want{
+				err: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, "missing-class"), errGetClass),
+			},
+		},
+		"UpdateFailed": {
+			args: args{
+				cr: nodeGroup(withClass("cool-class")),
+				kube: &test.MockClient{
+					MockGet:    getNodeGroupClass(defaults),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errKubeUpdateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := &defaulter{kube: tc.kube}
+			err := d.Initialize(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr); err == nil && diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	invalid := &v1alpha1.NodeGroupScalingConfig{MinSize: &maxSize, MaxSize: &minSize}
+
+	cases := map[string]struct {
+		cr      *v1alpha1.NodeGroup
+		invalid bool
+	}{
+		"Valid": {
+			cr: nodeGroup(withScalingConfig(&v1alpha1.NodeGroupScalingConfig{MinSize: &minSize, DesiredSize: &desiredSize, MaxSize: &maxSize})),
+		},
+		"Invalid": {
+			cr:      nodeGroup(withScalingConfig(invalid)),
+			invalid: true,
+		},
+		"InvalidButDeleted": {
+			cr: nodeGroup(withScalingConfig(invalid), withDeletionTimestamp(&deletionTime)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validate(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.invalid, err != nil); diff != "" {
+				t.Errorf("invalid: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultTagsDrift(t *testing.T) {
+	cr := nodeGroup(withProviderConfig("default"), withTags(map[string]string{"key": "val", "team": "cooler"}))
+	e := &external{kube: &test.MockClient{
+		MockGet: getProviderConfig(map[string]string{"team": "cool", "env": "prod", "aws:reserved": "nope"}),
+	}}
+	p, err := e.resolveParameters(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("resolveParameters(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(map[string]string{"key": "val", "team": "cooler"}, cr.Spec.ForProvider.Tags); diff != "" {
+		t.Errorf("spec tags: -want, +got:\n%s", diff)
+	}
+
+	untagged := &awseks.Nodegroup{Tags: map[string]string{"key": "val", "team": "cooler"}}
+	if eks.IsNodeGroupUpToDate(p, untagged) {
+		t.Errorf("IsNodeGroupUpToDate(...): node group without default tags should not be up to date")
+	}
+	tagged := &awseks.Nodegroup{Tags: map[string]string{"key": "val", "team": "cooler", "env": "prod"}}
+	if !eks.IsNodeGroupUpToDate(p, tagged) {
+		t.Errorf("IsNodeGroupUpToDate(...): node group with default tags should be up to date")
+	}
+
+	e.kube = &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}
+	_, err = e.resolveParameters(context.Background(), cr)
+	if diff := cmp.Diff(errors.Wrap(errBoom, "cannot get referenced ProviderConfig"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("resolveParameters(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestAutoscalerDiscoveryTags(t *testing.T) {
+	cr := nodeGroup(withAutoscalerDiscovery("cool-cluster"), withTags(map[string]string{eks.TagKeyAutoscalerEnabled: "false"}))
+	e := &external{kube: &test.MockClient{MockGet: getProviderConfig(nil)}}
+	p, err := e.resolveParameters(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("resolveParameters(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(map[string]string{eks.TagKeyAutoscalerEnabled: "false"}, cr.Spec.ForProvider.Tags); diff != "" {
+		t.Errorf("spec tags: -want, +got:\n%s", diff)
+	}
+	want := map[string]string{
+		eks.TagKeyAutoscalerEnabled:                        "false",
+		eks.TagKeyAutoscalerClusterPrefix + "cool-cluster": "owned",
+	}
+	if diff := cmp.Diff(want, p.Tags); diff != "" {
+		t.Errorf("resolved tags: -want, +got:\n%s", diff)
+	}
+}
+
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		pc := obj.(*apisv1beta1.ProviderConfig)
+		pc.Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
+func TestAutoscalerDiscovery(t *testing.T) {
+	cluster := "cool-cluster"
+	tags := eks.GetAutoscalerDiscoveryTags(cluster)
+	describeNodegroup := func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+		return awseks.DescribeNodegroupRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+				Nodegroup: &awseks.Nodegroup{
+					Status: awseks.NodegroupStatusActive,
+					Tags:   tags,
+					Resources: &awseks.NodegroupResources{AutoScalingGroups: []awseks.AutoScalingGroup{
+						{Name: aws.String("asg-tagged")},
+						{Name: aws.String("asg-untagged")},
+					}},
+				},
+			}},
+		}
+	}
+	describeASGs := func(_ *awsautoscaling.DescribeAutoScalingGroupsInput) awsautoscaling.DescribeAutoScalingGroupsRequest {
+		return awsautoscaling.DescribeAutoScalingGroupsRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsautoscaling.DescribeAutoScalingGroupsOutput{
+				AutoScalingGroups: []awsautoscaling.AutoScalingGroup{
+					{
+						AutoScalingGroupName: aws.String("asg-tagged"),
+						Tags: []awsautoscaling.TagDescription{
+							{Key: aws.String(eks.TagKeyAutoscalerEnabled), Value: aws.String("true")},
+							{Key: aws.String(eks.TagKeyAutoscalerClusterPrefix + cluster), Value: aws.String("owned")},
+						},
+					},
+					{
+						AutoScalingGroupName: aws.String("asg-untagged"),
+						Tags: []awsautoscaling.TagDescription{
+							{Key: aws.String(eks.TagKeyAutoscalerEnabled), Value: aws.String("true")},
+						},
+					},
+				},
+			}},
+		}
+	}
+	type want struct {
+		upToDate bool
+		tagged   *awsautoscaling.CreateOrUpdateTagsInput
+	}
+
+	cases := map[string]struct {
+		cr   *v1alpha1.NodeGroup
+		want want
+	}{
+		"Enabled": {
+			cr: nodeGroup(withAutoscalerDiscovery(cluster)),
+			want: want{
+				upToDate: false,
+				tagged:   eks.GenerateCreateOrUpdateAutoScalingGroupTagsInput([]string{"asg-untagged"}, tags),
+			},
+		},
+		"Disabled": {
+			cr:   nodeGroup(withTags(tags)),
+			want: want{upToDate: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var tagged *awsautoscaling.CreateOrUpdateTagsInput
+			e := &external{
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				client: &fake.MockClient{
+					MockDescribeClusterRequest:   describeClusterStatus(awseks.ClusterStatusActive),
+					MockDescribeNodegroupRequest: describeNodegroup,
+				},
+				asgs: &asfake.MockGroupClient{
+					MockDescribeAutoScalingGroupsRequest: describeASGs,
+					MockCreateOrUpdateTagsRequest: func(in *awsautoscaling.CreateOrUpdateTagsInput) awsautoscaling.CreateOrUpdateTagsRequest {
+						tagged = in
+						return awsautoscaling.CreateOrUpdateTagsRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awsautoscaling.CreateOrUpdateTagsOutput{}},
+						}
+					},
+				},
+				record: event.NewNopRecorder(),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("Update(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.tagged, tagged); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateMaintenanceWindow(t *testing.T) {
+	window := "sun:02:00-sun:06:00"
+	// The first of November 2020 was a Sunday.
+	inWindow := time.Date(2020, time.November, 1, 3, 0, 0, 0, time.UTC)
+	outOfWindow := time.Date(2020, time.November, 2, 3, 0, 0, 0, time.UTC)
+	configUpdateID := "config-update"
+
+	type want struct {
+		cr             *v1alpha1.NodeGroup
+		versionUpdated bool
+		configUpdated  bool
+	}
+
+	cases := map[string]struct {
+		now  time.Time
+		cr   *v1alpha1.NodeGroup
+		want want
+	}{
+		"InWindow": {
+			now: inWindow,
+			cr:  nodeGroup(withVersion(&version), withMaintenanceWindow(window), withLabels(map[string]string{"cool": "true"})),
+			want: want{
+				cr:             nodeGroup(withVersion(&version), withMaintenanceWindow(window), withLabels(map[string]string{"cool": "true"}), withLastUpdateID(updateID)),
+				versionUpdated: true,
+			},
+		},
+		"OutOfWindow": {
+			now: outOfWindow,
+			cr:  nodeGroup(withVersion(&version), withMaintenanceWindow(window), withLabels(map[string]string{"cool": "true"})),
+			want: want{
+				cr: nodeGroup(
+					withVersion(&version),
+					withMaintenanceWindow(window),
+					withLabels(map[string]string{"cool": "true"}),
+					withLastUpdateID(configUpdateID),
+					withConditions(updated(corev1.ConditionFalse, ReasonWaitingForMaintenanceWindow, fmt.Sprintf(msgMaintenance, window, version)))),
+				configUpdated: true,
+			},
+		},
+		"ResumedInWindow": {
+			now: inWindow,
+			cr: nodeGroup(
+				withVersion(&version),
+				withMaintenanceWindow(window),
+				withConditions(updated(corev1.ConditionFalse, ReasonWaitingForMaintenanceWindow, fmt.Sprintf(msgMaintenance, window, version)))),
+			want: want{
+				cr: nodeGroup(
+					withVersion(&version),
+					withMaintenanceWindow(window),
+					withLastUpdateID(updateID),
+					withConditions(updated(corev1.ConditionUnknown, ReasonUpdateResumed, ""))),
+				versionUpdated: true,
+			},
+		},
+		"NoWindow": {
+			now: outOfWindow,
+			cr:  nodeGroup(withVersion(&version)),
+			want: want{
+				cr:             nodeGroup(withVersion(&version), withLastUpdateID(updateID)),
+				versionUpdated: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var versionUpdated, configUpdated bool
+			e := &external{
+				client: &fake.MockClient{
+					MockDescribeClusterRequest: describeClusterStatus(awseks.ClusterStatusActive),
+					MockDescribeNodegroupRequest: func(_ *awseks.DescribeNodegroupInput) awseks.DescribeNodegroupRequest {
+						return awseks.DescribeNodegroupRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.DescribeNodegroupOutput{
+								Nodegroup: &awseks.Nodegroup{},
+							}},
+						}
+					},
+					MockUpdateNodegroupVersionRequest: func(_ *awseks.UpdateNodegroupVersionInput) awseks.UpdateNodegroupVersionRequest {
+						versionUpdated = true
+						return awseks.UpdateNodegroupVersionRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupVersionOutput{
+								Update: &awseks.Update{Id: &updateID},
+							}},
+						}
+					},
+					MockUpdateNodegroupConfigRequest: func(_ *awseks.UpdateNodegroupConfigInput) awseks.UpdateNodegroupConfigRequest {
+						configUpdated = true
+						return awseks.UpdateNodegroupConfigRequest{
+							Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awseks.UpdateNodegroupConfigOutput{
+								Update: &awseks.Update{Id: &configUpdateID},
+							}},
+						}
+					},
+				},
+				record: event.NewNopRecorder(),
+				now:    func() time.Time { return tc.now },
+			}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("Update(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.versionUpdated, versionUpdated); diff != "" {
+				t.Errorf("UpdateNodegroupVersion: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.configUpdated, configUpdated); diff != "" {
+				t.Errorf("UpdateNodegroupConfig: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
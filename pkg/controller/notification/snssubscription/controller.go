@@ -18,11 +18,18 @@ package snssubscription
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,39 +41,131 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
+	sqsv1beta1 "github.com/crossplane/provider-aws/apis/sqs/v1beta1"
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/sns"
 	snsclient "github.com/crossplane/provider-aws/pkg/clients/sns"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
 	errUnexpectedObject    = "the managed resource is not a SNS Subscription resource"
 	errGetSubscriptionAttr = "failed to get SNS Subscription Attributes"
+	errGetTopicAttr        = "failed to get SNS Topic Attributes of the SNS Subscription"
+	errListSubscriptions   = "failed to list the SNS Subscriptions of the SNS Topic"
 	errCreate              = "failed to create the SNS Subscription"
 	errDelete              = "failed to delete the SNS Subscription"
 	errUpdate              = "failed to update the SNS Subscription"
+	errRecreate            = "failed to delete the SNS Subscription in order to recreate it"
+	errGetTopic            = "failed to get the SNS Topic of the SNS Subscription"
+	errInheritDefaults     = "failed to update the SNS Subscription with the subscription defaults of its SNS Topic"
+
+	errNewProbeRequest = "cannot create endpoint probe request"
+	errProbeRequest    = "cannot reach endpoint"
+	errProbeStatus     = "unexpected endpoint response status %d"
+
+	errListQueues     = "cannot list SQS queues"
+	errDescribeKey    = "cannot describe the KMS key of the SQS queue"
+	errGetKeyPolicy   = "cannot get the policy of the KMS key of the SQS queue"
+	errParseKeyPolicy = "cannot parse the policy of the KMS key of the SQS queue"
+	msgKeyAWSManaged  = "the SQS queue is encrypted with the AWS managed key %s, whose policy cannot allow SNS to use it; messages cannot be delivered unless the queue is encrypted with a customer managed key"
+	msgKeyNotGranted  = "the policy of the KMS key %s of the SQS queue does not allow " + sns.ServicePrincipal + " kms:GenerateDataKey* and kms:Decrypt; messages cannot be delivered to the queue"
+)
+
+const (
+	// AnnotationKeyProbeEndpoint is the annotation that enables probing the
+	// endpoint of a confirmed HTTP or HTTPS subscription.
+	AnnotationKeyProbeEndpoint = "sns.aws.crossplane.io/probe-endpoint"
+
+	// TypeEndpointReachable indicates whether the endpoint of an HTTP or HTTPS
+	// subscription could be reached.
+	TypeEndpointReachable runtimev1alpha1.ConditionType = "EndpointReachable"
+
+	reasonEndpointReachable   runtimev1alpha1.ConditionReason = "EndpointReachable"
+	reasonEndpointUnreachable runtimev1alpha1.ConditionReason = "EndpointUnreachable"
+)
+
+const (
+	// TypeEndpointKeyGranted indicates whether SNS may use the KMS key that
+	// encrypts the SQS queue of an SQS subscription. It is only reported for
+	// queues that are managed by Crossplane.
+	TypeEndpointKeyGranted runtimev1alpha1.ConditionType = "EndpointKeyGranted"
+
+	reasonEndpointKeyGranted    runtimev1alpha1.ConditionReason = "EndpointKeyGranted"
+	reasonEndpointKeyNotGranted runtimev1alpha1.ConditionReason = "EndpointKeyNotGranted"
+	reasonEndpointKeyUnknown    runtimev1alpha1.ConditionReason = "EndpointKeyUnknown"
+)
+
+const (
+	// TypeOrphaned indicates whether a subscription no longer exists because
+	// its topic was deleted.
+	TypeOrphaned runtimev1alpha1.ConditionType = "Orphaned"
+
+	reasonTopicDeleted runtimev1alpha1.ConditionReason = "TopicDeleted"
+	reasonTopicExists  runtimev1alpha1.ConditionReason = "TopicExists"
+
+	msgTopicDeleted = "the topic of the subscription was deleted; the subscription is recreated once the topic exists again"
 )
 
+// endpointClient is used to probe the endpoints of HTTP and HTTPS
+// subscriptions. The timeout bounds how long an unreachable endpoint may delay
+// an observation.
+var endpointClient = &http.Client{Timeout: 5 * time.Second}
+
 // SetupSubscription adds a controller than reconciles SNSSubscription
-func SetupSubscription(mgr ctrl.Manager, l logging.Logger) error {
+func SetupSubscription(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.SNSSubscriptionGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	refs := reconciler.NewReferenceCache(mgr.GetClient(), mgr.GetCache(), o.ReferenceCacheTTL)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.SNSSubscription{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.SNSSubscriptionGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: sns.NewSubscriptionClient}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient(), newClientFn: sns.NewSubscriptionClient, newKeyClientFn: sns.NewKeyClient}, record, l.WithValues("controller", name), v1alpha1.SNSSubscriptionKind, reconciler.WithConnecterHistory(reconciler.DefaultHistorySize))),
+			managed.WithReferenceResolver(&defaultsResolver{ReferenceResolver: managed.NewAPISimpleReferenceResolver(refs), kube: refs}),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
+}
+
+// defaultsResolver resolves the references of an SNSSubscription, then fills
+// its unset attributes with the subscription defaults of the SNSTopic it
+// references.
+type defaultsResolver struct {
+	managed.ReferenceResolver
+	kube client.Client
+}
+
+func (r *defaultsResolver) ResolveReferences(ctx context.Context, mg resource.Managed) error {
+	if err := r.ReferenceResolver.ResolveReferences(ctx, mg); err != nil {
+		return err
+	}
+	cr, ok := mg.(*v1alpha1.SNSSubscription)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+	ref := cr.Spec.ForProvider.TopicARNRef
+	if ref == nil {
+		return nil
+	}
+	t := &v1alpha1.SNSTopic{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, t); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errGetTopic)
+	}
+	if !snsclient.InheritSubscriptionDefaults(&cr.Spec.ForProvider, t.Spec.ForProvider.SubscriptionDefaults) {
+		return nil
+	}
+	return errors.Wrap(r.kube.Update(ctx, cr), errInheritDefaults)
 }
 
 type connector struct {
-	kube        client.Client
-	newClientFn func(config aws.Config) sns.SubscriptionClient
+	kube           client.Client
+	newClientFn    func(config aws.Config) sns.SubscriptionClient
+	newKeyClientFn func(config aws.Config) sns.KeyClient
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -78,12 +177,14 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, err
 	}
-	return &external{client: c.newClientFn(*cfg), kube: c.kube}, nil
+	return &external{client: c.newClientFn(*cfg), keys: c.newKeyClientFn(*cfg), kube: c.kube, http: endpointClient}, nil
 }
 
 type external struct {
 	client snsclient.SubscriptionClient
+	keys   snsclient.KeyClient
 	kube   client.Client
+	http   *http.Client
 }
 
 func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
@@ -93,6 +194,9 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 	}
 
 	if meta.GetExternalName(cr) == "" {
+		if !snsclient.IsSubscriptionArnReturned(cr.Spec.ForProvider) {
+			return e.observeUnconfirmed(ctx, cr)
+		}
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
@@ -102,9 +206,14 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 	res, err := e.client.GetSubscriptionAttributesRequest(&awssns.GetSubscriptionAttributesInput{
 		SubscriptionArn: aws.String(meta.GetExternalName(cr)),
 	}).Send(ctx)
+	if sns.IsSubscriptionNotFound(err) {
+		return e.observeMissing(ctx, cr)
+	}
 	if err != nil {
-		return managed.ExternalObservation{},
-			errors.Wrap(resource.Ignore(sns.IsSubscriptionNotFound, err), errGetSubscriptionAttr)
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetSubscriptionAttr)
+	}
+	if cr.GetCondition(TypeOrphaned).Status == corev1.ConditionTrue {
+		cr.SetConditions(orphaned(false))
 	}
 
 	current := cr.Spec.ForProvider.DeepCopy()
@@ -113,15 +222,35 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 	// GenerateObservation for SNS Subscription
 	cr.Status.AtProvider = snsclient.GenerateSubscriptionObservation(res.Attributes)
 
-	// Set Status for SNS Subcription
-	switch *cr.Status.AtProvider.Status { //nolint:exhaustive
-	case v1alpha1.ConfirmationSuccessful:
-		cr.Status.SetConditions(runtimev1alpha1.Available())
-	default:
+	// A subscription is only available once the endpoint owner confirmed it.
+	// Its attributes can not be updated until then, so it is considered up to
+	// date while the confirmation is pending.
+	if !snsclient.IsSubscriptionConfirmed(res.Attributes) {
 		cr.Status.SetConditions(runtimev1alpha1.Creating())
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        true,
+			ResourceLateInitialized: !reflect.DeepEqual(current, &cr.Spec.ForProvider),
+		}, nil
+	}
+	cr.Status.SetConditions(runtimev1alpha1.Available())
+
+	// An endpoint can only be expected to accept deliveries once the
+	// subscription has been confirmed.
+	if isEndpointProbed(cr) {
+		cr.Status.SetConditions(endpointReachability(probeEndpoint(ctx, e.http, cr.Spec.ForProvider.Endpoint)))
+	}
+
+	if cr.Spec.ForProvider.Protocol == "sqs" {
+		if c, ok := e.checkEndpointKey(ctx, cr.Spec.ForProvider.Endpoint); ok {
+			cr.Status.SetConditions(c)
+		}
 	}
 
 	upToDate := snsclient.IsSNSSubscriptionAttributesUpToDate(cr.Spec.ForProvider, res.Attributes)
+	if snsclient.IsSubscriptionRecreatedOnEndpointUpdate(cr.Spec.ForProvider) {
+		upToDate = upToDate && snsclient.IsSNSSubscriptionEndpointUpToDate(cr.Spec.ForProvider, res.Attributes)
+	}
 	return managed.ExternalObservation{
 		ResourceExists:          true,
 		ResourceUpToDate:        upToDate,
@@ -129,20 +258,101 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 	}, nil
 }
 
+// observeMissing observes a subscription that does not exist. SNS deletes the
+// subscriptions of a deleted topic, and subscribing to the deleted topic would
+// fail until it is recreated. Such a subscription is therefore reported as
+// orphaned and considered to exist, unless it is being deleted, in which case
+// it is already gone.
+func (e *external) observeMissing(ctx context.Context, cr *v1alpha1.SNSSubscription) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	_, err := e.client.GetTopicAttributesRequest(&awssns.GetTopicAttributesInput{
+		TopicArn: aws.String(cr.Spec.ForProvider.TopicARN),
+	}).Send(ctx)
+	if sns.IsTopicNotFound(err) {
+		cr.SetConditions(runtimev1alpha1.Unavailable(), orphaned(true))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetTopicAttr)
+	}
+	if cr.GetCondition(TypeOrphaned).Status == corev1.ConditionTrue {
+		cr.SetConditions(orphaned(false))
+	}
+	return managed.ExternalObservation{ResourceExists: false}, nil
+}
+
+// observeUnconfirmed observes a subscription that was created without
+// returning its ARN. Its ARN is only known once it has been confirmed, so we
+// find it by its protocol and endpoint instead. A subscription that has yet to
+// be confirmed exists, but has no external name.
+func (e *external) observeUnconfirmed(ctx context.Context, cr *v1alpha1.SNSSubscription) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(cr) {
+		// AWS deletes subscriptions that are not confirmed within three
+		// days; they cannot be unsubscribed.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	var sub *awssns.Subscription
+	input := &awssns.ListSubscriptionsByTopicInput{TopicArn: aws.String(cr.Spec.ForProvider.TopicARN)}
+	for sub == nil {
+		rsp, err := e.client.ListSubscriptionsByTopicRequest(input).Send(ctx)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListSubscriptions)
+		}
+		sub = snsclient.FindSubscription(cr.Spec.ForProvider, rsp.Subscriptions)
+		if rsp.NextToken == nil {
+			break
+		}
+		input.NextToken = rsp.NextToken
+	}
+	if sub == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if !snsclient.IsSubscriptionArn(aws.StringValue(sub.SubscriptionArn)) {
+		pending := v1alpha1.ConfirmationPending
+		cr.Status.AtProvider.Status = &pending
+		cr.SetConditions(runtimev1alpha1.Creating())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	meta.SetExternalName(cr, aws.StringValue(sub.SubscriptionArn))
+	o, err := e.Observe(ctx, cr)
+	o.ResourceLateInitialized = true
+	return o, err
+}
+
 func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mgd.(*v1alpha1.SNSSubscription)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
 	}
 
-	input := snsclient.GenerateSubscribeInput(&cr.Spec.ForProvider)
+	input, err := snsclient.GenerateSubscribeInput(&cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
 	res, err := e.client.SubscribeRequest(input).Send(ctx)
 
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
 	}
 
-	meta.SetExternalName(cr, aws.StringValue(res.SubscribeOutput.SubscriptionArn))
+	// Subscriptions that have yet to be confirmed are only assigned an
+	// external name once they are, unless their ARN was returned. A
+	// subscription that is recreated still has the ARN of the one it
+	// replaces, which we clear so that it is observed as pending.
+	arn := aws.StringValue(res.SubscribeOutput.SubscriptionArn)
+	if !snsclient.IsSubscriptionArn(arn) {
+		pending := v1alpha1.ConfirmationPending
+		cr.Status.AtProvider.Status = &pending
+		cr.SetConditions(runtimev1alpha1.Creating())
+		if meta.GetExternalName(cr) == "" {
+			return managed.ExternalCreation{}, nil
+		}
+		meta.SetExternalName(cr, "")
+		return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+	}
+	meta.SetExternalName(cr, arn)
 	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
 }
 
@@ -159,6 +369,18 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
 	}
+
+	// The endpoint and protocol of a subscription cannot be updated. We
+	// unsubscribe so that the next observation finds that the subscription no
+	// longer exists and subscribes again with the desired endpoint.
+	if snsclient.IsSubscriptionRecreatedOnEndpointUpdate(cr.Spec.ForProvider) &&
+		!snsclient.IsSNSSubscriptionEndpointUpToDate(cr.Spec.ForProvider, resp.Attributes) {
+		_, err := e.client.UnsubscribeRequest(&awssns.UnsubscribeInput{
+			SubscriptionArn: aws.String(meta.GetExternalName(cr)),
+		}).Send(ctx)
+		return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(sns.IsSubscriptionNotFound, err), errRecreate)
+	}
+
 	// Update Subscription
 	attrs := snsclient.GetChangedSubAttributes(cr.Spec.ForProvider, resp.Attributes)
 	for k, v := range attrs {
@@ -190,3 +412,137 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 	}).Send(ctx)
 	return errors.Wrap(resource.Ignore(sns.IsSubscriptionNotFound, err), errDelete)
 }
+
+func isEndpointProbed(cr *v1alpha1.SNSSubscription) bool {
+	if cr.GetAnnotations()[AnnotationKeyProbeEndpoint] != "true" {
+		return false
+	}
+	switch cr.Spec.ForProvider.Protocol {
+	case "http", "https":
+		return true
+	}
+	return false
+}
+
+// probeEndpoint returns an error if the supplied endpoint cannot be reached or
+// does not respond with a 2xx status.
+func probeEndpoint(ctx context.Context, c *http.Client, endpoint string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return errors.Wrap(err, errNewProbeRequest)
+	}
+	rsp, err := c.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errProbeRequest)
+	}
+	defer rsp.Body.Close() // nolint:errcheck
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return errors.Errorf(errProbeStatus, rsp.StatusCode)
+	}
+	return nil
+}
+
+// checkEndpointKey returns a condition that reports whether SNS may use the
+// KMS key that encrypts the supplied SQS queue endpoint. The key is that of the
+// Crossplane managed Queue with the endpoint as its ARN; no condition is
+// returned if there is no such queue, or if it is not encrypted.
+func (e *external) checkEndpointKey(ctx context.Context, endpoint string) (runtimev1alpha1.Condition, bool) {
+	l := &sqsv1beta1.QueueList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return endpointKeyGranted(reasonEndpointKeyUnknown, errors.Wrap(err, errListQueues).Error()), true
+	}
+	var keyID string
+	for _, q := range l.Items {
+		if q.Status.AtProvider.ARN == endpoint {
+			keyID = aws.StringValue(q.Spec.ForProvider.KMSMasterKeyID)
+			break
+		}
+	}
+	if keyID == "" {
+		return runtimev1alpha1.Condition{}, false
+	}
+
+	// The policy of a key can only be retrieved by its ID or ARN, whereas a
+	// queue may refer to its key by alias.
+	key, err := e.keys.DescribeKeyRequest(&kms.DescribeKeyInput{KeyId: aws.String(keyID)}).Send(ctx)
+	if err != nil {
+		return endpointKeyGranted(reasonEndpointKeyUnknown, errors.Wrap(err, errDescribeKey).Error()), true
+	}
+	if key.KeyMetadata.KeyManager == kms.KeyManagerTypeAws {
+		return endpointKeyGranted(reasonEndpointKeyNotGranted, fmt.Sprintf(msgKeyAWSManaged, keyID)), true
+	}
+	policy, err := e.keys.GetKeyPolicyRequest(&kms.GetKeyPolicyInput{
+		KeyId:      key.KeyMetadata.KeyId,
+		PolicyName: aws.String(sns.DefaultKeyPolicyName),
+	}).Send(ctx)
+	if err != nil {
+		return endpointKeyGranted(reasonEndpointKeyUnknown, errors.Wrap(err, errGetKeyPolicy).Error()), true
+	}
+	ok, err := sns.IsKeyUsableByService(aws.StringValue(policy.Policy), sns.ServicePrincipal)
+	if err != nil {
+		return endpointKeyGranted(reasonEndpointKeyUnknown, errors.Wrap(err, errParseKeyPolicy).Error()), true
+	}
+	if !ok {
+		return endpointKeyGranted(reasonEndpointKeyNotGranted, fmt.Sprintf(msgKeyNotGranted, keyID)), true
+	}
+	return endpointKeyGranted(reasonEndpointKeyGranted, ""), true
+}
+
+// endpointKeyGranted returns a condition that reports whether SNS may use the
+// KMS key of the SQS queue of a subscription for the supplied reason.
+func endpointKeyGranted(r runtimev1alpha1.ConditionReason, msg string) runtimev1alpha1.Condition {
+	c := runtimev1alpha1.Condition{
+		Type:               TypeEndpointKeyGranted,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             r,
+		Message:            msg,
+	}
+	switch r { //nolint:exhaustive
+	case reasonEndpointKeyGranted:
+		c.Status = corev1.ConditionTrue
+	case reasonEndpointKeyUnknown:
+		c.Status = corev1.ConditionUnknown
+	}
+	return c
+}
+
+// endpointReachability returns a condition that reports the result of probing
+// the endpoint of a subscription.
+func endpointReachability(err error) runtimev1alpha1.Condition {
+	if err != nil {
+		return runtimev1alpha1.Condition{
+			Type:               TypeEndpointReachable,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonEndpointUnreachable,
+			Message:            err.Error(),
+		}
+	}
+	return runtimev1alpha1.Condition{
+		Type:               TypeEndpointReachable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonEndpointReachable,
+	}
+}
+
+// orphaned returns a condition that reports whether a subscription is
+// orphaned because its topic was deleted.
+func orphaned(o bool) runtimev1alpha1.Condition {
+	if o {
+		return runtimev1alpha1.Condition{
+			Type:               TypeOrphaned,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonTopicDeleted,
+			Message:            msgTopicDeleted,
+		}
+	}
+	return runtimev1alpha1.Condition{
+		Type:               TypeOrphaned,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonTopicExists,
+	}
+}
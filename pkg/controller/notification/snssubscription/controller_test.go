@@ -4,12 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
@@ -18,6 +27,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
+	sqsv1beta1 "github.com/crossplane/provider-aws/apis/sqs/v1beta1"
 	"github.com/crossplane/provider-aws/pkg/clients/sns"
 	"github.com/crossplane/provider-aws/pkg/clients/sns/fake"
 )
@@ -27,6 +37,7 @@ var (
 	unexpecedItem resource.Managed
 	subName       = "some-topic"
 	errBoom       = errors.New("boom")
+	errNotFound   = awserr.New(awssns.ErrCodeNotFoundException, "not found", nil)
 )
 
 type args struct {
@@ -61,6 +72,63 @@ func withSubARN(s *string) subModifier {
 	}
 }
 
+func withExternalName(n string) subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		meta.SetExternalName(t, n)
+	}
+}
+
+func withEndpoint(protocol, endpoint string) subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		t.Spec.ForProvider.Protocol = protocol
+		t.Spec.ForProvider.Endpoint = endpoint
+	}
+}
+
+func withEndpointUpdatePolicy(p v1alpha1.EndpointUpdatePolicy) subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		t.Spec.ForProvider.EndpointUpdatePolicy = &p
+	}
+}
+
+func withReturnSubscriptionArn(b bool) subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		t.Spec.ForProvider.ReturnSubscriptionArn = &b
+	}
+}
+
+func withStatus(s v1alpha1.ConfirmationStatus) subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		t.Status.AtProvider.Status = &s
+	}
+}
+
+func withOwner(o string) subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		t.Status.AtProvider.Owner = &o
+	}
+}
+
+// getSubscriptionAttributes returns a mock that observes a confirmed
+// subscription with the supplied protocol and endpoint.
+func getSubscriptionAttributes(protocol, endpoint string) func(*awssns.GetSubscriptionAttributesInput) awssns.GetSubscriptionAttributesRequest {
+	return func(_ *awssns.GetSubscriptionAttributesInput) awssns.GetSubscriptionAttributesRequest {
+		return awssns.GetSubscriptionAttributesRequest{
+			Request: &aws.Request{
+				HTTPRequest: &http.Request{},
+				Retryer:     aws.NoOpRetryer{},
+				Data: &awssns.GetSubscriptionAttributesOutput{
+					Attributes: map[string]string{
+						sns.SubscriptionProtocol:            protocol,
+						sns.SubscriptionEndpoint:            endpoint,
+						sns.SubscriptionPendingConfirmation: "false",
+					},
+				},
+			},
+		}
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type want struct {
 		cr     resource.Managed
@@ -72,6 +140,82 @@ func TestObserve(t *testing.T) {
 		args
 		want
 	}{
+		"EndpointChangedWithRecreatePolicy": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("sqs", "old-queue"),
+				},
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+				),
+			},
+			want: want{
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+					withOwner(""),
+					withStatus(v1alpha1.ConfirmationSuccessful),
+					withConditions(corev1alpha1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"EndpointChangedWithoutRecreatePolicy": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("sqs", "old-queue"),
+				},
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+				),
+			},
+			want: want{
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+					withOwner(""),
+					withStatus(v1alpha1.ConfirmationSuccessful),
+					withConditions(corev1alpha1.Available()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"PendingConfirmation": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getPendingSubscriptionAttributes("email", "old@example.org"),
+				},
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("email", "new@example.org"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+				),
+			},
+			want: want{
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("email", "new@example.org"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+					withOwner(""),
+					withStatus(v1alpha1.ConfirmationPending),
+					withConditions(corev1alpha1.Creating()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
 		"InValidInput": {
 			args: args{
 				cr: unexpecedItem,
@@ -85,7 +229,239 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.sub}
+			e := &external{client: tc.sub, kube: &test.MockClient{MockList: test.NewMockListFn(nil)}}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+
+		})
+	}
+}
+
+func withDeletionTimestamp(ts *metav1.Time) subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		t.SetDeletionTimestamp(ts)
+	}
+}
+
+func getMissingSubscriptionAttributes(_ *awssns.GetSubscriptionAttributesInput) awssns.GetSubscriptionAttributesRequest {
+	return awssns.GetSubscriptionAttributesRequest{
+		Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Error: errNotFound},
+	}
+}
+
+func getTopicAttributes(err error) func(*awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
+	return func(_ *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
+		return awssns.GetTopicAttributesRequest{
+			Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Data: &awssns.GetTopicAttributesOutput{}, Error: err},
+		}
+	}
+}
+
+func TestObserveOrphaned(t *testing.T) {
+	deleted := metav1.Now()
+
+	type want struct {
+		cr     resource.Managed
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"TopicDeleted": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getMissingSubscriptionAttributes,
+					MockGetTopicAttributesRequest:        getTopicAttributes(errNotFound),
+				},
+				cr: subscription(withSubARN(&subName)),
+			},
+			want: want{
+				cr:     subscription(withSubARN(&subName), withConditions(corev1alpha1.Unavailable(), orphaned(true))),
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"TopicExists": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getMissingSubscriptionAttributes,
+					MockGetTopicAttributesRequest:        getTopicAttributes(nil),
+				},
+				cr: subscription(withSubARN(&subName)),
+			},
+			want: want{
+				cr:     subscription(withSubARN(&subName)),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"TopicRecreated": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getMissingSubscriptionAttributes,
+					MockGetTopicAttributesRequest:        getTopicAttributes(nil),
+				},
+				cr: subscription(withSubARN(&subName), withConditions(corev1alpha1.Unavailable(), orphaned(true))),
+			},
+			want: want{
+				cr:     subscription(withSubARN(&subName), withConditions(corev1alpha1.Unavailable(), orphaned(false))),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"OrphanedSubscriptionDeleted": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getMissingSubscriptionAttributes,
+				},
+				cr: subscription(withSubARN(&subName), withDeletionTimestamp(&deleted), withConditions(corev1alpha1.Unavailable(), orphaned(true))),
+			},
+			want: want{
+				cr:     subscription(withSubARN(&subName), withDeletionTimestamp(&deleted), withConditions(corev1alpha1.Unavailable(), orphaned(true))),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetTopicAttributesFailed": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getMissingSubscriptionAttributes,
+					MockGetTopicAttributesRequest:        getTopicAttributes(errBoom),
+				},
+				cr: subscription(withSubARN(&subName)),
+			},
+			want: want{
+				cr:  subscription(withSubARN(&subName)),
+				err: errors.Wrap(errBoom, errGetTopicAttr),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.sub, kube: &test.MockClient{MockList: test.NewMockListFn(nil)}}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// listSubscriptions returns a mock that lists a subscription with the
+// supplied ARN, protocol and endpoint.
+func listSubscriptions(arn, protocol, endpoint string) func(*awssns.ListSubscriptionsByTopicInput) awssns.ListSubscriptionsByTopicRequest {
+	return func(_ *awssns.ListSubscriptionsByTopicInput) awssns.ListSubscriptionsByTopicRequest {
+		return awssns.ListSubscriptionsByTopicRequest{
+			Request: &aws.Request{
+				HTTPRequest: &http.Request{},
+				Retryer:     aws.NoOpRetryer{},
+				Data: &awssns.ListSubscriptionsByTopicOutput{
+					Subscriptions: []awssns.Subscription{{
+						SubscriptionArn: &arn,
+						Protocol:        &protocol,
+						Endpoint:        &endpoint,
+					}},
+				},
+			},
+		}
+	}
+}
+
+func TestObserveUnconfirmed(t *testing.T) {
+	type want struct {
+		cr     resource.Managed
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"ArnReturned": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{},
+				cr:  subscription(withEndpoint("email", "cool@example.org")),
+			},
+			want: want{
+				cr:     subscription(withEndpoint("email", "cool@example.org")),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotSubscribed": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockListSubscriptionsByTopicRequest: listSubscriptions(makeARN(subName), "email", "other@example.org"),
+				},
+				cr: subscription(withReturnSubscriptionArn(false), withEndpoint("email", "cool@example.org")),
+			},
+			want: want{
+				cr:     subscription(withReturnSubscriptionArn(false), withEndpoint("email", "cool@example.org")),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"PendingConfirmation": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockListSubscriptionsByTopicRequest: listSubscriptions("PendingConfirmation", "email", "cool@example.org"),
+				},
+				cr: subscription(withReturnSubscriptionArn(false), withEndpoint("email", "cool@example.org")),
+			},
+			want: want{
+				cr: subscription(withReturnSubscriptionArn(false), withEndpoint("email", "cool@example.org"),
+					withStatus(v1alpha1.ConfirmationPending), withConditions(corev1alpha1.Creating())),
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Confirmed": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockListSubscriptionsByTopicRequest:  listSubscriptions(makeARN(subName), "email", "cool@example.org"),
+					MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("email", "cool@example.org"),
+				},
+				cr: subscription(withReturnSubscriptionArn(false), withEndpoint("email", "cool@example.org")),
+			},
+			want: want{
+				cr: subscription(withReturnSubscriptionArn(false), withEndpoint("email", "cool@example.org"), withSubARN(&subName),
+					withStatus(v1alpha1.ConfirmationSuccessful), withOwner(""), withConditions(corev1alpha1.Available())),
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"ListFailed": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockListSubscriptionsByTopicRequest: func(_ *awssns.ListSubscriptionsByTopicInput) awssns.ListSubscriptionsByTopicRequest {
+						return awssns.ListSubscriptionsByTopicRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom}}
+					},
+				},
+				cr: subscription(withReturnSubscriptionArn(false)),
+			},
+			want: want{
+				cr:  subscription(withReturnSubscriptionArn(false)),
+				err: errors.Wrap(errBoom, errListSubscriptions),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.sub, kube: &test.MockClient{MockList: test.NewMockListFn(nil)}}
 			o, err := e.Observe(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -97,7 +473,196 @@ func TestObserve(t *testing.T) {
 			if diff := cmp.Diff(tc.want.result, o); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
+		})
+	}
+}
+
+func withProbe() subModifier {
+	return func(t *v1alpha1.SNSSubscription) {
+		meta.AddAnnotations(t, map[string]string{AnnotationKeyProbeEndpoint: "true"})
+	}
+}
+
+// getPendingSubscriptionAttributes returns a mock that observes a subscription
+// with the supplied protocol and endpoint that is pending confirmation.
+func getPendingSubscriptionAttributes(protocol, endpoint string) func(*awssns.GetSubscriptionAttributesInput) awssns.GetSubscriptionAttributesRequest {
+	return func(_ *awssns.GetSubscriptionAttributesInput) awssns.GetSubscriptionAttributesRequest {
+		return awssns.GetSubscriptionAttributesRequest{
+			Request: &aws.Request{
+				HTTPRequest: &http.Request{},
+				Retryer:     aws.NoOpRetryer{},
+				Data: &awssns.GetSubscriptionAttributesOutput{
+					Attributes: map[string]string{
+						sns.SubscriptionProtocol:            protocol,
+						sns.SubscriptionEndpoint:            endpoint,
+						sns.SubscriptionPendingConfirmation: "true",
+					},
+				},
+			},
+		}
+	}
+}
+
+func TestObserveEndpointReachability(t *testing.T) {
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer endpoint.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	type want struct {
+		status corev1.ConditionStatus
+		reason corev1alpha1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		sub sns.SubscriptionClient
+		cr  *v1alpha1.SNSSubscription
+		want
+	}{
+		"Reachable": {
+			sub:  &fake.MockSubscriptionClient{MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("http", endpoint.URL)},
+			cr:   subscription(withSubARN(&subName), withEndpoint("http", endpoint.URL), withProbe()),
+			want: want{status: corev1.ConditionTrue, reason: reasonEndpointReachable},
+		},
+		"Non2xxStatus": {
+			sub:  &fake.MockSubscriptionClient{MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("http", failing.URL)},
+			cr:   subscription(withSubARN(&subName), withEndpoint("http", failing.URL), withProbe()),
+			want: want{status: corev1.ConditionFalse, reason: reasonEndpointUnreachable},
+		},
+		"Unreachable": {
+			sub:  &fake.MockSubscriptionClient{MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("http", unreachable.URL)},
+			cr:   subscription(withSubARN(&subName), withEndpoint("http", unreachable.URL), withProbe()),
+			want: want{status: corev1.ConditionFalse, reason: reasonEndpointUnreachable},
+		},
+		"Unconfirmed": {
+			sub:  &fake.MockSubscriptionClient{MockGetSubscriptionAttributesRequest: getPendingSubscriptionAttributes("http", unreachable.URL)},
+			cr:   subscription(withSubARN(&subName), withEndpoint("http", unreachable.URL), withProbe()),
+			want: want{status: corev1.ConditionUnknown},
+		},
+		"ProbeNotEnabled": {
+			sub:  &fake.MockSubscriptionClient{MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("http", unreachable.URL)},
+			cr:   subscription(withSubARN(&subName), withEndpoint("http", unreachable.URL)),
+			want: want{status: corev1.ConditionUnknown},
+		},
+		"NotHTTP": {
+			sub:  &fake.MockSubscriptionClient{MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("sqs", "some-queue")},
+			cr:   subscription(withSubARN(&subName), withEndpoint("sqs", "some-queue"), withProbe()),
+			want: want{status: corev1.ConditionUnknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.sub, kube: &test.MockClient{MockList: test.NewMockListFn(nil)}, http: &http.Client{}}
+			if _, err := e.Observe(context.Background(), tc.cr); err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			c := tc.cr.GetCondition(TypeEndpointReachable)
+			if diff := cmp.Diff(tc.want.status, c.Status); diff != "" {
+				t.Errorf("status: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, c.Reason); diff != "" {
+				t.Errorf("reason: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveEndpointKey(t *testing.T) {
+	queueARN := "arn:aws:sqs:ap-south-1:862356124505:some-queue"
+	keyID := "1234abcd-12ab-34cd-56ef-1234567890ab"
+	granted := `{"Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":["kms:GenerateDataKey*","kms:Decrypt"],"Resource":"*"}]}`
+	notGranted := `{"Statement":{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::862356124505:root"},"Action":"kms:*","Resource":"*"}}`
+
+	queues := func(keyID *string) *test.MockClient {
+		return &test.MockClient{MockList: func(_ context.Context, obj runtime.Object, _ ...client.ListOption) error {
+			q := sqsv1beta1.Queue{}
+			q.Status.AtProvider.ARN = queueARN
+			q.Spec.ForProvider.KMSMasterKeyID = keyID
+			obj.(*sqsv1beta1.QueueList).Items = []sqsv1beta1.Queue{q}
+			return nil
+		}}
+	}
+	keys := func(manager kms.KeyManagerType, policy string) *fake.MockKeyClient {
+		return &fake.MockKeyClient{
+			MockDescribeKeyRequest: func(_ *kms.DescribeKeyInput) kms.DescribeKeyRequest {
+				return kms.DescribeKeyRequest{Request: &aws.Request{
+					HTTPRequest: &http.Request{},
+					Retryer:     aws.NoOpRetryer{},
+					Data:        &kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{KeyId: aws.String(keyID), KeyManager: manager}},
+				}}
+			},
+			MockGetKeyPolicyRequest: func(in *kms.GetKeyPolicyInput) kms.GetKeyPolicyRequest {
+				if aws.StringValue(in.KeyId) != keyID {
+					t.Errorf("GetKeyPolicyRequest(...): unexpected key %s", aws.StringValue(in.KeyId))
+				}
+				return kms.GetKeyPolicyRequest{Request: &aws.Request{
+					HTTPRequest: &http.Request{},
+					Retryer:     aws.NoOpRetryer{},
+					Data:        &kms.GetKeyPolicyOutput{Policy: aws.String(policy)},
+				}}
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		kube *test.MockClient
+		keys *fake.MockKeyClient
+		want corev1alpha1.Condition
+	}{
+		"GrantPresent": {
+			kube: queues(aws.String("alias/queue")),
+			keys: keys(kms.KeyManagerTypeCustomer, granted),
+			want: endpointKeyGranted(reasonEndpointKeyGranted, ""),
+		},
+		"GrantMissing": {
+			kube: queues(aws.String("alias/queue")),
+			keys: keys(kms.KeyManagerTypeCustomer, notGranted),
+			want: endpointKeyGranted(reasonEndpointKeyNotGranted, fmt.Sprintf(msgKeyNotGranted, "alias/queue")),
+		},
+		"AWSManagedKey": {
+			kube: queues(aws.String("alias/aws/sqs")),
+			keys: keys(kms.KeyManagerTypeAws, ""),
+			want: endpointKeyGranted(reasonEndpointKeyNotGranted, fmt.Sprintf(msgKeyAWSManaged, "alias/aws/sqs")),
+		},
+		"QueueNotEncrypted": {
+			kube: queues(nil),
+		},
+		"QueueNotManaged": {
+			kube: &test.MockClient{MockList: test.NewMockListFn(nil)},
+		},
+		"ListFailed": {
+			kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want: endpointKeyGranted(reasonEndpointKeyUnknown, errors.Wrap(errBoom, errListQueues).Error()),
+		},
+	}
 
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := subscription(withSubARN(&subName), withEndpoint("sqs", queueARN))
+			e := &external{
+				client: &fake.MockSubscriptionClient{MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("sqs", queueARN)},
+				keys:   tc.keys,
+				kube:   tc.kube,
+			}
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			want := tc.want
+			if want.Type == "" {
+				want = corev1alpha1.Condition{Type: TypeEndpointKeyGranted, Status: corev1.ConditionUnknown}
+			}
+			if diff := cmp.Diff(want, cr.GetCondition(TypeEndpointKeyGranted), test.EquateConditions()); diff != "" {
+				t.Errorf("condition: -want, +got:\n%s", diff)
+			}
 		})
 	}
 }
@@ -144,6 +709,54 @@ func TestCreate(t *testing.T) {
 				err: errors.New(errUnexpectedObject),
 			},
 		},
+		"PendingConfirmation": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockSubscribeRequest: func(input *awssns.SubscribeInput) awssns.SubscribeRequest {
+						if aws.BoolValue(input.ReturnSubscriptionArn) {
+							return awssns.SubscribeRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errBoom}}
+						}
+						return awssns.SubscribeRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Data:        &awssns.SubscribeOutput{SubscriptionArn: aws.String("pending confirmation")},
+								Retryer:     aws.NoOpRetryer{},
+							},
+						}
+					},
+				},
+				cr: subscription(withReturnSubscriptionArn(false)),
+			},
+			want: want{
+				cr: subscription(withReturnSubscriptionArn(false),
+					withStatus(v1alpha1.ConfirmationPending),
+					withConditions(corev1alpha1.Creating())),
+				result: managed.ExternalCreation{},
+			},
+		},
+		"PendingConfirmationRecreated": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockSubscribeRequest: func(input *awssns.SubscribeInput) awssns.SubscribeRequest {
+						return awssns.SubscribeRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Data:        &awssns.SubscribeOutput{SubscriptionArn: aws.String("pending confirmation")},
+								Retryer:     aws.NoOpRetryer{},
+							},
+						}
+					},
+				},
+				cr: subscription(withReturnSubscriptionArn(false), withSubARN(&subName)),
+			},
+			want: want{
+				cr: subscription(withReturnSubscriptionArn(false),
+					withExternalName(""),
+					withStatus(v1alpha1.ConfirmationPending),
+					withConditions(corev1alpha1.Creating())),
+				result: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
 		"ClientSubscribeError": {
 			args: args{
 				sub: &fake.MockSubscriptionClient{
@@ -252,6 +865,81 @@ func TestUpdate(t *testing.T) {
 				),
 			},
 		},
+		"RecreateOnChangedEndpoint": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("sqs", "old-queue"),
+					MockUnsubscribeRequest: func(input *awssns.UnsubscribeInput) awssns.UnsubscribeRequest {
+						return awssns.UnsubscribeRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Data:        &awssns.UnsubscribeOutput{},
+								Retryer:     aws.NoOpRetryer{},
+							},
+						}
+					},
+				},
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+				),
+			},
+			want: want{
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+				),
+			},
+		},
+		"RecreateOnChangedEndpointUnsubscribeError": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("sqs", "old-queue"),
+					MockUnsubscribeRequest: func(input *awssns.UnsubscribeInput) awssns.UnsubscribeRequest {
+						return awssns.UnsubscribeRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Error:       errBoom,
+								Retryer:     aws.NoOpRetryer{},
+							},
+						}
+					},
+				},
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+				),
+			},
+			want: want{
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+					withEndpointUpdatePolicy(v1alpha1.EndpointUpdatePolicyRecreate),
+				),
+				err: errors.Wrap(errBoom, errRecreate),
+			},
+		},
+		"IgnoreChangedEndpointWithoutRecreatePolicy": {
+			args: args{
+				// Unsubscribing would panic, as no mock is supplied.
+				sub: &fake.MockSubscriptionClient{
+					MockGetSubscriptionAttributesRequest: getSubscriptionAttributes("sqs", "old-queue"),
+				},
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+				),
+			},
+			want: want{
+				cr: subscription(
+					withSubARN(&subName),
+					withEndpoint("sqs", "new-queue"),
+				),
+			},
+		},
 		"InValidInput": {
 			args: args{
 				cr: unexpecedItem,
@@ -342,6 +1030,93 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestResolveReferences(t *testing.T) {
+	topicFilterPolicy := `{"event":["created"]}`
+	subFilterPolicy := `{"event":["deleted"]}`
+
+	withTopicRef := func(t *v1alpha1.SNSSubscription) {
+		t.Spec.ForProvider.TopicARNRef = &corev1alpha1.Reference{Name: "cool-topic"}
+	}
+	withFilterPolicy := func(p *string) subModifier {
+		return func(t *v1alpha1.SNSSubscription) { t.Spec.ForProvider.FilterPolicy = p }
+	}
+	getTopic := func(d *v1alpha1.SNSSubscriptionDefaults) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+			obj.(*v1alpha1.SNSTopic).Spec.ForProvider.SubscriptionDefaults = d
+			return nil
+		}
+	}
+
+	type want struct {
+		cr      *v1alpha1.SNSSubscription
+		updated bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		resolveErr error
+		get        test.MockGetFn
+		cr         *v1alpha1.SNSSubscription
+		want       want
+	}{
+		"ResolveError": {
+			resolveErr: errBoom,
+			cr:         subscription(withTopicRef),
+			want:       want{cr: subscription(withTopicRef), err: errBoom},
+		},
+		"NoTopicReference": {
+			cr:   subscription(),
+			want: want{cr: subscription()},
+		},
+		"TopicNotFound": {
+			get:  test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cool-topic")),
+			cr:   subscription(withTopicRef),
+			want: want{cr: subscription(withTopicRef)},
+		},
+		"GetTopicError": {
+			get:  test.NewMockGetFn(errBoom),
+			cr:   subscription(withTopicRef),
+			want: want{cr: subscription(withTopicRef), err: errors.Wrap(errBoom, errGetTopic)},
+		},
+		"Inherited": {
+			get:  getTopic(&v1alpha1.SNSSubscriptionDefaults{FilterPolicy: &topicFilterPolicy}),
+			cr:   subscription(withTopicRef),
+			want: want{cr: subscription(withTopicRef, withFilterPolicy(&topicFilterPolicy)), updated: true},
+		},
+		"Overridden": {
+			get:  getTopic(&v1alpha1.SNSSubscriptionDefaults{FilterPolicy: &topicFilterPolicy}),
+			cr:   subscription(withTopicRef, withFilterPolicy(&subFilterPolicy)),
+			want: want{cr: subscription(withTopicRef, withFilterPolicy(&subFilterPolicy))},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			r := &defaultsResolver{
+				ReferenceResolver: managed.ReferenceResolverFn(func(_ context.Context, _ resource.Managed) error { return tc.resolveErr }),
+				kube: &test.MockClient{
+					MockGet: tc.get,
+					MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+						updated = true
+						return nil
+					},
+				},
+			}
+			err := r.ResolveReferences(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.cr); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("updated: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	type want struct {
 		cr  resource.Managed
@@ -411,6 +1186,31 @@ func TestDelete(t *testing.T) {
 				err: errors.Wrap(errBoom, errDelete),
 			},
 		},
+		"Orphaned": {
+			args: args{
+				sub: &fake.MockSubscriptionClient{
+					MockUnsubscribeRequest: func(input *awssns.UnsubscribeInput) awssns.UnsubscribeRequest {
+						return awssns.UnsubscribeRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Error:       errNotFound,
+								Retryer:     aws.NoOpRetryer{},
+							},
+						}
+					},
+				},
+				cr: subscription(
+					withSubARN(&subName),
+					withConditions(corev1alpha1.Unavailable(), orphaned(true)),
+				),
+			},
+			want: want{
+				cr: subscription(
+					withSubARN(&subName),
+					withConditions(corev1alpha1.Deleting(), orphaned(true)),
+				),
+			},
+		},
 		"ResourceDoesNotExist": {
 			args: args{
 				sub: &fake.MockSubscriptionClient{
@@ -19,6 +19,7 @@ package snstopic
 import (
 	"context"
 	"reflect"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
@@ -37,31 +38,37 @@ import (
 	awscommon "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/sns"
 	snsclient "github.com/crossplane/provider-aws/pkg/clients/sns"
+	"github.com/crossplane/provider-aws/pkg/utils/reconciler"
 )
 
 const (
 	errUnexpectedObject = "the managed resource is not a SNSTopic resource"
 	errGetTopicAttr     = "failed to get SNS Topic Attribute"
+	errGetDataProtPol   = "failed to get the data protection policy of the SNS Topic"
+	errPutDataProtPol   = "failed to put the data protection policy of the SNS Topic"
 	errCreate           = "failed to create the SNS Topic"
 	errDelete           = "failed to delete the SNS Topic"
 	errUpdate           = "failed to update the SNS Topic"
+	errInvalidParams    = "invalid SNS Topic parameters"
 )
 
 // SetupSNSTopic adds a controller that reconciles SNSTopic.
-func SetupSNSTopic(mgr ctrl.Manager, l logging.Logger) error {
+func SetupSNSTopic(mgr ctrl.Manager, l logging.Logger, o reconciler.Options) error {
 	name := managed.ControllerName(v1alpha1.SNSTopicGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.SNSTopic{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(reconciler.Wrap(o, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.SNSTopicGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: sns.NewTopicClient}),
+			reconciler.WithConnecter(reconciler.NewConnecter(&connector{kube: mgr.GetClient(), newClientFn: sns.NewTopicClient}, record, l.WithValues("controller", name), v1alpha1.SNSTopicKind, reconciler.WithConnecterHistory(reconciler.DefaultHistorySize))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithInitializers(managed.InitializerFn(validate), managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
+			reconciler.WithOptions(o),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(record))))
 }
 
 type connector struct {
@@ -105,6 +112,20 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 			errors.Wrap(resource.Ignore(sns.IsTopicNotFound, err), errGetTopicAttr)
 	}
 
+	// The data protection policy of a topic is only observed while it is
+	// desired, or until a policy that is no longer desired was cleared.
+	dppUpToDate, dppApplied := true, false
+	if cr.Spec.ForProvider.DataProtectionPolicy != nil || cr.Status.AtProvider.DataProtectionPolicyApplied {
+		dpp, err := e.client.GetDataProtectionPolicyRequest(&snsclient.GetDataProtectionPolicyInput{
+			ResourceArn: aws.String(meta.GetExternalName(cr)),
+		}).Send(ctx)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetDataProtPol)
+		}
+		dppUpToDate = snsclient.IsDataProtectionPolicyUpToDate(cr.Spec.ForProvider, dpp.DataProtectionPolicy)
+		dppApplied = aws.StringValue(dpp.DataProtectionPolicy) != ""
+	}
+
 	current := cr.Spec.ForProvider.DeepCopy()
 	snsclient.LateInitializeTopicAttr(&cr.Spec.ForProvider, res.Attributes)
 
@@ -112,10 +133,11 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 
 	// GenerateObservation for SNS Topic
 	cr.Status.AtProvider = snsclient.GenerateTopicObservation(res.Attributes)
+	cr.Status.AtProvider.DataProtectionPolicyApplied = dppApplied
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        snsclient.IsSNSTopicUpToDate(cr.Spec.ForProvider, res.Attributes),
+		ResourceUpToDate:        snsclient.IsSNSTopicUpToDate(cr.Spec.ForProvider, res.Attributes) && dppUpToDate,
 		ResourceLateInitialized: !reflect.DeepEqual(current, &cr.Spec.ForProvider),
 	}, nil
 }
@@ -127,7 +149,11 @@ func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.Ex
 		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
 	}
 
-	resp, err := e.client.CreateTopicRequest(snsclient.GenerateCreateTopicInput(&cr.Spec.ForProvider)).Send(ctx)
+	p, err := e.resolveParameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	resp, err := e.client.CreateTopicRequest(snsclient.GenerateCreateTopicInput(p)).Send(ctx)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
 	}
@@ -136,6 +162,34 @@ func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.Ex
 	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
 }
 
+// resolveParameters returns the parameters of the supplied topic with the
+// default tags of its ProviderConfig, which are only applied when the topic is
+// created because its tags cannot be changed afterwards. They are merged here
+// rather than written to the spec, so that they remain distinguishable from
+// the tags of the topic.
+func (e *external) resolveParameters(ctx context.Context, cr *v1alpha1.SNSTopic) (*v1alpha1.SNSTopicParameters, error) {
+	defaults, err := awscommon.GetDefaultTags(ctx, e.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	p := cr.Spec.ForProvider.DeepCopy()
+	tags := make(map[string]bool, len(p.Tags))
+	for _, tag := range p.Tags {
+		tags[tag.Key] = true
+	}
+	keys := make([]string, 0, len(defaults))
+	for k := range defaults {
+		if !tags[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p.Tags = append(p.Tags, v1alpha1.Tag{Key: k, Value: aws.String(defaults[k])})
+	}
+	return p, nil
+}
+
 func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mgd.(*v1alpha1.SNSTopic)
 	if !ok {
@@ -151,7 +205,10 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 	}
 
 	// Update Topic Attributes
-	attrs := snsclient.GetChangedAttributes(cr.Spec.ForProvider, resp.Attributes)
+	attrs, err := snsclient.GetChangedAttributes(cr.Spec.ForProvider, resp.Attributes)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidParams)
+	}
 	for k, v := range attrs {
 		_, err = e.client.SetTopicAttributesRequest(&awssns.SetTopicAttributesInput{
 			AttributeName:  aws.String(k),
@@ -160,7 +217,29 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 		}).Send(ctx)
 
 	}
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	// The data protection policy of a topic is set using its own API, so it
+	// is put after the topic is created rather than by Create.
+	if cr.Spec.ForProvider.DataProtectionPolicy == nil && !cr.Status.AtProvider.DataProtectionPolicyApplied {
+		return managed.ExternalUpdate{}, nil
+	}
+	dpp, err := e.client.GetDataProtectionPolicyRequest(&snsclient.GetDataProtectionPolicyInput{
+		ResourceArn: aws.String(meta.GetExternalName(cr)),
+	}).Send(ctx)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetDataProtPol)
+	}
+	if snsclient.IsDataProtectionPolicyUpToDate(cr.Spec.ForProvider, dpp.DataProtectionPolicy) {
+		return managed.ExternalUpdate{}, nil
+	}
+	_, err = e.client.PutDataProtectionPolicyRequest(&snsclient.PutDataProtectionPolicyInput{
+		ResourceArn:          aws.String(meta.GetExternalName(cr)),
+		DataProtectionPolicy: aws.String(aws.StringValue(cr.Spec.ForProvider.DataProtectionPolicy)),
+	}).Send(ctx)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errPutDataProtPol)
 }
 
 func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
@@ -177,3 +256,17 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 
 	return errors.Wrap(resource.Ignore(sns.IsTopicNotFound, err), errDelete)
 }
+
+// validate rejects topics whose parameters SNS would reject, before anything
+// is written to the API server or AWS. Topics that are being deleted are not
+// validated so that their deletion is never blocked.
+func validate(_ context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.SNSTopic)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+	if meta.WasDeleted(cr) {
+		return nil
+	}
+	return errors.Wrap(snsclient.ValidateTopic(&cr.Spec.ForProvider), errInvalidParams)
+}
@@ -25,7 +25,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -35,6 +38,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
 	"github.com/crossplane/provider-aws/pkg/clients/sns"
 	"github.com/crossplane/provider-aws/pkg/clients/sns/fake"
 )
@@ -77,10 +81,26 @@ func withDeliveryPolicy(s *string) topicModifier {
 	return func(t *v1alpha1.SNSTopic) { t.Spec.ForProvider.DeliveryPolicy = s }
 }
 
+func withArchivePolicy(s *string) topicModifier {
+	return func(t *v1alpha1.SNSTopic) { t.Spec.ForProvider.ArchivePolicy = s }
+}
+
+func withHTTPSuccessFeedbackSampleRate(r int64) topicModifier {
+	return func(t *v1alpha1.SNSTopic) { t.Spec.ForProvider.HTTPSuccessFeedbackSampleRate = &r }
+}
+
 func withObservationOwner(s *string) topicModifier {
 	return func(t *v1alpha1.SNSTopic) { t.Status.AtProvider.Owner = s }
 }
 
+func withObservationSubscriptions(confirmed, pending, deleted int64) topicModifier {
+	return func(t *v1alpha1.SNSTopic) {
+		t.Status.AtProvider.ConfirmedSubscriptions = &confirmed
+		t.Status.AtProvider.PendingSubscriptions = &pending
+		t.Status.AtProvider.DeletedSubscriptions = &deleted
+	}
+}
+
 func withTopicARN(s *string) topicModifier {
 	return func(t *v1alpha1.SNSTopic) {
 		t.Spec.ForProvider.Name = *s
@@ -100,6 +120,39 @@ func withConditions(c ...corev1alpha1.Condition) topicModifier {
 	return func(r *v1alpha1.SNSTopic) { r.Status.ConditionedStatus.Conditions = c }
 }
 
+func withDataProtectionPolicy(s *string) topicModifier {
+	return func(t *v1alpha1.SNSTopic) { t.Spec.ForProvider.DataProtectionPolicy = s }
+}
+
+func getDataProtectionPolicy(p *string) func(*sns.GetDataProtectionPolicyInput) sns.GetDataProtectionPolicyRequest {
+	return func(_ *sns.GetDataProtectionPolicyInput) sns.GetDataProtectionPolicyRequest {
+		return sns.GetDataProtectionPolicyRequest{
+			Request: &aws.Request{
+				HTTPRequest: &http.Request{},
+				Retryer:     aws.NoOpRetryer{},
+				Data:        &sns.GetDataProtectionPolicyOutput{DataProtectionPolicy: p},
+			},
+		}
+	}
+}
+
+func withTags(tags ...v1alpha1.Tag) topicModifier {
+	return func(t *v1alpha1.SNSTopic) { t.Spec.ForProvider.Tags = tags }
+}
+
+func withProviderConfig(name string) topicModifier {
+	return func(t *v1alpha1.SNSTopic) {
+		t.SetProviderConfigReference(&corev1alpha1.Reference{Name: name})
+	}
+}
+
+func getProviderConfig(defaultTags map[string]string) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*apisv1beta1.ProviderConfig).Spec.DefaultTags = defaultTags
+		return nil
+	}
+}
+
 func topic(m ...topicModifier) *v1alpha1.SNSTopic {
 	cr := &v1alpha1.SNSTopic{}
 
@@ -206,6 +259,7 @@ func TestObserve(t *testing.T) {
 		"ValidInputResourceNotUpToDate": {
 			args: args{
 				topic: &fake.MockTopicClient{
+					MockGetDataProtectionPolicyRequest: getDataProtectionPolicy(nil),
 					MockGetTopicAttributesRequest: func(input *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
 						return awssns.GetTopicAttributesRequest{
 							Request: &aws.Request{
@@ -245,6 +299,57 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"SubscriptionCounts": {
+			args: args{
+				topic: &fake.MockTopicClient{
+					MockGetDataProtectionPolicyRequest: getDataProtectionPolicy(nil),
+					MockGetTopicAttributesRequest: func(input *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
+						return awssns.GetTopicAttributesRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Retryer:     aws.NoOpRetryer{},
+								Data: &awssns.GetTopicAttributesOutput{
+									Attributes: map[string]string{
+										"TopicArn":               makeARN(topicName),
+										"SubscriptionsConfirmed": "3",
+										"SubscriptionsPending":   "1",
+										"SubscriptionsDeleted":   "0",
+										"DisplayName":            topicDisplayName,
+										"Policy":                 "",
+										"DeliveryPolicy":         "",
+										"KmsMasterKeyId":         "",
+									},
+								},
+							},
+						}
+					},
+				},
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				cr: topic(
+					withDisplayName(&topicDisplayName),
+					withTopicARN(&topicName),
+				),
+			},
+			want: want{
+				cr: topic(
+					withDisplayName(&topicDisplayName),
+					withTopicARN(&topicName),
+					withPolicy(&empty),
+					withDeliveryPolicy(&empty),
+					withKmsMasterKeyID(&empty),
+					withConditions(corev1alpha1.Available()),
+					withObservationOwner(&empty),
+					withObservationSubscriptions(3, 1, 0),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -302,6 +407,60 @@ func TestCreate(t *testing.T) {
 				result: managed.ExternalCreation{ExternalNameAssigned: true},
 			},
 		},
+		"DefaultTags": {
+			args: args{
+				kube: &test.MockClient{MockGet: getProviderConfig(map[string]string{"team": "other", "env": "prod", "cost": "1"})},
+				topic: &fake.MockTopicClient{
+					MockCreateTopicRequest: func(input *awssns.CreateTopicInput) awssns.CreateTopicRequest {
+						want := []awssns.Tag{
+							{Key: aws.String("team"), Value: aws.String("cool")},
+							{Key: aws.String("cost"), Value: aws.String("1")},
+							{Key: aws.String("env"), Value: aws.String("prod")},
+						}
+						if diff := cmp.Diff(want, input.Tags); diff != "" {
+							return awssns.CreateTopicRequest{
+								Request: &aws.Request{HTTPRequest: &http.Request{}, Retryer: aws.NoOpRetryer{}, Error: errors.New(diff)},
+							}
+						}
+						return awssns.CreateTopicRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Retryer:     aws.NoOpRetryer{},
+								Data:        &awssns.CreateTopicOutput{TopicArn: aws.String(topicName)},
+							},
+						}
+					},
+				},
+				cr: topic(
+					withTopicName(&topicName),
+					withProviderConfig("default"),
+					withTags(v1alpha1.Tag{Key: "team", Value: aws.String("cool")}),
+				),
+			},
+			want: want{
+				// The default tags are not written to the spec.
+				cr: topic(
+					withTopicName(&topicName),
+					withProviderConfig("default"),
+					withTags(v1alpha1.Tag{Key: "team", Value: aws.String("cool")})),
+				result: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+		"GetProviderConfigFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				cr: topic(
+					withTopicName(&topicName),
+					withProviderConfig("default"),
+				),
+			},
+			want: want{
+				cr: topic(
+					withTopicName(&topicName),
+					withProviderConfig("default")),
+				err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig"),
+			},
+		},
 		"InValidInput": {
 			args: args{
 				cr: unexpecedItem,
@@ -370,6 +529,7 @@ func TestUpdate(t *testing.T) {
 		"VaildInput": {
 			args: args{
 				topic: &fake.MockTopicClient{
+					MockGetDataProtectionPolicyRequest: getDataProtectionPolicy(nil),
 					MockGetTopicAttributesRequest: func(input *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
 						return awssns.GetTopicAttributesRequest{
 							Request: &aws.Request{
@@ -389,6 +549,7 @@ func TestUpdate(t *testing.T) {
 		"VaildInputWithChangedAttributes": {
 			args: args{
 				topic: &fake.MockTopicClient{
+					MockGetDataProtectionPolicyRequest: getDataProtectionPolicy(nil),
 					MockGetTopicAttributesRequest: func(input *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
 						return awssns.GetTopicAttributesRequest{
 							Request: &aws.Request{
@@ -449,6 +610,26 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errGetTopicAttr),
 			},
 		},
+		"InvalidAttributes": {
+			args: args{
+				topic: &fake.MockTopicClient{
+					MockGetTopicAttributesRequest: func(input *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
+						return awssns.GetTopicAttributesRequest{
+							Request: &aws.Request{
+								HTTPRequest: &http.Request{},
+								Retryer:     aws.NoOpRetryer{},
+								Data:        &awssns.GetTopicAttributesOutput{},
+							},
+						}
+					},
+				},
+				cr: topic(withTopicName(&topicName), withHTTPSuccessFeedbackSampleRate(150)),
+			},
+			want: want{
+				cr:  topic(withTopicName(&topicName), withHTTPSuccessFeedbackSampleRate(150)),
+				err: errors.Wrap(errors.New("httpSuccessFeedbackSampleRate must be between 0 and 100, not 150"), errInvalidParams),
+			},
+		},
 		"ClientSetTopicAttributeError": {
 			args: args{
 				topic: &fake.MockTopicClient{
@@ -503,6 +684,183 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestObserveDataProtectionPolicy(t *testing.T) {
+	policy := `{"Name":"redact","Version":"2021-06-01","Statement":[]}`
+	reformatted := `{"Version": "2021-06-01", "Statement": [], "Name": "redact"}`
+	changed := `{"Name":"mask","Version":"2021-06-01","Statement":[]}`
+
+	type want struct {
+		upToDate bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		desired  *string
+		observed *string
+		applied  bool
+		err      error
+		want     want
+	}{
+		"UpToDate": {
+			desired:  &policy,
+			observed: &reformatted,
+			want:     want{upToDate: true},
+		},
+		"NotSet": {
+			desired: &policy,
+		},
+		"Changed": {
+			desired:  &policy,
+			observed: &changed,
+		},
+		"Cleared": {
+			observed: &policy,
+			applied:  true,
+		},
+		"NeitherSet": {
+			observed: &empty,
+			applied:  true,
+			want:     want{upToDate: true},
+		},
+		"NeitherDesiredNorApplied": {
+			// The policy is not observed, so the lack of permission to
+			// get it does not matter.
+			err:  errBoom,
+			want: want{upToDate: true},
+		},
+		"GetFailed": {
+			desired: &policy,
+			err:     errBoom,
+			want:    want{err: errors.Wrap(errBoom, errGetDataProtPol)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: &fake.MockTopicClient{
+				MockGetTopicAttributesRequest: func(input *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
+					return awssns.GetTopicAttributesRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Retryer:     aws.NoOpRetryer{},
+							Data: &awssns.GetTopicAttributesOutput{
+								Attributes: map[string]string{"TopicArn": makeARN(topicName)},
+							},
+						},
+					}
+				},
+				MockGetDataProtectionPolicyRequest: func(input *sns.GetDataProtectionPolicyInput) sns.GetDataProtectionPolicyRequest {
+					return sns.GetDataProtectionPolicyRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Retryer:     aws.NoOpRetryer{},
+							Error:       tc.err,
+							Data:        &sns.GetDataProtectionPolicyOutput{DataProtectionPolicy: tc.observed},
+						},
+					}
+				},
+			}}
+			cr := topic(withTopicARN(&topicName), withDataProtectionPolicy(tc.desired))
+			cr.Status.AtProvider.DataProtectionPolicyApplied = tc.applied
+			o, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateDataProtectionPolicy(t *testing.T) {
+	policy := `{"Name":"redact","Version":"2021-06-01","Statement":[]}`
+	changed := `{"Name":"mask","Version":"2021-06-01","Statement":[]}`
+
+	type want struct {
+		input *sns.PutDataProtectionPolicyInput
+		err   error
+	}
+
+	put := func(p string) *sns.PutDataProtectionPolicyInput {
+		return &sns.PutDataProtectionPolicyInput{
+			ResourceArn:          aws.String(topicName),
+			DataProtectionPolicy: aws.String(p),
+		}
+	}
+
+	cases := map[string]struct {
+		desired  *string
+		observed *string
+		applied  bool
+		putErr   error
+		want     want
+	}{
+		"Set": {
+			desired: &policy,
+			want:    want{input: put(policy)},
+		},
+		"Changed": {
+			desired:  &changed,
+			observed: &policy,
+			want:     want{input: put(changed)},
+		},
+		"Cleared": {
+			observed: &policy,
+			applied:  true,
+			want:     want{input: put("")},
+		},
+		"NeitherDesiredNorApplied": {},
+		"Unchanged": {
+			desired:  &policy,
+			observed: &policy,
+		},
+		"PutFailed": {
+			desired: &policy,
+			putErr:  errBoom,
+			want:    want{input: put(policy), err: errors.Wrap(errBoom, errPutDataProtPol)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var input *sns.PutDataProtectionPolicyInput
+			e := &external{client: &fake.MockTopicClient{
+				MockGetTopicAttributesRequest: func(input *awssns.GetTopicAttributesInput) awssns.GetTopicAttributesRequest {
+					return awssns.GetTopicAttributesRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Retryer:     aws.NoOpRetryer{},
+							Data:        &awssns.GetTopicAttributesOutput{},
+						},
+					}
+				},
+				MockGetDataProtectionPolicyRequest: getDataProtectionPolicy(tc.observed),
+				MockPutDataProtectionPolicyRequest: func(in *sns.PutDataProtectionPolicyInput) sns.PutDataProtectionPolicyRequest {
+					input = in
+					return sns.PutDataProtectionPolicyRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Retryer:     aws.NoOpRetryer{},
+							Error:       tc.putErr,
+							Data:        &sns.PutDataProtectionPolicyOutput{},
+						},
+					}
+				},
+			}}
+			cr := topic(withTopicName(&topicName), withDataProtectionPolicy(tc.desired))
+			cr.Status.AtProvider.DataProtectionPolicyApplied = tc.applied
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.input, input, cmpopts.IgnoreUnexported(sns.PutDataProtectionPolicyInput{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	type want struct {
 		cr  resource.Managed
@@ -601,3 +959,34 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	fifoTopicName := "some-topic.fifo"
+	archivePolicy := `{"MessageRetentionPeriod":"30"}`
+	now := metav1.Now()
+
+	cases := map[string]struct {
+		cr   *v1alpha1.SNSTopic
+		want error
+	}{
+		"ArchivingFIFOTopic": {
+			cr: topic(withTopicName(&fifoTopicName), withArchivePolicy(&archivePolicy)),
+		},
+		"ArchivingStandardTopic": {
+			cr:   topic(withTopicName(&topicName), withArchivePolicy(&archivePolicy)),
+			want: errors.Wrap(sns.ValidateTopic(&v1alpha1.SNSTopicParameters{Name: topicName, ArchivePolicy: &archivePolicy}), errInvalidParams),
+		},
+		"ArchivingStandardTopicDeleted": {
+			cr: topic(withTopicName(&topicName), withArchivePolicy(&archivePolicy), func(t *v1alpha1.SNSTopic) { t.SetDeletionTimestamp(&now) }),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validate(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health reports whether a replica of the provider is the elected
+// leader and how many of its controllers are running, and summarizes the
+// states of the managed resources it reconciles.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Paths served by the health endpoint.
+const (
+	PathHealthz = "/healthz"
+	PathStatus  = "/status"
+)
+
+const (
+	errListen        = "cannot listen for health probes"
+	shutdownDeadline = 5 * time.Second
+)
+
+// A Report is the status that is served by the health endpoint.
+type Report struct {
+	// Leader is true if this replica is the elected leader, or if leader
+	// election is disabled.
+	Leader bool `json:"leader"`
+
+	// Controllers is the number of controllers that are running. Controllers
+	// only run on the leader.
+	Controllers int `json:"controllers"`
+}
+
+// A Status tracks the leader election status and the controllers of a
+// controller manager. It is a manager.Runnable that requires leader election,
+// so the manager only starts it once this replica is elected leader.
+type Status struct {
+	mu          sync.RWMutex
+	leader      bool
+	controllers int
+}
+
+// NewStatus returns a new Status.
+func NewStatus() *Status {
+	return &Status{}
+}
+
+// Start marks this replica as the leader until the supplied channel is
+// closed.
+func (s *Status) Start(stop <-chan struct{}) error {
+	s.setLeader(true)
+	<-stop
+	s.setLeader(false)
+	return nil
+}
+
+func (s *Status) setLeader(l bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leader = l
+}
+
+// Report returns the current status.
+func (s *Status) Report() Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r := Report{Leader: s.leader}
+	if s.leader {
+		r.Controllers = s.controllers
+	}
+	return r
+}
+
+// ServeHTTP serves the current status as JSON.
+func (s *Status) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Report())
+}
+
+// Counting returns a manager that counts the controllers that are added to
+// the supplied manager.
+func (s *Status) Counting(mgr ctrl.Manager) ctrl.Manager {
+	return &countingManager{Manager: mgr, status: s}
+}
+
+type countingManager struct {
+	ctrl.Manager
+	status *Status
+}
+
+// Add counts the supplied runnable as a controller if it requires leader
+// election, as all controllers do, and adds it to the wrapped manager.
+func (m *countingManager) Add(r manager.Runnable) error {
+	if err := m.Manager.Add(r); err != nil {
+		return err
+	}
+	if le, ok := r.(manager.LeaderElectionRunnable); ok && !le.NeedLeaderElection() {
+		return nil
+	}
+	m.status.mu.Lock()
+	defer m.status.mu.Unlock()
+	m.status.controllers++
+	return nil
+}
+
+// Handler returns a handler that serves a liveness probe at PathHealthz and
+// the supplied status at PathStatus.
+func Handler(s *Status) http.Handler {
+	h := http.StripPrefix(PathHealthz, &healthz.Handler{Checks: map[string]healthz.Checker{"ping": healthz.Ping}})
+	mux := http.NewServeMux()
+	mux.Handle(PathHealthz, h)
+	mux.Handle(PathHealthz+"/", h)
+	mux.Handle(PathStatus, s)
+	return mux
+}
+
+// A Server serves the health endpoint. Unlike the Status it reports, it runs
+// on every replica.
+type Server struct {
+	addr    string
+	handler http.Handler
+}
+
+// NewServer returns a Server that serves the supplied status on the supplied
+// address.
+func NewServer(addr string, s *Status) *Server {
+	return &Server{addr: addr, handler: Handler(s)}
+}
+
+// NeedLeaderElection returns false, so that the health endpoint is served
+// by replicas that are not the leader too.
+func (*Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the health endpoint until the supplied channel is closed.
+func (srv *Server) Start(stop <-chan struct{}) error {
+	l, err := net.Listen("tcp", srv.addr)
+	if err != nil {
+		return errors.Wrap(err, errListen)
+	}
+	s := &http.Server{Handler: srv.handler}
+	go func() {
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownDeadline)
+		defer cancel()
+		_ = s.Shutdown(ctx)
+	}()
+	if err := s.Serve(l); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
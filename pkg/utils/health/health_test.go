@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+type fakeManager struct {
+	ctrl.Manager
+}
+
+func (fakeManager) Add(manager.Runnable) error { return nil }
+
+type nonLeaderRunnable struct{}
+
+func (nonLeaderRunnable) Start(<-chan struct{}) error { return nil }
+func (nonLeaderRunnable) NeedLeaderElection() bool    { return false }
+
+func get(t *testing.T, h http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func report(t *testing.T, h http.Handler) Report {
+	t.Helper()
+	rec := get(t, h, PathStatus)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: want status %d, got %d", PathStatus, http.StatusOK, rec.Code)
+	}
+	r := Report{}
+	if err := json.NewDecoder(rec.Body).Decode(&r); err != nil {
+		t.Fatalf("GET %s: cannot decode report: %s", PathStatus, err)
+	}
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	s := NewStatus()
+	h := Handler(s)
+
+	mgr := s.Counting(fakeManager{})
+	for _, r := range []manager.Runnable{manager.RunnableFunc(nil), manager.RunnableFunc(nil), nonLeaderRunnable{}} {
+		if err := mgr.Add(r); err != nil {
+			t.Fatalf("Add(...): unexpected error: %s", err)
+		}
+	}
+
+	if rec := get(t, h, PathHealthz); rec.Code != http.StatusOK {
+		t.Errorf("GET %s: want status %d, got %d", PathHealthz, http.StatusOK, rec.Code)
+	}
+
+	if diff := cmp.Diff(Report{}, report(t, h)); diff != "" {
+		t.Errorf("before election: -want, +got:\n%s", diff)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_ = s.Start(stop)
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for !report(t, h).Leader && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if diff := cmp.Diff(Report{Leader: true, Controllers: 2}, report(t, h)); diff != "" {
+		t.Errorf("elected: -want, +got:\n%s", diff)
+	}
+
+	close(stop)
+	<-done
+	if diff := cmp.Diff(Report{}, report(t, h)); diff != "" {
+		t.Errorf("stopped: -want, +got:\n%s", diff)
+	}
+}
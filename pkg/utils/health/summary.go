@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// States that managed resources are summarized by.
+const (
+	StateReady    = "ready"
+	StateCreating = "creating"
+	StateDeleting = "deleting"
+	StateDegraded = "degraded"
+	StateError    = "error"
+	StateUnknown  = "unknown"
+)
+
+const (
+	errNewList  = "cannot create list of managed resources"
+	errListKind = "cannot list managed resources"
+)
+
+// ManagedResources is the number of managed resources by group, kind and
+// state, as of the most recent summary.
+var ManagedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "aws_managed_resources",
+	Help: "Number of managed resources by group, kind and state.",
+}, []string{"group", "kind", "state"})
+
+func init() {
+	metrics.Registry.MustRegister(ManagedResources)
+}
+
+// StateOf returns the state the supplied managed resource is summarized by.
+// A resource whose most recent reconcile failed is in the error state,
+// regardless of whether it is ready.
+func StateOf(mg resource.Managed) string {
+	if mg.GetCondition(runtimev1alpha1.TypeSynced).Reason == runtimev1alpha1.ReasonReconcileError {
+		return StateError
+	}
+	switch mg.GetCondition(runtimev1alpha1.TypeReady).Reason { // nolint:exhaustive
+	case runtimev1alpha1.ReasonAvailable:
+		return StateReady
+	case runtimev1alpha1.ReasonCreating:
+		return StateCreating
+	case runtimev1alpha1.ReasonDeleting:
+		return StateDeleting
+	case runtimev1alpha1.ReasonUnavailable:
+		return StateDegraded
+	}
+	return StateUnknown
+}
+
+// A Summary is the number of managed resources of each kind by state.
+type Summary map[schema.GroupKind]map[string]int
+
+// Add counts the supplied managed resource of the supplied kind.
+func (s Summary) Add(gk schema.GroupKind, mg resource.Managed) {
+	if s[gk] == nil {
+		s[gk] = map[string]int{}
+	}
+	s[gk][StateOf(mg)]++
+}
+
+// ManagedKinds returns the kinds of managed resources known to the supplied
+// scheme that can be listed, sorted by group version and kind.
+func ManagedKinds(s *runtime.Scheme) []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for gvk := range s.AllKnownTypes() {
+		if strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		o, err := s.New(gvk)
+		if err != nil {
+			continue
+		}
+		if _, ok := o.(resource.Managed); !ok {
+			continue
+		}
+		l, err := s.New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err != nil {
+			continue
+		}
+		if _, ok := l.(resource.ManagedList); !ok {
+			continue
+		}
+		kinds = append(kinds, gvk)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].String() < kinds[j].String() })
+	return kinds
+}
+
+// A SummarizerOption configures a Summarizer.
+type SummarizerOption func(*Summarizer)
+
+// WithSummarizerLogger configures the logger used to report summaries that
+// could not be made.
+func WithSummarizerLogger(l logging.Logger) SummarizerOption {
+	return func(s *Summarizer) {
+		s.log = l
+	}
+}
+
+// WithSummarizerKinds configures the kinds of managed resources that are
+// summarized. All managed kinds known to the scheme are summarized by default.
+func WithSummarizerKinds(k ...schema.GroupVersionKind) SummarizerOption {
+	return func(s *Summarizer) {
+		s.kinds = k
+	}
+}
+
+// A Summarizer periodically summarizes the states of all managed resources by
+// kind and exports the summary using the ManagedResources metric. It reads the
+// managed resources from the supplied client, which should be backed by the
+// informers of the managed resource controllers. It is a manager.Runnable
+// that requires leader election, so that only one replica reports.
+type Summarizer struct {
+	client   client.Reader
+	scheme   *runtime.Scheme
+	kinds    []schema.GroupVersionKind
+	interval time.Duration
+	log      logging.Logger
+}
+
+// NewSummarizer returns a Summarizer that summarizes the managed resources
+// read from the supplied client every interval.
+func NewSummarizer(c client.Reader, s *runtime.Scheme, interval time.Duration, o ...SummarizerOption) *Summarizer {
+	sm := &Summarizer{
+		client:   c,
+		scheme:   s,
+		kinds:    ManagedKinds(s),
+		interval: interval,
+		log:      logging.NewNopLogger(),
+	}
+	for _, fn := range o {
+		fn(sm)
+	}
+	return sm
+}
+
+// Summarize returns the current summary of the managed resources. Kinds
+// without any managed resources are included with no counts.
+func (s *Summarizer) Summarize(ctx context.Context) (Summary, error) {
+	sum := Summary{}
+	for _, gvk := range s.kinds {
+		o, err := s.scheme.New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err != nil {
+			return nil, errors.Wrap(err, errNewList)
+		}
+		l, ok := o.(resource.ManagedList)
+		if !ok {
+			return nil, errors.New(errNewList)
+		}
+		if err := s.client.List(ctx, l); err != nil {
+			return nil, errors.Wrapf(err, "%s %s", errListKind, gvk.GroupKind())
+		}
+		gk := gvk.GroupKind()
+		if sum[gk] == nil {
+			sum[gk] = map[string]int{}
+		}
+		for _, mg := range l.GetItems() {
+			sum.Add(gk, mg)
+		}
+	}
+	return sum, nil
+}
+
+// Export replaces the values of the ManagedResources metric with the supplied
+// summary. Every state of every summarized kind is exported, so that states
+// without managed resources are reported as zero.
+func Export(sum Summary) {
+	ManagedResources.Reset()
+	for gk, states := range sum {
+		for _, st := range []string{StateReady, StateCreating, StateDeleting, StateDegraded, StateError, StateUnknown} {
+			ManagedResources.WithLabelValues(gk.Group, gk.Kind, st).Set(float64(states[st]))
+		}
+	}
+}
+
+// Start summarizes the managed resources every interval until the supplied
+// channel is closed.
+func (s *Summarizer) Start(stop <-chan struct{}) error {
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-t.C:
+			sum, err := s.Summarize(context.Background())
+			if err != nil {
+				s.log.Debug("Cannot summarize managed resources", "error", err)
+				continue
+			}
+			Export(sum)
+		}
+	}
+}
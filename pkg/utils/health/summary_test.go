@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-aws/apis/eks/v1alpha1"
+)
+
+var errBoom = errors.New("boom")
+
+func nodeGroup(c ...runtimev1alpha1.Condition) v1alpha1.NodeGroup {
+	ng := v1alpha1.NodeGroup{}
+	ng.SetConditions(c...)
+	return ng
+}
+
+func managedNodeGroup(c ...runtimev1alpha1.Condition) resource.Managed {
+	ng := nodeGroup(c...)
+	return &ng
+}
+
+func TestStateOf(t *testing.T) {
+	cases := map[string]struct {
+		mg   resource.Managed
+		want string
+	}{
+		"Ready": {
+			mg:   managedNodeGroup(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess()),
+			want: StateReady,
+		},
+		"Creating": {
+			mg:   managedNodeGroup(runtimev1alpha1.Creating()),
+			want: StateCreating,
+		},
+		"Deleting": {
+			mg:   managedNodeGroup(runtimev1alpha1.Deleting()),
+			want: StateDeleting,
+		},
+		"Degraded": {
+			mg:   managedNodeGroup(runtimev1alpha1.Unavailable()),
+			want: StateDegraded,
+		},
+		"ErrorWhileReady": {
+			mg:   managedNodeGroup(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileError(errBoom)),
+			want: StateError,
+		},
+		"NotYetReconciled": {
+			mg:   managedNodeGroup(),
+			want: StateUnknown,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, StateOf(tc.mg)); diff != "" {
+				t.Errorf("StateOf(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme(...): %s", err)
+	}
+	ngKind := v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.NodeGroupKind)
+	fpKind := v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.FargateProfileKind)
+
+	list := func(_ context.Context, obj runtime.Object, _ ...client.ListOption) error {
+		if l, ok := obj.(*v1alpha1.NodeGroupList); ok {
+			l.Items = []v1alpha1.NodeGroup{
+				nodeGroup(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess()),
+				nodeGroup(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess()),
+				nodeGroup(runtimev1alpha1.Creating(), runtimev1alpha1.ReconcileSuccess()),
+				nodeGroup(runtimev1alpha1.Unavailable(), runtimev1alpha1.ReconcileSuccess()),
+				nodeGroup(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileError(errBoom)),
+			}
+		}
+		return nil
+	}
+
+	type want struct {
+		sum Summary
+		err error
+	}
+
+	cases := map[string]struct {
+		kube client.Reader
+		want want
+	}{
+		"CountsByKindAndState": {
+			kube: &test.MockClient{MockList: list},
+			want: want{
+				sum: Summary{
+					ngKind.GroupKind(): {StateReady: 2, StateCreating: 1, StateDegraded: 1, StateError: 1},
+					fpKind.GroupKind(): {},
+				},
+			},
+		},
+		"ListFailed": {
+			kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want: want{
+				err: errors.Wrapf(errBoom, "%s %s", errListKind, fpKind.GroupKind()),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sm := NewSummarizer(tc.kube, s, 0, WithSummarizerKinds(fpKind, ngKind))
+			sum, err := sm.Summarize(context.Background())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Summarize(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sum, sum); diff != "" {
+				t.Errorf("Summarize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManagedKinds(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme(...): %s", err)
+	}
+
+	// NodeGroupClasses are not managed resources.
+	want := []schema.GroupVersionKind{
+		v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.FargateProfileKind),
+		v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.NodeGroupKind),
+	}
+	if diff := cmp.Diff(want, ManagedKinds(s)); diff != "" {
+		t.Errorf("ManagedKinds(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// TypeAuthorized indicates whether the most recent call to the AWS API on
+// behalf of a managed resource was rejected because of its credentials or
+// their permissions. It is only set once such a call was rejected.
+const TypeAuthorized runtimev1alpha1.ConditionType = "Authorized"
+
+// Reasons of the Authorized condition.
+const (
+	ReasonAuthorized       runtimev1alpha1.ConditionReason = "Authorized"
+	ReasonCredentialsError runtimev1alpha1.ConditionReason = "CredentialsError"
+	ReasonPermissionsError runtimev1alpha1.ConditionReason = "PermissionsError"
+)
+
+const (
+	msgCredentialsError     = "cannot %s: the credentials of the ProviderConfig are invalid or expired (%s)"
+	msgPermissionsError     = "cannot %s: the credentials of the ProviderConfig lack the IAM permission %s"
+	msgUnknownPermissionErr = "cannot %s: the credentials of the ProviderConfig lack a required IAM permission (%s)"
+)
+
+// The error codes with which AWS services reject calls because of the
+// credentials or the permissions of the caller. Services with JSON protocols
+// suffix some codes with "Exception".
+var (
+	credentialsErrorCodes = map[string]bool{
+		"ExpiredToken":          true,
+		"ExpiredTokenException": true,
+		"InvalidClientTokenId":  true,
+	}
+	permissionsErrorCodes = map[string]bool{
+		"AccessDenied":          true,
+		"AccessDeniedException": true,
+		"UnauthorizedOperation": true,
+	}
+)
+
+// deniedAction matches the IAM action that most services name in the message
+// of an AccessDenied error, e.g. "... is not authorized to perform:
+// eks:DescribeNodegroup on resource ...".
+var deniedAction = regexp.MustCompile(`perform: ([\w-]+:\w+)`)
+
+// AuthCondition returns an Authorized condition that describes the supplied
+// error, and true, if the error was returned by an AWS client of either SDK
+// because of the credentials or the permissions of the caller. It returns
+// false for any other error. The supplied operation describes what failed,
+// e.g. "observe the external resource".
+func AuthCondition(err error, operation string) (runtimev1alpha1.Condition, bool) {
+	var ce interface{ Code() string }
+	if !errors.As(err, &ce) {
+		return runtimev1alpha1.Condition{}, false
+	}
+	c := runtimev1alpha1.Condition{
+		Type:               TypeAuthorized,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+	}
+	code := ce.Code()
+	switch {
+	case credentialsErrorCodes[code]:
+		c.Reason = ReasonCredentialsError
+		c.Message = fmt.Sprintf(msgCredentialsError, operation, code)
+	case permissionsErrorCodes[code]:
+		c.Reason = ReasonPermissionsError
+		c.Message = fmt.Sprintf(msgUnknownPermissionErr, operation, code)
+		if m := deniedAction.FindStringSubmatch(err.Error()); m != nil {
+			c.Message = fmt.Sprintf(msgPermissionsError, operation, m[1])
+		}
+	default:
+		return runtimev1alpha1.Condition{}, false
+	}
+	return c, true
+}
+
+// An AuthConnecter produces ExternalClients that report calls that AWS
+// rejected because of the credentials or permissions of the caller using the
+// Authorized condition of the managed resource.
+type AuthConnecter struct {
+	connecter managed.ExternalConnecter
+}
+
+// NewAuthConnecter returns an AuthConnecter that wraps the supplied
+// ExternalConnecter.
+func NewAuthConnecter(c managed.ExternalConnecter) *AuthConnecter {
+	return &AuthConnecter{connecter: c}
+}
+
+// Connect to the provider specified by the supplied managed resource.
+func (c *AuthConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.connecter.Connect(ctx, mg)
+	setAuthorized(mg, err, "connect to AWS")
+	if err != nil {
+		return nil, err
+	}
+	return &authClient{client: ec}, nil
+}
+
+// setAuthorized sets the Authorized condition of the supplied managed resource
+// if the supplied error was caused by its credentials or permissions, and
+// clears it if there was no error.
+func setAuthorized(mg resource.Managed, err error, operation string) {
+	if err == nil {
+		if mg.GetCondition(TypeAuthorized).Status == corev1.ConditionFalse {
+			mg.SetConditions(runtimev1alpha1.Condition{
+				Type:               TypeAuthorized,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+				Reason:             ReasonAuthorized,
+			})
+		}
+		return
+	}
+	if c, ok := AuthCondition(err, operation); ok {
+		mg.SetConditions(c)
+	}
+}
+
+type authClient struct {
+	client managed.ExternalClient
+}
+
+func (c *authClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	setAuthorized(mg, err, "observe the external resource")
+	return o, err
+}
+
+func (c *authClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cre, err := c.client.Create(ctx, mg)
+	setAuthorized(mg, err, "create the external resource")
+	return cre, err
+}
+
+func (c *authClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	upd, err := c.client.Update(ctx, mg)
+	setAuthorized(mg, err, "update the external resource")
+	return upd, err
+}
+
+func (c *authClient) Delete(ctx context.Context, mg resource.Managed) error {
+	err := c.client.Delete(ctx, mg)
+	setAuthorized(mg, err, "delete the external resource")
+	return err
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	awserr "github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awserrv1 "github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestAuthCondition(t *testing.T) {
+	const op = "observe the external resource"
+	denied := "User: arn:aws:iam::123456789012:user/cool is not authorized to perform: eks:DescribeNodegroup on resource: cool-ng"
+
+	type want struct {
+		reason  runtimev1alpha1.ConditionReason
+		message string
+		ok      bool
+	}
+
+	cases := map[string]struct {
+		err  error
+		want want
+	}{
+		"AccessDenied": {
+			err: errors.Wrap(awserr.New("AccessDenied", denied, nil), "cannot describe EKS node group"),
+			want: want{
+				reason:  ReasonPermissionsError,
+				message: fmt.Sprintf(msgPermissionsError, op, "eks:DescribeNodegroup"),
+				ok:      true,
+			},
+		},
+		"AccessDeniedExceptionOfSDKV1": {
+			err: errors.Wrap(awserrv1.New("AccessDeniedException", "User: cool is not authorized to perform: apigateway:GET", nil), "cannot get Authorizer"),
+			want: want{
+				reason:  ReasonPermissionsError,
+				message: fmt.Sprintf(msgPermissionsError, op, "apigateway:GET"),
+				ok:      true,
+			},
+		},
+		"UnauthorizedOperation": {
+			err: errors.Wrap(awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil), "cannot describe subnets"),
+			want: want{
+				reason:  ReasonPermissionsError,
+				message: fmt.Sprintf(msgUnknownPermissionErr, op, "UnauthorizedOperation"),
+				ok:      true,
+			},
+		},
+		"ExpiredToken": {
+			err: errors.Wrap(awserr.New("ExpiredToken", "The security token included in the request is expired", nil), "cannot get topic"),
+			want: want{
+				reason:  ReasonCredentialsError,
+				message: fmt.Sprintf(msgCredentialsError, op, "ExpiredToken"),
+				ok:      true,
+			},
+		},
+		"InvalidClientTokenId": {
+			err: awserr.New("InvalidClientTokenId", "The security token included in the request is invalid", nil),
+			want: want{
+				reason:  ReasonCredentialsError,
+				message: fmt.Sprintf(msgCredentialsError, op, "InvalidClientTokenId"),
+				ok:      true,
+			},
+		},
+		"OtherAWSError": {
+			err: awserr.New("ResourceNotFoundException", "not found", nil),
+		},
+		"OtherError": {
+			err: errors.New("boom"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, ok := AuthCondition(tc.err, op)
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("ok: -want, +got:\n%s", diff)
+			}
+			if !ok {
+				return
+			}
+			if diff := cmp.Diff(corev1.ConditionFalse, c.Status); diff != "" {
+				t.Errorf("status: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, c.Reason); diff != "" {
+				t.Errorf("reason: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.message, c.Message); diff != "" {
+				t.Errorf("message: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAuthConnecter(t *testing.T) {
+	errDenied := awserr.New("AccessDenied", "not authorized to perform: sns:GetTopicAttributes", nil)
+
+	cases := map[string]struct {
+		unauthorized bool
+		connectErr   error
+		observeErr   error
+		want         corev1.ConditionStatus
+	}{
+		"ConnectDenied": {
+			connectErr: errDenied,
+			want:       corev1.ConditionFalse,
+		},
+		"ObserveDenied": {
+			observeErr: errDenied,
+			want:       corev1.ConditionFalse,
+		},
+		"OtherErrorIgnored": {
+			observeErr: errors.New("boom"),
+			want:       corev1.ConditionUnknown,
+		},
+		"NotSetUntilDenied": {
+			want: corev1.ConditionUnknown,
+		},
+		"ClearedOnceAuthorized": {
+			unauthorized: true,
+			want:         corev1.ConditionTrue,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			if tc.unauthorized {
+				c, _ := AuthCondition(errDenied, "observe the external resource")
+				mg.SetConditions(c)
+			}
+			c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				if tc.connectErr != nil {
+					return nil, tc.connectErr
+				}
+				return &managed.ExternalClientFns{
+					ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, tc.observeErr
+					},
+				}, nil
+			})
+			if ec, err := NewAuthConnecter(c).Connect(context.Background(), mg); err == nil {
+				_, _ = ec.Observe(context.Background(), mg)
+			}
+			if diff := cmp.Diff(tc.want, mg.GetCondition(TypeAuthorized).Status); diff != "" {
+				t.Errorf("status: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A BackoffResetHandler resets the backoff of a resource when its generation
+// changes, i.e. when its spec is updated. A resource whose reconciles keep
+// failing is otherwise retried with exponentially increasing delays, so a fix
+// to its spec could take a long time to be picked up. It is intended to be
+// used in addition to the handler that enqueues the resource.
+type BackoffResetHandler struct {
+	// CoalesceWindow delays the reconcile of a resource whose generation
+	// changed by the supplied duration. All changes made within the window are
+	// then reconciled at once, rather than each triggering a reconcile that
+	// describes the external resource. A zero value reconciles immediately.
+	CoalesceWindow time.Duration
+}
+
+// Create does nothing; new resources have no backoff to reset.
+func (h *BackoffResetHandler) Create(event.CreateEvent, workqueue.RateLimitingInterface) {}
+
+// Update resets the backoff of the updated resource and enqueues it if its
+// generation changed.
+func (h *BackoffResetHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if e.MetaOld == nil || e.MetaNew == nil || e.MetaOld.GetGeneration() == e.MetaNew.GetGeneration() {
+		return
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.MetaNew.GetNamespace(),
+		Name:      e.MetaNew.GetName(),
+	}}
+	q.Forget(req)
+	if h.CoalesceWindow > 0 {
+		// A request that is already waiting keeps its earlier ready time, so
+		// later changes within the window do not postpone the reconcile.
+		q.AddAfter(req, h.CoalesceWindow)
+		return
+	}
+	q.Add(req)
+}
+
+// Delete does nothing; the backoff of deleted resources is reset once their
+// reconciles succeed.
+func (h *BackoffResetHandler) Delete(event.DeleteEvent, workqueue.RateLimitingInterface) {}
+
+// Generic does nothing.
+func (h *BackoffResetHandler) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {}
+
+// CoalescePredicate returns a predicate that filters out updates that change
+// the generation of a resource if the supplied options coalesce them. It is
+// intended to be used with the handler that enqueues the resource, so that
+// such updates are only enqueued by a BackoffResetHandler with the same
+// CoalesceWindow.
+func CoalescePredicate(o Options) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if o.CoalesceWindow <= 0 || e.MetaOld == nil || e.MetaNew == nil {
+				return true
+			}
+			return e.MetaOld.GetGeneration() == e.MetaNew.GetGeneration()
+		},
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestBackoffResetHandler(t *testing.T) {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "coolresource"}}
+	withGeneration := func(g int64) *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetName(req.Name)
+		mg.SetGeneration(g)
+		return mg
+	}
+
+	type want struct {
+		requeues int
+		queued   int
+	}
+
+	cases := map[string]struct {
+		old  *fake.Managed
+		new  *fake.Managed
+		want want
+	}{
+		"GenerationChanged": {
+			old:  withGeneration(1),
+			new:  withGeneration(2),
+			want: want{requeues: 0, queued: 1},
+		},
+		"GenerationUnchanged": {
+			old:  withGeneration(1),
+			new:  withGeneration(1),
+			want: want{requeues: 3, queued: 0},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Backoff long enough that a rate limited request is never
+			// queued during the test.
+			q := workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Hour, time.Hour))
+			defer q.ShutDown()
+			for i := 0; i < 3; i++ {
+				q.AddRateLimited(req)
+			}
+
+			h := &BackoffResetHandler{}
+			h.Update(event.UpdateEvent{MetaOld: tc.old, ObjectOld: tc.old, MetaNew: tc.new, ObjectNew: tc.new}, q)
+
+			if diff := cmp.Diff(tc.want.requeues, q.NumRequeues(req)); diff != "" {
+				t.Errorf("NumRequeues(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.queued, q.Len()); diff != "" {
+				t.Errorf("Len(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// A delayingQueue is a rate limiting queue that uses a delaying queue with a
+// fake clock. Rate limiting is not used by the tests that use it.
+type delayingQueue struct {
+	workqueue.DelayingInterface
+}
+
+func (q *delayingQueue) AddRateLimited(item interface{})  { q.Add(item) }
+func (q *delayingQueue) Forget(item interface{})          {}
+func (q *delayingQueue) NumRequeues(item interface{}) int { return 0 }
+
+func TestBackoffResetHandlerCoalesce(t *testing.T) {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "coolresource"}}
+	withGeneration := func(g int64) *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetName(req.Name)
+		mg.SetGeneration(g)
+		return mg
+	}
+	window := 5 * time.Second
+
+	c := clock.NewFakeClock(time.Now())
+	q := &delayingQueue{DelayingInterface: workqueue.NewDelayingQueueWithCustomClock(c, "")}
+	defer q.ShutDown()
+
+	// Three rapid successive changes to the spec of the resource.
+	h := &BackoffResetHandler{CoalesceWindow: window}
+	for g := int64(1); g < 4; g++ {
+		h.Update(event.UpdateEvent{MetaOld: withGeneration(g), ObjectOld: withGeneration(g), MetaNew: withGeneration(g + 1), ObjectNew: withGeneration(g + 1)}, q)
+		c.Step(time.Second)
+	}
+	if diff := cmp.Diff(0, q.Len()); diff != "" {
+		t.Errorf("Len() within window: -want, +got:\n%s", diff)
+	}
+
+	// The window starts with the first change, so the request is ready once it
+	// has passed regardless of the later changes.
+	c.Step(window)
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) { return q.Len() > 0, nil }); err != nil {
+		t.Fatalf("request was not queued after the window passed: %s", err)
+	}
+	c.Step(window)
+	if diff := cmp.Diff(1, q.Len()); diff != "" {
+		t.Errorf("Len() after window: -want, +got:\n%s", diff)
+	}
+}
+
+func TestCoalescePredicate(t *testing.T) {
+	withGeneration := func(g int64) *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetGeneration(g)
+		return mg
+	}
+
+	cases := map[string]struct {
+		o    Options
+		old  *fake.Managed
+		new  *fake.Managed
+		want bool
+	}{
+		"NotCoalescing": {
+			old:  withGeneration(1),
+			new:  withGeneration(2),
+			want: true,
+		},
+		"GenerationChanged": {
+			o:    Options{CoalesceWindow: time.Second},
+			old:  withGeneration(1),
+			new:  withGeneration(2),
+			want: false,
+		},
+		"GenerationUnchanged": {
+			o:    Options{CoalesceWindow: time.Second},
+			old:  withGeneration(1),
+			new:  withGeneration(1),
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CoalescePredicate(tc.o).Update(event.UpdateEvent{MetaOld: tc.old, ObjectOld: tc.old, MetaNew: tc.new, ObjectNew: tc.new})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+type connecterOptions struct {
+	historySize    int
+	connectionKeys bool
+}
+
+// A ConnecterOption configures the ExternalConnecter returned by NewConnecter.
+type ConnecterOption func(*connecterOptions)
+
+// WithConnecterHistory records the supplied number of most recent reconcile
+// outcomes of managed resources that are HistoryRecorders.
+func WithConnecterHistory(size int) ConnecterOption {
+	return func(o *connecterOptions) {
+		o.historySize = size
+	}
+}
+
+// WithConnecterConnectionKeys publishes connection details under the key
+// names annotated on managed resources.
+func WithConnecterConnectionKeys() ConnecterOption {
+	return func(o *connecterOptions) {
+		o.connectionKeys = true
+	}
+}
+
+// WithConnecter configures a managed reconciler to use the supplied
+// ExternalConnecter, which is expected to be returned by NewConnecter. Its
+// calls time out as annotated on each managed resource, which may be longer
+// than the default timeout of the managed reconciler, so the timeout of each
+// reconcile is raised to MaxReconcileTimeout.
+func WithConnecter(c managed.ExternalConnecter) managed.ReconcilerOption {
+	return func(r *managed.Reconciler) {
+		managed.WithExternalConnecter(c)(r)
+		managed.WithTimeout(MaxReconcileTimeout)(r)
+	}
+}
+
+// NewConnecter wraps the supplied ExternalConnecter with the behaviour that is
+// shared by the ExternalConnecters of all controllers: call timeouts, AWS
+// authentication errors, recovery from panics, deletion protection and
+// metrics. Warning events are recorded using the supplied recorder, panics are
+// logged using the supplied logger, and metrics are labelled with the supplied
+// kind.
+func NewConnecter(c managed.ExternalConnecter, r event.Recorder, l logging.Logger, kind string, o ...ConnecterOption) managed.ExternalConnecter {
+	opts := &connecterOptions{}
+	for _, fn := range o {
+		fn(opts)
+	}
+	c = NewTimeoutConnecter(c, r)
+	if opts.historySize > 0 {
+		c = NewHistoryConnecter(c, opts.historySize)
+	}
+	if opts.connectionKeys {
+		c = NewConnectionKeysConnecter(c, r)
+	}
+	c = NewAuthConnecter(c)
+	c = NewRecoverConnecter(c, l)
+	c = NewDeletionProtectionConnecter(c)
+	return NewMetricsConnecter(c, kind)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestNewConnecter(t *testing.T) {
+	type want struct {
+		err     error
+		history int
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		o           []ConnecterOption
+		want        want
+	}{
+		"Defaults": {
+			want: want{},
+		},
+		"WithHistory": {
+			o:    []ConnecterOption{WithConnecterHistory(DefaultHistorySize)},
+			want: want{history: 1},
+		},
+		"DeletionProtected": {
+			annotations: map[string]string{AnnotationKeyDeletionProtection: "true"},
+			o:           []ConnecterOption{WithConnecterHistory(DefaultHistorySize)},
+			want:        want{err: errors.New(errDeletionProtected)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &historied{}
+			mg.SetAnnotations(tc.annotations)
+			c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					DeleteFn: func(context.Context, resource.Managed) error { return nil },
+				}, nil
+			})
+			ec, err := NewConnecter(c, event.NewNopRecorder(), logging.NewNopLogger(), "Kind", tc.o...).Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): unexpected error: %s", err)
+			}
+			err = ec.Delete(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.history, len(mg.history)); diff != "" {
+				t.Errorf("history: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestWithConnecter asserts that only the managed reconcilers configured
+// WithConnecter allow their calls to take longer than the default reconcile
+// timeout of the managed reconciler.
+func TestWithConnecter(t *testing.T) {
+	cases := map[string]struct {
+		o    func(managed.ExternalConnecter) managed.ReconcilerOption
+		want time.Duration
+	}{
+		"WithConnecter": {
+			o:    WithConnecter,
+			want: MaxReconcileTimeout,
+		},
+		"WithExternalConnecter": {
+			o:    managed.WithExternalConnecter,
+			want: time.Minute,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &fake.Manager{
+				Client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				Scheme: fake.SchemeWith(&fake.Managed{}),
+			}
+			var got time.Duration
+			c := managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						if d, ok := ctx.Deadline(); ok {
+							got = time.Until(d)
+						}
+						return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+					},
+				}, nil
+			})
+			r := managed.NewReconciler(m, resource.ManagedKind(fake.GVK(&fake.Managed{})),
+				managed.WithInitializers(),
+				managed.WithReferenceResolver(managed.ReferenceResolverFn(func(_ context.Context, _ resource.Managed) error { return nil })),
+				tc.o(c),
+				managed.WithConnectionPublishers(),
+				managed.WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }}))
+
+			if _, err := r.Reconcile(reconcile.Request{}); err != nil {
+				t.Fatalf("Reconcile(...): %s", err)
+			}
+			// The deadline is set before Observe is called, so a little of
+			// the timeout has passed by the time we see it.
+			if got > tc.want || got < tc.want-time.Minute/2 {
+				t.Errorf("Observe(...): want a timeout of about %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
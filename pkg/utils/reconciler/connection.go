@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyConnectionSecretKeys is the annotation that renames the keys of
+// the connection details a managed resource publishes to its connection
+// secret. The value is a comma separated list of default=custom pairs, e.g.
+// "clusterCA=ca.crt,endpoint=server". Keys that are not listed are published
+// under their default names.
+const AnnotationKeyConnectionSecretKeys = "crossplane.io/connection-secret-keys"
+
+const (
+	errInvalidConnectionSecretKeys = "cannot parse annotation " + AnnotationKeyConnectionSecretKeys + " as a list of default=custom key pairs; ignoring it"
+
+	reasonInvalidConnectionSecretKeys event.Reason = "InvalidConnectionSecretKeys"
+)
+
+// GetConnectionSecretKeys returns the connection secret key names annotated on
+// the supplied object, keyed by their default names. It returns nil if the
+// object is not annotated, and an error if the annotation is malformed.
+func GetConnectionSecretKeys(o metav1.Object) (map[string]string, error) {
+	v, ok := o.GetAnnotations()[AnnotationKeyConnectionSecretKeys]
+	if !ok {
+		return nil, nil
+	}
+	keys := map[string]string{}
+	for _, pair := range strings.Split(v, ",") {
+		kv := strings.Split(strings.TrimSpace(pair), "=")
+		if len(kv) != 2 {
+			return nil, errors.New(errInvalidConnectionSecretKeys)
+		}
+		from, to := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if from == "" || to == "" {
+			return nil, errors.New(errInvalidConnectionSecretKeys)
+		}
+		keys[from] = to
+	}
+	return keys, nil
+}
+
+// RenameConnectionDetails returns the supplied connection details with their
+// keys renamed according to the supplied map of default to custom names.
+func RenameConnectionDetails(cd managed.ConnectionDetails, keys map[string]string) managed.ConnectionDetails {
+	if cd == nil || len(keys) == 0 {
+		return cd
+	}
+	out := make(managed.ConnectionDetails, len(cd))
+	for k, v := range cd {
+		if to, ok := keys[k]; ok {
+			k = to
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// A ConnectionKeysConnecter produces ExternalClients that publish connection
+// details under the key names annotated on the managed resource.
+type ConnectionKeysConnecter struct {
+	connecter managed.ExternalConnecter
+	record    event.Recorder
+}
+
+// NewConnectionKeysConnecter returns a ConnectionKeysConnecter that wraps the
+// supplied ExternalConnecter. Invalid annotations are reported as warning
+// events using the supplied recorder.
+func NewConnectionKeysConnecter(c managed.ExternalConnecter, r event.Recorder) *ConnectionKeysConnecter {
+	return &ConnectionKeysConnecter{connecter: c, record: r}
+}
+
+// Connect to the provider specified by the supplied managed resource.
+func (c *ConnectionKeysConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	keys, err := GetConnectionSecretKeys(mg)
+	if err != nil {
+		c.record.Event(mg, event.Warning(reasonInvalidConnectionSecretKeys, err))
+	}
+	ec, err := c.connecter.Connect(ctx, mg)
+	if err != nil || len(keys) == 0 {
+		return ec, err
+	}
+	return &connectionKeysClient{client: ec, keys: keys}, nil
+}
+
+type connectionKeysClient struct {
+	client managed.ExternalClient
+	keys   map[string]string
+}
+
+func (c *connectionKeysClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	o.ConnectionDetails = RenameConnectionDetails(o.ConnectionDetails, c.keys)
+	return o, err
+}
+
+func (c *connectionKeysClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cre, err := c.client.Create(ctx, mg)
+	cre.ConnectionDetails = RenameConnectionDetails(cre.ConnectionDetails, c.keys)
+	return cre, err
+}
+
+func (c *connectionKeysClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	upd, err := c.client.Update(ctx, mg)
+	upd.ConnectionDetails = RenameConnectionDetails(upd.ConnectionDetails, c.keys)
+	return upd, err
+}
+
+func (c *connectionKeysClient) Delete(ctx context.Context, mg resource.Managed) error {
+	return c.client.Delete(ctx, mg)
+}
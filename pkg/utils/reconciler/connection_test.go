@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestConnectionKeysConnecter(t *testing.T) {
+	details := managed.ConnectionDetails{
+		"clusterCA": []byte("ca"),
+		"endpoint":  []byte("https://example.org"),
+	}
+
+	type want struct {
+		details  managed.ConnectionDetails
+		warnings int
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		want        want
+	}{
+		"Remapped": {
+			annotations: map[string]string{AnnotationKeyConnectionSecretKeys: "clusterCA=ca.crt, endpoint = server"},
+			want: want{details: managed.ConnectionDetails{
+				"ca.crt": []byte("ca"),
+				"server": []byte("https://example.org"),
+			}},
+		},
+		"PartiallyRemapped": {
+			annotations: map[string]string{AnnotationKeyConnectionSecretKeys: "clusterCA=ca.crt"},
+			want: want{details: managed.ConnectionDetails{
+				"ca.crt":   []byte("ca"),
+				"endpoint": []byte("https://example.org"),
+			}},
+		},
+		"InvalidAnnotation": {
+			annotations: map[string]string{AnnotationKeyConnectionSecretKeys: "clusterCA"},
+			want:        want{details: details, warnings: 1},
+		},
+		"DefaultKeys": {
+			want: want{details: details},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			record := &eventCounter{}
+			mg := &fake.Managed{}
+			mg.SetAnnotations(tc.annotations)
+
+			c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ConnectionDetails: details}, nil
+					},
+					CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+						return managed.ExternalCreation{ConnectionDetails: details}, nil
+					},
+					UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+						return managed.ExternalUpdate{ConnectionDetails: details}, nil
+					},
+				}, nil
+			})
+			ec, err := NewConnectionKeysConnecter(c, record).Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): unexpected error: %s", err)
+			}
+			o, _ := ec.Observe(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.details, o.ConnectionDetails); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			cre, _ := ec.Create(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.details, cre.ConnectionDetails); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+			upd, _ := ec.Update(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.details, upd.ConnectionDetails); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.warnings, record.warnings); diff != "" {
+				t.Errorf("warnings: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A Drainer tracks the reconciles that are in flight, so that the provider
+// can allow them to complete when it is shut down. A reconcile that is
+// abruptly terminated may leave the external resource in an ambiguous state,
+// e.g. created but without its external name recorded.
+type Drainer struct {
+	mu      sync.Mutex
+	active  int
+	drained chan struct{}
+}
+
+// NewDrainer returns a Drainer that tracks no reconciles.
+func NewDrainer() *Drainer {
+	return &Drainer{}
+}
+
+// Wrap the supplied reconciler so that its reconciles are tracked.
+func (d *Drainer) Wrap(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(req reconcile.Request) (reconcile.Result, error) {
+		d.start()
+		defer d.done()
+		return r.Reconcile(req)
+	})
+}
+
+func (d *Drainer) start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.active == 0 {
+		d.drained = make(chan struct{})
+	}
+	d.active++
+}
+
+func (d *Drainer) done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.active--
+	if d.active == 0 {
+		close(d.drained)
+	}
+}
+
+// Active returns the number of reconciles that are in flight.
+func (d *Drainer) Active() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.active
+}
+
+// Wait for the reconciles that are in flight to complete, for at most the
+// supplied grace period. It returns false if reconciles were still in flight
+// when the grace period passed.
+func (d *Drainer) Wait(grace time.Duration) bool {
+	d.mu.Lock()
+	if d.active == 0 {
+		d.mu.Unlock()
+		return true
+	}
+	drained := d.drained
+	d.mu.Unlock()
+
+	t := time.NewTimer(grace)
+	defer t.Stop()
+	select {
+	case <-drained:
+		return true
+	case <-t.C:
+		return false
+	}
+}
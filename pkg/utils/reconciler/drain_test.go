@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDrainer(t *testing.T) {
+	cases := map[string]struct {
+		reconcile time.Duration
+		grace     time.Duration
+		want      bool
+	}{
+		"CompletedWithinGracePeriod": {
+			reconcile: 10 * time.Millisecond,
+			grace:     5 * time.Second,
+			want:      true,
+		},
+		"GracePeriodPassed": {
+			reconcile: 5 * time.Second,
+			grace:     10 * time.Millisecond,
+			want:      false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := NewDrainer()
+			started := make(chan struct{})
+			release := make(chan struct{})
+			r := d.Wrap(reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				close(started)
+				select {
+				case <-release:
+				case <-time.After(tc.reconcile):
+				}
+				return reconcile.Result{}, nil
+			}))
+			go func() { _, _ = r.Reconcile(reconcile.Request{}) }()
+			<-started
+			defer close(release)
+
+			if diff := cmp.Diff(1, d.Active()); diff != "" {
+				t.Errorf("Active(): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, d.Wait(tc.grace)); diff != "" {
+				t.Errorf("Wait(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDrainerIdle(t *testing.T) {
+	d := NewDrainer()
+	r := d.Wrap(reconcile.Func(func(reconcile.Request) (reconcile.Result, error) { return reconcile.Result{}, nil }))
+	if _, err := r.Reconcile(reconcile.Request{}); err != nil {
+		t.Fatalf("Reconcile(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(true, d.Wait(0)); diff != "" {
+		t.Errorf("Wait(...): -want, +got:\n%s", diff)
+	}
+}
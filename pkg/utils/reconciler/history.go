@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-aws/apis/v1beta1"
+)
+
+// DefaultHistorySize is the number of reconcile outcomes that are kept by
+// default.
+const DefaultHistorySize = 10
+
+// Reconcile actions and results.
+const (
+	ActionObserve = "Observe"
+	ActionCreate  = "Create"
+	ActionUpdate  = "Update"
+	ActionDelete  = "Delete"
+
+	ResultSuccess     = "Success"
+	ResultUpToDate    = "UpToDate"
+	ResultNotUpToDate = "NotUpToDate"
+	ResultNotFound    = "NotFound"
+)
+
+// A HistoryRecorder is a managed resource that records the outcomes of its
+// most recent reconciles.
+type HistoryRecorder interface {
+	GetReconcileHistory() []v1beta1.ReconcileRecord
+	SetReconcileHistory(h []v1beta1.ReconcileRecord)
+}
+
+// RecordReconcile appends the supplied outcome to the reconcile history of the
+// supplied HistoryRecorder, dropping the oldest outcomes if there are more than
+// size. An outcome that is the same as the most recent one is not recorded
+// at all; updating the history on every observation would change the status
+// of the managed resource, and thus trigger another reconcile, every time.
+func RecordReconcile(hr HistoryRecorder, action, result string, t time.Time, size int) {
+	h := hr.GetReconcileHistory()
+	if n := len(h); n > 0 && h[n-1].Action == action && h[n-1].Result == result {
+		return
+	}
+	h = append(h, v1beta1.ReconcileRecord{Action: action, Result: result, Time: metav1.NewTime(t)})
+	if len(h) > size {
+		h = append([]v1beta1.ReconcileRecord(nil), h[len(h)-size:]...)
+	}
+	hr.SetReconcileHistory(h)
+}
+
+// A HistoryConnecter produces ExternalClients that record the outcome of
+// every call in the reconcile history of the managed resource, if it is a
+// HistoryRecorder.
+type HistoryConnecter struct {
+	connecter managed.ExternalConnecter
+	size      int
+	now       func() time.Time
+}
+
+// NewHistoryConnecter returns a HistoryConnecter that wraps the supplied
+// ExternalConnecter and keeps at most size outcomes.
+func NewHistoryConnecter(c managed.ExternalConnecter, size int) *HistoryConnecter {
+	return &HistoryConnecter{connecter: c, size: size, now: time.Now}
+}
+
+// Connect to the provider specified by the supplied managed resource.
+func (c *HistoryConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.connecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &historyClient{client: ec, size: c.size, now: c.now}, nil
+}
+
+type historyClient struct {
+	client managed.ExternalClient
+	size   int
+	now    func() time.Time
+}
+
+func (c *historyClient) record(mg resource.Managed, action, result string, err error) {
+	hr, ok := mg.(HistoryRecorder)
+	if !ok {
+		return
+	}
+	if err != nil {
+		result = err.Error()
+	}
+	RecordReconcile(hr, action, result, c.now(), c.size)
+}
+
+func (c *historyClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	result := ResultUpToDate
+	switch {
+	case !o.ResourceExists:
+		result = ResultNotFound
+	case !o.ResourceUpToDate:
+		result = ResultNotUpToDate
+	}
+	c.record(mg, ActionObserve, result, err)
+	return o, err
+}
+
+func (c *historyClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cre, err := c.client.Create(ctx, mg)
+	c.record(mg, ActionCreate, ResultSuccess, err)
+	return cre, err
+}
+
+func (c *historyClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	upd, err := c.client.Update(ctx, mg)
+	c.record(mg, ActionUpdate, ResultSuccess, err)
+	return upd, err
+}
+
+func (c *historyClient) Delete(ctx context.Context, mg resource.Managed) error {
+	err := c.client.Delete(ctx, mg)
+	c.record(mg, ActionDelete, ResultSuccess, err)
+	return err
+}
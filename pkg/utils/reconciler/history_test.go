@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	"github.com/crossplane/provider-aws/apis/v1beta1"
+)
+
+type historied struct {
+	fake.Managed
+	history []v1beta1.ReconcileRecord
+}
+
+func (h *historied) GetReconcileHistory() []v1beta1.ReconcileRecord  { return h.history }
+func (h *historied) SetReconcileHistory(r []v1beta1.ReconcileRecord) { h.history = r }
+
+func TestHistoryConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(i int) metav1.Time { return metav1.NewTime(now.Add(time.Duration(i) * time.Minute)) }
+
+	type call func(context.Context, managed.ExternalClient, resource.Managed)
+	observe := func(ctx context.Context, ec managed.ExternalClient, mg resource.Managed) { _, _ = ec.Observe(ctx, mg) }
+	create := func(ctx context.Context, ec managed.ExternalClient, mg resource.Managed) { _, _ = ec.Create(ctx, mg) }
+	update := func(ctx context.Context, ec managed.ExternalClient, mg resource.Managed) { _, _ = ec.Update(ctx, mg) }
+
+	cases := map[string]struct {
+		client *managed.ExternalClientFns
+		size   int
+		calls  []call
+		want   []v1beta1.ReconcileRecord
+	}{
+		"RecordsOutcomes": {
+			client: &managed.ExternalClientFns{
+				ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, nil
+				},
+				CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+					return managed.ExternalCreation{}, nil
+				},
+				UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+					return managed.ExternalUpdate{}, errBoom
+				},
+			},
+			size:  DefaultHistorySize,
+			calls: []call{observe, create, update},
+			want: []v1beta1.ReconcileRecord{
+				{Action: ActionObserve, Result: ResultNotFound, Time: at(0)},
+				{Action: ActionCreate, Result: ResultSuccess, Time: at(1)},
+				{Action: ActionUpdate, Result: errBoom.Error(), Time: at(2)},
+			},
+		},
+		"CollapsesRepeatedOutcomes": {
+			client: &managed.ExternalClientFns{
+				ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+				},
+			},
+			size:  DefaultHistorySize,
+			calls: []call{observe, observe, observe},
+			want: []v1beta1.ReconcileRecord{
+				{Action: ActionObserve, Result: ResultUpToDate, Time: at(0)},
+			},
+		},
+		"RotatesAtCap": {
+			client: &managed.ExternalClientFns{
+				ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{ResourceExists: true}, nil
+				},
+				UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+					return managed.ExternalUpdate{}, nil
+				},
+			},
+			size:  2,
+			calls: []call{observe, update, observe},
+			want: []v1beta1.ReconcileRecord{
+				{Action: ActionUpdate, Result: ResultSuccess, Time: at(1)},
+				{Action: ActionObserve, Result: ResultNotUpToDate, Time: at(2)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			c := NewHistoryConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return tc.client, nil
+			}), tc.size)
+			c.now = func() time.Time {
+				defer func() { calls++ }()
+				return at(calls).Time
+			}
+			mg := &historied{}
+			ec, err := c.Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): unexpected error: %s", err)
+			}
+			for _, call := range tc.calls {
+				call(context.Background(), ec, mg)
+			}
+			if diff := cmp.Diff(tc.want, mg.history); diff != "" {
+				t.Errorf("history: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// DefaultPostReconcileTemplate renders the notification sent by a
+// PostReconcileHook as JSON.
+const DefaultPostReconcileTemplate = "{{ json . }}"
+
+const (
+	// DefaultPostReconcileTimeout is how long a PostReconcileHook waits for
+	// its webhook to respond to a notification.
+	DefaultPostReconcileTimeout = 10 * time.Second
+
+	// DefaultPostReconcileQueueSize is how many notifications a
+	// PostReconcileHook queues for delivery. Notifications are dropped while
+	// the queue is full.
+	DefaultPostReconcileQueueSize = 100
+)
+
+const (
+	errParseTemplate = "cannot parse post-reconcile template"
+	errGetInformer   = "cannot get informer for %s"
+	errRender        = "cannot render post-reconcile notification"
+	errSend          = "cannot send post-reconcile notification"
+	errWebhookStatus = "post-reconcile webhook responded with %s"
+)
+
+// A Notification describes a managed resource whose conditions changed.
+type Notification struct {
+	APIVersion   string                      `json:"apiVersion"`
+	Kind         string                      `json:"kind"`
+	Name         string                      `json:"name"`
+	ExternalName string                      `json:"externalName,omitempty"`
+	Conditions   []runtimev1alpha1.Condition `json:"conditions"`
+}
+
+// A PostReconcileHookOption configures a PostReconcileHook.
+type PostReconcileHookOption func(*PostReconcileHook)
+
+// WithHookHTTPClient configures the HTTP client used to deliver
+// notifications.
+func WithHookHTTPClient(c *http.Client) PostReconcileHookOption {
+	return func(h *PostReconcileHook) {
+		h.client = c
+	}
+}
+
+// WithHookLogger configures the logger used to report notifications that
+// could not be delivered.
+func WithHookLogger(l logging.Logger) PostReconcileHookOption {
+	return func(h *PostReconcileHook) {
+		h.log = l
+	}
+}
+
+// WithHookQueueSize configures how many notifications are queued for
+// delivery.
+func WithHookQueueSize(n int) PostReconcileHookOption {
+	return func(h *PostReconcileHook) {
+		h.queue = make(chan Notification, n)
+	}
+}
+
+// A PostReconcileHook notifies a webhook when the Ready or Synced condition
+// of a managed resource changes, e.g. to keep an external inventory of
+// resources up to date. Notifications are rendered using a template and sent
+// as the body of a POST request. They are delivered in the background, one at
+// a time, and never block reconciles; notifications that cannot be queued or
+// delivered are logged and dropped.
+type PostReconcileHook struct {
+	url      string
+	template *template.Template
+	client   *http.Client
+	log      logging.Logger
+	queue    chan Notification
+	started  int32
+}
+
+// NewPostReconcileHook returns a PostReconcileHook that sends notifications
+// rendered using the supplied text/template to the supplied URL. The template
+// is executed with a Notification, and may use the json function to render
+// a value as JSON.
+func NewPostReconcileHook(url, tmpl string, o ...PostReconcileHookOption) (*PostReconcileHook, error) {
+	t, err := template.New("post-reconcile").Funcs(template.FuncMap{"json": toJSON}).Parse(tmpl)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseTemplate)
+	}
+	h := &PostReconcileHook{
+		url:      url,
+		template: t,
+		client:   &http.Client{Timeout: DefaultPostReconcileTimeout},
+		log:      logging.NewNopLogger(),
+		queue:    make(chan Notification, DefaultPostReconcileQueueSize),
+	}
+	for _, f := range o {
+		f(h)
+	}
+	return h, nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// Watch the managed resources of all kinds known to the supplied scheme, using
+// informers from the supplied cache, and notify the webhook of changes to
+// their conditions.
+func (h *PostReconcileHook) Watch(ctx context.Context, c cache.Informers, s *runtime.Scheme) error {
+	for gvk, t := range s.AllKnownTypes() {
+		obj, ok := reflect.New(t).Interface().(resource.Managed)
+		if !ok {
+			continue
+		}
+		i, err := c.GetInformer(ctx, obj)
+		if err != nil {
+			return errors.Wrapf(err, errGetInformer, gvk)
+		}
+		i.AddEventHandler(h.HandlerFor(gvk))
+	}
+	return nil
+}
+
+// HandlerFor returns an informer event handler that notifies the webhook when
+// the conditions of a managed resource of the supplied kind change.
+func (h *PostReconcileHook) HandlerFor(gvk schema.GroupVersionKind) toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			o, ok := oldObj.(resource.Managed)
+			if !ok {
+				return
+			}
+			n, ok := newObj.(resource.Managed)
+			if !ok || !conditionsChanged(o, n) {
+				return
+			}
+			h.enqueue(Notification{
+				APIVersion:   gvk.GroupVersion().String(),
+				Kind:         gvk.Kind,
+				Name:         n.GetName(),
+				ExternalName: meta.GetExternalName(n),
+				Conditions:   conditions(n),
+			})
+		},
+	}
+}
+
+func conditions(mg resource.Managed) []runtimev1alpha1.Condition {
+	return []runtimev1alpha1.Condition{
+		mg.GetCondition(runtimev1alpha1.TypeReady),
+		mg.GetCondition(runtimev1alpha1.TypeSynced),
+	}
+}
+
+func conditionsChanged(o, n resource.Managed) bool {
+	oc, nc := conditions(o), conditions(n)
+	for i := range oc {
+		if !oc[i].Equal(nc[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *PostReconcileHook) enqueue(n Notification) {
+	// Notifications are only delivered by the leader, so there is no point
+	// in queueing them before this replica was elected.
+	if atomic.LoadInt32(&h.started) == 0 {
+		return
+	}
+	select {
+	case h.queue <- n:
+	default:
+		h.log.Info("Dropped post-reconcile notification; the queue is full", "kind", n.Kind, "name", n.Name)
+	}
+}
+
+// NeedLeaderElection returns true; only the leader delivers notifications.
+func (h *PostReconcileHook) NeedLeaderElection() bool {
+	return true
+}
+
+// Start delivering notifications until the supplied channel is closed.
+func (h *PostReconcileHook) Start(stop <-chan struct{}) error {
+	atomic.StoreInt32(&h.started, 1)
+	defer atomic.StoreInt32(&h.started, 0)
+	for {
+		select {
+		case <-stop:
+			return nil
+		case n := <-h.queue:
+			if err := h.deliver(n); err != nil {
+				h.log.Info("Cannot deliver post-reconcile notification", "kind", n.Kind, "name", n.Name, "error", err)
+			}
+		}
+	}
+}
+
+func (h *PostReconcileHook) deliver(n Notification) error {
+	body := &bytes.Buffer{}
+	if err := h.template.Execute(body, n); err != nil {
+		return errors.Wrap(err, errRender)
+	}
+	rsp, err := h.client.Post(h.url, "application/json", body)
+	if err != nil {
+		return errors.Wrap(err, errSend)
+	}
+	defer rsp.Body.Close() // nolint:errcheck
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return errors.Errorf(errWebhookStatus, rsp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestPostReconcileHook(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "eks.aws.crossplane.io", Version: "v1alpha1", Kind: "NodeGroup"}
+	withConditions := func(c ...runtimev1alpha1.Condition) *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetName("cool-ng")
+		meta.SetExternalName(mg, "cool-external-ng")
+		mg.SetConditions(c...)
+		return mg
+	}
+	later := func(c runtimev1alpha1.Condition) runtimev1alpha1.Condition {
+		c.LastTransitionTime = metav1.NewTime(c.LastTransitionTime.Add(time.Minute))
+		return c
+	}
+	available, creating := runtimev1alpha1.Available(), runtimev1alpha1.Creating()
+
+	cases := map[string]struct {
+		template string
+		old      *fake.Managed
+		new      *fake.Managed
+		want     []string
+	}{
+		"ConditionsChanged": {
+			template: DefaultPostReconcileTemplate,
+			old:      withConditions(creating),
+			new:      withConditions(available),
+			want: []string{`{"apiVersion":"eks.aws.crossplane.io/v1alpha1","kind":"NodeGroup","name":"cool-ng","externalName":"cool-external-ng","conditions":[` +
+				mustJSON(t, available) + `,{"type":"Synced","status":"Unknown","lastTransitionTime":null,"reason":""}]}`},
+		},
+		"ConditionsUnchanged": {
+			template: DefaultPostReconcileTemplate,
+			old:      withConditions(available),
+			new:      withConditions(later(available)),
+		},
+		"CustomTemplate": {
+			template: `{{ .Kind }}/{{ .Name }}{{ range .Conditions }} {{ .Type }}={{ .Status }}{{ end }}`,
+			old:      withConditions(available),
+			new:      withConditions(available, runtimev1alpha1.ReconcileSuccess()),
+			want:     []string{"NodeGroup/cool-ng Ready=True Synced=True"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			received := make(chan string, 10)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				received <- string(b)
+			}))
+			defer srv.Close()
+
+			h, err := NewPostReconcileHook(srv.URL, tc.template)
+			if err != nil {
+				t.Fatalf("NewPostReconcileHook(...): %s", err)
+			}
+			stop := make(chan struct{})
+			defer close(stop)
+			go h.Start(stop) // nolint:errcheck
+			for atomic.LoadInt32(&h.started) == 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			h.HandlerFor(gvk).OnUpdate(tc.old, tc.new)
+
+			var got []string
+			select {
+			case b := <-received:
+				got = append(got, b)
+			case <-time.After(100 * time.Millisecond):
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("notifications: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostReconcileHookNotStarted(t *testing.T) {
+	h, err := NewPostReconcileHook("http://example.org", DefaultPostReconcileTemplate)
+	if err != nil {
+		t.Fatalf("NewPostReconcileHook(...): %s", err)
+	}
+	o, n := &fake.Managed{}, &fake.Managed{}
+	n.SetConditions(runtimev1alpha1.Available())
+	h.HandlerFor(schema.GroupVersionKind{Kind: "NodeGroup"}).OnUpdate(o, n)
+	if diff := cmp.Diff(0, len(h.queue)); diff != "" {
+		t.Errorf("queued: -want, +got:\n%s", diff)
+	}
+}
+
+func TestNewPostReconcileHookInvalidTemplate(t *testing.T) {
+	if _, err := NewPostReconcileHook("http://example.org", "{{ .Kind "); err == nil {
+		t.Error("NewPostReconcileHook(...): want error, got nil")
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	s, err := toJSON(v)
+	if err != nil {
+		t.Fatalf("toJSON(...): %s", err)
+	}
+	return s
+}
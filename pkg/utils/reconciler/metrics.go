@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Outcomes of calls to the external API that are counted by the reconcile
+// outcomes metric.
+const (
+	OutcomeObserved = "observed"
+	OutcomeCreated  = "created"
+	OutcomeUpdated  = "updated"
+	OutcomeDeleted  = "deleted"
+	OutcomeError    = "error"
+)
+
+// ReconcileOutcomes counts the outcomes of the calls managed reconcilers make
+// to the external API, by kind of managed resource and outcome. Calls that
+// fail, including those to connect, are counted with the error outcome.
+var ReconcileOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "aws_managed_resource_reconcile_outcomes_total",
+	Help: "Total number of calls to the external API by managed resource kind and outcome.",
+}, []string{"kind", "outcome"})
+
+func init() {
+	metrics.Registry.MustRegister(ReconcileOutcomes)
+}
+
+// A MetricsConnecter produces ExternalClients that count the outcomes of
+// their calls using the ReconcileOutcomes metric.
+type MetricsConnecter struct {
+	connecter managed.ExternalConnecter
+	kind      string
+}
+
+// NewMetricsConnecter returns a MetricsConnecter that wraps the supplied
+// ExternalConnecter. Outcomes are counted for the supplied kind of managed
+// resource.
+func NewMetricsConnecter(c managed.ExternalConnecter, kind string) *MetricsConnecter {
+	return &MetricsConnecter{connecter: c, kind: kind}
+}
+
+// Connect to the provider specified by the supplied managed resource.
+func (c *MetricsConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.connecter.Connect(ctx, mg)
+	if err != nil {
+		ReconcileOutcomes.WithLabelValues(c.kind, OutcomeError).Inc()
+		return ec, err
+	}
+	return &metricsClient{client: ec, kind: c.kind}, nil
+}
+
+type metricsClient struct {
+	client managed.ExternalClient
+	kind   string
+}
+
+func (c *metricsClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	c.count(OutcomeObserved, err)
+	return o, err
+}
+
+func (c *metricsClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cre, err := c.client.Create(ctx, mg)
+	c.count(OutcomeCreated, err)
+	return cre, err
+}
+
+func (c *metricsClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	upd, err := c.client.Update(ctx, mg)
+	c.count(OutcomeUpdated, err)
+	return upd, err
+}
+
+func (c *metricsClient) Delete(ctx context.Context, mg resource.Managed) error {
+	err := c.client.Delete(ctx, mg)
+	c.count(OutcomeDeleted, err)
+	return err
+}
+
+func (c *metricsClient) count(outcome string, err error) {
+	if err != nil {
+		outcome = OutcomeError
+	}
+	ReconcileOutcomes.WithLabelValues(c.kind, outcome).Inc()
+}
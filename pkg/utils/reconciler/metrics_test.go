@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestMetricsConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		outcome string
+		err     error
+	}
+
+	cases := map[string]struct {
+		connectErr error
+		callErr    error
+		call       func(managed.ExternalClient) error
+		want       want
+	}{
+		"Observed": {
+			call: func(ec managed.ExternalClient) error {
+				_, err := ec.Observe(context.Background(), &fake.Managed{})
+				return err
+			},
+			want: want{outcome: OutcomeObserved},
+		},
+		"Created": {
+			call: func(ec managed.ExternalClient) error {
+				_, err := ec.Create(context.Background(), &fake.Managed{})
+				return err
+			},
+			want: want{outcome: OutcomeCreated},
+		},
+		"Updated": {
+			call: func(ec managed.ExternalClient) error {
+				_, err := ec.Update(context.Background(), &fake.Managed{})
+				return err
+			},
+			want: want{outcome: OutcomeUpdated},
+		},
+		"Deleted": {
+			call: func(ec managed.ExternalClient) error {
+				return ec.Delete(context.Background(), &fake.Managed{})
+			},
+			want: want{outcome: OutcomeDeleted},
+		},
+		"CallFailed": {
+			callErr: errBoom,
+			call: func(ec managed.ExternalClient) error {
+				_, err := ec.Update(context.Background(), &fake.Managed{})
+				return err
+			},
+			want: want{outcome: OutcomeError, err: errBoom},
+		},
+		"ConnectFailed": {
+			connectErr: errBoom,
+			want:       want{outcome: OutcomeError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Each case counts a kind of its own, so that counts do not leak
+			// between cases.
+			kind := "Test" + name
+			c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, tc.callErr
+					},
+					CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+						return managed.ExternalCreation{}, tc.callErr
+					},
+					UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+						return managed.ExternalUpdate{}, tc.callErr
+					},
+					DeleteFn: func(context.Context, resource.Managed) error {
+						return tc.callErr
+					},
+				}, tc.connectErr
+			})
+			ec, err := NewMetricsConnecter(c, kind).Connect(context.Background(), &fake.Managed{})
+			if err == nil {
+				err = tc.call(ec)
+				if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+					t.Errorf("call: -want, +got:\n%s", diff)
+				}
+			}
+			for _, o := range []string{OutcomeObserved, OutcomeCreated, OutcomeUpdated, OutcomeDeleted, OutcomeError} {
+				want := 0.0
+				if o == tc.want.outcome {
+					want = 1
+				}
+				if diff := cmp.Diff(want, testutil.ToFloat64(ReconcileOutcomes.WithLabelValues(kind, o))); diff != "" {
+					t.Errorf("%s: -want, +got:\n%s", o, diff)
+				}
+			}
+		})
+	}
+}
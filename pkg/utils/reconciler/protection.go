@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyDeletionProtection is the annotation that protects the external
+// resource of a managed resource from being deleted. A managed resource that
+// is annotated "true" is not deleted until the annotation is removed, unlike
+// one with the Orphan deletion policy, whose external resource is left behind.
+const AnnotationKeyDeletionProtection = "crossplane.io/deletion-protection"
+
+// TypeDeletionProtected indicates that the deletion of a managed resource is
+// refused because it is protected from deletion.
+const TypeDeletionProtected runtimev1alpha1.ConditionType = "DeletionProtected"
+
+// Reasons of the DeletionProtected condition.
+const (
+	ReasonDeletionProtected   runtimev1alpha1.ConditionReason = "DeletionProtected"
+	ReasonDeletionUnprotected runtimev1alpha1.ConditionReason = "DeletionUnprotected"
+)
+
+const errDeletionProtected = "refusing to delete the external resource: the managed resource is annotated " + AnnotationKeyDeletionProtection + "; remove the annotation to delete it"
+
+// IsDeletionProtected returns true if the supplied managed resource is
+// protected from deletion.
+func IsDeletionProtected(mg resource.Managed) bool {
+	return mg.GetAnnotations()[AnnotationKeyDeletionProtection] == "true"
+}
+
+// A DeletionProtectionConnecter produces ExternalClients that refuse to delete
+// the external resources of managed resources that are protected from
+// deletion.
+type DeletionProtectionConnecter struct {
+	connecter managed.ExternalConnecter
+}
+
+// NewDeletionProtectionConnecter returns a DeletionProtectionConnecter that
+// wraps the supplied ExternalConnecter.
+func NewDeletionProtectionConnecter(c managed.ExternalConnecter) *DeletionProtectionConnecter {
+	return &DeletionProtectionConnecter{connecter: c}
+}
+
+// Connect to the provider specified by the supplied managed resource.
+func (c *DeletionProtectionConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.connecter.Connect(ctx, mg)
+	if err != nil {
+		return ec, err
+	}
+	return &deletionProtectionClient{ExternalClient: ec}, nil
+}
+
+type deletionProtectionClient struct {
+	managed.ExternalClient
+}
+
+// Observe the external resource, clearing the DeletionProtected condition of
+// a managed resource whose protection was removed.
+func (c *deletionProtectionClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	unprotect(mg)
+	return c.ExternalClient.Observe(ctx, mg)
+}
+
+// Delete refuses to delete the external resource of a protected managed
+// resource. The managed reconciler reports the returned error and retries, so
+// the external resource is deleted once the annotation is removed.
+func (c *deletionProtectionClient) Delete(ctx context.Context, mg resource.Managed) error {
+	if IsDeletionProtected(mg) {
+		mg.SetConditions(deletionProtected(corev1.ConditionTrue, ReasonDeletionProtected))
+		return errors.New(errDeletionProtected)
+	}
+	unprotect(mg)
+	return c.ExternalClient.Delete(ctx, mg)
+}
+
+// unprotect sets the DeletionProtected condition of the supplied managed
+// resource to false if it is no longer protected. A managed resource whose
+// deletion was never refused is left without the condition.
+func unprotect(mg resource.Managed) {
+	if IsDeletionProtected(mg) || mg.GetCondition(TypeDeletionProtected).Status != corev1.ConditionTrue {
+		return
+	}
+	mg.SetConditions(deletionProtected(corev1.ConditionFalse, ReasonDeletionUnprotected))
+}
+
+func deletionProtected(s corev1.ConditionStatus, r runtimev1alpha1.ConditionReason) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             s,
+		LastTransitionTime: metav1.Now(),
+		Reason:             r,
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestDeletionProtectionConnecter(t *testing.T) {
+	type want struct {
+		deleted   bool
+		err       error
+		condition runtimev1alpha1.Condition
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		conditions  []runtimev1alpha1.Condition
+		want        want
+	}{
+		"Protected": {
+			annotations: map[string]string{AnnotationKeyDeletionProtection: "true"},
+			want: want{
+				err: errors.New(errDeletionProtected),
+				condition: runtimev1alpha1.Condition{
+					Type:   TypeDeletionProtected,
+					Status: corev1.ConditionTrue,
+					Reason: ReasonDeletionProtected,
+				},
+			},
+		},
+		"NotProtected": {
+			annotations: map[string]string{AnnotationKeyDeletionProtection: "false"},
+			want: want{
+				deleted:   true,
+				condition: runtimev1alpha1.Condition{Type: TypeDeletionProtected, Status: corev1.ConditionUnknown},
+			},
+		},
+		"NotAnnotated": {
+			want: want{
+				deleted:   true,
+				condition: runtimev1alpha1.Condition{Type: TypeDeletionProtected, Status: corev1.ConditionUnknown},
+			},
+		},
+		"ProtectionRemoved": {
+			conditions: []runtimev1alpha1.Condition{deletionProtected(corev1.ConditionTrue, ReasonDeletionProtected)},
+			want: want{
+				deleted:   true,
+				condition: deletionProtected(corev1.ConditionFalse, ReasonDeletionUnprotected),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetAnnotations(tc.annotations)
+			mg.SetConditions(tc.conditions...)
+
+			deleted := false
+			c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+					DeleteFn: func(context.Context, resource.Managed) error {
+						deleted = true
+						return nil
+					},
+				}, nil
+			})
+			ec, err := NewDeletionProtectionConnecter(c).Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): unexpected error: %s", err)
+			}
+			if _, err := ec.Observe(context.Background(), mg); err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			err = ec.Delete(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("deleted: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, mg.GetCondition(TypeDeletionProtected), test.EquateConditions()); diff != "" {
+				t.Errorf("condition: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reconciler contains behaviour that is shared by all of the
+// reconcilers of this provider.
+package reconciler
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+// Options configures the behaviour that is shared by all reconcilers.
+type Options struct {
+	// StartupJitter is the window across which the first reconcile of each
+	// resource is spread after the provider starts. A zero value disables
+	// startup jitter.
+	StartupJitter time.Duration
+
+	// PollInterval is how often an up to date managed resource is observed in
+	// order to detect drift of its external resource. Changes to a managed
+	// resource are reconciled as soon as they are made regardless of the poll
+	// interval. A zero value uses the default of the managed reconciler.
+	PollInterval time.Duration
+
+	// CoalesceWindow is how long the reconcile of a resource whose spec was
+	// changed is delayed, so that rapid successive changes are reconciled at
+	// once. It only applies to controllers that reset the backoff of changed
+	// resources. A zero value reconciles changes immediately.
+	CoalesceWindow time.Duration
+
+	// ReferenceCacheTTL is how long the referents of managed resources are
+	// cached while resolving references, unless they change. It only applies
+	// to controllers that cache referents. A zero value disables caching.
+	ReferenceCacheTTL time.Duration
+
+	// Drainer tracks the reconciles that are in flight, so that they can be
+	// allowed to complete when the provider is shut down. A nil value does not
+	// track reconciles.
+	Drainer *Drainer
+
+	// PostReconcile notifies a webhook when the conditions of a managed
+	// resource change. A nil value disables notifications.
+	PostReconcile *PostReconcileHook
+}
+
+// WithOptions configures a managed reconciler with the supplied options.
+func WithOptions(o Options) managed.ReconcilerOption {
+	return func(r *managed.Reconciler) {
+		if o.PollInterval > 0 {
+			managed.WithLongWait(o.PollInterval)(r)
+		}
+	}
+}
+
+// Wrap the supplied reconciler with the behaviour configured by the supplied
+// options.
+func Wrap(o Options, r reconcile.Reconciler) reconcile.Reconciler {
+	if o.StartupJitter > 0 {
+		r = NewJitterReconciler(r, o.StartupJitter)
+	}
+	if o.Drainer != nil {
+		r = o.Drainer.Wrap(r)
+	}
+	return r
+}
+
+// A JitterOption configures a JitterReconciler.
+type JitterOption func(*JitterReconciler)
+
+// WithRandom configures the source of randomness used to pick the delay of
+// the first reconcile of each resource.
+func WithRandom(rnd *rand.Rand) JitterOption {
+	return func(r *JitterReconciler) {
+		r.random = rnd
+	}
+}
+
+// WithClock configures the function used to determine the current time.
+func WithClock(now func() time.Time) JitterOption {
+	return func(r *JitterReconciler) {
+		r.now = now
+	}
+}
+
+// A JitterReconciler delays the first reconcile of each resource by a random
+// duration within a window that starts with the first reconcile it handles.
+// This prevents all resources from calling the AWS API at the same time when
+// the provider is (re)started, which would likely result in throttling.
+type JitterReconciler struct {
+	wrapped reconcile.Reconciler
+	window  time.Duration
+	random  *rand.Rand
+	now     func() time.Time
+
+	mu      sync.Mutex
+	started time.Time
+	seen    map[types.NamespacedName]bool
+}
+
+// NewJitterReconciler returns a JitterReconciler that spreads the first
+// reconcile of each resource across the supplied window.
+func NewJitterReconciler(r reconcile.Reconciler, window time.Duration, o ...JitterOption) *JitterReconciler {
+	jr := &JitterReconciler{
+		wrapped: r,
+		window:  window,
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())), // nolint:gosec
+		now:     time.Now,
+		seen:    map[types.NamespacedName]bool{},
+	}
+	for _, f := range o {
+		f(jr)
+	}
+	return jr
+}
+
+// Reconcile the supplied request, requeueing it after a random delay if this
+// is its first reconcile within the startup window.
+func (r *JitterReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	if d := r.delay(req); d > 0 {
+		return reconcile.Result{RequeueAfter: d}, nil
+	}
+	return r.wrapped.Reconcile(req)
+}
+
+func (r *JitterReconciler) delay(req reconcile.Request) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.started.IsZero() {
+		r.started = now
+	}
+	remaining := r.window - now.Sub(r.started)
+	if remaining <= 0 {
+		// The startup window has passed; we no longer need to remember which
+		// resources we have seen.
+		r.seen = nil
+		return 0
+	}
+	if r.seen[req.NamespacedName] {
+		return 0
+	}
+	r.seen[req.NamespacedName] = true
+	return time.Duration(r.random.Int63n(int64(remaining)))
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type countingReconciler struct {
+	calls int
+}
+
+func (r *countingReconciler) Reconcile(_ reconcile.Request) (reconcile.Result, error) {
+	r.calls++
+	return reconcile.Result{}, nil
+}
+
+func request(name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}
+}
+
+func TestJitterReconciler(t *testing.T) {
+	window := time.Minute
+	now := time.Now()
+	wrapped := &countingReconciler{}
+	r := NewJitterReconciler(wrapped, window,
+		WithRandom(rand.New(rand.NewSource(42))), // nolint:gosec
+		WithClock(func() time.Time { return now }))
+
+	// The first reconcile of every resource should be delayed by a duration
+	// within the window, and the delays should be spread out.
+	delays := map[time.Duration]bool{}
+	for i := 0; i < 10; i++ {
+		got, err := r.Reconcile(request(fmt.Sprintf("resource-%d", i)))
+		if err != nil {
+			t.Fatalf("Reconcile(...): %s", err)
+		}
+		if got.RequeueAfter < 0 || got.RequeueAfter >= window {
+			t.Errorf("Reconcile(...): want delay within [0, %s), got %s", window, got.RequeueAfter)
+		}
+		delays[got.RequeueAfter] = true
+	}
+	if len(delays) < 2 {
+		t.Errorf("Reconcile(...): want initial reconciles to be spread out, got delays %v", delays)
+	}
+	if diff := cmp.Diff(0, wrapped.calls); diff != "" {
+		t.Errorf("wrapped reconciles: -want, +got:\n%s", diff)
+	}
+
+	// Subsequent reconciles of a resource should not be delayed.
+	if _, err := r.Reconcile(request("resource-0")); err != nil {
+		t.Fatalf("Reconcile(...): %s", err)
+	}
+	if diff := cmp.Diff(1, wrapped.calls); diff != "" {
+		t.Errorf("wrapped reconciles: -want, +got:\n%s", diff)
+	}
+
+	// Resources seen for the first time once the window has passed should
+	// not be delayed.
+	now = now.Add(window)
+	got, err := r.Reconcile(request("resource-new"))
+	if err != nil {
+		t.Fatalf("Reconcile(...): %s", err)
+	}
+	if diff := cmp.Diff(reconcile.Result{}, got); diff != "" {
+		t.Errorf("Reconcile(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(2, wrapped.calls); diff != "" {
+		t.Errorf("wrapped reconciles: -want, +got:\n%s", diff)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	wrapped := &countingReconciler{}
+
+	if _, ok := Wrap(Options{}, wrapped).(*countingReconciler); !ok {
+		t.Errorf("Wrap(...): want reconciler to be returned unchanged when no options are set")
+	}
+	if _, ok := Wrap(Options{StartupJitter: time.Minute}, wrapped).(*JitterReconciler); !ok {
+		t.Errorf("Wrap(...): want a *JitterReconciler when startup jitter is set")
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	cases := map[string]struct {
+		o    Options
+		want reconcile.Result
+	}{
+		"DefaultPollInterval": {
+			o:    Options{},
+			want: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"PollInterval": {
+			o:    Options{PollInterval: time.Hour},
+			want: reconcile.Result{RequeueAfter: time.Hour},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &fake.Manager{
+				Client: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+				},
+				Scheme: fake.SchemeWith(&fake.Managed{}),
+			}
+			r := managed.NewReconciler(m, resource.ManagedKind(fake.GVK(&fake.Managed{})),
+				managed.WithInitializers(),
+				managed.WithReferenceResolver(managed.ReferenceResolverFn(func(_ context.Context, _ resource.Managed) error { return nil })),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &managed.ExternalClientFns{
+						ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+							return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+						},
+					}, nil
+				})),
+				managed.WithConnectionPublishers(),
+				managed.WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }}),
+				WithOptions(tc.o))
+
+			got, err := r.Reconcile(reconcile.Request{})
+			if err != nil {
+				t.Fatalf("Reconcile(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Reconcile(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestChangesBypassPollInterval asserts that a request that is enqueued due to
+// a change to a managed resource is processed immediately, even though the
+// request is already waiting to be requeued after the poll interval.
+func TestChangesBypassPollInterval(t *testing.T) {
+	q := workqueue.NewDelayingQueue()
+	defer q.ShutDown()
+
+	req := request("resource")
+	q.AddAfter(req, time.Hour)
+	q.Add(req)
+
+	got := make(chan interface{})
+	go func() {
+		item, _ := q.Get()
+		got <- item
+	}()
+
+	select {
+	case item := <-got:
+		if diff := cmp.Diff(req, item); diff != "" {
+			t.Errorf("q.Get(): -want, +got:\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("q.Get(): changed resource was not processed before the poll interval")
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const errPanic = "recovered from panic while calling the external API: %v"
+
+// A RecoverConnecter produces ExternalClients that recover from panics, e.g.
+// those caused by an unexpected shape of an AWS API response. A recovered
+// panic is logged and returned as an error, which the managed reconciler
+// surfaces as the ReconcileError condition of the managed resource, rather
+// than crashing the provider.
+type RecoverConnecter struct {
+	connecter managed.ExternalConnecter
+	log       logging.Logger
+}
+
+// NewRecoverConnecter returns a RecoverConnecter that wraps the supplied
+// ExternalConnecter. Recovered panics are logged using the supplied logger.
+func NewRecoverConnecter(c managed.ExternalConnecter, l logging.Logger) *RecoverConnecter {
+	return &RecoverConnecter{connecter: c, log: l}
+}
+
+// Connect to the provider specified by the supplied managed resource.
+func (c *RecoverConnecter) Connect(ctx context.Context, mg resource.Managed) (ec managed.ExternalClient, err error) {
+	defer recoverTo(c.log, mg, &err)
+	ec, err = c.connecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &recoverClient{client: ec, log: c.log}, nil
+}
+
+type recoverClient struct {
+	client managed.ExternalClient
+	log    logging.Logger
+}
+
+func (c *recoverClient) Observe(ctx context.Context, mg resource.Managed) (o managed.ExternalObservation, err error) {
+	defer recoverTo(c.log, mg, &err)
+	return c.client.Observe(ctx, mg)
+}
+
+func (c *recoverClient) Create(ctx context.Context, mg resource.Managed) (cre managed.ExternalCreation, err error) {
+	defer recoverTo(c.log, mg, &err)
+	return c.client.Create(ctx, mg)
+}
+
+func (c *recoverClient) Update(ctx context.Context, mg resource.Managed) (upd managed.ExternalUpdate, err error) {
+	defer recoverTo(c.log, mg, &err)
+	return c.client.Update(ctx, mg)
+}
+
+func (c *recoverClient) Delete(ctx context.Context, mg resource.Managed) (err error) {
+	defer recoverTo(c.log, mg, &err)
+	return c.client.Delete(ctx, mg)
+}
+
+// recoverTo recovers from a panic, if any, logging it and setting the supplied
+// error. It must be deferred directly by the function that may panic.
+func recoverTo(log logging.Logger, mg resource.Managed, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	log.Info("Recovered from panic",
+		"name", mg.GetName(),
+		"external-name", meta.GetExternalName(mg),
+		"panic", r,
+		"stack", string(debug.Stack()))
+	*err = errors.Errorf(errPanic, r)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestRecoverConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		observe error
+		create  error
+		update  error
+		delete  error
+	}
+
+	cases := map[string]struct {
+		client managed.ExternalClient
+		want   want
+	}{
+		"Panic": {
+			client: &managed.ExternalClientFns{
+				ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+					var s *string
+					return managed.ExternalObservation{ResourceExists: *s != ""}, nil
+				},
+				CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+					panic("create")
+				},
+				UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+					panic(errBoom)
+				},
+				DeleteFn: func(context.Context, resource.Managed) error {
+					panic("delete")
+				},
+			},
+			want: want{
+				observe: errors.Errorf(errPanic, "runtime error: invalid memory address or nil pointer dereference"),
+				create:  errors.Errorf(errPanic, "create"),
+				update:  errors.Errorf(errPanic, errBoom),
+				delete:  errors.Errorf(errPanic, "delete"),
+			},
+		},
+		"NoPanic": {
+			client: &managed.ExternalClientFns{
+				ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, errBoom
+				},
+				CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+					return managed.ExternalCreation{}, nil
+				},
+				UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+					return managed.ExternalUpdate{}, nil
+				},
+				DeleteFn: func(context.Context, resource.Managed) error {
+					return nil
+				},
+			},
+			want: want{
+				observe: errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return tc.client, nil
+			})
+			ec, err := NewRecoverConnecter(c, logging.NewNopLogger()).Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): unexpected error: %s", err)
+			}
+			_, err = ec.Observe(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.observe, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			_, err = ec.Create(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.create, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+			_, err = ec.Update(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.update, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+			err = ec.Delete(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.delete, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecoverConnecterConnect(t *testing.T) {
+	c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+		panic("connect")
+	})
+	_, err := NewRecoverConnecter(c, logging.NewNopLogger()).Connect(context.Background(), &fake.Managed{})
+	if diff := cmp.Diff(errors.Errorf(errPanic, "connect"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("Connect(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRecoverConnecterReconcile(t *testing.T) {
+	var got *fake.Managed
+	m := &fake.Manager{
+		Client: &test.MockClient{
+			MockGet:    test.NewMockGetFn(nil),
+			MockUpdate: test.NewMockUpdateFn(nil),
+			MockStatusUpdate: test.MockStatusUpdateFn(func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+				got = obj.(*fake.Managed)
+				return nil
+			}),
+		},
+		Scheme: fake.SchemeWith(&fake.Managed{}),
+	}
+	c := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+				panic("malformed response")
+			},
+		}, nil
+	})
+	r := managed.NewReconciler(m, resource.ManagedKind(fake.GVK(&fake.Managed{})),
+		managed.WithInitializers(),
+		managed.WithExternalConnecter(NewRecoverConnecter(c, logging.NewNopLogger())))
+
+	if _, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "coolresource"}}); err != nil {
+		t.Fatalf("Reconcile(...): unexpected error: %s", err)
+	}
+	if got == nil {
+		t.Fatal("Reconcile(...): status was not updated")
+	}
+	cond := got.GetCondition(v1alpha1.TypeSynced)
+	if cond.Reason != v1alpha1.ReasonReconcileError || !strings.Contains(cond.Message, "malformed response") {
+		t.Errorf("Reconcile(...): want ReconcileError condition reporting the panic, got %+v", cond)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// A ReferenceCacheOption configures a ReferenceCache.
+type ReferenceCacheOption func(*ReferenceCache)
+
+// WithReferenceCacheClock configures the function used to determine the
+// current time.
+func WithReferenceCacheClock(now func() time.Time) ReferenceCacheOption {
+	return func(c *ReferenceCache) {
+		c.now = now
+	}
+}
+
+type referenceKey struct {
+	kind string
+	name types.NamespacedName
+}
+
+type referenceEntry struct {
+	obj     runtime.Object
+	version string
+	expires time.Time
+}
+
+// A ReferenceCache is a client that caches the objects it gets for a short
+// time. It is intended to be used to resolve the references of managed
+// resources, so that repeated reconciles of a resource do not get its
+// unchanged referents again. A cached object is dropped once it expires or
+// its resource version changes. All other calls are passed through.
+type ReferenceCache struct {
+	client.Client
+	informers cache.Informers
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	entries map[referenceKey]referenceEntry
+	watched map[string]bool
+}
+
+// NewReferenceCache returns a ReferenceCache that gets objects using the
+// supplied client and caches them for the supplied TTL. The supplied informers
+// are used to drop cached objects that change; a nil value only drops them
+// once they expire. A zero TTL disables caching.
+func NewReferenceCache(c client.Client, i cache.Informers, ttl time.Duration, o ...ReferenceCacheOption) *ReferenceCache {
+	rc := &ReferenceCache{
+		Client:    c,
+		informers: i,
+		ttl:       ttl,
+		now:       time.Now,
+		entries:   map[referenceKey]referenceEntry{},
+		watched:   map[string]bool{},
+	}
+	for _, f := range o {
+		f(rc)
+	}
+	return rc
+}
+
+// Get the object with the supplied key, from the cache if it was cached and
+// has not expired since.
+func (c *ReferenceCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if c.ttl <= 0 {
+		return c.Client.Get(ctx, key, obj)
+	}
+	k := referenceKey{kind: fmt.Sprintf("%T", obj), name: key}
+
+	c.mu.Lock()
+	e, ok := c.entries[k]
+	c.mu.Unlock()
+	if ok && c.now().Before(e.expires) {
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(e.obj.DeepCopyObject()).Elem())
+		return nil
+	}
+
+	if err := c.Client.Get(ctx, key, obj); err != nil {
+		return err
+	}
+	if !c.watch(ctx, k.kind, obj) {
+		return nil
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil
+	}
+	c.mu.Lock()
+	c.entries[k] = referenceEntry{obj: obj.DeepCopyObject(), version: m.GetResourceVersion(), expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return nil
+}
+
+// watch returns true if objects of the supplied kind may be cached, i.e. if
+// they are dropped from the cache when they change.
+func (c *ReferenceCache) watch(ctx context.Context, kind string, obj runtime.Object) bool {
+	if c.informers == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watched[kind] {
+		return true
+	}
+	i, err := c.informers.GetInformer(ctx, obj)
+	if err != nil {
+		return false
+	}
+	i.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) { c.invalidate(obj, false) },
+		DeleteFunc: func(obj interface{}) { c.invalidate(obj, true) },
+	})
+	c.watched[kind] = true
+	return true
+}
+
+// invalidate drops the supplied object from the cache if it was deleted, or if
+// its resource version differs from that of the cached object. Informers
+// periodically resync objects that did not change; these are not dropped.
+func (c *ReferenceCache) invalidate(obj interface{}, deleted bool) {
+	if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	k := referenceKey{kind: fmt.Sprintf("%T", obj), name: types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()}}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[k]; ok && (deleted || e.version != m.GetResourceVersion()) {
+		delete(c.entries, k)
+	}
+}
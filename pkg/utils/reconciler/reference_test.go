@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type fakeInformers struct {
+	cache.Informers
+	informer *fakeInformer
+}
+
+func (i *fakeInformers) GetInformer(context.Context, runtime.Object) (cache.Informer, error) {
+	return i.informer, nil
+}
+
+type fakeInformer struct {
+	cache.Informer
+	handler toolscache.ResourceEventHandler
+}
+
+func (i *fakeInformer) AddEventHandler(h toolscache.ResourceEventHandler) {
+	i.handler = h
+}
+
+func TestReferenceCache(t *testing.T) {
+	key := types.NamespacedName{Name: "coolreferent"}
+	referent := func(version string) *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetName(key.Name)
+		mg.SetResourceVersion(version)
+		return mg
+	}
+
+	type want struct {
+		gets    int
+		version string
+	}
+
+	cases := map[string]struct {
+		ttl    time.Duration
+		change func(i *fakeInformer, step func(time.Duration))
+		want   want
+	}{
+		"Hit": {
+			ttl:  time.Minute,
+			want: want{gets: 1, version: "1"},
+		},
+		"Disabled": {
+			want: want{gets: 2, version: "2"},
+		},
+		"Expired": {
+			ttl:    time.Minute,
+			change: func(_ *fakeInformer, step func(time.Duration)) { step(time.Minute) },
+			want:   want{gets: 2, version: "2"},
+		},
+		"ReferentChanged": {
+			ttl: time.Minute,
+			change: func(i *fakeInformer, _ func(time.Duration)) {
+				i.handler.OnUpdate(referent("1"), referent("2"))
+			},
+			want: want{gets: 2, version: "2"},
+		},
+		"ReferentResynced": {
+			ttl: time.Minute,
+			change: func(i *fakeInformer, _ func(time.Duration)) {
+				i.handler.OnUpdate(referent("1"), referent("1"))
+			},
+			want: want{gets: 1, version: "1"},
+		},
+		"ReferentDeleted": {
+			ttl: time.Minute,
+			change: func(i *fakeInformer, _ func(time.Duration)) {
+				i.handler.OnDelete(toolscache.DeletedFinalStateUnknown{Obj: referent("1")})
+			},
+			want: want{gets: 2, version: "2"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gets := 0
+			kube := &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				gets++
+				// Each get returns a new version of the referent, so that
+				// a cached referent can be told apart from a fresh one.
+				*obj.(*fake.Managed) = *referent(string(rune('0' + gets)))
+				return nil
+			}}
+			now := time.Now()
+			i := &fakeInformer{}
+			c := NewReferenceCache(kube, &fakeInformers{informer: i}, tc.ttl, WithReferenceCacheClock(func() time.Time { return now }))
+
+			if err := c.Get(context.Background(), key, &fake.Managed{}); err != nil {
+				t.Fatalf("Get(...): unexpected error: %s", err)
+			}
+			if tc.change != nil {
+				tc.change(i, func(d time.Duration) { now = now.Add(d) })
+			}
+			got := &fake.Managed{}
+			if err := c.Get(context.Background(), key, got); err != nil {
+				t.Fatalf("Get(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, want{gets: gets, version: got.GetResourceVersion()}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("Get(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
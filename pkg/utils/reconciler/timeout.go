@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyReconcileTimeout is the annotation that overrides how long each
+// call to the external API may take for a particular managed resource, e.g. so
+// that a huge node group has longer than DefaultCallTimeout, or a resource
+// whose API is slow fails fast. The value is parsed as a Go duration.
+const AnnotationKeyReconcileTimeout = "crossplane.io/reconcile-timeout"
+
+const (
+	// DefaultCallTimeout is how long each call to the external API may take
+	// for managed resources that are not annotated with a reconcile timeout.
+	DefaultCallTimeout = 1 * time.Minute
+
+	// MaxReconcileTimeout is the timeout of each reconcile of the managed
+	// reconcilers that are configured WithConnecter. A context can only
+	// shorten the timeout of its parent, so it is also the longest reconcile
+	// timeout that may be annotated.
+	MaxReconcileTimeout = 30 * time.Minute
+)
+
+const (
+	errInvalidReconcileTimeout = "cannot parse annotation " + AnnotationKeyReconcileTimeout + " as a positive duration; ignoring it"
+	errReconcileTimeoutTooLong = "annotation " + AnnotationKeyReconcileTimeout + " exceeds the maximum of %s; ignoring it"
+
+	reasonInvalidReconcileTimeout event.Reason = "InvalidReconcileTimeout"
+)
+
+// GetReconcileTimeout returns the reconcile timeout annotated on the supplied
+// object. It returns DefaultCallTimeout if the object is not annotated, and an
+// error if the annotation is not a positive duration of at most
+// MaxReconcileTimeout.
+func GetReconcileTimeout(o metav1.Object) (time.Duration, error) {
+	v, ok := o.GetAnnotations()[AnnotationKeyReconcileTimeout]
+	if !ok {
+		return DefaultCallTimeout, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return DefaultCallTimeout, errors.Wrap(err, errInvalidReconcileTimeout)
+	}
+	if d <= 0 {
+		return DefaultCallTimeout, errors.New(errInvalidReconcileTimeout)
+	}
+	if d > MaxReconcileTimeout {
+		return DefaultCallTimeout, errors.Errorf(errReconcileTimeoutTooLong, MaxReconcileTimeout)
+	}
+	return d, nil
+}
+
+// A TimeoutConnecter connects using the reconcile timeout annotated on the
+// managed resource, and produces ExternalClients that use it for every call.
+type TimeoutConnecter struct {
+	connecter managed.ExternalConnecter
+	record    event.Recorder
+}
+
+// NewTimeoutConnecter returns a TimeoutConnecter that wraps the supplied
+// ExternalConnecter. Invalid timeouts are reported as warning events using the
+// supplied recorder.
+func NewTimeoutConnecter(c managed.ExternalConnecter, r event.Recorder) *TimeoutConnecter {
+	return &TimeoutConnecter{connecter: c, record: r}
+}
+
+// Connect to the provider specified by the supplied managed resource.
+func (c *TimeoutConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	d, err := GetReconcileTimeout(mg)
+	if err != nil {
+		c.record.Event(mg, event.Warning(reasonInvalidReconcileTimeout, err))
+	}
+	cctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	ec, err := c.connecter.Connect(cctx, mg)
+	if err != nil {
+		return ec, err
+	}
+	return &timeoutClient{client: ec, timeout: d}, nil
+}
+
+type timeoutClient struct {
+	client  managed.ExternalClient
+	timeout time.Duration
+}
+
+func (c *timeoutClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.Observe(ctx, mg)
+}
+
+func (c *timeoutClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.Create(ctx, mg)
+}
+
+func (c *timeoutClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.Update(ctx, mg)
+}
+
+func (c *timeoutClient) Delete(ctx context.Context, mg resource.Managed) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.Delete(ctx, mg)
+}
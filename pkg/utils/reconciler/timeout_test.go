@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+type eventCounter struct {
+	warnings int
+}
+
+func (r *eventCounter) Event(_ runtime.Object, e event.Event) {
+	if e.Type == event.TypeWarning {
+		r.warnings++
+	}
+}
+
+func (r *eventCounter) WithAnnotations(...string) event.Recorder { return r }
+
+// deadlines returns an ExternalConnecter whose ExternalClients record how long
+// they had until the deadline of the context of each call.
+func deadlines(got map[string]time.Duration) managed.ExternalConnecter {
+	record := func(ctx context.Context, op string) {
+		if d, ok := ctx.Deadline(); ok {
+			got[op] = time.Until(d)
+		}
+	}
+	return managed.ExternalConnectorFn(func(ctx context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		record(ctx, "Connect")
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				record(ctx, "Observe")
+				return managed.ExternalObservation{}, nil
+			},
+			CreateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+				record(ctx, "Create")
+				return managed.ExternalCreation{}, nil
+			},
+			UpdateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+				record(ctx, "Update")
+				return managed.ExternalUpdate{}, nil
+			},
+			DeleteFn: func(ctx context.Context, _ resource.Managed) error {
+				record(ctx, "Delete")
+				return nil
+			},
+		}, nil
+	})
+}
+
+func TestTimeoutConnecter(t *testing.T) {
+	type want struct {
+		timeout  time.Duration
+		warnings int
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		want        want
+	}{
+		"ValidOverride": {
+			annotations: map[string]string{AnnotationKeyReconcileTimeout: "10s"},
+			want:        want{timeout: 10 * time.Second},
+		},
+		"ExtendedOverride": {
+			annotations: map[string]string{AnnotationKeyReconcileTimeout: "10m"},
+			want:        want{timeout: 10 * time.Minute},
+		},
+		"AboveMaxReconcileTimeout": {
+			annotations: map[string]string{AnnotationKeyReconcileTimeout: "1h"},
+			want:        want{timeout: DefaultCallTimeout, warnings: 1},
+		},
+		"InvalidDuration": {
+			annotations: map[string]string{AnnotationKeyReconcileTimeout: "ten minutes"},
+			want:        want{timeout: DefaultCallTimeout, warnings: 1},
+		},
+		"NegativeDuration": {
+			annotations: map[string]string{AnnotationKeyReconcileTimeout: "-1m"},
+			want:        want{timeout: DefaultCallTimeout, warnings: 1},
+		},
+		"DefaultFallback": {
+			want: want{timeout: DefaultCallTimeout},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := map[string]time.Duration{}
+			record := &eventCounter{}
+			mg := &fake.Managed{}
+			mg.SetAnnotations(tc.annotations)
+
+			ec, err := NewTimeoutConnecter(deadlines(got), record).Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): unexpected error: %s", err)
+			}
+			_, _ = ec.Observe(context.Background(), mg)
+			_, _ = ec.Create(context.Background(), mg)
+			_, _ = ec.Update(context.Background(), mg)
+			_ = ec.Delete(context.Background(), mg)
+
+			for _, op := range []string{"Connect", "Observe", "Create", "Update", "Delete"} {
+				// Allow a second for the deadline to be recorded, so that
+				// an override is not mistaken for the default.
+				d, ok := got[op]
+				if !ok || d <= tc.want.timeout-time.Second || d > tc.want.timeout {
+					t.Errorf("%s: want deadline within %s, got %s", op, tc.want.timeout, d)
+				}
+			}
+			if diff := cmp.Diff(tc.want.warnings, record.warnings); diff != "" {
+				t.Errorf("warnings: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}